@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/urfave/cli"
+)
+
+// ConfigCommand contains the definition of the config command-line subcommand
+// and its own subcommands.
+var ConfigCommand cli.Command
+
+const configDescription = `The config command allows inspecting the client
+	configuration file. The "validate" subcommand checks the configuration
+	file without starting the client.`
+
+const configValidateDescription = `Validates the configuration file. Apart from
+	the checks performed on every client start, the command checks whether
+	the key file can be decrypted with the configured password and whether
+	the storage directory is writable. All problems found are reported at
+	once, each one with the path of the config field it relates to.`
+
+func init() {
+	ConfigCommand = cli.Command{
+		Name:        "config",
+		Usage:       `Provides access to the client configuration.`,
+		Description: configDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:        "validate",
+				Usage:       "Validates the configuration file.",
+				Description: configValidateDescription,
+				Action:      validateConfig,
+			},
+		},
+	}
+}
+
+// validateConfig validates the configuration file and reports whether it is
+// valid. If it is not, the returned error lists all problems found.
+func validateConfig(c *cli.Context) error {
+	configPath := c.GlobalString("config")
+
+	if _, err := config.ValidateConfig(configPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("Config file [%v] is valid.\n", configPath)
+
+	return nil
+}
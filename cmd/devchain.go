@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain/devchain"
+	"github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/urfave/cli"
+)
+
+// DevChainCommand contains the definition of the devchain command-line
+// subcommand and its own subcommands.
+var DevChainCommand cli.Command
+
+const devChainDescription = `The devchain command allows running a local
+	development chain shared by several clients on one machine, so that
+	a full random beacon can be run without Ethereum. The "start" subcommand
+	starts the chain, the "genesis" subcommand starts the first group
+	selection and the "request" subcommand requests a new relay entry.
+
+	Clients connect to the chain if the socket of the chain is set in the
+	DevChain section of their config files.`
+
+const devChainStartDescription = `Starts a development chain serving relay
+	chain calls of clients on a unix socket until interrupted.
+
+	The chain produces blocks at the given block time and starts with no
+	groups. Stakers given with --staker have five times the minimum stake;
+	they are usually addresses of the Ethereum accounts of clients connecting
+	to the chain.
+
+	Groups expire after the group active time and are no longer selected for
+	new relay entries. Each relay entry request pays the DKG contribution
+	margin of the group creation fee to the DKG fee pool. A new group
+	selection is started with the new entry as the seed once a requested
+	relay entry is submitted, if the pool covers the group creation fee.`
+
+const devChainGenesisDescription = `Starts the first group selection on the
+	development chain. Clients of all stakers should be running, as only
+	clients running at the start of a group selection take part in it.`
+
+const devChainRequestDescription = `Requests a new relay entry from one of the
+	groups registered on the development chain. By default, the command waits
+	until the requested entry is submitted. With --no-wait, it exits as soon
+	as the entry is requested.`
+
+const (
+	socketFlag       = "socket"
+	blockTimeFlag    = "block-time"
+	minimumStakeFlag = "minimum-stake"
+	stakerFlag       = "staker"
+
+	groupActiveTimeFlag       = "group-active-time"
+	dkgContributionMarginFlag = "dkg-contribution-margin"
+)
+
+var defaultDevChainSocket = filepath.Join(os.TempDir(), "keep-devchain.sock")
+
+func init() {
+	socket := &cli.StringFlag{
+		Name:  socketFlag,
+		Value: defaultDevChainSocket,
+		Usage: "path of the unix socket of the development chain",
+	}
+
+	DevChainCommand = cli.Command{
+		Name:        "devchain",
+		Usage:       `Runs a local development chain.`,
+		Description: devChainDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:        "start",
+				Usage:       "Starts the development chain.",
+				Description: devChainStartDescription,
+				Action:      devChainStart,
+				Flags: []cli.Flag{
+					socket,
+					&cli.DurationFlag{
+						Name:  blockTimeFlag,
+						Value: time.Second,
+						Usage: "time between two consecutive blocks",
+					},
+					&cli.IntFlag{
+						Name:  groupSizeFlag,
+						Value: defaultGroupSize,
+						Usage: "size of groups created on the chain",
+					},
+					&cli.IntFlag{
+						Name:  honestThresholdFlag,
+						Value: defaultHonestThreshold,
+						Usage: "honest threshold of groups created on the chain",
+					},
+					&cli.StringFlag{
+						Name:  minimumStakeFlag,
+						Value: "1",
+						Usage: "minimum stake needed to operate on the chain",
+					},
+					&cli.StringSliceFlag{
+						Name:  stakerFlag,
+						Usage: "address of a staker; can be repeated",
+					},
+					&cli.Uint64Flag{
+						Name:  groupActiveTimeFlag,
+						Value: 1000,
+						Usage: "time in blocks after which a group expires",
+					},
+					&cli.Uint64Flag{
+						Name:  dkgContributionMarginFlag,
+						Value: 100,
+						Usage: "percent of the group creation fee paid by each relay request",
+					},
+				},
+			},
+			{
+				Name:        "genesis",
+				Usage:       "Starts the first group selection.",
+				Description: devChainGenesisDescription,
+				Action:      devChainGenesis,
+				Flags: []cli.Flag{
+					socket,
+				},
+			},
+			{
+				Name:        "request",
+				Usage:       "Requests a new relay entry.",
+				Description: devChainRequestDescription,
+				Action:      devChainRequest,
+				Flags: []cli.Flag{
+					socket,
+					&cli.BoolFlag{
+						Name:  noWaitFlag,
+						Usage: "do not wait for the entry; exit once it is requested",
+					},
+					&cli.DurationFlag{
+						Name:  timeoutFlag,
+						Usage: "maximum time to wait, e.g. 10m; waits indefinitely if not set",
+					},
+				},
+			},
+		},
+	}
+}
+
+// devChainStart serves the development chain until the client is
+// interrupted.
+func devChainStart(c *cli.Context) error {
+	minimumStake, ok := new(big.Int).SetString(c.String(minimumStakeFlag), 10)
+	if !ok || minimumStake.Sign() <= 0 {
+		return fmt.Errorf(
+			"minimum stake [%v] is not a positive decimal number",
+			c.String(minimumStakeFlag),
+		)
+	}
+
+	groupSize := c.Int(groupSizeFlag)
+	honestThreshold := c.Int(honestThresholdFlag)
+	if honestThreshold <= 0 || honestThreshold > groupSize {
+		return fmt.Errorf(
+			"honest threshold [%v] must be between 1 and group size [%v]",
+			honestThreshold,
+			groupSize,
+		)
+	}
+
+	if c.Duration(blockTimeFlag) <= 0 {
+		return fmt.Errorf("block time must be positive")
+	}
+
+	if c.Uint64(groupActiveTimeFlag) == 0 {
+		return fmt.Errorf("group active time must be positive")
+	}
+
+	if c.Uint64(dkgContributionMarginFlag) == 0 {
+		return fmt.Errorf("DKG contribution margin must be positive")
+	}
+
+	server, err := devchain.NewServer(
+		&local.Config{
+			GroupSize:             groupSize,
+			HonestThreshold:       honestThreshold,
+			MinimumStake:          minimumStake,
+			BlockTime:             c.Duration(blockTimeFlag),
+			GroupActiveTime:       c.Uint64(groupActiveTimeFlag),
+			DKGContributionMargin: c.Uint64(dkgContributionMarginFlag),
+		},
+		c.StringSlice(stakerFlag),
+	)
+	if err != nil {
+		return fmt.Errorf("error creating development chain: [%v]", err)
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	stopSignals := handleShutdownSignals(cancelCtx)
+	defer stopSignals()
+
+	logger.Infof(
+		"serving development chain with [%v] stakers on socket [%v]",
+		len(c.StringSlice(stakerFlag)),
+		c.String(socketFlag),
+	)
+
+	return server.Serve(ctx, c.String(socketFlag))
+}
+
+// devChainGenesis starts the first group selection on the development chain.
+func devChainGenesis(c *cli.Context) error {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	chainHandle, err := connectDevChain(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	if err := chainHandle.Genesis(); err != nil {
+		return fmt.Errorf("error starting group selection: [%v]", err)
+	}
+
+	fmt.Printf("Group selection started.\n")
+
+	return nil
+}
+
+// devChainRequest requests a new relay entry from the development chain and
+// waits until it is submitted, unless requested otherwise.
+func devChainRequest(c *cli.Context) error {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	if timeout := c.Duration(timeoutFlag); timeout > 0 {
+		var cancelTimeoutCtx context.CancelFunc
+		ctx, cancelTimeoutCtx = context.WithTimeout(ctx, timeout)
+		defer cancelTimeoutCtx()
+	}
+
+	chainHandle, err := connectDevChain(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	// Subscribe for submitted entries before the request so that the entry
+	// cannot be missed even if it is submitted very quickly.
+	submittedEntries := make(chan *event.EntrySubmitted, 1)
+	subscription, err := chainHandle.ThresholdRelay().OnRelayEntrySubmitted(
+		func(entry *event.EntrySubmitted) {
+			select {
+			case submittedEntries <- entry:
+			default:
+			}
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error subscribing for relay entries: [%v]", err)
+	}
+	defer subscription.Unsubscribe()
+
+	request, err := chainHandle.RequestRelayEntry()
+	if err != nil {
+		return fmt.Errorf("error in requesting relay entry: [%v]", err)
+	}
+
+	fmt.Printf(
+		"Relay entry requested at block [%v] from group [0x%x].\n",
+		request.BlockNumber,
+		request.GroupPublicKey,
+	)
+
+	if c.Bool(noWaitFlag) {
+		return nil
+	}
+
+	select {
+	case entry := <-submittedEntries:
+		fmt.Printf("Relay entry submitted at block [%v].\n", entry.BlockNumber)
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("error waiting for relay entry: [%v]", ctx.Err())
+	}
+}
+
+// connectDevChain connects to the development chain on the socket set with
+// the command flags. The command connects with a newly generated operator
+// key as it does not operate on the chain.
+func connectDevChain(
+	ctx context.Context,
+	c *cli.Context,
+) (devchain.Handle, error) {
+	operatorPrivateKey, _, err := operator.GenerateKeyPair()
+	if err != nil {
+		return nil, fmt.Errorf("error generating operator key: [%v]", err)
+	}
+
+	chainHandle, err := devchain.Connect(
+		ctx,
+		c.String(socketFlag),
+		operatorPrivateKey,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error connecting to development chain: [%v]",
+			err,
+		)
+	}
+
+	return chainHandle, nil
+}
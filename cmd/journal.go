@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/journal"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/rewards"
+	"github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/diskpersistence"
+	"github.com/keep-network/keep-core/pkg/net/key"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/status"
+	"github.com/urfave/cli"
+)
+
+// JournalCommand contains the definition of the journal command-line
+// subcommand and its own subcommands.
+var JournalCommand cli.Command
+
+const journalDescription = `The journal command allows working with the
+	event journal. The running client appends every relay event it sees to
+	the journal in its data directory: relay entry requests and submissions,
+	group selection starts, group registrations and DKG result submissions,
+	each with its block number and transaction hash. The "replay" subcommand
+	replays recorded events against a local chain.`
+
+const journalReplayDescription = `Replays events recorded in the journal
+	against a single operator running on a local chain and a local network,
+	so that the behaviour of the client seen in a recorded incident can be
+	reproduced deterministically.
+
+	The replayed operator uses the key of the operator from the config file
+	and a copy of the operator's group memberships, so that it is a member of
+	the same groups. Changes made to the copy during the replay are
+	discarded.
+
+	Events are replayed ordered by their blocks. The first event is replayed
+	at the current local block and every following one the same number of
+	blocks later as it has been recorded after the first one. Only recorded
+	events are passed to the operator; events emitted by the local chain
+	itself are not. The current relay request is the last replayed one until
+	a relay entry submission is replayed. Other chain calls, like group
+	membership lookups, are answered by the local chain.
+
+	The journal in the data directory from the config file, including its
+	rotated file, is replayed if no journal file is set. Once all events are replayed, the operator shuts
+	down, waiting for work in progress to complete.`
+
+const (
+	journalFileFlag        = "file"
+	groupSizeFlag          = "group-size"
+	honestThresholdFlag    = "honest-threshold"
+	defaultGroupSize       = 5
+	defaultHonestThreshold = 3
+)
+
+func init() {
+	JournalCommand = cli.Command{
+		Name:        "journal",
+		Usage:       `Provides access to the event journal.`,
+		Description: journalDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:        "replay",
+				Usage:       "Replays recorded events against a local chain.",
+				Description: journalReplayDescription,
+				Action:      journalReplay,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  journalFileFlag,
+						Usage: "path of the journal file; the journal in the configured data directory if not set",
+					},
+					&cli.Uint64Flag{
+						Name:  fromBlockFlag,
+						Usage: "first block of the replayed range",
+					},
+					&cli.Uint64Flag{
+						Name:  toBlockFlag,
+						Usage: "last block of the replayed range; the last recorded block if not set",
+					},
+					&cli.IntFlag{
+						Name:  groupSizeFlag,
+						Value: defaultGroupSize,
+						Usage: "group size of the local chain",
+					},
+					&cli.IntFlag{
+						Name:  honestThresholdFlag,
+						Value: defaultHonestThreshold,
+						Usage: "honest threshold of the local chain",
+					},
+				},
+			},
+		},
+	}
+}
+
+// journalReplay replays events recorded in the journal against a beacon
+// running on a local chain and a local network.
+func journalReplay(c *cli.Context) error {
+	cfg, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	var records []*journal.Record
+	if journalFile := c.String(journalFileFlag); journalFile != "" {
+		records, err = journal.ReadFile(journalFile)
+	} else {
+		records, err = journal.ReadDataDir(cfg.Storage.DataDir)
+	}
+	if err != nil {
+		return fmt.Errorf("error reading journal: [%v]", err)
+	}
+
+	records = recordsInRange(records, c)
+	if len(records) == 0 {
+		return fmt.Errorf("no recorded events to replay")
+	}
+
+	operatorPrivateKey, operatorPublicKey, err := loadStaticKey(
+		cfg.Ethereum.Account.KeyFile,
+		cfg.Ethereum.Account.KeyFilePassword,
+	)
+	if err != nil {
+		return fmt.Errorf("error loading operator key: [%v]", err)
+	}
+
+	localChain := local.ConnectWithKey(
+		c.Int(groupSizeFlag),
+		c.Int(honestThresholdFlag),
+		big.NewInt(1),
+		operatorPrivateKey,
+	)
+
+	signing := localChain.Signing()
+	stakingID := common.BytesToAddress(
+		signing.PublicKeyBytesToAddress(signing.PublicKey()),
+	).Hex()
+
+	stakeMonitor, err := localChain.StakeMonitor()
+	if err != nil {
+		return fmt.Errorf("error obtaining stake monitor handle: [%v]", err)
+	}
+	if err := stakeMonitor.(*local.StakeMonitor).StakeTokens(stakingID); err != nil {
+		return fmt.Errorf("error staking operator tokens: [%v]", err)
+	}
+
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		return err
+	}
+
+	_, networkPublicKey := key.OperatorKeyToNetworkKey(
+		operatorPrivateKey,
+		operatorPublicKey,
+	)
+	netProvider := netLocal.ConnectWithKey(networkPublicKey)
+
+	// Groups of the replayed operator must not be mixed with groups of the
+	// operator hosted by the client, so the replay works on their copy in
+	// a temporary directory.
+	dataDir, err := ioutil.TempDir("", "keep-journal-replay")
+	if err != nil {
+		return fmt.Errorf("error creating temporary data directory: [%v]", err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	copiedMemberships, err := copyGroupMemberships(cfg.Storage.DataDir, dataDir)
+	if err != nil {
+		return fmt.Errorf("error copying group memberships: [%v]", err)
+	}
+	logger.Infof("copied [%v] group memberships of the operator", copiedMemberships)
+
+	handle, err := diskpersistence.NewHandle(dataDir)
+	if err != nil {
+		return fmt.Errorf(
+			"failed while creating a storage disk handler: [%v]",
+			err,
+		)
+	}
+
+	replayChain := journal.NewReplayChain(localChain.ThresholdRelay())
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	stopSignals := handleShutdownSignals(cancelCtx)
+	defer stopSignals()
+
+	beaconShutdownCompleted, err := beacon.Initialize(
+		ctx,
+		stakingID,
+		&replayHandle{Chain: localChain, relayChain: replayChain},
+		netProvider,
+		persistence.NewEncryptedPersistence(
+			handle,
+			cfg.Ethereum.Account.KeyFilePassword,
+		),
+		status.NewRegistry(),
+		&rewards.Config{
+			MinimumPayout:   big.NewInt(0),
+			GasPriceCeiling: big.NewInt(0),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error initializing beacon: [%v]", err)
+	}
+
+	logger.Infof("replaying [%v] recorded events", len(records))
+
+	replayErr := replayChain.Replay(ctx, records, blockCounter)
+	if replayErr == nil {
+		logger.Infof("all recorded events replayed; shutting down")
+	}
+
+	cancelCtx()
+	<-beaconShutdownCompleted
+
+	if replayErr != nil && replayErr != context.Canceled {
+		return fmt.Errorf("error replaying events: [%v]", replayErr)
+	}
+
+	return nil
+}
+
+// copyGroupMemberships copies group memberships stored in the source data
+// directory to the target data directory and returns the number of copied
+// memberships. Other data of the operator, like DKG checkpoints, are not
+// copied.
+func copyGroupMemberships(sourceDataDir string, targetDataDir string) (int, error) {
+	// Memberships are kept in group directories of the directory of
+	// non-archived data of the disk persistence.
+	const currentDir = "current"
+
+	sourceDir := filepath.Join(sourceDataDir, currentDir)
+	groupDirs, err := ioutil.ReadDir(sourceDir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	copied := 0
+	for _, groupDir := range groupDirs {
+		if !groupDir.IsDir() {
+			continue
+		}
+
+		files, err := ioutil.ReadDir(filepath.Join(sourceDir, groupDir.Name()))
+		if err != nil {
+			return copied, err
+		}
+
+		targetDir := filepath.Join(targetDataDir, currentDir, groupDir.Name())
+		for _, file := range files {
+			if file.IsDir() ||
+				!strings.HasPrefix(file.Name(), registry.MembershipFilePrefix) {
+				continue
+			}
+
+			data, err := ioutil.ReadFile(
+				filepath.Join(sourceDir, groupDir.Name(), file.Name()),
+			)
+			if err != nil {
+				return copied, err
+			}
+
+			if err := os.MkdirAll(targetDir, 0700); err != nil {
+				return copied, err
+			}
+
+			err = ioutil.WriteFile(
+				filepath.Join(targetDir, file.Name()),
+				data,
+				0600,
+			)
+			if err != nil {
+				return copied, err
+			}
+
+			copied++
+		}
+	}
+
+	return copied, nil
+}
+
+// recordsInRange returns records from the block range set with the command
+// flags.
+func recordsInRange(records []*journal.Record, c *cli.Context) []*journal.Record {
+	inRange := make([]*journal.Record, 0, len(records))
+	for _, record := range records {
+		if record.BlockNumber < c.Uint64(fromBlockFlag) {
+			continue
+		}
+		if c.IsSet(toBlockFlag) && record.BlockNumber > c.Uint64(toBlockFlag) {
+			continue
+		}
+
+		inRange = append(inRange, record)
+	}
+
+	return inRange
+}
+
+// replayHandle is a handle of the local chain passing recorded events to the
+// beacon instead of events of the local chain.
+type replayHandle struct {
+	local.Chain
+
+	relayChain *journal.ReplayChain
+}
+
+func (rh *replayHandle) ThresholdRelay() relaychain.Interface {
+	return rh.relayChain
+}
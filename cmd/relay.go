@@ -1,13 +1,17 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"os"
 	"time"
 
 	"github.com/keep-network/keep-core/config"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/gen/async"
 	"github.com/urfave/cli"
 )
 
@@ -18,10 +22,33 @@ var RelayCommand cli.Command
 const relayDescription = `The relay command allows interacting with Keep's
 	threshold relay. The "request" subcommand allows for requesting a new entry
 	from the relay, which is equivalent to asking for a new random number. This
-	subcommand waits for the entry to appear on-chain and then reports the value.
+	subcommand reports the entry fee, waits for the entry to appear on-chain
+	and then reports the value.
+	The "watch" subcommand streams relay events as they are seen on-chain.
+	The "history" subcommand lists past relay requests and entries and the
+	"verify" subcommand checks whether a past relay entry is genuine.
 	The "genesis" subcommand triggers the first group selection. This action 
     can be done only once when there are no groups on the chain.`
 
+const relayRequestDescription = `Requests a new entry from the relay paying the
+	estimated entry fee. The entry fee estimate and its breakdown are printed
+	before the request is submitted.
+
+	If a callback contract is set, the relay calls its
+	__beaconCallback(uint256) method with the new entry, providing the given
+	amount of callback gas. The entry fee grows with the callback gas.
+
+	By default, the command waits until the requested entry is generated.
+	With --no-wait, it exits as soon as the request is seen on-chain.`
+
+const (
+	callbackContractFlag = "callback-contract"
+	callbackGasFlag      = "callback-gas"
+	noWaitFlag           = "no-wait"
+	timeoutFlag          = "timeout"
+	jsonFlag             = "json"
+)
+
 func init() {
 	RelayCommand = cli.Command{
 		Name:        "relay",
@@ -29,9 +56,84 @@ func init() {
 		Description: relayDescription,
 		Subcommands: []cli.Command{
 			{
-				Name:   "request",
-				Usage:  "Requests a new entry from the relay.",
-				Action: relayRequest,
+				Name:        "request",
+				Usage:       "Requests a new entry from the relay.",
+				Description: relayRequestDescription,
+				Action:      relayRequest,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  callbackContractFlag,
+						Usage: "address of the contract called back with the new entry",
+					},
+					&cli.Uint64Flag{
+						Name:  callbackGasFlag,
+						Usage: "gas limit for the callback contract call",
+					},
+					&cli.BoolFlag{
+						Name:  noWaitFlag,
+						Usage: "do not wait for the entry; exit once the request id is known",
+					},
+					&cli.DurationFlag{
+						Name:  timeoutFlag,
+						Usage: "maximum time to wait, e.g. 10m; waits indefinitely if not set",
+					},
+					&cli.BoolFlag{
+						Name:  jsonFlag,
+						Usage: "print the result as JSON",
+					},
+				},
+			},
+			{
+				Name:        "watch",
+				Usage:       "Streams relay events as newline-delimited JSON.",
+				Description: relayWatchDescription,
+				Action:      relayWatch,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  groupPublicKeyFlag,
+						Usage: "report only events related to the group with this public key",
+					},
+				},
+			},
+			{
+				Name:        "history",
+				Usage:       "Lists past relay requests and entries as newline-delimited JSON.",
+				Description: relayHistoryDescription,
+				Action:      relayHistory,
+				Flags: []cli.Flag{
+					&cli.Uint64Flag{
+						Name:  fromBlockFlag,
+						Usage: "first block of the listed range",
+					},
+					&cli.Uint64Flag{
+						Name:  toBlockFlag,
+						Usage: "last block of the listed range; the current block if not set",
+					},
+					&cli.StringFlag{
+						Name:  groupPublicKeyFlag,
+						Usage: "report only events related to the group with this public key",
+					},
+				},
+			},
+			{
+				Name:        "verify",
+				Usage:       "Verifies the relay entry generated for a past relay request.",
+				Description: relayVerifyDescription,
+				Action:      relayVerify,
+				Flags: []cli.Flag{
+					&cli.Uint64Flag{
+						Name:  requestBlockFlag,
+						Usage: "block at which the relay request was made",
+					},
+					&cli.StringFlag{
+						Name:  requestIDFlag,
+						Usage: "id of the relay request; required if more than one request was made at the block",
+					},
+					&cli.BoolFlag{
+						Name:  jsonFlag,
+						Usage: "print the result as JSON",
+					},
+				},
 			},
 			{
 				Name:   "genesis",
@@ -43,47 +145,220 @@ func init() {
 }
 
 // relayRequest requests a new entry from the threshold relay and prints the
-// request id. By default, it also waits until the associated relay entry is
-// generated and prints out the entry.
+// entry fee and the request id. By default, it also waits until the associated
+// relay entry is generated and prints out the entry.
 func relayRequest(c *cli.Context) error {
 	cfg, err := config.ReadConfig(c.GlobalString("config"))
 	if err != nil {
 		return fmt.Errorf("error reading config file: [%v]", err)
 	}
 
+	callbackContract := c.String(callbackContractFlag)
+	callbackGas := new(big.Int).SetUint64(c.Uint64(callbackGasFlag))
+	if callbackContract == "" && callbackGas.Sign() > 0 {
+		return fmt.Errorf(
+			"callback gas can be set only together with a callback contract",
+		)
+	}
+
+	jsonOutput := c.Bool(jsonFlag)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	if timeout := c.Duration(timeoutFlag); timeout > 0 {
+		var cancelTimeoutCtx context.CancelFunc
+		ctx, cancelTimeoutCtx = context.WithTimeout(ctx, timeout)
+		defer cancelTimeoutCtx()
+	}
+
 	utility, err := ethereum.ConnectUtility(cfg.Ethereum)
 	if err != nil {
 		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
 	}
 
-	wait := make(chan struct{})
+	entryFee, err := utility.EntryFeeEstimate(callbackGas)
+	if err != nil {
+		return fmt.Errorf("error estimating relay entry fee: [%v]", err)
+	}
+
+	entryFeeBreakdown, err := utility.EntryFeeBreakdown()
+	if err != nil {
+		return fmt.Errorf("error getting relay entry fee breakdown: [%v]", err)
+	}
+
+	output := &relayRequestOutput{
+		EntryFee: entryFee.String(),
+		EntryFeeBreakdown: entryFeeBreakdownOutput{
+			EntryVerificationFee: entryFeeBreakdown.EntryVerificationFee.String(),
+			DkgContributionFee:   entryFeeBreakdown.DkgContributionFee.String(),
+			GroupProfitFee:       entryFeeBreakdown.GroupProfitFee.String(),
+			GasPriceCeiling:      entryFeeBreakdown.GasPriceCeiling.String(),
+		},
+	}
+
+	if !jsonOutput {
+		fmt.Printf(
+			"Relay entry fee estimate: [%v] wei "+
+				"(entry verification fee: [%v], DKG contribution fee: [%v], "+
+				"group profit fee: [%v], gas price ceiling: [%v])\n",
+			output.EntryFee,
+			output.EntryFeeBreakdown.EntryVerificationFee,
+			output.EntryFeeBreakdown.DkgContributionFee,
+			output.EntryFeeBreakdown.GroupProfitFee,
+			output.EntryFeeBreakdown.GasPriceCeiling,
+		)
+	}
+
+	noWait := c.Bool(noWaitFlag)
+
+	// Subscribe for generated entries before the request is submitted so
+	// that the entry cannot be missed even if it is generated very quickly.
+	generatedEntries := make(chan *event.EntryGenerated, 16)
+	if !noWait {
+		subscription, err := utility.OnRelayEntryGenerated(
+			func(entry *event.EntryGenerated) {
+				select {
+				case generatedEntries <- entry:
+				case <-ctx.Done():
+				}
+			},
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"error subscribing for generated relay entries: [%v]",
+				err,
+			)
+		}
+		defer subscription.Unsubscribe()
+	}
+
+	if !jsonOutput {
+		fmt.Printf("Requesting for a new relay entry at [%s]\n", time.Now())
+	}
+
+	request, err := waitForRelayRequest(
+		ctx,
+		utility.RequestRelayEntry(callbackContract, callbackGas),
+	)
+	if err != nil {
+		return fmt.Errorf("error in requesting relay entry: [%v]", err)
+	}
+
+	output.RequestID = request.RequestID.String()
+	output.RequestBlock = request.BlockNumber
+
+	if !jsonOutput {
+		fmt.Printf(
+			"Relay entry requested with id [%v] at block [%v].\n",
+			output.RequestID,
+			output.RequestBlock,
+		)
+	}
+
+	if !noWait {
+		entry, err := waitForRelayEntry(
+			ctx,
+			generatedEntries,
+			request.RequestID,
+		)
+		if err != nil {
+			return fmt.Errorf("error in waiting for relay entry: [%v]", err)
+		}
 
-	fmt.Printf("Requesting for a new relay entry at [%s]\n", time.Now())
+		output.Entry = entry.Value.String()
+		output.EntryBlock = entry.BlockNumber
 
-	utility.RequestRelayEntry().
-		OnSuccess(func(event *event.EntryGenerated) {
+		if !jsonOutput {
 			fmt.Fprintf(
 				os.Stderr,
 				"Relay entry generated with value: [%v].\n",
-				event.Value,
+				output.Entry,
 			)
-			wait <- struct{}{}
+		}
+	}
+
+	if jsonOutput {
+		return printJSON(output)
+	}
+
+	return nil
+}
+
+// relayRequestOutput is the machine-readable result of the relay request
+// command. Big numbers are represented as decimal strings so that they can be
+// safely processed by tools with limited number precision.
+type relayRequestOutput struct {
+	EntryFee          string                  `json:"entryFee"`
+	EntryFeeBreakdown entryFeeBreakdownOutput `json:"entryFeeBreakdown"`
+	RequestID         string                  `json:"requestId"`
+	RequestBlock      uint64                  `json:"requestBlock"`
+	Entry             string                  `json:"entry,omitempty"`
+	EntryBlock        uint64                  `json:"entryBlock,omitempty"`
+}
+
+type entryFeeBreakdownOutput struct {
+	EntryVerificationFee string `json:"entryVerificationFee"`
+	DkgContributionFee   string `json:"dkgContributionFee"`
+	GroupProfitFee       string `json:"groupProfitFee"`
+	GasPriceCeiling      string `json:"gasPriceCeiling"`
+}
+
+func waitForRelayRequest(
+	ctx context.Context,
+	promise *async.EventEntryRequestedPromise,
+) (*event.EntryRequested, error) {
+	requested := make(chan *event.EntryRequested, 1)
+	failed := make(chan error, 1)
+
+	promise.
+		OnSuccess(func(request *event.EntryRequested) {
+			requested <- request
 		}).
 		OnFailure(func(err error) {
-			fmt.Fprintf(
-				os.Stderr,
-				"Error in requesting relay entry: [%v].\n",
-				err,
-			)
-			wait <- struct{}{}
+			failed <- err
 		})
 
 	select {
-	case <-wait:
-		return nil
+	case request := <-requested:
+		return request, nil
+	case err := <-failed:
+		return nil, err
+	case <-ctx.Done():
+		return nil, fmt.Errorf(
+			"relay request not seen on-chain: [%v]",
+			ctx.Err(),
+		)
+	}
+}
+
+func waitForRelayEntry(
+	ctx context.Context,
+	generatedEntries <-chan *event.EntryGenerated,
+	requestID *big.Int,
+) (*event.EntryGenerated, error) {
+	for {
+		select {
+		case entry := <-generatedEntries:
+			if entry.RequestID.Cmp(requestID) == 0 {
+				return entry, nil
+			}
+		case <-ctx.Done():
+			return nil, fmt.Errorf(
+				"relay entry for request [%v] not generated: [%v]",
+				requestID,
+				ctx.Err(),
+			)
+		}
 	}
 }
 
+func printJSON(value interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
 // genesis kicks off protocol to create the first group.
 func genesis(c *cli.Context) error {
 	cfg, err := config.ReadConfig(c.GlobalString("config"))
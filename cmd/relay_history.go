@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/urfave/cli"
+)
+
+const relayHistoryDescription = `Lists relay entry requests and submitted relay
+	entries seen on-chain in the given block range, one JSON object per line,
+	in the same format as the "watch" subcommand. Submitted entries contain
+	the entry itself and its value as seen by relay consumers.
+
+	If the end of the range is not set, the range ends at the current block.
+	Entries submitted before the first request in the range are reported
+	without a group public key.`
+
+const (
+	fromBlockFlag = "from-block"
+	toBlockFlag   = "to-block"
+)
+
+// relayHistory prints relay entry requests and submitted relay entries from
+// the given block range to the standard output as newline-delimited JSON.
+func relayHistory(c *cli.Context) error {
+	cfg, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	groupFilter := strings.ToLower(
+		strings.TrimPrefix(c.String(groupPublicKeyFlag), "0x"),
+	)
+	if _, err := hex.DecodeString(groupFilter); err != nil {
+		return fmt.Errorf("invalid group public key: [%v]", err)
+	}
+
+	utility, err := ethereum.ConnectUtility(cfg.Ethereum)
+	if err != nil {
+		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	fromBlock := c.Uint64(fromBlockFlag)
+	toBlock := c.Uint64(toBlockFlag)
+	if !c.IsSet(toBlockFlag) {
+		toBlock, err = currentBlock(utility)
+		if err != nil {
+			return err
+		}
+	}
+
+	if fromBlock > toBlock {
+		return fmt.Errorf(
+			"from block [%v] is greater than to block [%v]",
+			fromBlock,
+			toBlock,
+		)
+	}
+
+	requests, err := utility.PastRelayEntryRequests(fromBlock, toBlock)
+	if err != nil {
+		return fmt.Errorf("error getting relay entry requests: [%v]", err)
+	}
+
+	entries, err := utility.PastRelayEntries(fromBlock, toBlock)
+	if err != nil {
+		return fmt.Errorf("error getting submitted relay entries: [%v]", err)
+	}
+
+	// Entries are already read so the watcher never reads them.
+	watcher := newRelayWatcher(os.Stdout, groupFilter, nil)
+	for _, historyEvent := range relayHistoryEvents(requests, entries) {
+		watcher.write(historyEvent)
+	}
+
+	return nil
+}
+
+// relayHistoryEvents merges relay entry requests and submitted relay entries
+// into a single list ordered by block number. Each entry is attributed to the
+// group of the most recent request preceding it. Requests are always created
+// at least one block before the entry is submitted, so entries are ordered
+// before requests from the same block.
+func relayHistoryEvents(
+	requests []*event.Request,
+	entries []*chain.RelayEntry,
+) []*watchEvent {
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].BlockNumber < requests[j].BlockNumber
+	})
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].BlockNumber < entries[j].BlockNumber
+	})
+
+	historyEvents := make([]*watchEvent, 0, len(requests)+len(entries))
+	currentGroup := ""
+
+	requestIndex := 0
+	for _, entry := range entries {
+		for requestIndex < len(requests) &&
+			requests[requestIndex].BlockNumber < entry.BlockNumber {
+			request := requests[requestIndex]
+			currentGroup = hex.EncodeToString(request.GroupPublicKey)
+
+			historyEvents = append(historyEvents, &watchEvent{
+				Type:           "relayEntryRequested",
+				BlockNumber:    request.BlockNumber,
+				GroupPublicKey: currentGroup,
+				PreviousEntry:  hex.EncodeToString(request.PreviousEntry),
+			})
+			requestIndex++
+		}
+
+		requestID := ""
+		if entry.RequestID != nil {
+			requestID = entry.RequestID.String()
+		}
+
+		historyEvents = append(historyEvents, &watchEvent{
+			Type:           "relayEntrySubmitted",
+			BlockNumber:    entry.BlockNumber,
+			RequestID:      requestID,
+			GroupPublicKey: currentGroup,
+			Entry:          hex.EncodeToString(entry.Entry),
+			Value:          relayEntryValue(entry.Entry).String(),
+		})
+	}
+
+	for _, request := range requests[requestIndex:] {
+		historyEvents = append(historyEvents, &watchEvent{
+			Type:           "relayEntryRequested",
+			BlockNumber:    request.BlockNumber,
+			GroupPublicKey: hex.EncodeToString(request.GroupPublicKey),
+			PreviousEntry:  hex.EncodeToString(request.PreviousEntry),
+		})
+	}
+
+	return historyEvents
+}
+
+// relayEntryValue returns the value of the relay entry passed to relay
+// consumers, that is the keccak256 hash of the entry as an unsigned integer.
+func relayEntryValue(entry []byte) *big.Int {
+	return new(big.Int).SetBytes(crypto.Keccak256(entry))
+}
+
+func currentBlock(utility chain.Utility) (uint64, error) {
+	blockCounter, err := utility.BlockCounter()
+	if err != nil {
+		return 0, fmt.Errorf("error getting block counter: [%v]", err)
+	}
+
+	block, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return 0, fmt.Errorf("error getting current block: [%v]", err)
+	}
+
+	return block, nil
+}
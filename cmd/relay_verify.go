@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/urfave/cli"
+)
+
+const relayVerifyDescription = `Verifies the relay entry generated for the
+	relay request made at the given block. The command fetches the previous
+	entry from the request, the public key of the group serving the request
+	and the entry submitted by that group, then checks the entry's BLS
+	signature offline.
+
+	The group public key is looked up with the chain state at the request
+	block which requires an archive Ethereum node. If that state is not
+	available, the group public key announced in the request is used.
+
+	If more than one relay request was made at the given block, the request
+	id must be set to select the verified request.
+
+	The command fails if the entry is not valid, if the request timed out or
+	if no entry has been submitted for the request yet.`
+
+const (
+	requestBlockFlag = "request-block"
+	requestIDFlag    = "request-id"
+)
+
+// relayVerifyScanWindow is the number of blocks queried at once when looking
+// for the relay entry submitted for the verified request.
+const relayVerifyScanWindow = 1000
+
+// relayVerifyOutput is the machine-readable result of the relay verify
+// command.
+type relayVerifyOutput struct {
+	RequestID      string `json:"requestId,omitempty"`
+	RequestBlock   uint64 `json:"requestBlock"`
+	GroupPublicKey string `json:"groupPublicKey"`
+	PreviousEntry  string `json:"previousEntry"`
+	EntryBlock     uint64 `json:"entryBlock"`
+	Entry          string `json:"entry"`
+	Value          string `json:"value"`
+	Valid          bool   `json:"valid"`
+}
+
+// relayVerify verifies the relay entry generated for the relay request made at
+// the given block and reports whether it is valid.
+func relayVerify(c *cli.Context) error {
+	cfg, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	if !c.IsSet(requestBlockFlag) {
+		return fmt.Errorf("request block must be set")
+	}
+	requestBlock := c.Uint64(requestBlockFlag)
+
+	var requestID *big.Int
+	if c.IsSet(requestIDFlag) {
+		var ok bool
+		requestID, ok = new(big.Int).SetString(c.String(requestIDFlag), 10)
+		if !ok {
+			return fmt.Errorf(
+				"invalid request id [%v]",
+				c.String(requestIDFlag),
+			)
+		}
+	}
+
+	utility, err := ethereum.ConnectUtility(cfg.Ethereum)
+	if err != nil {
+		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	request, err := findRelayRequest(utility, requestBlock, requestID)
+	if err != nil {
+		return err
+	}
+
+	groupPublicKey, err := utility.RequestGroupPublicKey(requestBlock)
+	if err != nil {
+		logger.Warningf(
+			"could not look up group public key at block [%v], "+
+				"using the one from the relay request: [%v]",
+			requestBlock,
+			err,
+		)
+		groupPublicKey = request.GroupPublicKey
+	} else if !bytes.Equal(groupPublicKey, request.GroupPublicKey) {
+		return fmt.Errorf(
+			"group public key [0x%x] from the chain state does not match "+
+				"group public key [0x%x] from the relay request",
+			groupPublicKey,
+			request.GroupPublicKey,
+		)
+	}
+
+	relayEntry, err := findRelayEntry(utility, request)
+	if err != nil {
+		return err
+	}
+
+	valid, err := entry.Verify(
+		groupPublicKey,
+		request.PreviousEntry,
+		relayEntry.Entry,
+	)
+	if err != nil {
+		return fmt.Errorf("could not verify relay entry: [%v]", err)
+	}
+
+	output := &relayVerifyOutput{
+		RequestBlock:   request.BlockNumber,
+		GroupPublicKey: "0x" + hex.EncodeToString(groupPublicKey),
+		PreviousEntry:  "0x" + hex.EncodeToString(request.PreviousEntry),
+		EntryBlock:     relayEntry.BlockNumber,
+		Entry:          "0x" + hex.EncodeToString(relayEntry.Entry),
+		Value:          relayEntryValue(relayEntry.Entry).String(),
+		Valid:          valid,
+	}
+	if requestID != nil {
+		output.RequestID = requestID.String()
+	}
+
+	if c.Bool(jsonFlag) {
+		if err := printJSON(output); err != nil {
+			return err
+		}
+	} else {
+		result := "PASS"
+		if !valid {
+			result = "FAIL"
+		}
+
+		fmt.Printf(
+			"Relay entry [%v] submitted at block [%v] by group [%v] "+
+				"for request at block [%v]: %v\n",
+			output.Value,
+			output.EntryBlock,
+			output.GroupPublicKey,
+			output.RequestBlock,
+			result,
+		)
+	}
+
+	if !valid {
+		return fmt.Errorf("relay entry is not valid")
+	}
+
+	return nil
+}
+
+// findRelayRequest returns the relay request made at the given block. If the
+// request id is set, the request with that id is returned. Otherwise, the
+// block must contain exactly one relay request.
+func findRelayRequest(
+	utility chain.Utility,
+	requestBlock uint64,
+	requestID *big.Int,
+) (*event.Request, error) {
+	requests, err := utility.PastRelayEntryRequests(requestBlock, requestBlock)
+	if err != nil {
+		return nil, fmt.Errorf("error getting relay entry requests: [%v]", err)
+	}
+
+	if len(requests) == 0 {
+		return nil, fmt.Errorf(
+			"no relay entry request found at block [%v]",
+			requestBlock,
+		)
+	}
+
+	if requestID == nil {
+		if len(requests) > 1 {
+			return nil, fmt.Errorf(
+				"[%v] relay entry requests found at block [%v]; "+
+					"set the request id to select one of them",
+				len(requests),
+				requestBlock,
+			)
+		}
+
+		return requests[0], nil
+	}
+
+	for _, request := range requests {
+		id, err := utility.RelayRequestID(request)
+		if err != nil {
+			logger.Warningf(
+				"could not get id of relay request made in transaction [%v]: [%v]",
+				request.TransactionHash,
+				err,
+			)
+			continue
+		}
+
+		if id.Cmp(requestID) == 0 {
+			return request, nil
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"no relay entry request with id [%v] found at block [%v]",
+		requestID,
+		requestBlock,
+	)
+}
+
+// findRelayEntry scans the chain forward from the request block and returns
+// the first relay entry submitted after the request. If the request is made
+// again before any entry is submitted, the original request timed out and an
+// error is returned.
+func findRelayEntry(
+	utility chain.Utility,
+	request *event.Request,
+) (*chain.RelayEntry, error) {
+	latestBlock, err := currentBlock(utility)
+	if err != nil {
+		return nil, err
+	}
+
+	for fromBlock := request.BlockNumber + 1; fromBlock <= latestBlock; {
+		toBlock := fromBlock + relayVerifyScanWindow - 1
+		if toBlock > latestBlock {
+			toBlock = latestBlock
+		}
+
+		entries, err := utility.PastRelayEntries(fromBlock, toBlock)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error getting submitted relay entries: [%v]",
+				err,
+			)
+		}
+
+		requests, err := utility.PastRelayEntryRequests(fromBlock, toBlock)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error getting relay entry requests: [%v]",
+				err,
+			)
+		}
+
+		for _, nextRequest := range requests {
+			if len(entries) == 0 ||
+				nextRequest.BlockNumber < entries[0].BlockNumber {
+				return nil, fmt.Errorf(
+					"relay request at block [%v] timed out; "+
+						"relay entry was requested again at block [%v]",
+					request.BlockNumber,
+					nextRequest.BlockNumber,
+				)
+			}
+		}
+
+		if len(entries) > 0 {
+			return entries[0], nil
+		}
+
+		fromBlock = toBlock + 1
+	}
+
+	return nil, fmt.Errorf(
+		"no relay entry submitted for relay request at block [%v] yet",
+		request.BlockNumber,
+	)
+}
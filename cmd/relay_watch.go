@@ -0,0 +1,390 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/keep-network/keep-core/config"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/subscription"
+	"github.com/urfave/cli"
+)
+
+const relayWatchDescription = `Streams threshold relay events as they are seen
+	on-chain, one JSON object per line. Reported events are relay entry
+	requests and submissions, group selection starts, DKG result submissions
+	and group registrations. Each event contains its type and block number.
+	Submitted entries contain the id of the relay request they were
+	submitted for, if known, the entry itself and its value as seen by relay
+	consumers.
+
+	If a group public key is set, only events related to that group are
+	reported. Relay entry submissions are attributed to the group serving
+	the relay request with the same id. Submissions which cannot be
+	attributed to any group are not reported then. Group selection starts
+	are not related to any group and are always reported.
+
+	The command runs until it is interrupted.`
+
+const groupPublicKeyFlag = "group-public-key"
+
+// watchEvent is a single entry of the relay event stream.
+type watchEvent struct {
+	Type           string `json:"type"`
+	BlockNumber    uint64 `json:"blockNumber"`
+	RequestID      string `json:"requestId,omitempty"`
+	GroupPublicKey string `json:"groupPublicKey,omitempty"`
+	PreviousEntry  string `json:"previousEntry,omitempty"`
+	Entry          string `json:"entry,omitempty"`
+	Value          string `json:"value,omitempty"`
+	Seed           string `json:"seed,omitempty"`
+	MemberIndex    uint32 `json:"memberIndex,omitempty"`
+	Misbehaved     []int  `json:"misbehaved,omitempty"`
+}
+
+// relayWatch subscribes for threshold relay events and prints them to the
+// standard output as newline-delimited JSON until the process is interrupted.
+func relayWatch(c *cli.Context) error {
+	cfg, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	groupFilter := strings.ToLower(
+		strings.TrimPrefix(c.String(groupPublicKeyFlag), "0x"),
+	)
+	if _, err := hex.DecodeString(groupFilter); err != nil {
+		return fmt.Errorf("invalid group public key: [%v]", err)
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	chainHandle, err := ethereum.Connect(ctx, cfg.Ethereum)
+	if err != nil {
+		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	// Submission events do not carry the entry; it is decoded from the
+	// submission transaction the same way as by the history command.
+	utility, err := ethereum.ConnectUtility(cfg.Ethereum)
+	if err != nil {
+		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	stopSignals := handleShutdownSignals(cancelCtx)
+	defer stopSignals()
+
+	watcher := newRelayWatcher(
+		os.Stdout,
+		groupFilter,
+		utility.SubmittedRelayEntry,
+	)
+
+	subscriptions, err := watcher.subscribe(chainHandle.ThresholdRelay())
+	for _, eventSubscription := range subscriptions {
+		defer eventSubscription.Unsubscribe()
+	}
+	if err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+
+	return nil
+}
+
+// relayWatcher writes relay events passing the group filter to the output.
+type relayWatcher struct {
+	outputMutex sync.Mutex
+	output      *json.Encoder
+
+	// groupFilter is the lower-case hex group public key without the 0x
+	// prefix; an empty filter lets all events through.
+	groupFilter string
+
+	// submittedEntry returns the relay entry submitted with the given
+	// submission event.
+	submittedEntry func(submission *event.EntrySubmitted) ([]byte, error)
+
+	// requestGroupsMutex guards the public keys of groups serving relay
+	// requests seen by the watcher, used to attribute relay entry
+	// submissions. Groups are keyed by the decimal request id.
+	requestGroupsMutex sync.Mutex
+	requestGroups      map[string]string
+	// lastRequestID is the id of the most recent relay request. A request
+	// repeated after a relay entry timeout keeps the id of the request which
+	// timed out but the chain may not be able to report it.
+	lastRequestID string
+}
+
+func newRelayWatcher(
+	output io.Writer,
+	groupFilter string,
+	submittedEntry func(submission *event.EntrySubmitted) ([]byte, error),
+) *relayWatcher {
+	return &relayWatcher{
+		output:         json.NewEncoder(output),
+		groupFilter:    groupFilter,
+		submittedEntry: submittedEntry,
+		requestGroups:  make(map[string]string),
+	}
+}
+
+func (rw *relayWatcher) subscribe(
+	relayChain relaychain.Interface,
+) ([]subscription.EventSubscription, error) {
+	subscriptions := make([]subscription.EventSubscription, 0)
+
+	if rw.groupFilter != "" {
+		if err := rw.loadCurrentGroup(relayChain); err != nil {
+			return subscriptions, err
+		}
+	}
+
+	relayEntryRequested, err := relayChain.OnRelayEntryRequested(
+		rw.relayEntryRequested,
+	)
+	if err != nil {
+		return subscriptions, fmt.Errorf(
+			"could not subscribe for relay entry requests: [%v]",
+			err,
+		)
+	}
+	subscriptions = append(subscriptions, relayEntryRequested)
+
+	relayEntrySubmitted, err := relayChain.OnRelayEntrySubmitted(
+		rw.relayEntrySubmitted,
+	)
+	if err != nil {
+		return subscriptions, fmt.Errorf(
+			"could not subscribe for relay entry submissions: [%v]",
+			err,
+		)
+	}
+	subscriptions = append(subscriptions, relayEntrySubmitted)
+
+	groupSelectionStarted, err := relayChain.OnGroupSelectionStarted(
+		rw.groupSelectionStarted,
+	)
+	if err != nil {
+		return subscriptions, fmt.Errorf(
+			"could not subscribe for group selection start: [%v]",
+			err,
+		)
+	}
+	subscriptions = append(subscriptions, groupSelectionStarted)
+
+	dkgResultSubmitted, err := relayChain.OnDKGResultSubmitted(
+		rw.dkgResultSubmitted,
+	)
+	if err != nil {
+		return subscriptions, fmt.Errorf(
+			"could not subscribe for DKG result submissions: [%v]",
+			err,
+		)
+	}
+	subscriptions = append(subscriptions, dkgResultSubmitted)
+
+	groupRegistered, err := relayChain.OnGroupRegistered(rw.groupRegistered)
+	if err != nil {
+		return subscriptions, fmt.Errorf(
+			"could not subscribe for group registrations: [%v]",
+			err,
+		)
+	}
+	subscriptions = append(subscriptions, groupRegistered)
+
+	return subscriptions, nil
+}
+
+func (rw *relayWatcher) relayEntryRequested(request *event.Request) {
+	groupPublicKey := hex.EncodeToString(request.GroupPublicKey)
+	rw.setRequestGroup(request.RequestID, groupPublicKey)
+
+	rw.write(&watchEvent{
+		Type:           "relayEntryRequested",
+		BlockNumber:    request.BlockNumber,
+		GroupPublicKey: groupPublicKey,
+		PreviousEntry:  hex.EncodeToString(request.PreviousEntry),
+	})
+}
+
+func (rw *relayWatcher) relayEntrySubmitted(entry *event.EntrySubmitted) {
+	watchEvent := &watchEvent{
+		Type:           "relayEntrySubmitted",
+		BlockNumber:    entry.BlockNumber,
+		GroupPublicKey: rw.takeRequestGroup(entry.RequestID),
+	}
+	if entry.RequestID != nil {
+		watchEvent.RequestID = entry.RequestID.String()
+	}
+
+	submittedEntry, err := rw.submittedEntry(entry)
+	if err != nil {
+		logger.Warningf(
+			"could not read relay entry submitted at block [%v]: [%v]",
+			entry.BlockNumber,
+			err,
+		)
+	} else {
+		watchEvent.Entry = hex.EncodeToString(submittedEntry)
+		watchEvent.Value = relayEntryValue(submittedEntry).String()
+	}
+
+	rw.write(watchEvent)
+}
+
+func (rw *relayWatcher) groupSelectionStarted(
+	groupSelectionStart *event.GroupSelectionStart,
+) {
+	rw.write(&watchEvent{
+		Type:        "groupSelectionStarted",
+		BlockNumber: groupSelectionStart.BlockNumber,
+		Seed:        groupSelectionStart.NewEntry.Text(16),
+	})
+}
+
+func (rw *relayWatcher) dkgResultSubmitted(
+	submission *event.DKGResultSubmission,
+) {
+	misbehaved := make([]int, len(submission.Misbehaved))
+	for i, memberIndex := range submission.Misbehaved {
+		misbehaved[i] = int(memberIndex)
+	}
+
+	rw.write(&watchEvent{
+		Type:           "dkgResultSubmitted",
+		BlockNumber:    submission.BlockNumber,
+		GroupPublicKey: hex.EncodeToString(submission.GroupPublicKey),
+		MemberIndex:    submission.MemberIndex,
+		Misbehaved:     misbehaved,
+	})
+}
+
+func (rw *relayWatcher) groupRegistered(
+	registration *event.GroupRegistration,
+) {
+	rw.write(&watchEvent{
+		Type:           "groupRegistered",
+		BlockNumber:    registration.BlockNumber,
+		GroupPublicKey: hex.EncodeToString(registration.GroupPublicKey),
+	})
+}
+
+// loadCurrentGroup sets the group serving the relay request in progress, if
+// any, so that its entry submission can be attributed correctly. The id of
+// the request in progress is not known so the group is used for the
+// submission of the first request not seen by the watcher.
+func (rw *relayWatcher) loadCurrentGroup(
+	relayChain relaychain.RelayEntryInterface,
+) error {
+	isEntryInProgress, err := relayChain.IsEntryInProgress()
+	if err != nil {
+		return fmt.Errorf(
+			"could not check if an entry is in progress: [%v]",
+			err,
+		)
+	}
+
+	if !isEntryInProgress {
+		return nil
+	}
+
+	groupPublicKey, err := relayChain.CurrentRequestGroupPublicKey()
+	if err != nil {
+		return fmt.Errorf(
+			"could not get group public key for the current request: [%v]",
+			err,
+		)
+	}
+
+	rw.setRequestGroup(nil, hex.EncodeToString(groupPublicKey))
+
+	return nil
+}
+
+// setRequestGroup records the group serving the relay request with the given
+// id. If the id is not known, the request is a repetition of the most recent
+// request or the request in progress when the watcher started.
+func (rw *relayWatcher) setRequestGroup(
+	requestID *big.Int,
+	groupPublicKey string,
+) {
+	rw.requestGroupsMutex.Lock()
+	defer rw.requestGroupsMutex.Unlock()
+
+	if requestID != nil {
+		rw.lastRequestID = requestID.String()
+	}
+
+	rw.requestGroups[rw.lastRequestID] = groupPublicKey
+}
+
+// takeRequestGroup returns the group which served the relay request with the
+// given id and forgets it as an entry is submitted once per request. It
+// returns an empty string if the group is not known.
+func (rw *relayWatcher) takeRequestGroup(requestID *big.Int) string {
+	rw.requestGroupsMutex.Lock()
+	defer rw.requestGroupsMutex.Unlock()
+
+	key := ""
+	if requestID != nil {
+		key = requestID.String()
+	}
+
+	groupPublicKey, ok := rw.requestGroups[key]
+	if !ok {
+		// The request may be the one in progress when the watcher started
+		// which id has not been known.
+		groupPublicKey, ok = rw.requestGroups[""]
+		key = ""
+	}
+	if !ok {
+		return ""
+	}
+
+	delete(rw.requestGroups, key)
+
+	return groupPublicKey
+}
+
+// write outputs the event if it passes the group filter. Group selection
+// starts are not related to any group and always pass the filter. Other
+// events pass it only if they are related to the filtered group.
+func (rw *relayWatcher) write(watchEvent *watchEvent) {
+	if rw.groupFilter != "" &&
+		watchEvent.Type != "groupSelectionStarted" &&
+		watchEvent.GroupPublicKey != rw.groupFilter {
+		return
+	}
+
+	if watchEvent.GroupPublicKey != "" {
+		watchEvent.GroupPublicKey = "0x" + watchEvent.GroupPublicKey
+	}
+	if watchEvent.PreviousEntry != "" {
+		watchEvent.PreviousEntry = "0x" + watchEvent.PreviousEntry
+	}
+	if watchEvent.Entry != "" {
+		watchEvent.Entry = "0x" + watchEvent.Entry
+	}
+	if watchEvent.Seed != "" {
+		watchEvent.Seed = "0x" + watchEvent.Seed
+	}
+
+	rw.outputMutex.Lock()
+	defer rw.outputMutex.Unlock()
+
+	if err := rw.output.Encode(watchEvent); err != nil {
+		logger.Errorf("could not write relay event: [%v]", err)
+	}
+}
@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+)
+
+func TestRelayWatcherOutput(t *testing.T) {
+	group1 := []byte{0x01, 0x02}
+	group2 := []byte{0x03, 0x04}
+
+	// Events of two requests served by different groups. The first request
+	// is repeated after a relay entry timeout, with an unknown id, and
+	// served by the second group then. Entries of both requests are
+	// submitted in the reverse order of the requests.
+	emitEvents := func(watcher *relayWatcher) {
+		watcher.groupSelectionStarted(&event.GroupSelectionStart{
+			NewEntry:    big.NewInt(255),
+			BlockNumber: 1,
+		})
+		watcher.groupRegistered(&event.GroupRegistration{
+			GroupPublicKey: group1,
+			BlockNumber:    2,
+		})
+		watcher.dkgResultSubmitted(&event.DKGResultSubmission{
+			MemberIndex:    3,
+			GroupPublicKey: group2,
+			Misbehaved:     []byte{1, 4},
+			BlockNumber:    3,
+		})
+		watcher.relayEntryRequested(&event.Request{
+			RequestID:      big.NewInt(1),
+			PreviousEntry:  []byte{0xaa},
+			GroupPublicKey: group1,
+			BlockNumber:    4,
+		})
+		watcher.relayEntryRequested(&event.Request{
+			PreviousEntry:  []byte{0xaa},
+			GroupPublicKey: group2,
+			BlockNumber:    5,
+		})
+		watcher.relayEntryRequested(&event.Request{
+			RequestID:      big.NewInt(2),
+			PreviousEntry:  []byte{0xbb},
+			GroupPublicKey: group1,
+			BlockNumber:    6,
+		})
+		watcher.relayEntrySubmitted(&event.EntrySubmitted{
+			RequestID:       big.NewInt(2),
+			BlockNumber:     7,
+			TransactionHash: "0x07",
+		})
+		watcher.relayEntrySubmitted(&event.EntrySubmitted{
+			RequestID:       big.NewInt(1),
+			BlockNumber:     8,
+			TransactionHash: "0x08",
+		})
+		watcher.relayEntrySubmitted(&event.EntrySubmitted{
+			BlockNumber:     9,
+			TransactionHash: "0x09",
+		})
+	}
+
+	// Entries submitted in transactions emitting the submission events.
+	submittedEntries := map[string][]byte{
+		"0x07": {0xcc},
+		"0x08": {0xdd},
+		"0x09": {0xee},
+	}
+	submittedEntry := func(submission *event.EntrySubmitted) ([]byte, error) {
+		entry, ok := submittedEntries[submission.TransactionHash]
+		if !ok {
+			return nil, fmt.Errorf("unknown transaction")
+		}
+		return entry, nil
+	}
+
+	allEvents := []watchEvent{
+		{
+			Type:        "groupSelectionStarted",
+			BlockNumber: 1,
+			Seed:        "0xff",
+		},
+		{
+			Type:           "groupRegistered",
+			BlockNumber:    2,
+			GroupPublicKey: "0x0102",
+		},
+		{
+			Type:           "dkgResultSubmitted",
+			BlockNumber:    3,
+			GroupPublicKey: "0x0304",
+			MemberIndex:    3,
+			Misbehaved:     []int{1, 4},
+		},
+		{
+			Type:           "relayEntryRequested",
+			BlockNumber:    4,
+			GroupPublicKey: "0x0102",
+			PreviousEntry:  "0xaa",
+		},
+		{
+			Type:           "relayEntryRequested",
+			BlockNumber:    5,
+			GroupPublicKey: "0x0304",
+			PreviousEntry:  "0xaa",
+		},
+		{
+			Type:           "relayEntryRequested",
+			BlockNumber:    6,
+			GroupPublicKey: "0x0102",
+			PreviousEntry:  "0xbb",
+		},
+		{
+			Type:           "relayEntrySubmitted",
+			BlockNumber:    7,
+			RequestID:      "2",
+			GroupPublicKey: "0x0102",
+			Entry:          "0xcc",
+			Value:          relayEntryValue([]byte{0xcc}).String(),
+		},
+		{
+			Type:           "relayEntrySubmitted",
+			BlockNumber:    8,
+			RequestID:      "1",
+			GroupPublicKey: "0x0304",
+			Entry:          "0xdd",
+			Value:          relayEntryValue([]byte{0xdd}).String(),
+		},
+		{
+			Type:        "relayEntrySubmitted",
+			BlockNumber: 9,
+			Entry:       "0xee",
+			Value:       relayEntryValue([]byte{0xee}).String(),
+		},
+	}
+
+	var tests = map[string]struct {
+		groupFilter    string
+		expectedEvents []watchEvent
+	}{
+		"no group filter": {
+			groupFilter:    "",
+			expectedEvents: allEvents,
+		},
+		"first group filter": {
+			groupFilter: "0102",
+			expectedEvents: []watchEvent{
+				allEvents[0],
+				allEvents[1],
+				allEvents[3],
+				allEvents[5],
+				allEvents[6],
+			},
+		},
+		"second group filter": {
+			groupFilter: "0304",
+			expectedEvents: []watchEvent{
+				allEvents[0],
+				allEvents[2],
+				allEvents[4],
+				allEvents[7],
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			watcher := newRelayWatcher(
+				output,
+				test.groupFilter,
+				submittedEntry,
+			)
+
+			emitEvents(watcher)
+
+			events := make([]watchEvent, 0)
+			decoder := json.NewDecoder(output)
+			for decoder.More() {
+				var decoded watchEvent
+				if err := decoder.Decode(&decoded); err != nil {
+					t.Fatal(err)
+				}
+				events = append(events, decoded)
+			}
+
+			if !reflect.DeepEqual(test.expectedEvents, events) {
+				t.Errorf(
+					"unexpected events\nexpected: [%+v]\nactual:   [%+v]",
+					test.expectedEvents,
+					events,
+				)
+			}
+		})
+	}
+}
+
+func TestRelayWatcherAttributesSubmissionToGroupInProgress(t *testing.T) {
+	output := &bytes.Buffer{}
+	watcher := newRelayWatcher(
+		output,
+		"0102",
+		func(submission *event.EntrySubmitted) ([]byte, error) {
+			return nil, fmt.Errorf("unknown transaction")
+		},
+	)
+
+	// The group serving the request in progress when the watcher started.
+	watcher.setRequestGroup(nil, "0102")
+
+	watcher.relayEntrySubmitted(&event.EntrySubmitted{
+		RequestID:   big.NewInt(7),
+		BlockNumber: 10,
+	})
+	// The group is forgotten once the entry is submitted.
+	watcher.relayEntrySubmitted(&event.EntrySubmitted{
+		RequestID:   big.NewInt(8),
+		BlockNumber: 11,
+	})
+
+	decoder := json.NewDecoder(output)
+
+	var decoded watchEvent
+	if err := decoder.Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedEvent := watchEvent{
+		Type:           "relayEntrySubmitted",
+		BlockNumber:    10,
+		GroupPublicKey: "0x0102",
+	}
+	if !reflect.DeepEqual(expectedEvent, decoded) {
+		t.Errorf(
+			"unexpected event\nexpected: [%+v]\nactual:   [%+v]",
+			expectedEvent,
+			decoded,
+		)
+	}
+
+	if decoder.More() {
+		t.Errorf("unexpected events after the attributed submission")
+	}
+}
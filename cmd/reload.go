@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/keep-network/keep-common/pkg/logging"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/urfave/cli"
+)
+
+const (
+	logLevelEnvVariable  = "LOG_LEVEL"
+	logFormatEnvVariable = "LOG_FORMAT"
+)
+
+// configReloader applies changes of the configuration file to the running
+// client. Only bootstrap peers and log levels can be changed live; changes of
+// all other fields are rejected and require a client restart.
+type configReloader struct {
+	cliContext *cli.Context
+	configPath string
+	// netProviders of all operators hosted by the client
+	netProviders []net.Provider
+
+	current *config.Config
+}
+
+// handleReloadSignals reloads the configuration file every time SIGHUP is
+// received, until the context is done.
+func handleReloadSignals(ctx context.Context, reloader *configReloader) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(signals)
+
+		for {
+			select {
+			case <-signals:
+				logger.Infof(
+					"received [%v] signal; reloading config file [%v]",
+					syscall.SIGHUP,
+					reloader.configPath,
+				)
+				reloader.reload()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// reload reads the configuration file again and applies all changes which
+// can be applied live. Each change is reported as either applied or rejected.
+// If the configuration file could not be read or is invalid, no changes are
+// applied.
+func (cr *configReloader) reload() {
+	updated, err := config.ReloadConfig(cr.configPath, cr.current)
+	if err != nil {
+		logger.Errorf(
+			"could not reload config file; keeping the current config: [%v]",
+			err,
+		)
+		return
+	}
+
+	applyStartFlags(cr.cliContext, updated)
+
+	changedFields := config.ChangedFields(cr.current, updated)
+	if len(changedFields) == 0 {
+		logger.Infof("config file reloaded; no changes found")
+		return
+	}
+
+	for _, field := range changedFields {
+		var err error
+		switch field {
+		case "LibP2P.Peers":
+			err = cr.applyBootstrapPeers(updated.LibP2P.Peers)
+		case "Logging.Level":
+			err = cr.applyLogLevel(updated.Logging.Level)
+		default:
+			err = fmt.Errorf("the change requires a client restart")
+		}
+
+		if err != nil {
+			logger.Warningf("config change of [%v] rejected: %v", field, err)
+			continue
+		}
+
+		logger.Infof("config change of [%v] applied", field)
+	}
+}
+
+func (cr *configReloader) applyBootstrapPeers(peers []string) error {
+	for _, netProvider := range cr.netProviders {
+		if err := netProvider.SetBootstrapPeers(peers); err != nil {
+			return fmt.Errorf("could not set bootstrap peers: [%v]", err)
+		}
+	}
+
+	cr.current.LibP2P.Peers = peers
+
+	return nil
+}
+
+func (cr *configReloader) applyLogLevel(level string) error {
+	if os.Getenv(logLevelEnvVariable) != "" {
+		return fmt.Errorf(
+			"log levels are set with the %v environment variable",
+			logLevelEnvVariable,
+		)
+	}
+
+	if err := logging.Configure(level); err != nil {
+		return err
+	}
+
+	cr.current.Logging.Level = level
+
+	return nil
+}
@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/rewards"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/urfave/cli"
+)
+
+// RewardsCommand contains the definition of the rewards command-line
+// subcommand and its own subcommands.
+var RewardsCommand cli.Command
+
+const rewardsDescription = `The rewards command allows inspecting rewards
+	earned by the operator as a member of relay groups. Rewards of stale
+	groups are withdrawn automatically by the running client, see the
+	[Rewards] section of the config file. The "list" subcommand lists
+	rewards of all groups the operator is a member of and the "report"
+	subcommand reports them per group or per period.`
+
+const rewardsListDescription = `Lists groups registered on-chain in the given
+	block range the operator is a member of, together with the member
+	indexes the operator holds, the reward of a single member, the total
+	reward of the operator and whether the rewards have already been
+	withdrawn. Rewards can be withdrawn only once the group is stale.
+
+	The operator from the config file is used if not set. If the end of the
+	range is not set, the range ends at the current block.`
+
+const operatorFlag = "operator"
+
+func init() {
+	RewardsCommand = cli.Command{
+		Name:        "rewards",
+		Usage:       `Provides access to group member rewards.`,
+		Description: rewardsDescription,
+		Subcommands: []cli.Command{
+			{
+				Name:        "list",
+				Usage:       "Lists rewards of groups the operator is a member of.",
+				Description: rewardsListDescription,
+				Action:      rewardsList,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  operatorFlag,
+						Usage: "address of the operator; the configured account if not set",
+					},
+					&cli.Uint64Flag{
+						Name:  fromBlockFlag,
+						Usage: "first block of the listed range",
+					},
+					&cli.Uint64Flag{
+						Name:  toBlockFlag,
+						Usage: "last block of the listed range; the current block if not set",
+					},
+					&cli.BoolFlag{
+						Name:  jsonFlag,
+						Usage: "print the result as JSON",
+					},
+				},
+			},
+			{
+				Name:        "report",
+				Usage:       "Reports rewards of the operator per group or period.",
+				Description: rewardsReportDescription,
+				Action:      rewardsReport,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  operatorFlag,
+						Usage: "address of the operator; the configured account if not set",
+					},
+					&cli.Uint64Flag{
+						Name:  fromBlockFlag,
+						Usage: "first block of the reported range",
+					},
+					&cli.Uint64Flag{
+						Name:  toBlockFlag,
+						Usage: "last block of the reported range; the current block if not set",
+					},
+					&cli.StringFlag{
+						Name:  formatFlag,
+						Value: csvFormat,
+						Usage: "output format, csv or json",
+					},
+					&cli.StringFlag{
+						Name:  byFlag,
+						Value: byGroup,
+						Usage: "report rewards per group or per period; csv format only",
+					},
+					&cli.StringFlag{
+						Name:  periodFlag,
+						Value: string(rewards.Month),
+						Usage: "length of the reported period, day, week or month",
+					},
+					&cli.StringFlag{
+						Name:  dataDirFlag,
+						Usage: "client data directory; the configured one if not set",
+					},
+					&cli.BoolFlag{
+						Name:  allOperatorsFlag,
+						Usage: "report all operators hosted by the client",
+					},
+				},
+			},
+		},
+	}
+}
+
+// rewardsList prints rewards of the operator for all groups it is a member
+// of registered in the given block range.
+func rewardsList(c *cli.Context) error {
+	cfg, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	operator, err := rewardsOperator(c, cfg)
+	if err != nil {
+		return err
+	}
+
+	utility, err := ethereum.ConnectUtility(cfg.Ethereum)
+	if err != nil {
+		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	fromBlock, toBlock, err := rewardsBlockRange(c, utility)
+	if err != nil {
+		return err
+	}
+
+	groupRewards, err := rewards.ListGroupRewards(
+		utility.ThresholdRelay(),
+		operator.Bytes(),
+		fromBlock,
+		toBlock,
+	)
+	if err != nil {
+		return fmt.Errorf("error listing group rewards: [%v]", err)
+	}
+
+	output := &rewardsListOutput{
+		Operator: operator.Hex(),
+		Groups:   make([]groupRewardsOutput, len(groupRewards)),
+	}
+
+	totalReward := big.NewInt(0)
+	withdrawableReward := big.NewInt(0)
+	for i, group := range groupRewards {
+		memberIndexes := make([]int, len(group.MemberIndexes))
+		for j, memberIndex := range group.MemberIndexes {
+			memberIndexes[j] = int(memberIndex)
+		}
+
+		output.Groups[i] = groupRewardsOutput{
+			GroupPublicKey:    "0x" + hex.EncodeToString(group.GroupPublicKey),
+			RegistrationBlock: group.RegistrationBlock,
+			MemberIndexes:     memberIndexes,
+			MemberReward:      group.MemberReward.String(),
+			TotalReward:       group.TotalReward.String(),
+			IsStale:           group.IsStale,
+			HasWithdrawn:      group.HasWithdrawn,
+		}
+
+		totalReward.Add(totalReward, group.TotalReward)
+		if group.IsStale && !group.HasWithdrawn {
+			withdrawableReward.Add(withdrawableReward, group.TotalReward)
+		}
+	}
+	output.TotalReward = totalReward.String()
+	output.WithdrawableReward = withdrawableReward.String()
+
+	if c.Bool(jsonFlag) {
+		return printJSON(output)
+	}
+
+	for _, group := range output.Groups {
+		fmt.Printf(
+			"Group [%v] registered at block [%v]: member indexes %v, "+
+				"member reward [%v] wei, total reward [%v] wei, "+
+				"stale: [%v], withdrawn: [%v]\n",
+			group.GroupPublicKey,
+			group.RegistrationBlock,
+			group.MemberIndexes,
+			group.MemberReward,
+			group.TotalReward,
+			group.IsStale,
+			group.HasWithdrawn,
+		)
+	}
+	fmt.Printf(
+		"Operator [%v] is a member of [%v] groups with total rewards of "+
+			"[%v] wei; [%v] wei can be withdrawn now\n",
+		output.Operator,
+		len(output.Groups),
+		output.TotalReward,
+		output.WithdrawableReward,
+	)
+
+	return nil
+}
+
+// rewardsOperator returns the operator set with the operator flag or the
+// operator from the config file if the flag is not set.
+func rewardsOperator(c *cli.Context, cfg *config.Config) (common.Address, error) {
+	operatorAddress := cfg.Ethereum.Account.Address
+	if c.IsSet(operatorFlag) {
+		operatorAddress = c.String(operatorFlag)
+	}
+	if !common.IsHexAddress(operatorAddress) {
+		return common.Address{}, fmt.Errorf(
+			"operator address [%v] is not a valid hex address",
+			operatorAddress,
+		)
+	}
+
+	return common.HexToAddress(operatorAddress), nil
+}
+
+// rewardsBlockRange returns the block range set with the block range flags.
+// The range ends at the current block if the end is not set.
+func rewardsBlockRange(
+	c *cli.Context,
+	utility chain.Utility,
+) (uint64, uint64, error) {
+	var err error
+
+	fromBlock := c.Uint64(fromBlockFlag)
+	toBlock := c.Uint64(toBlockFlag)
+	if !c.IsSet(toBlockFlag) {
+		toBlock, err = currentBlock(utility)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if fromBlock > toBlock {
+		return 0, 0, fmt.Errorf(
+			"from block [%v] is greater than to block [%v]",
+			fromBlock,
+			toBlock,
+		)
+	}
+
+	return fromBlock, toBlock, nil
+}
+
+// rewardsListOutput is the machine-readable result of the rewards list
+// command. Amounts are represented as decimal strings of wei so that they can
+// be safely processed by tools with limited number precision.
+type rewardsListOutput struct {
+	Operator           string               `json:"operator"`
+	Groups             []groupRewardsOutput `json:"groups"`
+	TotalReward        string               `json:"totalReward"`
+	WithdrawableReward string               `json:"withdrawableReward"`
+}
+
+type groupRewardsOutput struct {
+	GroupPublicKey    string `json:"groupPublicKey"`
+	RegistrationBlock uint64 `json:"registrationBlock"`
+	MemberIndexes     []int  `json:"memberIndexes"`
+	MemberReward      string `json:"memberReward"`
+	TotalReward       string `json:"totalReward"`
+	IsStale           bool   `json:"isStale"`
+	HasWithdrawn      bool   `json:"hasWithdrawn"`
+}
@@ -0,0 +1,467 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/rewards"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/urfave/cli"
+)
+
+const rewardsReportDescription = `Reports rewards the operator earned in the
+	given block range. The report covers groups of the operator registered
+	on-chain in the range and groups registered earlier which submitted a
+	relay entry or whose rewards were withdrawn in the range. The report is
+	built from group registrations, relay entries submitted by the groups and
+	group member rewards withdrawals seen on-chain, and memberships stored in
+	the data directory of the operator, including archived groups.
+
+	Rewards can be reported per group or per period. The chain does not
+	record the reward of a single relay entry, so the reward earned in
+	a period is an estimate: the current reward of a group is spread evenly
+	over all relay entries the group submitted and summed up per day, week
+	(starting on Monday) or month, in UTC. Withdrawn rewards are exact.
+	Amounts are reported in wei.
+
+	The operator from the config file is used if not set. Memberships of an
+	additional operator hosted by the client are read from its own data
+	directory. With --all-operators, the report covers the operator from the
+	config file and all additional operators: the JSON output is a list of
+	reports, one per operator, and every CSV record starts with the operator
+	address. If the end of the range is not set, the range ends at the
+	current block.`
+
+const (
+	formatFlag       = "format"
+	byFlag           = "by"
+	periodFlag       = "period"
+	dataDirFlag      = "data-dir"
+	allOperatorsFlag = "all-operators"
+)
+
+const (
+	csvFormat  = "csv"
+	jsonFormat = "json"
+
+	byGroup  = "group"
+	byPeriod = "period"
+)
+
+// reportedOperator is an operator covered by the rewards report together
+// with the storage its memberships are read from.
+type reportedOperator struct {
+	address  common.Address
+	dataDir  string
+	password string
+}
+
+// rewardsReport prints the rewards report of the operator, or of all
+// operators hosted by the client, for the given block range as CSV or JSON.
+func rewardsReport(c *cli.Context) error {
+	cfg, err := config.ReadConfig(c.GlobalString("config"))
+	if err != nil {
+		return fmt.Errorf("error reading config file: [%v]", err)
+	}
+
+	format := c.String(formatFlag)
+	if format != csvFormat && format != jsonFormat {
+		return fmt.Errorf(
+			"unknown format [%v]; expected one of [%v, %v]",
+			format,
+			csvFormat,
+			jsonFormat,
+		)
+	}
+
+	by := c.String(byFlag)
+	if by != byGroup && by != byPeriod {
+		return fmt.Errorf(
+			"unknown report grouping [%v]; expected one of [%v, %v]",
+			by,
+			byGroup,
+			byPeriod,
+		)
+	}
+
+	period, err := rewards.ParsePeriod(c.String(periodFlag))
+	if err != nil {
+		return err
+	}
+
+	operators, err := reportedOperators(c, cfg)
+	if err != nil {
+		return err
+	}
+
+	utility, err := ethereum.ConnectUtility(cfg.Ethereum)
+	if err != nil {
+		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	fromBlock, toBlock, err := rewardsBlockRange(c, utility)
+	if err != nil {
+		return err
+	}
+
+	// Groups registered before the range may still submit entries and get
+	// their rewards withdrawn in the range.
+	operatorGroupRewards := make([][]*rewards.GroupRewards, len(operators))
+	for i, operator := range operators {
+		operatorGroupRewards[i], err = rewards.ListGroupRewards(
+			utility.ThresholdRelay(),
+			operator.address.Bytes(),
+			0,
+			toBlock,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"error listing group rewards of operator [%v]: [%v]",
+				operator.address.Hex(),
+				err,
+			)
+		}
+	}
+
+	// Rewards of a group are estimated from all entries the group submitted
+	// so entries are read starting from the registration of the first group.
+	// The current reward of a group includes entries submitted after the
+	// reported range, so they are read up to the current block.
+	entriesToBlock, err := currentBlock(utility)
+	if err != nil {
+		return err
+	}
+	if entriesToBlock < toBlock {
+		entriesToBlock = toBlock
+	}
+
+	entriesFromBlock := fromBlock
+	for _, groupRewards := range operatorGroupRewards {
+		for _, group := range groupRewards {
+			if group.RegistrationBlock < entriesFromBlock {
+				entriesFromBlock = group.RegistrationBlock
+			}
+		}
+	}
+
+	requests, err := utility.PastRelayEntryRequests(
+		entriesFromBlock,
+		entriesToBlock,
+	)
+	if err != nil {
+		return fmt.Errorf("error getting relay entry requests: [%v]", err)
+	}
+
+	entries, err := utility.PastRelayEntries(entriesFromBlock, entriesToBlock)
+	if err != nil {
+		return fmt.Errorf("error getting submitted relay entries: [%v]", err)
+	}
+
+	signedEntries := make([]*rewards.SignedEntry, 0, len(entries))
+	for _, historyEvent := range relayHistoryEvents(requests, entries) {
+		if historyEvent.Type != "relayEntrySubmitted" ||
+			historyEvent.GroupPublicKey == "" {
+			continue
+		}
+
+		groupPublicKey, err := hex.DecodeString(historyEvent.GroupPublicKey)
+		if err != nil {
+			return fmt.Errorf("invalid group public key: [%v]", err)
+		}
+
+		signedEntries = append(signedEntries, &rewards.SignedEntry{
+			GroupPublicKey: groupPublicKey,
+			BlockNumber:    historyEvent.BlockNumber,
+		})
+	}
+
+	withdrawals, err := utility.PastGroupMemberRewardsWithdrawals(
+		fromBlock,
+		toBlock,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error getting group member rewards withdrawals: [%v]",
+			err,
+		)
+	}
+
+	outputs := make([]*rewardsReportOutput, len(operators))
+	for i, operator := range operators {
+		storedGroups := make(map[string]*registry.StoredGroup)
+		if operator.dataDir != "" {
+			storedGroups, err = registry.ReadStoredGroups(
+				operator.dataDir,
+				operator.password,
+			)
+			if err != nil {
+				return fmt.Errorf(
+					"error reading stored groups of operator [%v]: [%v]",
+					operator.address.Hex(),
+					err,
+				)
+			}
+		}
+
+		report, err := rewards.NewReport(
+			operator.address.Bytes(),
+			&rewards.ReportSources{
+				FromBlock:    fromBlock,
+				ToBlock:      toBlock,
+				Groups:       operatorGroupRewards[i],
+				StoredGroups: storedGroups,
+				Entries:      signedEntries,
+				Withdrawals:  withdrawals,
+				BlockTime:    utility.BlockTime,
+			},
+			period,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"error building rewards report of operator [%v]: [%v]",
+				operator.address.Hex(),
+				err,
+			)
+		}
+
+		outputs[i] = newRewardsReportOutput(report, period)
+		outputs[i].Operator = operator.address.Hex()
+		outputs[i].FromBlock = fromBlock
+		outputs[i].ToBlock = toBlock
+	}
+
+	if format == jsonFormat {
+		if c.Bool(allOperatorsFlag) {
+			return printJSON(outputs)
+		}
+		return printJSON(outputs[0])
+	}
+
+	writer := csv.NewWriter(os.Stdout)
+	if by == byPeriod {
+		writer.Write(append([]string{"operator"}, periodRewardsCSVHeader...))
+	} else {
+		writer.Write(append([]string{"operator"}, groupReportCSVHeader...))
+	}
+	for _, output := range outputs {
+		if by == byPeriod {
+			for _, periodRewards := range output.Periods {
+				writer.Write(
+					append([]string{output.Operator}, periodRewards.csvRecord()...),
+				)
+			}
+		} else {
+			for _, group := range output.Groups {
+				writer.Write(
+					append([]string{output.Operator}, group.csvRecord()...),
+				)
+			}
+		}
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// reportedOperators returns operators covered by the rewards report: all
+// operators hosted by the client if the all operators flag is set, the
+// operator set with the operator flag or the operator from the config file
+// otherwise.
+func reportedOperators(
+	c *cli.Context,
+	cfg *config.Config,
+) ([]*reportedOperator, error) {
+	if c.Bool(allOperatorsFlag) {
+		if c.IsSet(operatorFlag) || c.IsSet(dataDirFlag) {
+			return nil, fmt.Errorf(
+				"[%v] flag cannot be combined with [%v] and [%v] flags",
+				allOperatorsFlag,
+				operatorFlag,
+				dataDirFlag,
+			)
+		}
+
+		operators := make([]*reportedOperator, 0)
+		for _, hosted := range hostedOperators(cfg) {
+			if !common.IsHexAddress(hosted.account.Address) {
+				return nil, fmt.Errorf(
+					"operator address [%v] is not a valid hex address",
+					hosted.account.Address,
+				)
+			}
+
+			operators = append(operators, &reportedOperator{
+				address:  common.HexToAddress(hosted.account.Address),
+				dataDir:  hosted.dataDir,
+				password: hosted.account.KeyFilePassword,
+			})
+		}
+		return operators, nil
+	}
+
+	address, err := rewardsOperator(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dataDir, password := operatorStorage(cfg, address)
+	if c.IsSet(dataDirFlag) {
+		dataDir = c.String(dataDirFlag)
+	}
+
+	return []*reportedOperator{{
+		address:  address,
+		dataDir:  dataDir,
+		password: password,
+	}}, nil
+}
+
+// rewardsReportOutput is the machine-readable result of the rewards report
+// command. Amounts are represented as decimal strings of wei so that they can
+// be safely processed by tools with limited number precision.
+type rewardsReportOutput struct {
+	Operator             string                `json:"operator"`
+	FromBlock            uint64                `json:"fromBlock"`
+	ToBlock              uint64                `json:"toBlock"`
+	Period               string                `json:"period"`
+	Groups               []groupReportOutput   `json:"groups"`
+	Periods              []periodRewardsOutput `json:"periods"`
+	TotalEstimatedReward string                `json:"totalEstimatedReward"`
+	TotalWithdrawnReward string                `json:"totalWithdrawnReward"`
+}
+
+type groupReportOutput struct {
+	groupRewardsOutput
+	IsStored        bool   `json:"isStored"`
+	IsArchived      bool   `json:"isArchived"`
+	EntryCount      int    `json:"entryCount"`
+	WithdrawnReward string `json:"withdrawnReward"`
+	WithdrawalBlock uint64 `json:"withdrawalBlock"`
+}
+
+type periodRewardsOutput struct {
+	Start           string `json:"start"`
+	EntryCount      int    `json:"entryCount"`
+	EstimatedReward string `json:"estimatedReward"`
+	WithdrawnReward string `json:"withdrawnReward"`
+}
+
+var groupReportCSVHeader = []string{
+	"groupPublicKey",
+	"registrationBlock",
+	"memberIndexes",
+	"isStored",
+	"isArchived",
+	"entryCount",
+	"memberReward",
+	"totalReward",
+	"isStale",
+	"hasWithdrawn",
+	"withdrawnReward",
+	"withdrawalBlock",
+}
+
+var periodRewardsCSVHeader = []string{
+	"start",
+	"entryCount",
+	"estimatedReward",
+	"withdrawnReward",
+}
+
+func newRewardsReportOutput(
+	report *rewards.Report,
+	period rewards.Period,
+) *rewardsReportOutput {
+	output := &rewardsReportOutput{
+		Period:  string(period),
+		Groups:  make([]groupReportOutput, len(report.Groups)),
+		Periods: make([]periodRewardsOutput, len(report.Periods)),
+	}
+
+	for i, group := range report.Groups {
+		memberIndexes := make([]int, len(group.MemberIndexes))
+		for j, memberIndex := range group.MemberIndexes {
+			memberIndexes[j] = int(memberIndex)
+		}
+
+		output.Groups[i] = groupReportOutput{
+			groupRewardsOutput: groupRewardsOutput{
+				GroupPublicKey:    "0x" + hex.EncodeToString(group.GroupPublicKey),
+				RegistrationBlock: group.RegistrationBlock,
+				MemberIndexes:     memberIndexes,
+				MemberReward:      group.MemberReward.String(),
+				TotalReward:       group.TotalReward.String(),
+				IsStale:           group.IsStale,
+				HasWithdrawn:      group.HasWithdrawn,
+			},
+			IsStored:        group.IsStored,
+			IsArchived:      group.IsArchived,
+			EntryCount:      group.EntryCount,
+			WithdrawnReward: group.WithdrawnReward.String(),
+			WithdrawalBlock: group.WithdrawalBlock,
+		}
+	}
+
+	totalEstimatedReward := big.NewInt(0)
+	totalWithdrawnReward := big.NewInt(0)
+	for i, periodRewards := range report.Periods {
+		output.Periods[i] = periodRewardsOutput{
+			Start:           periodRewards.Start.Format("2006-01-02"),
+			EntryCount:      periodRewards.EntryCount,
+			EstimatedReward: periodRewards.EstimatedReward.String(),
+			WithdrawnReward: periodRewards.WithdrawnReward.String(),
+		}
+
+		totalEstimatedReward.Add(
+			totalEstimatedReward,
+			periodRewards.EstimatedReward,
+		)
+		totalWithdrawnReward.Add(
+			totalWithdrawnReward,
+			periodRewards.WithdrawnReward,
+		)
+	}
+	output.TotalEstimatedReward = totalEstimatedReward.String()
+	output.TotalWithdrawnReward = totalWithdrawnReward.String()
+
+	return output
+}
+
+func (gro groupReportOutput) csvRecord() []string {
+	memberIndexes := make([]string, len(gro.MemberIndexes))
+	for i, memberIndex := range gro.MemberIndexes {
+		memberIndexes[i] = strconv.Itoa(memberIndex)
+	}
+
+	return []string{
+		gro.GroupPublicKey,
+		strconv.FormatUint(gro.RegistrationBlock, 10),
+		strings.Join(memberIndexes, " "),
+		strconv.FormatBool(gro.IsStored),
+		strconv.FormatBool(gro.IsArchived),
+		strconv.Itoa(gro.EntryCount),
+		gro.MemberReward,
+		gro.TotalReward,
+		strconv.FormatBool(gro.IsStale),
+		strconv.FormatBool(gro.HasWithdrawn),
+		gro.WithdrawnReward,
+		strconv.FormatUint(gro.WithdrawalBlock, 10),
+	}
+}
+
+func (pro periodRewardsOutput) csvRecord() []string {
+	return []string{
+		pro.Start,
+		strconv.Itoa(pro.EntryCount),
+		pro.EstimatedReward,
+		pro.WithdrawnReward,
+	}
+}
@@ -3,20 +3,35 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/ipfs/go-log"
+	ethereumConfig "github.com/keep-network/keep-common/pkg/chain/ethereum"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-common/pkg/logging"
 	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-core/config"
 	"github.com/keep-network/keep-core/pkg/beacon"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/journal"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/rewards"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/devchain"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/failover"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/gasprice"
+	"github.com/keep-network/keep-core/pkg/diskpersistence"
+	"github.com/keep-network/keep-core/pkg/fieldlog"
 	"github.com/keep-network/keep-core/pkg/firewall"
+	"github.com/keep-network/keep-core/pkg/metrics"
+	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/key"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
 	"github.com/keep-network/keep-core/pkg/net/retransmission"
 	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/status"
 	"github.com/urfave/cli"
 )
 
@@ -63,100 +78,530 @@ func Start(c *cli.Context) error {
 		return fmt.Errorf("error reading config file: %v", err)
 	}
 
-	if c.Int(portFlag) > 0 {
-		config.LibP2P.Port = c.Int(portFlag)
+	applyStartFlags(c, config)
+
+	if config.Logging.Format != "" && os.Getenv(logFormatEnvVariable) == "" {
+		if err := fieldlog.SetFormat(config.Logging.Format); err != nil {
+			return fmt.Errorf("error configuring log format: [%v]", err)
+		}
 	}
 
-	// FIXME This needs to happen inside the `pkg/chain/ethereum` scope,
-	// FIXME probably.
-	operatorPrivateKey, operatorPublicKey, err := loadStaticKey(
-		config.Ethereum.Account.KeyFile,
-		config.Ethereum.Account.KeyFilePassword,
-	)
-	if err != nil {
-		return fmt.Errorf("error loading static peer's key [%v]", err)
+	if config.Logging.Level != "" && os.Getenv(logLevelEnvVariable) == "" {
+		if err := logging.Configure(config.Logging.Level); err != nil {
+			return fmt.Errorf("error configuring log levels: [%v]", err)
+		}
 	}
 
-	chainProvider, err := ethereum.Connect(config.Ethereum)
+	operators := hostedOperators(config)
+
+	chainCtx, cancelChainCtx := context.WithCancel(context.Background())
+	defer cancelChainCtx()
+
+	chainHandles, err := connectChain(chainCtx, config, operators)
 	if err != nil {
-		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+		return err
 	}
 
-	blockCounter, err := chainProvider.BlockCounter()
+	// All operators share the block counter and, unless they are connected
+	// to a development chain, the connection to the Ethereum node.
+	blockCounter, err := chainHandles[0].BlockCounter()
 	if err != nil {
 		return err
 	}
 
-	stakeMonitor, err := chainProvider.StakeMonitor()
+	stakeMonitor, err := chainHandles[0].StakeMonitor()
 	if err != nil {
 		return fmt.Errorf("error obtaining stake monitor handle [%v]", err)
 	}
+	for _, hosted := range operators {
+		if err := checkStake(
+			c,
+			stakeMonitor,
+			hosted.account.Address,
+		); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	stopSignals := handleShutdownSignals(cancelCtx)
+	defer stopSignals()
+
+	// The network and the block watcher driving retransmissions are still
+	// needed by the relay entry signing and key generation processes the
+	// beacon waits for when shutting down. They get their own context which
+	// is cancelled only once the beacons shut down.
+	networkCtx, cancelNetworkCtx := context.WithCancel(context.Background())
+	defer cancelNetworkCtx()
+
+	rewardsConfig, err := newRewardsConfig(config.Rewards)
+	if err != nil {
+		return fmt.Errorf("error configuring rewards withdrawal: [%v]", err)
+	}
+
+	statusRegistry := status.NewRegistry()
+
+	// Each operator gets its own status registry exposed under the operators
+	// status source, keyed by the operator address. The status of the
+	// operator of the Ethereum account is reported at the top level as well.
+	operatorStatusRegistries := make(map[string]*status.Registry)
+
+	netProviders := make([]net.Provider, 0, len(operators))
+	beaconShutdowns := make([]<-chan struct{}, 0, len(operators))
+	for i, hosted := range operators {
+		operatorStatusRegistry := status.NewRegistry()
+		operatorStatusRegistries[hosted.account.Address] = operatorStatusRegistry
+		if i == 0 {
+			statusRegistry.Include(operatorStatusRegistry)
+		}
+
+		netProvider, beaconShutdownCompleted, err := startOperator(
+			ctx,
+			networkCtx,
+			hosted,
+			chainHandles[i],
+			blockCounter,
+			stakeMonitor,
+			operatorStatusRegistry,
+			rewardsConfig,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"error starting operator [%v]: [%v]",
+				hosted.account.Address,
+				err,
+			)
+		}
+
+		if i == 0 {
+			nodeHeader(
+				netProvider.ConnectionManager().AddrStrings(),
+				hosted.libp2p.Port,
+			)
+		} else {
+			logger.Infof(
+				"operator [%v] started on port [%v] with addresses %v",
+				hosted.account.Address,
+				hosted.libp2p.Port,
+				netProvider.ConnectionManager().AddrStrings(),
+			)
+		}
+
+		netProviders = append(netProviders, netProvider)
+		beaconShutdowns = append(beaconShutdowns, beaconShutdownCompleted)
+	}
+
+	statusRegistry.RegisterSource("operators", func() (interface{}, error) {
+		snapshots := make(map[string]interface{})
+		for address, registry := range operatorStatusRegistries {
+			snapshots[address] = registry.Snapshot()
+		}
+		return snapshots, nil
+	})
+
+	handleReloadSignals(ctx, &configReloader{
+		cliContext:   c,
+		configPath:   c.GlobalString("config"),
+		netProviders: netProviders,
+		current:      config,
+	})
+
+	if config.Status.Port > 0 {
+		statusRegistry.EnableServer(ctx, config.Status.ListenAddress())
+	}
+
+	if config.Metrics.Port > 0 {
+		metrics.EnableServer(ctx, config.Metrics.ListenAddress())
+	}
+
+	// Block until the root context is cancelled and the beacons of all
+	// operators complete their shutdown.
+	for _, beaconShutdownCompleted := range beaconShutdowns {
+		<-beaconShutdownCompleted
+	}
+	cancelNetworkCtx()
+
+	logger.Infof("client shut down")
+
+	return nil
+}
+
+// connectChain connects all hosted operators to the development chain if its
+// socket is configured or to the Ethereum node otherwise. Connections are
+// closed, or stop being kept up to date, once the context is done.
+func connectChain(
+	ctx context.Context,
+	config *config.Config,
+	operators []*hostedOperator,
+) ([]chain.Handle, error) {
+	if config.DevChain.Socket != "" {
+		chainHandles := make([]chain.Handle, 0, len(operators))
+		for _, hosted := range operators {
+			operatorPrivateKey, _, err := loadStaticKey(
+				hosted.account.KeyFile,
+				hosted.account.KeyFilePassword,
+			)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"error loading static peer's key [%v]",
+					err,
+				)
+			}
+
+			chainHandle, err := devchain.Connect(
+				ctx,
+				config.DevChain.Socket,
+				operatorPrivateKey,
+			)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"error connecting to development chain: [%v]",
+					err,
+				)
+			}
+
+			chainHandles = append(chainHandles, chainHandle)
+		}
+
+		return chainHandles, nil
+	}
+
+	operatorConfigs := make([]ethereum.OperatorConfig, 0, len(operators))
+	for _, hosted := range operators {
+		operatorConfigs = append(operatorConfigs, ethereum.OperatorConfig{
+			Account: hosted.account,
+			DataDir: hosted.dataDir,
+		})
+	}
+
+	gasPriceConfig, err := newGasPriceConfig(config.GasPrice)
+	if err != nil {
+		return nil, fmt.Errorf("error configuring gas price: [%v]", err)
+	}
+
+	chainHandles, err := ethereum.ConnectOperators(
+		ctx,
+		config.Ethereum,
+		operatorConfigs,
+		newFailoverConfig(config.EthereumFailover),
+		gasPriceConfig,
+		uint64(config.Events.ConfirmationDepth),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	return chainHandles, nil
+}
+
+// hostedOperator describes a single operator hosted by the client.
+type hostedOperator struct {
+	account ethereumConfig.Account
+	libp2p  libp2p.Config
+	dataDir string
+}
+
+// hostedOperators returns all operators hosted by the client: the operator of
+// the Ethereum account first, followed by the additional operators. Network
+// settings of additional operators other than the port and the announced
+// addresses are the same as for the operator of the Ethereum account.
+func hostedOperators(config *config.Config) []*hostedOperator {
+	operators := []*hostedOperator{
+		{
+			account: config.Ethereum.Account,
+			libp2p:  config.LibP2P,
+			dataDir: config.Storage.DataDir,
+		},
+	}
+
+	for _, operatorConfig := range config.Operators {
+		libp2pConfig := config.LibP2P
+		libp2pConfig.Port = operatorConfig.Port
+		libp2pConfig.AnnouncedAddresses = operatorConfig.AnnouncedAddresses
+
+		operators = append(operators, &hostedOperator{
+			account: operatorConfig.Account,
+			libp2p:  libp2pConfig,
+			dataDir: operatorConfig.DataDir,
+		})
+	}
+
+	return operators
+}
+
+// checkStake waits for the operator to have the minimum stake if requested
+// with the wait for stake flag and checks whether the operator has it.
+func checkStake(
+	c *cli.Context,
+	stakeMonitor chain.StakeMonitor,
+	address string,
+) error {
 	if c.Int(waitForStakeFlag) != 0 {
-		err = waitForStake(stakeMonitor, config.Ethereum.Account.Address, c.Int(waitForStakeFlag))
+		err := waitForStake(stakeMonitor, address, c.Int(waitForStakeFlag))
 		if err != nil {
 			return err
 		}
 	}
-	hasMinimumStake, err := stakeMonitor.HasMinimumStake(
-		config.Ethereum.Account.Address,
-	)
+	hasMinimumStake, err := stakeMonitor.HasMinimumStake(address)
 	if err != nil {
 		return fmt.Errorf("could not check the stake [%v]", err)
 	}
 	if !hasMinimumStake {
 		return fmt.Errorf(
-			"no minimum KEEP stake or operator is not authorized to use it; " +
-				"please make sure the operator address in the configuration " +
-				"is correct and it has KEEP tokens delegated and the operator " +
-				"contract has been authorized to operate on the stake",
+			"no minimum KEEP stake for operator [%v] or operator is not "+
+				"authorized to use it; please make sure the operator address "+
+				"in the configuration is correct and it has KEEP tokens "+
+				"delegated and the operator contract has been authorized to "+
+				"operate on the stake",
+			address,
 		)
 	}
 
-	ctx := context.Background()
+	return nil
+}
+
+// startOperator starts the libp2p host and the beacon of the operator. The
+// host is stopped once the network context is done and the beacon shuts down
+// once the root context is done.
+func startOperator(
+	ctx context.Context,
+	networkCtx context.Context,
+	hosted *hostedOperator,
+	chainHandle chain.Handle,
+	blockCounter chain.BlockCounter,
+	stakeMonitor chain.StakeMonitor,
+	statusRegistry *status.Registry,
+	rewardsConfig *rewards.Config,
+) (net.Provider, <-chan struct{}, error) {
+	// FIXME This needs to happen inside the `pkg/chain/ethereum` scope,
+	// FIXME probably.
+	operatorPrivateKey, operatorPublicKey, err := loadStaticKey(
+		hosted.account.KeyFile,
+		hosted.account.KeyFilePassword,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading static peer's key [%v]", err)
+	}
+
 	networkPrivateKey, _ := key.OperatorKeyToNetworkKey(
 		operatorPrivateKey, operatorPublicKey,
 	)
 	netProvider, err := libp2p.Connect(
-		ctx,
-		config.LibP2P,
+		networkCtx,
+		hosted.libp2p,
 		networkPrivateKey,
 		firewall.MinimumStakePolicy(stakeMonitor),
-		retransmission.NewTicker(blockCounter.WatchBlocks(ctx)),
+		retransmission.NewTicker(blockCounter.WatchBlocks(networkCtx)),
 	)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	nodeHeader(netProvider.ConnectionManager().AddrStrings(), config.LibP2P.Port)
-
-	handle, err := persistence.NewDiskHandle(config.Storage.DataDir)
+	handle, err := diskpersistence.NewHandle(hosted.dataDir)
 	if err != nil {
-		return fmt.Errorf("failed while creating a storage disk handler: [%v]", err)
+		return nil, nil, fmt.Errorf(
+			"failed while creating a storage disk handler: [%v]",
+			err,
+		)
 	}
 	persistence := persistence.NewEncryptedPersistence(
 		handle,
-		config.Ethereum.Account.KeyFilePassword,
+		hosted.account.KeyFilePassword,
 	)
 
-	err = beacon.Initialize(
+	if err := startJournal(ctx, hosted.dataDir, chainHandle); err != nil {
+		return nil, nil, fmt.Errorf("error starting event journal: [%v]", err)
+	}
+
+	registerNodeStatusSources(
+		statusRegistry,
+		hosted.account.Address,
+		netProvider,
+		blockCounter,
+	)
+
+	beaconShutdownCompleted, err := beacon.Initialize(
 		ctx,
-		config.Ethereum.Account.Address,
-		chainProvider,
+		hosted.account.Address,
+		chainHandle,
 		netProvider,
 		persistence,
+		statusRegistry,
+		rewardsConfig,
 	)
 	if err != nil {
-		return fmt.Errorf("error initializing beacon: [%v]", err)
+		return nil, nil, fmt.Errorf("error initializing beacon: [%v]", err)
 	}
 
-	select {
-	case <-ctx.Done():
-		if err != nil {
-			return err
+	return netProvider, beaconShutdownCompleted, nil
+}
+
+// startJournal records relay events seen by the operator in the event
+// journal in the operator's data directory until the context is done.
+func startJournal(
+	ctx context.Context,
+	dataDir string,
+	chainHandle chain.Handle,
+) error {
+	eventJournal, err := journal.Open(dataDir)
+	if err != nil {
+		return err
+	}
+
+	subscription, err := journal.Watch(chainHandle.ThresholdRelay(), eventJournal)
+	if err != nil {
+		eventJournal.Close()
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		subscription.Unsubscribe()
+		if err := eventJournal.Close(); err != nil {
+			logger.Errorf("could not close event journal: [%v]", err)
 		}
+	}()
 
-		return fmt.Errorf("uh-oh, we went boom boom for no reason")
+	return nil
+}
+
+// newRewardsConfig converts the rewards section of the config file to the
+// config of the rewards withdrawal service.
+func newRewardsConfig(rewardsConfig config.Rewards) (*rewards.Config, error) {
+	minimumPayout, err := rewardsConfig.MinimumPayoutWei()
+	if err != nil {
+		return nil, fmt.Errorf("invalid minimum payout: [%v]", err)
 	}
+
+	gasPriceCeiling, err := rewardsConfig.GasPriceCeilingWei()
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas price ceiling: [%v]", err)
+	}
+
+	return &rewards.Config{
+		MinimumPayout:   minimumPayout,
+		GasPriceCeiling: gasPriceCeiling,
+	}, nil
+}
+
+// newFailoverConfig converts the Ethereum failover section of the config file
+// to the config of the Ethereum endpoints failover.
+func newFailoverConfig(failoverConfig config.EthereumFailover) *failover.Config {
+	endpoints := make([]failover.EndpointConfig, 0, len(failoverConfig.Endpoints))
+	for _, endpoint := range failoverConfig.Endpoints {
+		endpoints = append(endpoints, failover.EndpointConfig{
+			URL:    endpoint.URL,
+			URLRPC: endpoint.URLRPC,
+		})
+	}
+
+	return &failover.Config{
+		Endpoints: endpoints,
+		CheckInterval: time.Duration(failoverConfig.HealthCheckInterval) *
+			time.Second,
+		MaxBlockLag: uint64(failoverConfig.MaxBlockLag),
+	}
+}
+
+// newGasPriceConfig converts the gas price section of the config file to the
+// config of the gas price strategy.
+func newGasPriceConfig(gasPriceConfig config.GasPrice) (*gasprice.Config, error) {
+	price, err := gasPriceConfig.PriceWei()
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas price: [%v]", err)
+	}
+
+	maxPrice, err := gasPriceConfig.MaxPriceWei()
+	if err != nil {
+		return nil, fmt.Errorf("invalid maximum gas price: [%v]", err)
+	}
+
+	return &gasprice.Config{
+		Strategy:       gasPriceConfig.Strategy,
+		Price:          price,
+		OracleURL:      gasPriceConfig.OracleURL,
+		OracleField:    gasPriceConfig.OracleField,
+		MaxPrice:       maxPrice,
+		BumpPercent:    gasPriceConfig.BumpPercent,
+		ResubmitBlocks: uint64(gasPriceConfig.ResubmitBlocks),
+	}, nil
+}
+
+// applyStartFlags overrides config values with values of the start command
+// flags, which take precedence over the config file and the environment.
+func applyStartFlags(c *cli.Context, config *config.Config) {
+	if c.Int(portFlag) > 0 {
+		config.LibP2P.Port = c.Int(portFlag)
+	}
+}
+
+// handleShutdownSignals cancels the root context of the client when SIGINT or
+// SIGTERM is received so that all components can shut down gracefully.
+// Subsequent signals are logged and ignored so that they do not interrupt
+// writing group data to the storage; signals are handled this way until the
+// returned function is called once the shutdown completes. The shutdown does
+// not wait for the work in progress longer than a few minutes and SIGKILL
+// still terminates the client immediately.
+func handleShutdownSignals(cancelCtx context.CancelFunc) (stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	stopped := make(chan struct{})
+
+	go func() {
+		select {
+		case receivedSignal := <-signals:
+			logger.Infof("received [%v] signal; shutting down", receivedSignal)
+			cancelCtx()
+		case <-stopped:
+			return
+		}
+
+		for {
+			select {
+			case receivedSignal := <-signals:
+				logger.Warningf(
+					"received [%v] signal; shutdown is already in progress",
+					receivedSignal,
+				)
+			case <-stopped:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(stopped)
+	}
+}
+
+// registerNodeStatusSources exposes the operator's staker address, peers the
+// node is connected to and the current block in the node status.
+func registerNodeStatusSources(
+	statusRegistry *status.Registry,
+	stakerAddress string,
+	netProvider net.Provider,
+	blockCounter chain.BlockCounter,
+) {
+	statusRegistry.RegisterSource("stakerAddress", func() (interface{}, error) {
+		return stakerAddress, nil
+	})
+
+	statusRegistry.RegisterSource("connectedPeers", func() (interface{}, error) {
+		connectedPeers := netProvider.ConnectionManager().ConnectedPeers()
+		if connectedPeers == nil {
+			connectedPeers = []string{}
+		}
+
+		return connectedPeers, nil
+	})
+
+	statusRegistry.RegisterSource("currentBlock", func() (interface{}, error) {
+		return blockCounter.CurrentBlock()
+	})
 }
 
 func loadStaticKey(
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"reflect"
+)
+
+// ChangedFields returns paths of all config fields which values differ
+// between the two configs, e.g. LibP2P.Peers. List and map fields are
+// compared as a whole; empty and unset lists and maps are considered equal.
+func ChangedFields(current, updated *Config) []string {
+	return changedFields(
+		reflect.ValueOf(*current),
+		reflect.ValueOf(*updated),
+		"",
+	)
+}
+
+func changedFields(current, updated reflect.Value, fieldPath string) []string {
+	if current.Kind() == reflect.Struct {
+		changed := make([]string, 0)
+		for i := 0; i < current.NumField(); i++ {
+			field := current.Type().Field(i)
+			if field.PkgPath != "" {
+				continue // unexported field
+			}
+
+			nestedPath := field.Name
+			if fieldPath != "" {
+				nestedPath = fieldPath + "." + field.Name
+			}
+
+			changed = append(
+				changed,
+				changedFields(current.Field(i), updated.Field(i), nestedPath)...,
+			)
+		}
+		return changed
+	}
+
+	switch current.Kind() {
+	case reflect.Slice, reflect.Map:
+		if current.Len() == 0 && updated.Len() == 0 {
+			return nil
+		}
+	}
+
+	if !reflect.DeepEqual(current.Interface(), updated.Interface()) {
+		return []string{fieldPath}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangedFields(t *testing.T) {
+	var tests = map[string]struct {
+		modifyUpdated  func(*Config)
+		expectedFields []string
+	}{
+		"no changes": {
+			modifyUpdated:  func(c *Config) {},
+			expectedFields: []string{},
+		},
+		"unset and empty lists": {
+			modifyUpdated: func(c *Config) {
+				c.LibP2P.AnnouncedAddresses = nil
+			},
+			expectedFields: []string{},
+		},
+		"changed fields": {
+			modifyUpdated: func(c *Config) {
+				c.Ethereum.Account.Address = "0x65ea55c1f10491038425725dc00dffeab2a1e28a"
+				c.Ethereum.ContractAddresses = map[string]string{
+					"KeepRandomBeaconOperator": "0x65ea55c1f10491038425725dc00dffeab2a1e28a",
+				}
+				c.LibP2P.Peers = []string{}
+				c.Logging.Level = "keep*=debug"
+			},
+			expectedFields: []string{
+				"Ethereum.ContractAddresses",
+				"Ethereum.Account.Address",
+				"LibP2P.Peers",
+				"Logging.Level",
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			current := validConfig()
+			current.LibP2P.AnnouncedAddresses = []string{}
+
+			updated := validConfig()
+			updated.LibP2P.AnnouncedAddresses = []string{}
+			test.modifyUpdated(updated)
+
+			actualFields := ChangedFields(current, updated)
+
+			if !reflect.DeepEqual(test.expectedFields, actualFields) {
+				t.Errorf(
+					"unexpected changed fields\nexpected: [%v]\nactual:   [%v]",
+					test.expectedFields,
+					actualFields,
+				)
+			}
+		})
+	}
+}
@@ -2,23 +2,66 @@ package config
 
 import (
 	"fmt"
+	"math/big"
+	"net"
 	"os"
+	"strconv"
 	"strings"
 	"syscall"
 
 	"github.com/BurntSushi/toml"
+	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
 	"golang.org/x/crypto/ssh/terminal"
 )
 
+var logger = log.Logger("keep-config")
+
 const passwordEnvVariable = "KEEP_ETHEREUM_PASSWORD"
 
 // Config is the top level config structure.
 type Config struct {
-	Ethereum ethereum.Config
-	LibP2P   libp2p.Config
-	Storage  Storage
+	Ethereum         ethereum.Config
+	EthereumFailover EthereumFailover
+	LibP2P           libp2p.Config
+	Storage          Storage
+	Status           Status
+	Metrics          Metrics
+	Logging          Logging
+	Rewards          Rewards
+	GasPrice         GasPrice
+	Events           Events
+	DevChain         DevChain
+
+	// Operators lists additional operators hosted by the client next to the
+	// operator of the Ethereum account.
+	Operators []Operator
+}
+
+// EthereumFailover stores configuration of additional Ethereum endpoints the
+// client switches to when the Ethereum node of the Ethereum section is not
+// healthy.
+type EthereumFailover struct {
+	// Endpoints are the additional Ethereum endpoints in the order of
+	// preference. The Ethereum node of the Ethereum section is preferred
+	// over all of them.
+	Endpoints []EthereumEndpoint
+
+	// HealthCheckInterval is the number of seconds between health checks of
+	// all endpoints. 15 is used if not set.
+	HealthCheckInterval int
+
+	// MaxBlockLag is the number of blocks an endpoint can be behind the best
+	// endpoint and still be considered healthy. 5 is used if not set.
+	MaxBlockLag int
+}
+
+// EthereumEndpoint stores URLs of an additional Ethereum endpoint, in the
+// same format as URL and URLRPC of the Ethereum section.
+type EthereumEndpoint struct {
+	URL    string
+	URLRPC string
 }
 
 // Storage stores meta-info about keeping data on disk
@@ -26,6 +69,189 @@ type Storage struct {
 	DataDir string
 }
 
+// Operator stores configuration of an additional operator hosted by the
+// client. All operators share the connection to the Ethereum node; each of
+// them has its own libp2p host and storage directory.
+type Operator struct {
+	// Account of the operator. The password of the Ethereum account is used
+	// if the key file password is not set.
+	Account ethereum.Account
+
+	// Port on which the libp2p host of the operator listens.
+	Port int
+
+	// AnnouncedAddresses overrides the default addresses of the libp2p host
+	// of the operator announced in the network.
+	AnnouncedAddresses []string
+
+	// DataDir is the directory where data of the operator are stored. It
+	// must be different from the storage directory of every other operator.
+	DataDir string
+}
+
+// DefaultEndpointAddress is the address on which the node status and
+// metrics endpoints listen if no address is configured. The endpoints are
+// reachable only from the local machine by default.
+const DefaultEndpointAddress = "127.0.0.1"
+
+// Status stores configuration of the local node status endpoint.
+type Status struct {
+	// Address of the network interface on which the node status is served.
+	// Defaults to DefaultEndpointAddress.
+	Address string
+	// Port on which the node status is served over HTTP. The status endpoint
+	// is disabled if the port is not set.
+	Port int
+}
+
+// ListenAddress returns the host and port on which the node status is
+// served.
+func (s Status) ListenAddress() string {
+	return listenAddress(s.Address, s.Port)
+}
+
+// Metrics stores configuration of the Prometheus metrics endpoint.
+type Metrics struct {
+	// Address of the network interface on which metrics are served.
+	// Defaults to DefaultEndpointAddress.
+	Address string
+	// Port on which metrics are served over HTTP. The metrics endpoint is
+	// disabled if the port is not set.
+	Port int
+}
+
+// ListenAddress returns the host and port on which metrics are served.
+func (m Metrics) ListenAddress() string {
+	return listenAddress(m.Address, m.Port)
+}
+
+// Logging stores configuration of log levels and the log output format.
+type Logging struct {
+	// Level is a space-delimited set of log level directives in the same
+	// format as the LOG_LEVEL environment variable, which takes precedence
+	// if set.
+	Level string
+
+	// Format is the log output format, either `text` or `json`. The
+	// LOG_FORMAT environment variable takes precedence if set.
+	Format string
+}
+
+// Rewards stores configuration of the automatic withdrawal of group member
+// rewards. Amounts are decimal numbers of wei.
+type Rewards struct {
+	// MinimumPayout is the minimum amount withdrawn for a single group.
+	// Lower rewards are left for a manual withdrawal. All rewards are
+	// withdrawn if not set.
+	MinimumPayout string
+
+	// GasPriceCeiling is the maximum gas price at which rewards are
+	// withdrawn. Withdrawals are postponed while the gas price is higher.
+	// There is no ceiling if not set.
+	GasPriceCeiling string
+}
+
+// MinimumPayoutWei returns the minimum payout as a number of wei.
+func (r Rewards) MinimumPayoutWei() (*big.Int, error) {
+	return parseWei(r.MinimumPayout)
+}
+
+// GasPriceCeilingWei returns the gas price ceiling as a number of wei.
+func (r Rewards) GasPriceCeilingWei() (*big.Int, error) {
+	return parseWei(r.GasPriceCeiling)
+}
+
+// GasPrice stores configuration of the gas price of relay entries, tickets,
+// DKG results and relay entry timeout reports submitted by the client and of
+// replacing such transactions when they get stuck. Prices are decimal numbers
+// of wei. Gas prices are always capped at the gas price ceiling of the
+// operator contract.
+type GasPrice struct {
+	// Strategy is `node` to use the gas price suggested by the Ethereum node,
+	// `fixed` to use Price or `oracle` to read the gas price from OracleURL.
+	// The node strategy is used if not set.
+	Strategy string
+
+	// Price is the gas price of the fixed strategy.
+	Price string
+
+	// OracleURL is the URL of the gas price oracle serving a JSON object.
+	OracleURL string
+
+	// OracleField is the field of the oracle response holding the gas price
+	// in gwei. Fields of nested objects are separated with dots.
+	OracleField string
+
+	// MaxPrice is the maximum gas price of a transaction, including
+	// replacements of stuck transactions. There is no maximum other than the
+	// contract gas price ceiling if not set.
+	MaxPrice string
+
+	// BumpPercent is the percentage by which the gas price of a stuck
+	// transaction is increased when it is replaced. It must be at least 10;
+	// 20 is used if not set.
+	BumpPercent int
+
+	// ResubmitBlocks is the number of blocks after which a transaction which
+	// has not been mined is replaced. 5 is used if not set.
+	ResubmitBlocks int
+}
+
+// Events stores configuration of chain events handled by the client.
+type Events struct {
+	// ConfirmationDepth is the number of blocks a relay entry request or
+	// a group selection start has to be buried under before the client acts
+	// on it. Events are handled as soon as they are seen if not set.
+	ConfirmationDepth int
+}
+
+// DevChain stores configuration of the development chain the client connects
+// to instead of Ethereum.
+type DevChain struct {
+	// Socket is the path of the unix socket of the development chain started
+	// with `keep-client devchain start`. The client connects to Ethereum if
+	// not set.
+	Socket string
+}
+
+// listenAddress joins the configured address, or DefaultEndpointAddress if
+// no address is configured, with the port.
+func listenAddress(address string, port int) string {
+	if address == "" {
+		address = DefaultEndpointAddress
+	}
+
+	return net.JoinHostPort(address, strconv.Itoa(port))
+}
+
+// PriceWei returns the price of the fixed strategy as a number of wei.
+func (gp GasPrice) PriceWei() (*big.Int, error) {
+	return parseWei(gp.Price)
+}
+
+// MaxPriceWei returns the maximum gas price as a number of wei.
+func (gp GasPrice) MaxPriceWei() (*big.Int, error) {
+	return parseWei(gp.MaxPrice)
+}
+
+// parseWei parses a non-negative decimal amount of wei. An empty value is
+// parsed as zero.
+func parseWei(value string) (*big.Int, error) {
+	if value == "" {
+		return big.NewInt(0), nil
+	}
+
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil, fmt.Errorf("[%v] is not a decimal number", value)
+	}
+	if amount.Sign() < 0 {
+		return nil, fmt.Errorf("[%v] is negative", value)
+	}
+
+	return amount, nil
+}
+
 var (
 	// KeepOpts contains global application settings
 	KeepOpts Config
@@ -33,11 +259,81 @@ var (
 
 // ReadConfig reads in the configuration file at `filePath` and returns the
 // valid config stored there, or an error if something fails while reading the
-// file or the config is invalid in a known way.
+// file or the config is invalid in a known way. If the config is invalid,
+// the returned error is a *ValidationError listing all problems found.
+//
+// Values from the file can be overridden with KEEP_<SECTION>_<FIELD>
+// environment variables, e.g. KEEP_LIBP2P_PEERS. Command-line flags, like
+// --port, take precedence over both and are applied by the commands on the
+// returned config.
+//
+// ReadConfig checks only the config values. Checks which depend on the
+// environment, like whether the key file can be decrypted, are performed by
+// ValidateConfig.
 func ReadConfig(filePath string) (*Config, error) {
-	config := &Config{}
-	if _, err := toml.DecodeFile(filePath, config); err != nil {
-		return nil, fmt.Errorf("unable to decode .toml file [%s] error [%s]", filePath, err)
+	config, validation, err := readConfig(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	config.validateFields(validation)
+
+	if err := validation.err(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// ValidateConfig reads in the configuration file at `filePath` and performs
+// all available checks of the config stored there. Apart from the checks
+// performed by ReadConfig, it checks whether the key file can be decrypted
+// with the configured password and whether the storage directory is
+// writable. If the config is invalid, the returned error is
+// a *ValidationError listing all problems found.
+func ValidateConfig(filePath string) (*Config, error) {
+	config, validation, err := readConfig(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	config.validateFields(validation)
+	config.validateEnvironment(validation)
+
+	if err := validation.err(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// ReloadConfig reads in the configuration file at `filePath` again for the
+// client running with the current config. It works the same way as
+// ReadConfig but the account password is taken from the current config
+// instead of being read from the environment or prompted for.
+func ReloadConfig(filePath string, current *Config) (*Config, error) {
+	config, validation, err := readConfigFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	config.Ethereum.Account.KeyFilePassword =
+		current.Ethereum.Account.KeyFilePassword
+	config.applyOperatorPasswords()
+
+	config.validateFields(validation)
+
+	if err := validation.err(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func readConfig(filePath string) (*Config, *validation, error) {
+	config, validation, err := readConfigFile(filePath)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	envPassword := os.Getenv(passwordEnvVariable)
@@ -47,31 +343,40 @@ func ReadConfig(filePath string) (*Config, error) {
 			err      error
 		)
 		if password, err = readPassword("Enter Account Password: "); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		config.Ethereum.Account.KeyFilePassword = password
 	} else {
 		config.Ethereum.Account.KeyFilePassword = envPassword
 	}
+	config.applyOperatorPasswords()
 
-	if config.Ethereum.Account.KeyFilePassword == "" {
-		return nil, fmt.Errorf(
-			"password is required; set in the config file, set environment "+
-				"variable %v to the password, or set the same environment "+
-				"variable to 'prompt' to be prompted for the password at startup",
-			passwordEnvVariable,
-		)
-	}
+	return config, validation, nil
+}
 
-	if config.LibP2P.Port == 0 {
-		return nil, fmt.Errorf("missing value for port; see node section in config file or use --port flag")
+// applyOperatorPasswords sets the password of the Ethereum account for all
+// additional operators with no key file password set.
+func (c *Config) applyOperatorPasswords() {
+	for i := range c.Operators {
+		if c.Operators[i].Account.KeyFilePassword == "" {
+			c.Operators[i].Account.KeyFilePassword =
+				c.Ethereum.Account.KeyFilePassword
+		}
 	}
+}
 
-	if config.Storage.DataDir == "" {
-		return nil, fmt.Errorf("missing value for storage directory data")
+// readConfigFile decodes the configuration file at `filePath` and applies
+// environment variable overrides.
+func readConfigFile(filePath string) (*Config, *validation, error) {
+	config := &Config{}
+	if _, err := toml.DecodeFile(filePath, config); err != nil {
+		return nil, nil, fmt.Errorf("unable to decode .toml file [%s] error [%s]", filePath, err)
 	}
 
-	return config, nil
+	validation := &validation{}
+	config.applyEnvironment(os.Environ(), validation)
+
+	return config, validation, nil
 }
 
 // ReadEthereumConfig reads in the configuration file at `filePath` and returns
@@ -4,6 +4,8 @@ import (
 	"os"
 	"reflect"
 	"testing"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
 )
 
 func TestReadConfig(t *testing.T) {
@@ -50,6 +52,20 @@ func TestReadConfig(t *testing.T) {
 			readValueFunc: func(c *Config) interface{} { return c.Storage.DataDir },
 			expectedValue: "/my/secure/location",
 		},
+		"Operators": {
+			readValueFunc: func(c *Config) interface{} { return c.Operators },
+			expectedValue: []Operator{
+				{
+					Account: ethereum.Account{
+						Address:         "0x6ffba2d0f4c8fd7263f546afaaf25fe2d56f6044",
+						KeyFile:         "/tmp/UTC--2018-03-11T01-37-33.202765887Z--6ffba2d0f4c8fd7263f546afaaf25fe2d56f6044",
+						KeyFilePassword: "not-my-password",
+					},
+					Port:    27002,
+					DataDir: "/my/secure/location/2",
+				},
+			},
+		},
 	}
 
 	for testName, test := range configReadTests {
@@ -63,3 +79,36 @@ func TestReadConfig(t *testing.T) {
 	}
 
 }
+
+func TestListenAddress(t *testing.T) {
+	var tests = map[string]struct {
+		status          Status
+		expectedAddress string
+	}{
+		"default address": {
+			status:          Status{Port: 9601},
+			expectedAddress: "127.0.0.1:9601",
+		},
+		"configured address": {
+			status:          Status{Address: "0.0.0.0", Port: 9601},
+			expectedAddress: "0.0.0.0:9601",
+		},
+		"configured IPv6 address": {
+			status:          Status{Address: "::1", Port: 9601},
+			expectedAddress: "[::1]:9601",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			address := test.status.ListenAddress()
+			if address != test.expectedAddress {
+				t.Errorf(
+					"unexpected listen address\nexpected: [%v]\nactual:   [%v]",
+					test.expectedAddress,
+					address,
+				)
+			}
+		})
+	}
+}
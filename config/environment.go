@@ -0,0 +1,203 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// environmentPrefix is the prefix of environment variables overriding config
+// values.
+const environmentPrefix = "KEEP"
+
+// environmentListSeparator separates elements of list-valued config fields
+// set with environment variables.
+const environmentListSeparator = ","
+
+// environmentExcludedFields lists config fields which cannot be overridden
+// with KEEP_<SECTION>_<FIELD> environment variables because they are read
+// from the environment in a different way.
+var environmentExcludedFields = map[string]bool{
+	"Ethereum.Account.KeyFilePassword": true, // see passwordEnvVariable
+}
+
+// applyEnvironment overrides config values read from the config file with
+// values of environment variables. Each config field can be overridden with
+// a variable named KEEP_<SECTION>_<FIELD>, where section and field names are
+// upper-cased and nested sections are joined with underscores, for example
+// KEEP_LIBP2P_PORT or KEEP_ETHEREUM_ACCOUNT_ADDRESS. Values of list fields
+// are comma-separated. Map fields are set one key at a time, for example
+// KEEP_ETHEREUM_CONTRACTADDRESSES_KeepRandomBeaconOperator.
+//
+// The environment is passed in the form returned by os.Environ. Values which
+// could not be applied are reported to the validation.
+func (c *Config) applyEnvironment(environment []string, validation *validation) {
+	variables := make(map[string]string)
+	for _, variable := range environment {
+		nameAndValue := strings.SplitN(variable, "=", 2)
+		if len(nameAndValue) != 2 ||
+			!strings.HasPrefix(nameAndValue[0], environmentPrefix+"_") {
+			continue
+		}
+		variables[nameAndValue[0]] = nameAndValue[1]
+	}
+
+	override := &environmentOverride{
+		variables:  variables,
+		applied:    make(map[string]bool),
+		validation: validation,
+	}
+	override.apply(reflect.ValueOf(c).Elem(), "", environmentPrefix)
+
+	override.warnUnapplied(reflect.TypeOf(*c))
+}
+
+type environmentOverride struct {
+	variables  map[string]string
+	applied    map[string]bool
+	validation *validation
+}
+
+func (eo *environmentOverride) apply(
+	value reflect.Value,
+	fieldPath string,
+	variableName string,
+) {
+	if environmentExcludedFields[fieldPath] {
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if field.PkgPath != "" {
+				continue // unexported field
+			}
+
+			eo.apply(
+				value.Field(i),
+				strings.TrimPrefix(fieldPath+"."+field.Name, "."),
+				variableName+"_"+strings.ToUpper(field.Name),
+			)
+		}
+
+	case reflect.String:
+		if variable, ok := eo.lookup(variableName); ok {
+			value.SetString(variable)
+		}
+
+	case reflect.Int:
+		if variable, ok := eo.lookup(variableName); ok {
+			parsed, err := strconv.Atoi(strings.TrimSpace(variable))
+			if err != nil {
+				eo.validation.report(
+					fieldPath,
+					"environment variable %v value [%v] is not an integer",
+					variableName,
+					variable,
+				)
+				return
+			}
+			value.SetInt(int64(parsed))
+		}
+
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.String {
+			return
+		}
+
+		if variable, ok := eo.lookup(variableName); ok {
+			elements := make([]string, 0)
+			for _, element := range strings.Split(
+				variable,
+				environmentListSeparator,
+			) {
+				if element = strings.TrimSpace(element); element != "" {
+					elements = append(elements, element)
+				}
+			}
+			value.Set(reflect.ValueOf(elements))
+		}
+
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String ||
+			value.Type().Elem().Kind() != reflect.String {
+			return
+		}
+
+		eo.applyMap(value, variableName+"_")
+	}
+}
+
+// applyMap sets map entries from variables named <prefix><KEY>. Existing
+// entries are matched with keys case-insensitively, other keys are added
+// exactly as they appear in the variable name.
+func (eo *environmentOverride) applyMap(value reflect.Value, prefix string) {
+	names := make([]string, 0)
+	for name := range eo.variables {
+		if strings.HasPrefix(name, prefix) && len(name) > len(prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		return
+	}
+
+	if value.IsNil() {
+		value.Set(reflect.MakeMap(value.Type()))
+	}
+
+	for _, name := range names {
+		key := strings.TrimPrefix(name, prefix)
+		for _, existingKey := range value.MapKeys() {
+			if strings.EqualFold(existingKey.String(), key) {
+				key = existingKey.String()
+				break
+			}
+		}
+
+		variable, _ := eo.lookup(name)
+		value.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(variable))
+	}
+}
+
+func (eo *environmentOverride) lookup(variableName string) (string, bool) {
+	variable, ok := eo.variables[variableName]
+	if ok {
+		eo.applied[variableName] = true
+	}
+	return variable, ok
+}
+
+// warnUnapplied logs variables which look like config overrides, because they
+// start with the name of one of the config sections, but do not match any
+// config field.
+func (eo *environmentOverride) warnUnapplied(configType reflect.Type) {
+	sectionPrefixes := make([]string, 0, configType.NumField())
+	for i := 0; i < configType.NumField(); i++ {
+		sectionPrefixes = append(
+			sectionPrefixes,
+			environmentPrefix+"_"+strings.ToUpper(configType.Field(i).Name)+"_",
+		)
+	}
+
+	for name := range eo.variables {
+		if eo.applied[name] || name == passwordEnvVariable {
+			continue
+		}
+
+		for _, sectionPrefix := range sectionPrefixes {
+			if strings.HasPrefix(name, sectionPrefix) {
+				logger.Warningf(
+					"environment variable [%v] does not match any config field",
+					name,
+				)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyEnvironment(t *testing.T) {
+	var tests = map[string]struct {
+		environment      []string
+		modifyExpected   func(*Config)
+		expectedProblems []string
+	}{
+		"no overrides": {
+			environment: []string{
+				"HOME=/root",
+				"KEEP_CLIENT_PORT=3919",
+			},
+			modifyExpected: func(c *Config) {},
+		},
+		"string and integer fields": {
+			environment: []string{
+				"KEEP_ETHEREUM_URL=ws://10.0.0.1:8546",
+				"KEEP_ETHEREUM_ACCOUNT_ADDRESS=0x65ea55c1f10491038425725dc00dffeab2a1e28a",
+				"KEEP_LIBP2P_PORT=3919",
+				"KEEP_STORAGE_DATADIR=/mnt/keep",
+				"KEEP_METRICS_PORT= 9602 ",
+			},
+			modifyExpected: func(c *Config) {
+				c.Ethereum.URL = "ws://10.0.0.1:8546"
+				c.Ethereum.Account.Address = "0x65ea55c1f10491038425725dc00dffeab2a1e28a"
+				c.LibP2P.Port = 3919
+				c.Storage.DataDir = "/mnt/keep"
+				c.Metrics.Port = 9602
+			},
+		},
+		"list fields": {
+			environment: []string{
+				"KEEP_LIBP2P_PEERS=/ip4/10.0.0.1/tcp/3919/ipfs/peer1, /ip4/10.0.0.2/tcp/3919/ipfs/peer2,",
+				"KEEP_LIBP2P_ANNOUNCEDADDRESSES=",
+			},
+			modifyExpected: func(c *Config) {
+				c.LibP2P.Peers = []string{
+					"/ip4/10.0.0.1/tcp/3919/ipfs/peer1",
+					"/ip4/10.0.0.2/tcp/3919/ipfs/peer2",
+				}
+				c.LibP2P.AnnouncedAddresses = []string{}
+			},
+		},
+		"map fields": {
+			environment: []string{
+				"KEEP_ETHEREUM_CONTRACTADDRESSES_KEEPRANDOMBEACONOPERATOR=0x65ea55c1f10491038425725dc00dffeab2a1e28a",
+				"KEEP_ETHEREUM_CONTRACTADDRESSES_TokenStaking=0xcf64c2a367341170cb4e09cf8c0ed137d8473ceb",
+			},
+			modifyExpected: func(c *Config) {
+				c.Ethereum.ContractAddresses = map[string]string{
+					"KeepRandomBeaconOperator": "0x65ea55c1f10491038425725dc00dffeab2a1e28a",
+					"TokenStaking":             "0xcf64c2a367341170cb4e09cf8c0ed137d8473ceb",
+				}
+			},
+		},
+		"excluded fields": {
+			environment: []string{
+				"KEEP_ETHEREUM_ACCOUNT_KEYFILEPASSWORD=password",
+			},
+			modifyExpected: func(c *Config) {},
+		},
+		"invalid integer": {
+			environment: []string{
+				"KEEP_LIBP2P_PORT=port",
+			},
+			modifyExpected: func(c *Config) {},
+			expectedProblems: []string{
+				"LibP2P.Port: environment variable KEEP_LIBP2P_PORT value " +
+					"[port] is not an integer",
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			config := validConfig()
+
+			expectedConfig := validConfig()
+			test.modifyExpected(expectedConfig)
+
+			validation := &validation{}
+			config.applyEnvironment(test.environment, validation)
+
+			if !reflect.DeepEqual(expectedConfig, config) {
+				t.Errorf(
+					"unexpected config\nexpected: [%+v]\nactual:   [%+v]",
+					expectedConfig,
+					config,
+				)
+			}
+
+			if !reflect.DeepEqual(test.expectedProblems, validation.problems) {
+				t.Errorf(
+					"unexpected problems\nexpected: [%v]\nactual:   [%v]",
+					test.expectedProblems,
+					validation.problems,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,476 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/gasprice"
+	"github.com/keep-network/keep-core/pkg/fieldlog"
+	"github.com/keep-network/keep-core/pkg/net/libp2p"
+)
+
+const maxPort = 65535
+
+// ValidationError is returned when the config is invalid. It lists all
+// problems found in the config, each one prefixed with the path of the config
+// field it relates to.
+type ValidationError struct {
+	Problems []string
+}
+
+func (ve *ValidationError) Error() string {
+	return fmt.Sprintf(
+		"invalid config:\n\t%v",
+		strings.Join(ve.Problems, "\n\t"),
+	)
+}
+
+// validation collects problems found in the config.
+type validation struct {
+	problems []string
+}
+
+func (v *validation) report(field string, format string, args ...interface{}) {
+	v.problems = append(
+		v.problems,
+		fmt.Sprintf("%v: %v", field, fmt.Sprintf(format, args...)),
+	)
+}
+
+func (v *validation) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Problems: v.problems}
+}
+
+// validateFields checks the config values without accessing the environment.
+func (c *Config) validateFields(validation *validation) {
+	if c.Ethereum.Account.KeyFilePassword == "" {
+		validation.report(
+			"Ethereum.Account.KeyFilePassword",
+			"password is required; set in the config file, set environment "+
+				"variable %v to the password, or set the same environment "+
+				"variable to 'prompt' to be prompted for the password at startup",
+			passwordEnvVariable,
+		)
+	}
+
+	if address := c.Ethereum.Account.Address; address != "" &&
+		!common.IsHexAddress(address) {
+		validation.report(
+			"Ethereum.Account.Address",
+			"[%v] is not a valid hex address",
+			address,
+		)
+	}
+
+	contractNames := make([]string, 0, len(c.Ethereum.ContractAddresses))
+	for contractName := range c.Ethereum.ContractAddresses {
+		contractNames = append(contractNames, contractName)
+	}
+	sort.Strings(contractNames)
+
+	for _, contractName := range contractNames {
+		address := c.Ethereum.ContractAddresses[contractName]
+		if !common.IsHexAddress(address) {
+			validation.report(
+				"Ethereum.ContractAddresses."+contractName,
+				"[%v] is not a valid hex address",
+				address,
+			)
+		}
+	}
+
+	c.validateEthereumFailover(validation)
+
+	if c.LibP2P.Port == 0 {
+		validation.report(
+			"LibP2P.Port",
+			"missing value for port; see node section in config file or use --port flag",
+		)
+	} else if c.LibP2P.Port < 0 || c.LibP2P.Port > maxPort {
+		validation.report(
+			"LibP2P.Port",
+			"port [%v] is out of range [1, %v]",
+			c.LibP2P.Port,
+			maxPort,
+		)
+	}
+
+	for i, peer := range c.LibP2P.Peers {
+		if err := libp2p.ValidatePeerAddress(peer); err != nil {
+			validation.report(
+				fmt.Sprintf("LibP2P.Peers[%v]", i),
+				"[%v] is not a valid peer multiaddress: [%v]",
+				peer,
+				err,
+			)
+		}
+	}
+
+	for i, address := range c.LibP2P.AnnouncedAddresses {
+		if err := libp2p.ValidateAnnouncedAddress(address); err != nil {
+			validation.report(
+				fmt.Sprintf("LibP2P.AnnouncedAddresses[%v]", i),
+				"[%v] is not a valid multiaddress: [%v]",
+				address,
+				err,
+			)
+		}
+	}
+
+	if c.LibP2P.DisseminationTime < 0 ||
+		c.LibP2P.DisseminationTime > libp2p.MaximumDisseminationTime {
+		validation.report(
+			"LibP2P.DisseminationTime",
+			"dissemination time [%v] is out of range [0, %v]",
+			c.LibP2P.DisseminationTime,
+			libp2p.MaximumDisseminationTime,
+		)
+	}
+
+	if c.Storage.DataDir == "" {
+		validation.report(
+			"Storage.DataDir",
+			"missing value for storage directory data",
+		)
+	}
+
+	if c.Status.Address != "" && net.ParseIP(c.Status.Address) == nil {
+		validation.report(
+			"Status.Address",
+			"[%v] is not an IP address",
+			c.Status.Address,
+		)
+	}
+
+	if c.Status.Port < 0 || c.Status.Port > maxPort {
+		validation.report(
+			"Status.Port",
+			"port [%v] is out of range [0, %v]",
+			c.Status.Port,
+			maxPort,
+		)
+	}
+
+	if c.Metrics.Address != "" && net.ParseIP(c.Metrics.Address) == nil {
+		validation.report(
+			"Metrics.Address",
+			"[%v] is not an IP address",
+			c.Metrics.Address,
+		)
+	}
+
+	if c.Metrics.Port < 0 || c.Metrics.Port > maxPort {
+		validation.report(
+			"Metrics.Port",
+			"port [%v] is out of range [0, %v]",
+			c.Metrics.Port,
+			maxPort,
+		)
+	}
+
+	if err := fieldlog.ValidateFormat(c.Logging.Format); err != nil {
+		validation.report("Logging.Format", "%v", err)
+	}
+
+	if _, err := c.Rewards.MinimumPayoutWei(); err != nil {
+		validation.report("Rewards.MinimumPayout", "%v", err)
+	}
+
+	if _, err := c.Rewards.GasPriceCeilingWei(); err != nil {
+		validation.report("Rewards.GasPriceCeiling", "%v", err)
+	}
+
+	c.validateGasPrice(validation)
+
+	if c.Events.ConfirmationDepth < 0 {
+		validation.report(
+			"Events.ConfirmationDepth",
+			"number of blocks [%v] is negative",
+			c.Events.ConfirmationDepth,
+		)
+	}
+
+	c.validateOperators(validation)
+}
+
+// validateEthereumFailover checks configuration of additional Ethereum
+// endpoints. Both URLs of every endpoint are required.
+func (c *Config) validateEthereumFailover(validation *validation) {
+	for i, endpoint := range c.EthereumFailover.Endpoints {
+		field := fmt.Sprintf("EthereumFailover.Endpoints[%v]", i)
+
+		if endpoint.URL == "" {
+			validation.report(field+".URL", "missing value for endpoint URL")
+		}
+		if endpoint.URLRPC == "" {
+			validation.report(
+				field+".URLRPC",
+				"missing value for endpoint RPC URL",
+			)
+		}
+	}
+
+	if c.EthereumFailover.HealthCheckInterval < 0 {
+		validation.report(
+			"EthereumFailover.HealthCheckInterval",
+			"number of seconds [%v] is negative",
+			c.EthereumFailover.HealthCheckInterval,
+		)
+	}
+
+	if c.EthereumFailover.MaxBlockLag < 0 {
+		validation.report(
+			"EthereumFailover.MaxBlockLag",
+			"number of blocks [%v] is negative",
+			c.EthereumFailover.MaxBlockLag,
+		)
+	}
+}
+
+// validateGasPrice checks configuration of the gas price strategy. The price
+// of the fixed strategy and the URL and field of the oracle strategy are
+// required by the respective strategies.
+func (c *Config) validateGasPrice(validation *validation) {
+	if err := gasprice.ValidateStrategy(c.GasPrice.Strategy); err != nil {
+		validation.report("GasPrice.Strategy", "%v", err)
+	}
+
+	price, err := c.GasPrice.PriceWei()
+	if err != nil {
+		validation.report("GasPrice.Price", "%v", err)
+	} else if c.GasPrice.Strategy == gasprice.FixedStrategy && price.Sign() == 0 {
+		validation.report(
+			"GasPrice.Price",
+			"price is required by the [%v] strategy",
+			gasprice.FixedStrategy,
+		)
+	}
+
+	if c.GasPrice.Strategy == gasprice.OracleStrategy {
+		if oracleURL, err := url.Parse(c.GasPrice.OracleURL); err != nil ||
+			(oracleURL.Scheme != "http" && oracleURL.Scheme != "https") {
+			validation.report(
+				"GasPrice.OracleURL",
+				"[%v] is not a valid HTTP URL; URL is required by the [%v] strategy",
+				c.GasPrice.OracleURL,
+				gasprice.OracleStrategy,
+			)
+		}
+
+		if c.GasPrice.OracleField == "" {
+			validation.report(
+				"GasPrice.OracleField",
+				"field is required by the [%v] strategy",
+				gasprice.OracleStrategy,
+			)
+		}
+	}
+
+	if _, err := c.GasPrice.MaxPriceWei(); err != nil {
+		validation.report("GasPrice.MaxPrice", "%v", err)
+	}
+
+	if c.GasPrice.BumpPercent != 0 &&
+		c.GasPrice.BumpPercent < gasprice.MinimumBumpPercent {
+		validation.report(
+			"GasPrice.BumpPercent",
+			"bump [%v] is lower than the minimum [%v]",
+			c.GasPrice.BumpPercent,
+			gasprice.MinimumBumpPercent,
+		)
+	}
+
+	if c.GasPrice.ResubmitBlocks < 0 {
+		validation.report(
+			"GasPrice.ResubmitBlocks",
+			"number of blocks [%v] is negative",
+			c.GasPrice.ResubmitBlocks,
+		)
+	}
+}
+
+// validateOperators checks configuration of additional operators. Accounts,
+// libp2p ports and storage directories must not be shared between operators.
+func (c *Config) validateOperators(validation *validation) {
+	addresses := make(map[common.Address]string)
+	if common.IsHexAddress(c.Ethereum.Account.Address) {
+		addresses[common.HexToAddress(c.Ethereum.Account.Address)] =
+			"Ethereum.Account.Address"
+	}
+	ports := map[int]string{c.LibP2P.Port: "LibP2P.Port"}
+	dataDirs := map[string]string{c.Storage.DataDir: "Storage.DataDir"}
+
+	for i, operator := range c.Operators {
+		field := fmt.Sprintf("Operators[%v]", i)
+
+		address := operator.Account.Address
+		if !common.IsHexAddress(address) {
+			validation.report(
+				field+".Account.Address",
+				"[%v] is not a valid hex address",
+				address,
+			)
+		} else if other, ok := addresses[common.HexToAddress(address)]; ok {
+			validation.report(
+				field+".Account.Address",
+				"account [%v] is already used by %v",
+				address,
+				other,
+			)
+		} else {
+			addresses[common.HexToAddress(address)] = field + ".Account.Address"
+		}
+
+		if operator.Account.KeyFilePassword == "" {
+			validation.report(
+				field+".Account.KeyFilePassword",
+				"password is required; set in the config file or use the "+
+					"password of the Ethereum account",
+			)
+		}
+
+		if operator.Port <= 0 || operator.Port > maxPort {
+			validation.report(
+				field+".Port",
+				"port [%v] is out of range [1, %v]",
+				operator.Port,
+				maxPort,
+			)
+		} else if other, ok := ports[operator.Port]; ok {
+			validation.report(
+				field+".Port",
+				"port [%v] is already used by %v",
+				operator.Port,
+				other,
+			)
+		} else {
+			ports[operator.Port] = field + ".Port"
+		}
+
+		for j, announcedAddress := range operator.AnnouncedAddresses {
+			if err := libp2p.ValidateAnnouncedAddress(announcedAddress); err != nil {
+				validation.report(
+					fmt.Sprintf("%v.AnnouncedAddresses[%v]", field, j),
+					"[%v] is not a valid multiaddress: [%v]",
+					announcedAddress,
+					err,
+				)
+			}
+		}
+
+		if operator.DataDir == "" {
+			validation.report(
+				field+".DataDir",
+				"missing value for storage directory data",
+			)
+		} else if other, ok := dataDirs[operator.DataDir]; ok {
+			validation.report(
+				field+".DataDir",
+				"storage directory [%v] is already used by %v",
+				operator.DataDir,
+				other,
+			)
+		} else {
+			dataDirs[operator.DataDir] = field + ".DataDir"
+		}
+	}
+}
+
+// validateEnvironment checks whether the key files and the storage directories
+// from the config can be used by the client. Values already reported as
+// missing are not checked again.
+func (c *Config) validateEnvironment(validation *validation) {
+	validateKeyFile("Ethereum.Account", c.Ethereum.Account, validation)
+
+	if c.Storage.DataDir != "" {
+		if err := checkDirectoryWritable(c.Storage.DataDir); err != nil {
+			validation.report(
+				"Storage.DataDir",
+				"storage directory is not writable: [%v]",
+				err,
+			)
+		}
+	}
+
+	for i, operator := range c.Operators {
+		field := fmt.Sprintf("Operators[%v]", i)
+
+		validateKeyFile(field+".Account", operator.Account, validation)
+
+		if operator.DataDir != "" {
+			if err := checkDirectoryWritable(operator.DataDir); err != nil {
+				validation.report(
+					field+".DataDir",
+					"storage directory is not writable: [%v]",
+					err,
+				)
+			}
+		}
+	}
+}
+
+// validateKeyFile checks whether the key file of the account can be decrypted
+// with the configured password and whether it matches the configured address.
+func validateKeyFile(
+	field string,
+	account ethereum.Account,
+	validation *validation,
+) {
+	if account.KeyFile == "" {
+		validation.report(
+			field+".KeyFile",
+			"missing value for key file",
+		)
+	} else if account.KeyFilePassword != "" {
+		key, err := ethutil.DecryptKeyFile(account.KeyFile, account.KeyFilePassword)
+		if err != nil {
+			validation.report(
+				field+".KeyFile",
+				"could not decrypt key file: [%v]",
+				err,
+			)
+		} else if common.IsHexAddress(account.Address) &&
+			common.HexToAddress(account.Address) != key.Address {
+			validation.report(
+				field+".KeyFile",
+				"key file is for account [%v], not for the configured account [%v]",
+				key.Address.Hex(),
+				account.Address,
+			)
+		}
+	}
+}
+
+func checkDirectoryWritable(directory string) error {
+	info, err := os.Stat(directory)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("[%v] is not a directory", directory)
+	}
+
+	file, err := ioutil.TempFile(directory, ".write-check")
+	if err != nil {
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(file.Name())
+}
@@ -0,0 +1,375 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/net/libp2p"
+)
+
+const (
+	validPeer    = "/ip4/127.0.0.1/tcp/27001/ipfs/12D3KooWKRyzVWW6ChFjQjK4miCty85Niy49tpPV95XdKu1BcvMA"
+	validAddress = "0xc2a56884538778bacd91aa5bf343bf882c5fb18b"
+)
+
+func validConfig() *Config {
+	return &Config{
+		Ethereum: ethereum.Config{
+			Account: ethereum.Account{
+				Address:         validAddress,
+				KeyFilePassword: "password",
+			},
+			ContractAddresses: map[string]string{
+				"KeepRandomBeaconOperator": "0xcf64c2a367341170cb4e09cf8c0ed137d8473ceb",
+			},
+		},
+		LibP2P: libp2p.Config{
+			Port:               27001,
+			Peers:              []string{validPeer},
+			AnnouncedAddresses: []string{"/ip4/203.0.113.1/tcp/27001"},
+			DisseminationTime:  10,
+		},
+		Storage: Storage{
+			DataDir: "/my/secure/location",
+		},
+	}
+}
+
+func TestValidateFields(t *testing.T) {
+	var tests = map[string]struct {
+		modifyConfig   func(*Config)
+		expectedFields []string
+	}{
+		"valid config": {
+			modifyConfig: func(c *Config) {},
+		},
+		"missing password": {
+			modifyConfig: func(c *Config) {
+				c.Ethereum.Account.KeyFilePassword = ""
+			},
+			expectedFields: []string{"Ethereum.Account.KeyFilePassword"},
+		},
+		"invalid addresses": {
+			modifyConfig: func(c *Config) {
+				c.Ethereum.Account.Address = "0x123"
+				c.Ethereum.ContractAddresses["TokenStaking"] = "TokenStaking"
+			},
+			expectedFields: []string{
+				"Ethereum.Account.Address",
+				"Ethereum.ContractAddresses.TokenStaking",
+			},
+		},
+		"invalid network settings": {
+			modifyConfig: func(c *Config) {
+				c.LibP2P.Port = 0
+				c.LibP2P.Peers = append(
+					c.LibP2P.Peers,
+					"/ip4/127.0.0.1/tcp/27001",
+				)
+				c.LibP2P.AnnouncedAddresses = []string{"203.0.113.1:27001"}
+				c.LibP2P.DisseminationTime = libp2p.MaximumDisseminationTime + 1
+			},
+			expectedFields: []string{
+				"LibP2P.Port",
+				"LibP2P.Peers[1]",
+				"LibP2P.AnnouncedAddresses[0]",
+				"LibP2P.DisseminationTime",
+			},
+		},
+		"invalid ports": {
+			modifyConfig: func(c *Config) {
+				c.LibP2P.Port = 70000
+				c.Status.Port = -1
+				c.Metrics.Port = 70000
+			},
+			expectedFields: []string{
+				"LibP2P.Port",
+				"Status.Port",
+				"Metrics.Port",
+			},
+		},
+		"invalid endpoint addresses": {
+			modifyConfig: func(c *Config) {
+				c.Status.Address = "localhost:9601"
+				c.Metrics.Address = "metrics.example.com"
+			},
+			expectedFields: []string{"Status.Address", "Metrics.Address"},
+		},
+		"invalid log format": {
+			modifyConfig: func(c *Config) {
+				c.Logging.Format = "yaml"
+			},
+			expectedFields: []string{"Logging.Format"},
+		},
+		"invalid rewards amounts": {
+			modifyConfig: func(c *Config) {
+				c.Rewards.MinimumPayout = "0.5"
+				c.Rewards.GasPriceCeiling = "-1"
+			},
+			expectedFields: []string{
+				"Rewards.MinimumPayout",
+				"Rewards.GasPriceCeiling",
+			},
+		},
+		"valid gas price oracle": {
+			modifyConfig: func(c *Config) {
+				c.GasPrice.Strategy = "oracle"
+				c.GasPrice.OracleURL = "https://gasprice.example.com/api"
+				c.GasPrice.OracleField = "result.fast"
+				c.GasPrice.MaxPrice = "100000000000"
+			},
+		},
+		"invalid fixed gas price": {
+			modifyConfig: func(c *Config) {
+				c.GasPrice.Strategy = "fixed"
+				c.GasPrice.BumpPercent = 5
+				c.GasPrice.ResubmitBlocks = -1
+			},
+			expectedFields: []string{
+				"GasPrice.Price",
+				"GasPrice.BumpPercent",
+				"GasPrice.ResubmitBlocks",
+			},
+		},
+		"invalid gas price oracle": {
+			modifyConfig: func(c *Config) {
+				c.GasPrice.Strategy = "oracle"
+				c.GasPrice.OracleURL = "gasprice.example.com"
+				c.GasPrice.MaxPrice = "1.5"
+			},
+			expectedFields: []string{
+				"GasPrice.OracleURL",
+				"GasPrice.OracleField",
+				"GasPrice.MaxPrice",
+			},
+		},
+		"unknown gas price strategy": {
+			modifyConfig: func(c *Config) {
+				c.GasPrice.Strategy = "cheapest"
+			},
+			expectedFields: []string{"GasPrice.Strategy"},
+		},
+		"valid failover endpoints": {
+			modifyConfig: func(c *Config) {
+				c.EthereumFailover.Endpoints = []EthereumEndpoint{
+					{
+						URL:    "ws://192.168.0.159:8546",
+						URLRPC: "http://192.168.0.159:8545",
+					},
+				}
+				c.EthereumFailover.HealthCheckInterval = 30
+				c.EthereumFailover.MaxBlockLag = 10
+			},
+		},
+		"failover endpoint without URLs": {
+			modifyConfig: func(c *Config) {
+				c.EthereumFailover.Endpoints = []EthereumEndpoint{{}}
+			},
+			expectedFields: []string{
+				"EthereumFailover.Endpoints[0].URL",
+				"EthereumFailover.Endpoints[0].URLRPC",
+			},
+		},
+		"negative failover health check interval and block lag": {
+			modifyConfig: func(c *Config) {
+				c.EthereumFailover.HealthCheckInterval = -1
+				c.EthereumFailover.MaxBlockLag = -1
+			},
+			expectedFields: []string{
+				"EthereumFailover.HealthCheckInterval",
+				"EthereumFailover.MaxBlockLag",
+			},
+		},
+		"negative confirmation depth": {
+			modifyConfig: func(c *Config) {
+				c.Events.ConfirmationDepth = -1
+			},
+			expectedFields: []string{"Events.ConfirmationDepth"},
+		},
+		"missing storage directory": {
+			modifyConfig: func(c *Config) {
+				c.Storage.DataDir = ""
+			},
+			expectedFields: []string{"Storage.DataDir"},
+		},
+		"valid operators": {
+			modifyConfig: func(c *Config) {
+				c.Operators = []Operator{
+					{
+						Account: ethereum.Account{
+							Address:         "0x6ffba2d0f4c8fd7263f546afaaf25fe2d56f6044",
+							KeyFilePassword: "password",
+						},
+						Port:    27002,
+						DataDir: "/my/secure/location/2",
+					},
+				}
+			},
+		},
+		"invalid operators": {
+			modifyConfig: func(c *Config) {
+				c.Operators = []Operator{
+					{
+						Account: ethereum.Account{
+							Address: "0x123",
+						},
+						Port:               27001,
+						AnnouncedAddresses: []string{"203.0.113.1:27002"},
+					},
+					{
+						Account: ethereum.Account{
+							Address:         strings.ToUpper(validAddress[2:]),
+							KeyFilePassword: "password",
+						},
+						Port:    0,
+						DataDir: "/my/secure/location",
+					},
+				}
+			},
+			expectedFields: []string{
+				"Operators[0].Account.Address",
+				"Operators[0].Account.KeyFilePassword",
+				"Operators[0].Port",
+				"Operators[0].AnnouncedAddresses[0]",
+				"Operators[0].DataDir",
+				"Operators[1].Account.Address",
+				"Operators[1].Port",
+				"Operators[1].DataDir",
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			config := validConfig()
+			test.modifyConfig(config)
+
+			validation := &validation{}
+			config.validateFields(validation)
+
+			actualFields := problemFields(validation.problems)
+
+			if !reflect.DeepEqual(test.expectedFields, actualFields) {
+				t.Errorf(
+					"unexpected invalid fields\nexpected: [%v]\nactual:   [%v]",
+					test.expectedFields,
+					actualFields,
+				)
+			}
+		})
+	}
+}
+
+func TestValidateEnvironment(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "keep-config-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	keyStore := keystore.NewKeyStore(
+		dataDir,
+		keystore.LightScryptN,
+		keystore.LightScryptP,
+	)
+	account, err := keyStore.NewAccount("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		modifyConfig     func(*Config)
+		expectedProblems []string
+	}{
+		"valid environment": {
+			modifyConfig: func(c *Config) {},
+		},
+		"missing key file": {
+			modifyConfig: func(c *Config) {
+				c.Ethereum.Account.KeyFile = ""
+			},
+			expectedProblems: []string{
+				"Ethereum.Account.KeyFile: missing value for key file",
+			},
+		},
+		"wrong password": {
+			modifyConfig: func(c *Config) {
+				c.Ethereum.Account.KeyFilePassword = "not-my-password"
+			},
+			expectedProblems: []string{
+				"Ethereum.Account.KeyFile: could not decrypt key file: " +
+					"[unable to decrypt " + account.URL.Path +
+					" [could not decrypt key with given password]]",
+			},
+		},
+		"key file for another account": {
+			modifyConfig: func(c *Config) {
+				c.Ethereum.Account.Address = validAddress
+			},
+			expectedProblems: []string{
+				"Ethereum.Account.KeyFile: key file is for account [" +
+					account.Address.Hex() + "], not for the configured " +
+					"account [" + validAddress + "]",
+			},
+		},
+		"storage directory does not exist": {
+			modifyConfig: func(c *Config) {
+				c.Storage.DataDir = path.Join(dataDir, "missing")
+			},
+			expectedProblems: []string{
+				"Storage.DataDir: storage directory is not writable: " +
+					"[stat " + path.Join(dataDir, "missing") +
+					": no such file or directory]",
+			},
+		},
+		"storage directory is a file": {
+			modifyConfig: func(c *Config) {
+				c.Storage.DataDir = account.URL.Path
+			},
+			expectedProblems: []string{
+				"Storage.DataDir: storage directory is not writable: " +
+					"[[" + account.URL.Path + "] is not a directory]",
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			config := validConfig()
+			config.Ethereum.Account.Address = account.Address.Hex()
+			config.Ethereum.Account.KeyFile = account.URL.Path
+			config.Storage.DataDir = dataDir
+			test.modifyConfig(config)
+
+			validation := &validation{}
+			config.validateEnvironment(validation)
+
+			if !reflect.DeepEqual(test.expectedProblems, validation.problems) {
+				t.Errorf(
+					"unexpected problems\nexpected: [%v]\nactual:   [%v]",
+					test.expectedProblems,
+					validation.problems,
+				)
+			}
+		})
+	}
+}
+
+func problemFields(problems []string) []string {
+	if len(problems) == 0 {
+		return nil
+	}
+
+	fields := make([]string, len(problems))
+	for i, problem := range problems {
+		fields[i] = strings.SplitN(problem, ":", 2)[0]
+	}
+
+	return fields
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-common/pkg/logging"
 	"github.com/keep-network/keep-core/cmd"
+	"github.com/keep-network/keep-core/pkg/fieldlog"
 	"github.com/urfave/cli"
 )
 
@@ -32,7 +33,12 @@ func main() {
 		revision = "unknown"
 	}
 
-	err := logging.Configure(os.Getenv("LOG_LEVEL"))
+	err := fieldlog.SetFormat(os.Getenv("LOG_FORMAT"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to configure log format: [%v]\n", err)
+	}
+
+	err = logging.Configure(os.Getenv("LOG_LEVEL"))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to configure logging: [%v]\n", err)
 	}
@@ -60,15 +66,24 @@ func main() {
 	app.Commands = []cli.Command{
 		cmd.StartCommand,
 		cmd.RelayCommand,
+		cmd.RewardsCommand,
+		cmd.JournalCommand,
+		cmd.DevChainCommand,
 		cmd.PingCommand,
 		cmd.EthereumCommand,
+		cmd.ConfigCommand,
 	}
 
 	cli.AppHelpTemplate = fmt.Sprintf(`%s
 ENVIRONMENT VARIABLES:
    KEEP_ETHEREUM_PASSWORD    keep client password
+   KEEP_<SECTION>_<FIELD>    overrides the given config file field, e.g.
+                             KEEP_LIBP2P_PEERS; list values are
+                             comma-separated; command-line flags take
+                             precedence
    LOG_LEVEL                 space-delimited set of log level directives; set to
                              "help" for help
+   LOG_FORMAT                log output format, "text" (default) or "json"
 
 `, cli.AppHelpTemplate)
 
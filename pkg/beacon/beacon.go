@@ -3,6 +3,8 @@ package beacon
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
+	"math/big"
 	"sync"
 	"time"
 
@@ -11,45 +13,66 @@ import (
 	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-core/pkg/beacon/relay"
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/groupselection"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/rewards"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/status"
+	"github.com/keep-network/keep-core/pkg/subscription"
 )
 
 var logger = log.Logger("keep-beacon")
 
+// shutdownTimeout is the maximum time the beacon waits for relay entry signing
+// and distributed key generation processes in progress to complete when it is
+// shutting down. The processes may be allowed to last much longer, e.g. over
+// an hour for the distributed key generation, but the operator has to be able
+// to stop the client in a reasonable time.
+const shutdownTimeout = 5 * time.Minute
+
 // Initialize kicks off the random beacon by initializing internal state,
 // ensuring preconditions like staking are met, and then kicking off the
-// internal random beacon implementation. Returns an error if this failed,
-// otherwise enters a blocked loop.
+// internal random beacon implementation. Returns an error if this failed.
+//
+// Once the provided context is done, the beacon stops accepting new work,
+// unsubscribes from chain events, waits for relay entry signing and
+// distributed key generation processes in progress to complete, but no
+// longer than five minutes, and flushes the group registry to disk. The returned channel is closed when the
+// shutdown completes.
+//
+// Rewards for groups archived by the group registry are withdrawn according
+// to the provided rewards config.
 func Initialize(
 	ctx context.Context,
 	stakingID string,
 	chainHandle chain.Handle,
 	netProvider net.Provider,
 	persistence persistence.Handle,
-) error {
+	statusRegistry *status.Registry,
+	rewardsConfig *rewards.Config,
+) (<-chan struct{}, error) {
 	relayChain := chainHandle.ThresholdRelay()
 	chainConfig, err := relayChain.GetConfig()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	stakeMonitor, err := chainHandle.StakeMonitor()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	staker, err := stakeMonitor.StakerFor(stakingID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	blockCounter, err := chainHandle.BlockCounter()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	signing := chainHandle.Signing()
@@ -57,12 +80,26 @@ func Initialize(
 	groupRegistry := registry.NewGroupRegistry(relayChain, persistence)
 	groupRegistry.LoadExistingGroups()
 
+	rewardsService := rewards.NewService(
+		relayChain,
+		staker.Address(),
+		rewardsConfig,
+		persistence,
+	)
+	groupRegistry.OnGroupArchived(
+		func(groupPublicKey []byte, memberships []*registry.Membership) {
+			rewardsService.Withdraw(groupPublicKey, len(memberships))
+		},
+	)
+	go rewardsService.Run(ctx)
+
 	node := relay.NewNode(
 		staker,
 		netProvider,
 		blockCounter,
 		chainConfig,
 		groupRegistry,
+		dkg.NewCheckpointStorage(persistence),
 	)
 
 	pendingGroupSelections := &event.GroupSelectionTrack{
@@ -75,10 +112,77 @@ func Initialize(
 		Mutex: &sync.Mutex{},
 	}
 
-	node.ResumeSigningIfEligible(relayChain, signing)
+	registerStatusSources(
+		statusRegistry,
+		groupRegistry,
+		pendingRelayRequests,
+		pendingGroupSelections,
+	)
+
+	node.MonitorUnauthorizedSigning(ctx, relayChain)
+
+	// Relay entry signing and group selections started because of events
+	// removed by a chain reorganization are abandoned. Distributed key
+	// generations resumed after a restart are known only by the seed of
+	// their group selection.
+	relayRequestWork := newEventWork()
+	groupSelectionWork := newEventWork()
+	resumedDKGWork := newEventWork()
+
+	node.ResumeSigningIfEligible(
+		relayChain,
+		signing,
+		func(request *event.Request) context.Context {
+			return relayRequestWork.start(relayRequestKey(request))
+		},
+	)
+	node.ResumeDKGIfEligible(
+		relayChain,
+		signing,
+		func(seed *big.Int) context.Context {
+			return resumedDKGWork.start(seedKey(seed))
+		},
+	)
+
+	subscriptions := make([]subscription.EventSubscription, 0)
+	// Chain events must not be handled by a beacon which failed to
+	// initialize, so all subscriptions made so far are cancelled then.
+	initialized := false
+	defer func() {
+		if !initialized {
+			unsubscribeAll(subscriptions)
+		}
+	}()
+
+	stakes := newStakeStatus(stakeMonitor, stakingID, staker.Address())
+	stakeSubscriptions, err := watchStake(stakes)
+	if err != nil {
+		return nil, err
+	}
+	subscriptions = append(subscriptions, stakeSubscriptions...)
+
+	relayEntryRequestedSubscription, err := relayChain.OnRelayEntryRequested(func(request *event.Request) {
+		if ctx.Err() != nil {
+			logger.Warningf(
+				"ignoring relay entry request at block [%v]; "+
+					"beacon is shutting down",
+				request.BlockNumber,
+			)
+			return
+		}
+
+		requestCtx := relayRequestWork.start(relayRequestKey(request))
 
-	relayChain.OnRelayEntryRequested(func(request *event.Request) {
 		onConfirmed := func() {
+			if requestCtx.Err() != nil {
+				logger.Warningf(
+					"ignoring relay entry request at block [%v]; "+
+						"request has been removed from the chain",
+					request.BlockNumber,
+				)
+				return
+			}
+
 			if node.IsInGroup(request.GroupPublicKey) {
 				go func() {
 					previousEntry := hex.EncodeToString(request.PreviousEntry[:])
@@ -103,6 +207,7 @@ func Initialize(
 					)
 
 					node.GenerateRelayEntry(
+						requestCtx,
 						request.PreviousEntry,
 						relayChain,
 						signing,
@@ -132,9 +237,71 @@ func Initialize(
 			currentRelayRequestConfirmationDelay,
 		)
 	})
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not subscribe for relay entry requests: [%v]",
+			err,
+		)
+	}
+	subscriptions = append(subscriptions, relayEntryRequestedSubscription)
+
+	relayEntryRequestRemovedSubscription, err := relayChain.OnRelayEntryRequestRemoved(
+		func(request *event.Request) {
+			if relayRequestWork.cancel(relayRequestKey(request)) {
+				logger.Warningf(
+					"relay entry request at block [%v] with previous entry "+
+						"[0x%x] has been removed from the chain; "+
+						"abandoning relay entry signing",
+					request.BlockNumber,
+					request.PreviousEntry,
+				)
+			}
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not subscribe for relay entry request removals: [%v]",
+			err,
+		)
+	}
+	subscriptions = append(subscriptions, relayEntryRequestRemovedSubscription)
+
+	onGroupSelectionStarted := func(event *event.GroupSelectionStart) {
+		if ctx.Err() != nil {
+			logger.Warningf(
+				"ignoring group selection started at block [%v]; "+
+					"beacon is shutting down",
+				event.BlockNumber,
+			)
+			return
+		}
+
+		// The event could have been fetched from the chain a while after it
+		// was emitted, e.g. after the connection to the chain was lost.
+		currentBlock, err := blockCounter.CurrentBlock()
+		if err == nil &&
+			currentBlock > event.BlockNumber+chainConfig.TicketSubmissionTimeout {
+			logger.Warningf(
+				"ignoring group selection started with seed [0x%x] "+
+					"at block [%v]; ticket submission is already over",
+				event.NewEntry,
+				event.BlockNumber,
+			)
+			return
+		}
+
+		selectionCtx := groupSelectionWork.start(groupSelectionKey(event))
 
-	relayChain.OnGroupSelectionStarted(func(event *event.GroupSelectionStart) {
 		onGroupSelected := func(group *groupselection.Result) {
+			if selectionCtx.Err() != nil {
+				logger.Warningf(
+					"not joining group selected with seed [0x%x]; "+
+						"group selection start has been removed from the chain",
+					event.NewEntry,
+				)
+				return
+			}
+
 			for index, staker := range group.SelectedStakers {
 				logger.Infof(
 					"new candidate group member [0x%v] with index [%v]",
@@ -143,6 +310,7 @@ func Initialize(
 				)
 			}
 			node.JoinGroupIfEligible(
+				selectionCtx,
 				relayChain,
 				signing,
 				group,
@@ -152,6 +320,21 @@ func Initialize(
 
 		newEntry := event.NewEntry.Text(16)
 		go func() {
+			// The stake could have changed without the client noticing,
+			// e.g. if a stake event has been missed.
+			stakes.check()
+			stakeCtx, hasMinimumStake := stakes.ticketSubmissionContext()
+			if !hasMinimumStake {
+				logger.Warningf(
+					"not taking part in group selection started with "+
+						"seed [0x%x] at block [%v]; operator does not have "+
+						"the minimum stake",
+					event.NewEntry,
+					event.BlockNumber,
+				)
+				return
+			}
+
 			if ok := pendingGroupSelections.Add(newEntry); !ok {
 				logger.Errorf(
 					"group selection event with seed [0x%x] has been registered already",
@@ -168,7 +351,19 @@ func Initialize(
 				event.BlockNumber,
 			)
 
+			// Tickets are not submitted anymore once the operator drops
+			// below the minimum stake or the group selection start is
+			// removed from the chain. The stake status is read again before
+			// each ticket is submitted.
+			ticketSubmissionCtx, cancelTicketSubmissionCtx := mergeContexts(
+				selectionCtx,
+				stakeCtx,
+			)
+			defer cancelTicketSubmissionCtx()
+
 			err := groupselection.CandidateToNewGroup(
+				ticketSubmissionCtx,
+				stakes.hasMinimum,
 				relayChain,
 				blockCounter,
 				chainConfig,
@@ -181,9 +376,70 @@ func Initialize(
 				logger.Errorf("Tickets submission failed: [%v]", err)
 			}
 		}()
-	})
+	}
+
+	groupSelectionStartedSubscription, err := relayChain.OnGroupSelectionStarted(
+		onGroupSelectionStarted,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not subscribe for group selection start: [%v]",
+			err,
+		)
+	}
+	subscriptions = append(subscriptions, groupSelectionStartedSubscription)
+
+	groupSelectionStartRemovedSubscription, err := relayChain.OnGroupSelectionStartRemoved(
+		func(event *event.GroupSelectionStart) {
+			resumedDKGCancelled := resumedDKGWork.cancel(seedKey(event.NewEntry))
+			if groupSelectionWork.cancel(groupSelectionKey(event)) ||
+				resumedDKGCancelled {
+				logger.Warningf(
+					"group selection started with seed [0x%x] at block [%v] "+
+						"has been removed from the chain; "+
+						"abandoning group selection",
+					event.NewEntry,
+					event.BlockNumber,
+				)
+			}
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not subscribe for group selection start removals: [%v]",
+			err,
+		)
+	}
+	subscriptions = append(subscriptions, groupSelectionStartRemovedSubscription)
+
+	// The group selection started event could have been emitted while the
+	// client was down. If the ticket submission is still in progress, take
+	// part in it as if the event has just been received.
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("could not read current block: [%v]", err)
+	}
+	groupSelectionStart, err := ongoingGroupSelection(
+		relayChain,
+		currentBlock,
+		chainConfig.TicketSubmissionTimeout,
+	)
+	if err != nil {
+		logger.Errorf(
+			"could not check for group selection in progress: [%v]",
+			err,
+		)
+	} else if groupSelectionStart != nil {
+		logger.Infof(
+			"resuming participation in group selection started "+
+				"with seed [0x%x] at block [%v]",
+			groupSelectionStart.NewEntry,
+			groupSelectionStart.BlockNumber,
+		)
+		onGroupSelectionStarted(groupSelectionStart)
+	}
 
-	relayChain.OnGroupRegistered(func(registration *event.GroupRegistration) {
+	groupRegisteredSubscription, err := relayChain.OnGroupRegistered(func(registration *event.GroupRegistration) {
 		logger.Infof(
 			"new group with public key [0x%x] registered on-chain at block [%v]",
 			registration.GroupPublicKey,
@@ -191,8 +447,173 @@ func Initialize(
 		)
 		go groupRegistry.UnregisterStaleGroups()
 	})
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not subscribe for group registrations: [%v]",
+			err,
+		)
+	}
+	subscriptions = append(subscriptions, groupRegisteredSubscription)
+
+	initialized = true
+
+	shutdownCompleted := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		shutdown(&node, groupRegistry, subscriptions)
+		close(shutdownCompleted)
+	}()
+
+	return shutdownCompleted, nil
+}
+
+// relayRequestKey identifies work started because of the relay request.
+func relayRequestKey(request *event.Request) string {
+	return fmt.Sprintf("%x@%v", request.PreviousEntry, request.BlockNumber)
+}
+
+// groupSelectionKey identifies work started because of the group selection.
+func groupSelectionKey(groupSelectionStart *event.GroupSelectionStart) string {
+	return fmt.Sprintf(
+		"%x@%v",
+		groupSelectionStart.NewEntry,
+		groupSelectionStart.BlockNumber,
+	)
+}
+
+// seedKey identifies work started because of the group selection with the
+// given seed.
+func seedKey(seed *big.Int) string {
+	return fmt.Sprintf("%x", seed)
+}
+
+// shutdown unsubscribes from chain events, waits for the work in progress of
+// the node and flushes the group registry to disk.
+func shutdown(
+	node *relay.Node,
+	groupRegistry *registry.Groups,
+	subscriptions []subscription.EventSubscription,
+) {
+	logger.Infof("shutting down the beacon")
+
+	for _, eventSubscription := range subscriptions {
+		eventSubscription.Unsubscribe()
+	}
+
+	if err := node.Shutdown(shutdownTimeout); err != nil {
+		logger.Warningf("could not gracefully stop the node: [%v]", err)
+	}
+
+	if err := groupRegistry.Flush(); err != nil {
+		logger.Errorf("could not flush the group registry: [%v]", err)
+	}
+
+	logger.Infof("beacon shut down")
+}
+
+// registerStatusSources exposes groups held by this client and relay requests
+// and group selections currently processed by this client in the node status.
+func registerStatusSources(
+	statusRegistry *status.Registry,
+	groupRegistry *registry.Groups,
+	pendingRelayRequests *event.RelayRequestTrack,
+	pendingGroupSelections *event.GroupSelectionTrack,
+) {
+	statusRegistry.RegisterSource("groups", func() (interface{}, error) {
+		type groupStatus struct {
+			GroupPublicKey string `json:"groupPublicKey"`
+			ChannelName    string `json:"channelName"`
+			MemberIndexes  []int  `json:"memberIndexes"`
+		}
+
+		groups := make([]groupStatus, 0)
+		for groupPublicKey, memberships := range groupRegistry.GetGroups() {
+			if len(memberships) == 0 {
+				continue
+			}
+
+			memberIndexes := make([]int, len(memberships))
+			for i, membership := range memberships {
+				memberIndexes[i] = int(membership.Signer.MemberID())
+			}
+
+			groups = append(groups, groupStatus{
+				GroupPublicKey: "0x" + groupPublicKey,
+				ChannelName:    memberships[0].ChannelName,
+				MemberIndexes:  memberIndexes,
+			})
+		}
+
+		return groups, nil
+	})
+
+	statusRegistry.RegisterSource(
+		"pendingRelayRequests",
+		func() (interface{}, error) {
+			return pendingRelayRequests.PreviousEntries(), nil
+		},
+	)
+
+	statusRegistry.RegisterSource(
+		"pendingGroupSelections",
+		func() (interface{}, error) {
+			return pendingGroupSelections.Entries(), nil
+		},
+	)
+}
+
+// ongoingGroupSelection returns the start of the group selection in progress
+// on-chain if its ticket submission has not ended yet at the current block.
+// If there is no such group selection, nil is returned.
+func ongoingGroupSelection(
+	chain relaychain.GroupSelectionInterface,
+	currentBlock uint64,
+	ticketSubmissionTimeout uint64,
+) (*event.GroupSelectionStart, error) {
+	isGroupSelectionPossible, err := chain.IsGroupSelectionPossible()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not check if group selection is possible: [%v]",
+			err,
+		)
+	}
+
+	// A new group selection can be started only if there is no group
+	// selection in progress.
+	if isGroupSelectionPossible {
+		return nil, nil
+	}
+
+	fromBlock := uint64(0)
+	if currentBlock > ticketSubmissionTimeout {
+		fromBlock = currentBlock - ticketSubmissionTimeout
+	}
+
+	groupSelectionStarts, err := chain.PastGroupSelectionStarts(
+		fromBlock,
+		currentBlock,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not get past group selection starts: [%v]",
+			err,
+		)
+	}
+
+	// The group selection in progress started earlier and it is past
+	// the ticket submission already.
+	if len(groupSelectionStarts) == 0 {
+		return nil, nil
+	}
+
+	lastGroupSelectionStart := groupSelectionStarts[len(groupSelectionStarts)-1]
+	ticketSubmissionEndBlock := lastGroupSelectionStart.BlockNumber +
+		ticketSubmissionTimeout
+	if currentBlock >= ticketSubmissionEndBlock {
+		return nil, nil
+	}
 
-	return nil
+	return lastGroupSelectionStart, nil
 }
 
 // Before we start relay entry signing process we need to confirm the current
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/gen/async"
 	"github.com/keep-network/keep-core/pkg/subscription"
@@ -208,6 +209,76 @@ func TestConfirmRelayRequestWithAllAttemptsFailedBecauseOfError(t *testing.T) {
 	}
 }
 
+func TestOngoingGroupSelection(t *testing.T) {
+	ticketSubmissionTimeout := uint64(50)
+	groupSelectionStart := &event.GroupSelectionStart{
+		NewEntry:    big.NewInt(1918),
+		BlockNumber: 1000,
+	}
+
+	var tests = map[string]struct {
+		isGroupSelectionPossible bool
+		groupSelectionStarts     []*event.GroupSelectionStart
+		currentBlock             uint64
+		expectedStart            *event.GroupSelectionStart
+	}{
+		"no group selection in progress": {
+			isGroupSelectionPossible: true,
+			groupSelectionStarts:     []*event.GroupSelectionStart{},
+			currentBlock:             1010,
+			expectedStart:            nil,
+		},
+		"ticket submission in progress": {
+			isGroupSelectionPossible: false,
+			groupSelectionStarts: []*event.GroupSelectionStart{
+				groupSelectionStart,
+			},
+			currentBlock:  1049,
+			expectedStart: groupSelectionStart,
+		},
+		"ticket submission ended": {
+			isGroupSelectionPossible: false,
+			groupSelectionStarts: []*event.GroupSelectionStart{
+				groupSelectionStart,
+			},
+			currentBlock:  1050,
+			expectedStart: nil,
+		},
+		"group selection started before the lookup range": {
+			isGroupSelectionPossible: false,
+			groupSelectionStarts:     []*event.GroupSelectionStart{},
+			currentBlock:             1080,
+			expectedStart:            nil,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			chain := &mockGroupSelectionChain{
+				isGroupSelectionPossible: test.isGroupSelectionPossible,
+				groupSelectionStarts:     test.groupSelectionStarts,
+			}
+
+			start, err := ongoingGroupSelection(
+				chain,
+				test.currentBlock,
+				ticketSubmissionTimeout,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.expectedStart != start {
+				t.Errorf(
+					"unexpected group selection start\nexpected: [%v]\nactual:   [%v]",
+					test.expectedStart,
+					start,
+				)
+			}
+		})
+	}
+}
+
 func newMockRelayChain(
 	currentRequestStartBlockFn func(int) (int, error),
 ) *mockRelayChain {
@@ -240,6 +311,12 @@ func (mrc *mockRelayChain) OnRelayEntryRequested(
 	panic("not implemented")
 }
 
+func (mrc *mockRelayChain) OnRelayEntryRequestRemoved(
+	func(request *event.Request),
+) (subscription.EventSubscription, error) {
+	panic("not implemented")
+}
+
 func (mrc *mockRelayChain) ReportRelayEntryTimeout() error {
 	panic("not implemented")
 }
@@ -261,3 +338,48 @@ func (mrc *mockRelayChain) CurrentRequestPreviousEntry() ([]byte, error) {
 func (mrc *mockRelayChain) CurrentRequestGroupPublicKey() ([]byte, error) {
 	panic("not implemented")
 }
+
+type mockGroupSelectionChain struct {
+	isGroupSelectionPossible bool
+	groupSelectionStarts     []*event.GroupSelectionStart
+}
+
+func (mgsc *mockGroupSelectionChain) OnGroupSelectionStarted(
+	func(groupSelectionStarted *event.GroupSelectionStart),
+) (subscription.EventSubscription, error) {
+	panic("not implemented")
+}
+
+func (mgsc *mockGroupSelectionChain) OnGroupSelectionStartRemoved(
+	func(groupSelectionStarted *event.GroupSelectionStart),
+) (subscription.EventSubscription, error) {
+	panic("not implemented")
+}
+
+func (mgsc *mockGroupSelectionChain) SubmitTicket(
+	ticket *relaychain.Ticket,
+) *async.EventGroupTicketSubmissionPromise {
+	panic("not implemented")
+}
+
+func (mgsc *mockGroupSelectionChain) GetSubmittedTickets() ([]uint64, error) {
+	panic("not implemented")
+}
+
+func (mgsc *mockGroupSelectionChain) GetSelectedParticipants() (
+	[]relaychain.StakerAddress,
+	error,
+) {
+	panic("not implemented")
+}
+
+func (mgsc *mockGroupSelectionChain) IsGroupSelectionPossible() (bool, error) {
+	return mgsc.isGroupSelectionPossible, nil
+}
+
+func (mgsc *mockGroupSelectionChain) PastGroupSelectionStarts(
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	return mgsc.groupSelectionStarts, nil
+}
@@ -0,0 +1,100 @@
+package beacon
+
+import (
+	"context"
+	"sync"
+)
+
+// maxTrackedEvents is the number of the most recent events of a single type
+// for which the work can be cancelled. Relay requests and group selections
+// are processed one at a time by the chain so only the latest ones can be
+// removed by a chain reorganization.
+const maxTrackedEvents = 32
+
+// eventWork tracks work started because of chain events so that it can be
+// cancelled when the event turns out to be removed by a chain reorganization.
+// Work is not cancelled on beacon shutdown; it is left to complete instead.
+type eventWork struct {
+	mutex   sync.Mutex
+	cancels map[string]context.CancelFunc
+	keys    []string
+}
+
+func newEventWork() *eventWork {
+	return &eventWork{
+		cancels: make(map[string]context.CancelFunc),
+	}
+}
+
+// start returns the context of work started because of the event with the
+// given key. Contexts returned for the same key are all done once the work of
+// the event is cancelled.
+func (ew *eventWork) start(key string) context.Context {
+	ew.mutex.Lock()
+	defer ew.mutex.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if previousCancel, ok := ew.cancels[key]; ok {
+		// The event is handled again; its removal abandons all its work.
+		ew.cancels[key] = func() {
+			previousCancel()
+			cancel()
+		}
+		return ctx
+	}
+
+	ew.cancels[key] = cancel
+	ew.keys = append(ew.keys, key)
+
+	if len(ew.keys) > maxTrackedEvents {
+		// Contexts of forgotten events are not derived from any other
+		// context so they do not need to be cancelled to be released.
+		delete(ew.cancels, ew.keys[0])
+		ew.keys = ew.keys[1:]
+	}
+
+	return ctx
+}
+
+// mergeContexts returns a context derived from the first context which is
+// also done once the second context is done.
+func mergeContexts(
+	first context.Context,
+	second context.Context,
+) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(first)
+
+	go func() {
+		select {
+		case <-second.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// cancel cancels all work started because of the event with the given key.
+// It returns false if there is no such work.
+func (ew *eventWork) cancel(key string) bool {
+	ew.mutex.Lock()
+	defer ew.mutex.Unlock()
+
+	cancel, ok := ew.cancels[key]
+	if !ok {
+		return false
+	}
+
+	cancel()
+	delete(ew.cancels, key)
+	for i, trackedKey := range ew.keys {
+		if trackedKey == key {
+			ew.keys = append(ew.keys[:i], ew.keys[i+1:]...)
+			break
+		}
+	}
+
+	return true
+}
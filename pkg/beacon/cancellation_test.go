@@ -0,0 +1,72 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestEventWorkCancel(t *testing.T) {
+	work := newEventWork()
+
+	first := work.start("event-1")
+	again := work.start("event-1")
+	other := work.start("event-2")
+
+	if !work.cancel("event-1") {
+		t.Fatal("expected work of the event to be cancelled")
+	}
+
+	if first.Err() == nil || again.Err() == nil {
+		t.Errorf("expected all work of the event to be cancelled")
+	}
+	if other.Err() != nil {
+		t.Errorf("unexpected cancellation of work of another event")
+	}
+
+	if work.cancel("event-1") {
+		t.Errorf("unexpected cancellation of already cancelled work")
+	}
+}
+
+func TestEventWorkForgetsOldEvents(t *testing.T) {
+	work := newEventWork()
+
+	oldest := work.start("event-0")
+	for i := 1; i <= maxTrackedEvents; i++ {
+		work.start(fmt.Sprintf("event-%v", i))
+	}
+
+	if work.cancel("event-0") {
+		t.Errorf("unexpected cancellation of forgotten event")
+	}
+	if oldest.Err() != nil {
+		t.Errorf("unexpected cancellation of work of forgotten event")
+	}
+
+	if !work.cancel(fmt.Sprintf("event-%v", maxTrackedEvents)) {
+		t.Errorf("expected work of the latest event to be cancelled")
+	}
+}
+
+func TestMergeContexts(t *testing.T) {
+	first, cancelFirst := context.WithCancel(context.Background())
+	defer cancelFirst()
+	second, cancelSecond := context.WithCancel(context.Background())
+
+	merged, cancelMerged := mergeContexts(first, second)
+	defer cancelMerged()
+
+	if merged.Err() != nil {
+		t.Fatal("unexpected cancellation of the merged context")
+	}
+
+	cancelSecond()
+
+	select {
+	case <-merged.Done():
+	case <-time.After(time.Second):
+		t.Errorf("expected the merged context to be done")
+	}
+}
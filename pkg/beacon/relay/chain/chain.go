@@ -36,6 +36,13 @@ type RelayEntryInterface interface {
 	OnRelayEntryRequested(
 		func(request *event.Request),
 	) (subscription.EventSubscription, error)
+	// OnRelayEntryRequestRemoved is a callback that is invoked when a relay
+	// request already passed to OnRelayEntryRequested handlers turns out to
+	// be removed from the chain by a chain reorganization. Work started
+	// because of the request should be abandoned.
+	OnRelayEntryRequestRemoved(
+		func(request *event.Request),
+	) (subscription.EventSubscription, error)
 	// ReportRelayEntryTimeout notifies the chain when a selected group which was
 	// supposed to submit a relay entry, did not deliver it within a specified
 	// time frame (relayEntryTimeout) counted in blocks.
@@ -58,6 +65,13 @@ type GroupSelectionInterface interface {
 	OnGroupSelectionStarted(
 		func(groupSelectionStarted *event.GroupSelectionStart),
 	) (subscription.EventSubscription, error)
+	// OnGroupSelectionStartRemoved is a callback that is invoked when a group
+	// selection start already passed to OnGroupSelectionStarted handlers
+	// turns out to be removed from the chain by a chain reorganization. Work
+	// started because of the group selection should be abandoned.
+	OnGroupSelectionStartRemoved(
+		func(groupSelectionStarted *event.GroupSelectionStart),
+	) (subscription.EventSubscription, error)
 	// SubmitTicket submits a ticket corresponding to the virtual staker to
 	// the chain, and returns a promise to track the submission. The promise
 	// is fulfilled with the entry as seen on-chain, or failed if there is an
@@ -68,6 +82,17 @@ type GroupSelectionInterface interface {
 	// GetSelectedParticipants returns `GroupSize` slice of addresses of
 	// candidates which have been selected to the currently assembling group.
 	GetSelectedParticipants() ([]StakerAddress, error)
+	// IsGroupSelectionPossible checks if a new group selection can be
+	// started. It returns false when a group selection is currently in
+	// progress on-chain.
+	IsGroupSelectionPossible() (bool, error)
+	// PastGroupSelectionStarts returns all group selection starts seen
+	// on-chain between fromBlock and toBlock, inclusive, ordered by block
+	// number.
+	PastGroupSelectionStarts(
+		fromBlock uint64,
+		toBlock uint64,
+	) ([]*event.GroupSelectionStart, error)
 }
 
 // GroupRegistrationInterface defines the subset of the relay chain interface
@@ -87,6 +112,38 @@ type GroupRegistrationInterface interface {
 	// GetGroupMembers returns `GroupSize` slice of addresses of
 	// participants which have been selected to the group with given public key.
 	GetGroupMembers(groupPublicKey []byte) ([]StakerAddress, error)
+	// PastGroupRegistrations returns all group registrations seen on-chain
+	// between fromBlock and toBlock, inclusive, ordered by block number.
+	PastGroupRegistrations(
+		fromBlock uint64,
+		toBlock uint64,
+	) ([]*event.GroupRegistration, error)
+}
+
+// GroupRewardsInterface defines the subset of the relay chain interface that
+// pertains to rewards of relay group members.
+type GroupRewardsInterface interface {
+	// GetGroupMemberRewards returns the reward, in wei, of a single member of
+	// the group with the given public key. An operator is paid the reward for
+	// each member it held in the group.
+	GetGroupMemberRewards(groupPublicKey []byte) (*big.Int, error)
+	// HasWithdrawnRewards checks if the operator has already withdrawn its
+	// rewards for the group with the given public key.
+	HasWithdrawnRewards(
+		operator StakerAddress,
+		groupPublicKey []byte,
+	) (bool, error)
+	// WithdrawGroupMemberRewards withdraws rewards for all members the
+	// operator held in the group with the given public key to the operator's
+	// beneficiary. Rewards can be withdrawn only once the group is stale.
+	// Returns once the withdrawal transaction is mined successfully.
+	WithdrawGroupMemberRewards(
+		operator StakerAddress,
+		groupPublicKey []byte,
+	) error
+	// CurrentGasPrice returns the gas price, in wei, transactions are
+	// currently submitted with.
+	CurrentGasPrice() (*big.Int, error)
 }
 
 // GroupInterface defines the subset of the relay chain interface that pertains
@@ -103,11 +160,14 @@ type DistributedKeyGenerationInterface interface {
 	// SubmitDKGResult sends DKG result to a chain, along with signatures over
 	// result hash from group participants supporting the result.
 	// Signatures over DKG result hash are collected in a map keyed by signer's
-	// member index.
+	// member index. The publication block is the block at which the
+	// participant became eligible to submit the result; the next participant
+	// becomes eligible one result publication block step later.
 	SubmitDKGResult(
 		participantIndex GroupMemberIndex,
 		dkgResult *DKGResult,
 		signatures map[GroupMemberIndex][]byte,
+		publicationBlock uint64,
 	) *async.EventDKGResultSubmissionPromise
 	// OnDKGResultSubmitted registers a callback that is invoked when an on-chain
 	// notification of a new, valid submitted result is seen.
@@ -130,8 +190,18 @@ type Interface interface {
 	// GetKeys returns the key pair used to attest for messages being sent to
 	// the chain.
 	GetKeys() (*operator.PrivateKey, *operator.PublicKey)
+	// ReportUnauthorizedSigning reports to the chain a signature over the
+	// operator's address made with the private key of the group with the
+	// given public key. Such a signature proves the group private key
+	// leaked; the chain terminates the group and seizes stakes of its
+	// members.
+	ReportUnauthorizedSigning(
+		groupPublicKey []byte,
+		signedOperatorAddress []byte,
+	) error
 
 	GroupInterface
+	GroupRewardsInterface
 	RelayEntryInterface
 	DistributedKeyGenerationInterface
 }
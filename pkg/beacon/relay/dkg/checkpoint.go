@@ -0,0 +1,199 @@
+package dkg
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg/gen/pb"
+	"github.com/keep-network/keep-core/pkg/metrics"
+)
+
+const (
+	checkpointDirectoryPrefix = "dkg_"
+	checkpointFileName        = "checkpoint"
+)
+
+// Checkpoint is a state of the distributed key generation executed by one
+// member, saved at the end of every GJKR protocol phase. It lets the member
+// resume the distributed key generation after a client restart.
+type Checkpoint struct {
+	Seed             *big.Int
+	Index            uint8 // starts with 0
+	SelectedStakers  []relayChain.StakerAddress
+	StartBlockHeight uint64
+
+	gjkrCheckpoint []byte
+}
+
+// Marshal converts the checkpoint to a byte array.
+func (c *Checkpoint) Marshal() ([]byte, error) {
+	selectedStakers := make([][]byte, 0, len(c.SelectedStakers))
+	for _, staker := range c.SelectedStakers {
+		selectedStakers = append(selectedStakers, staker)
+	}
+
+	return (&pb.Checkpoint{
+		Seed:             c.Seed.Bytes(),
+		Index:            uint32(c.Index),
+		SelectedStakers:  selectedStakers,
+		StartBlockHeight: c.StartBlockHeight,
+		GjkrCheckpoint:   c.gjkrCheckpoint,
+	}).Marshal()
+}
+
+// Unmarshal converts a byte array back to the checkpoint.
+func (c *Checkpoint) Unmarshal(bytes []byte) error {
+	pbCheckpoint := pb.Checkpoint{}
+	if err := pbCheckpoint.Unmarshal(bytes); err != nil {
+		return err
+	}
+
+	if pbCheckpoint.Index > 255 {
+		return fmt.Errorf("invalid member index [%v]", pbCheckpoint.Index)
+	}
+
+	selectedStakers := make(
+		[]relayChain.StakerAddress,
+		0,
+		len(pbCheckpoint.SelectedStakers),
+	)
+	for _, staker := range pbCheckpoint.SelectedStakers {
+		selectedStakers = append(selectedStakers, staker)
+	}
+
+	c.Seed = new(big.Int).SetBytes(pbCheckpoint.Seed)
+	c.Index = uint8(pbCheckpoint.Index)
+	c.SelectedStakers = selectedStakers
+	c.StartBlockHeight = pbCheckpoint.StartBlockHeight
+	c.gjkrCheckpoint = pbCheckpoint.GjkrCheckpoint
+
+	return nil
+}
+
+// directory returns the name of the persistence directory holding the
+// checkpoint. Every member executing the distributed key generation has its
+// own directory.
+func (c *Checkpoint) directory() string {
+	return fmt.Sprintf(
+		"%v%v_%v",
+		checkpointDirectoryPrefix,
+		c.Seed.Text(16),
+		c.Index,
+	)
+}
+
+// operatorLabel returns the value of the operator label of metrics of the
+// distributed key generation executed by the member.
+func (c *Checkpoint) operatorLabel() string {
+	if int(c.Index) >= len(c.SelectedStakers) {
+		return metrics.OperatorLabelValue(nil)
+	}
+
+	return metrics.OperatorLabelValue(c.SelectedStakers[c.Index])
+}
+
+// IsCheckpointDirectory returns true if the given persistence directory holds
+// a checkpoint of the distributed key generation.
+func IsCheckpointDirectory(directory string) bool {
+	return strings.HasPrefix(directory, checkpointDirectoryPrefix)
+}
+
+// CheckpointStorage persists checkpoints of distributed key generations in
+// progress.
+type CheckpointStorage struct {
+	handle persistence.Handle
+}
+
+// NewCheckpointStorage creates a checkpoint storage backed by the given
+// persistence handle.
+func NewCheckpointStorage(handle persistence.Handle) *CheckpointStorage {
+	return &CheckpointStorage{handle}
+}
+
+func (cs *CheckpointStorage) save(checkpoint *Checkpoint) error {
+	checkpointBytes, err := checkpoint.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshalling of the checkpoint failed: [%v]", err)
+	}
+
+	return cs.handle.Save(
+		checkpointBytes,
+		checkpoint.directory(),
+		"/"+checkpointFileName,
+	)
+}
+
+// Archive moves the given checkpoint to the archive so that the distributed
+// key generation is not resumed from it anymore.
+func (cs *CheckpointStorage) Archive(checkpoint *Checkpoint) error {
+	return cs.handle.Archive(checkpoint.directory())
+}
+
+// ReadAll reads all checkpoints which were not archived yet.
+func (cs *CheckpointStorage) ReadAll() (<-chan *Checkpoint, <-chan error) {
+	outputCheckpoints := make(chan *Checkpoint)
+	outputErrors := make(chan error)
+
+	inputData, inputErrors := cs.handle.ReadAll()
+
+	// The same as for the group registry storage, data and errors channels
+	// are read at the same time by two goroutines and the output channels
+	// are closed by the third one once both are done.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		wg.Wait()
+		close(outputCheckpoints)
+		close(outputErrors)
+	}()
+
+	go func() {
+		for err := range inputErrors {
+			outputErrors <- err
+		}
+		wg.Done()
+	}()
+
+	go func() {
+		for descriptor := range inputData {
+			// The persistence handle is shared with the group registry;
+			// skip all files which are not checkpoints.
+			if !IsCheckpointDirectory(descriptor.Directory()) ||
+				descriptor.Name() != checkpointFileName {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				outputErrors <- fmt.Errorf(
+					"could not read checkpoint from directory [%v]: [%v]",
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			checkpoint := &Checkpoint{}
+			if err := checkpoint.Unmarshal(content); err != nil {
+				outputErrors <- fmt.Errorf(
+					"could not unmarshal checkpoint from directory [%v]: [%v]",
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			outputCheckpoints <- checkpoint
+		}
+
+		wg.Done()
+	}()
+
+	return outputCheckpoints, outputErrors
+}
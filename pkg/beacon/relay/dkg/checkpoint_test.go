@@ -0,0 +1,148 @@
+package dkg
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/internal/pbutils"
+)
+
+var testCheckpoint = &Checkpoint{
+	Seed:  big.NewInt(1923183),
+	Index: 3,
+	SelectedStakers: []relayChain.StakerAddress{
+		[]byte{0x01, 0x02},
+		[]byte{0x03, 0x04},
+	},
+	StartBlockHeight: 1201,
+	gjkrCheckpoint:   []byte{0x0a, 0x0b, 0x0c},
+}
+
+func TestCheckpointRoundtrip(t *testing.T) {
+	unmarshaled := &Checkpoint{}
+
+	err := pbutils.RoundTrip(testCheckpoint, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(testCheckpoint, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled checkpoint")
+	}
+}
+
+func TestCheckpointStorage(t *testing.T) {
+	handle := &persistenceHandleMock{}
+	storage := NewCheckpointStorage(handle)
+
+	if err := storage.save(testCheckpoint); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSaved := []string{"dkg_1d586f_3/checkpoint"}
+	if !reflect.DeepEqual(expectedSaved, handle.saved) {
+		t.Errorf(
+			"unexpected saved checkpoints\nexpected: [%v]\nactual:   [%v]",
+			expectedSaved,
+			handle.saved,
+		)
+	}
+
+	checkpoints, errors := storage.ReadAll()
+
+	var readCheckpoints []*Checkpoint
+	var readErrors []error
+	done := make(chan struct{})
+	go func() {
+		for err := range errors {
+			readErrors = append(readErrors, err)
+		}
+		close(done)
+	}()
+	for checkpoint := range checkpoints {
+		readCheckpoints = append(readCheckpoints, checkpoint)
+	}
+	<-done
+
+	if len(readErrors) != 0 {
+		t.Errorf("unexpected errors [%v]", readErrors)
+	}
+	if !reflect.DeepEqual([]*Checkpoint{testCheckpoint}, readCheckpoints) {
+		t.Errorf(
+			"unexpected read checkpoints\nexpected: [%v]\nactual:   [%v]",
+			[]*Checkpoint{testCheckpoint},
+			readCheckpoints,
+		)
+	}
+
+	if err := storage.Archive(testCheckpoint); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedArchived := []string{"dkg_1d586f_3"}
+	if !reflect.DeepEqual(expectedArchived, handle.archived) {
+		t.Errorf(
+			"unexpected archived checkpoints\nexpected: [%v]\nactual:   [%v]",
+			expectedArchived,
+			handle.archived,
+		)
+	}
+}
+
+type persistenceHandleMock struct {
+	saved    []string
+	archived []string
+}
+
+func (phm *persistenceHandleMock) Save(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	phm.saved = append(phm.saved, directory+name)
+	return nil
+}
+
+func (phm *persistenceHandleMock) ReadAll() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	checkpointBytes, _ := testCheckpoint.Marshal()
+
+	outputData := make(chan persistence.DataDescriptor, 3)
+	outputErrors := make(chan error)
+
+	// A membership saved by the group registry to the same handle.
+	outputData <- &testDataDescriptor{"membership_1", "ab12", []byte{0x01}}
+	outputData <- &testDataDescriptor{"checkpoint", "dkg_1d586f_3", checkpointBytes}
+
+	close(outputData)
+	close(outputErrors)
+
+	return outputData, outputErrors
+}
+
+func (phm *persistenceHandleMock) Archive(directory string) error {
+	phm.archived = append(phm.archived, directory)
+	return nil
+}
+
+type testDataDescriptor struct {
+	name      string
+	directory string
+	content   []byte
+}
+
+func (tdd *testDataDescriptor) Name() string {
+	return tdd.name
+}
+
+func (tdd *testDataDescriptor) Directory() string {
+	return tdd.directory
+}
+
+func (tdd *testDataDescriptor) Content() ([]byte, error) {
+	return tdd.content, nil
+}
@@ -2,6 +2,7 @@ package dkg
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"math/big"
 
@@ -13,41 +14,221 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/fieldlog"
+	"github.com/keep-network/keep-core/pkg/metrics"
 	"github.com/keep-network/keep-core/pkg/net"
 )
 
-var logger = log.Logger("keep-dkg")
+const loggerSubsystem = "keep-dkg"
+
+var logger = log.Logger(loggerSubsystem)
+
+var (
+	dkgStarted = metrics.NewCounterVec(
+		"keep_dkg_started_total",
+		"Number of distributed key generation executions started.",
+		metrics.OperatorLabel,
+	)
+	dkgSucceeded = metrics.NewCounterVec(
+		"keep_dkg_succeeded_total",
+		"Number of distributed key generation executions completed successfully.",
+		metrics.OperatorLabel,
+	)
+	dkgFailed = metrics.NewCounterVec(
+		"keep_dkg_failed_total",
+		"Number of distributed key generation executions failed.",
+		metrics.OperatorLabel,
+	)
+	phaseFailures = metrics.NewCounterVec(
+		"keep_gjkr_phase_failures_total",
+		"Number of GJKR protocol executions failed, partitioned by phase.",
+		"phase",
+		metrics.OperatorLabel,
+	)
+)
 
 // ExecuteDKG runs the full distributed key generation lifecycle.
+//
+// If the checkpoint storage is not nil, a checkpoint of the member's state is
+// saved to it at the end of every GJKR protocol phase so that the distributed
+// key generation can be resumed with ResumeDKG after a client restart.
+//
+// The distributed key generation is abandoned once the context is done, for
+// example because the group selection it was started for has been removed by
+// a chain reorganization. The DKG result is not published then.
 func ExecuteDKG(
+	ctx context.Context,
 	seed *big.Int,
 	index uint8, // starts with 0
 	groupSize int,
 	dishonestThreshold int,
-	membershipValidator group.MembershipValidator,
+	selectedStakers []relayChain.StakerAddress,
 	startBlockHeight uint64,
 	blockCounter chain.BlockCounter,
 	relayChain relayChain.Interface,
 	signing chain.Signing,
 	channel net.BroadcastChannel,
+	checkpointStorage *CheckpointStorage,
+) (*ThresholdSigner, error) {
+	checkpoint := &Checkpoint{
+		Seed:             seed,
+		Index:            index,
+		SelectedStakers:  selectedStakers,
+		StartBlockHeight: startBlockHeight,
+	}
+
+	operatorLabel := checkpoint.operatorLabel()
+	dkgStarted.WithLabelValues(operatorLabel).Inc()
+
+	signer, err := executeDKG(
+		ctx,
+		checkpoint,
+		blockCounter,
+		relayChain,
+		signing,
+		channel,
+		checkpointStorage,
+		func(
+			membershipValidator group.MembershipValidator,
+			checkpointHandler gjkr.CheckpointHandler,
+		) (*gjkr.Result, uint64, error) {
+			return gjkr.Execute(
+				ctx,
+				group.MemberIndex(index+1),
+				groupSize,
+				blockCounter,
+				channel,
+				dishonestThreshold,
+				seed,
+				membershipValidator,
+				startBlockHeight,
+				checkpointHandler,
+			)
+		},
+	)
+	if err != nil {
+		dkgFailed.WithLabelValues(operatorLabel).Inc()
+		return nil, err
+	}
+
+	dkgSucceeded.WithLabelValues(operatorLabel).Inc()
+	return signer, nil
+}
+
+// ResumeDKG resumes the distributed key generation from the given checkpoint
+// saved by ExecuteDKG or ResumeDKG before a client restart. The checkpoint is
+// archived once the GJKR protocol completes or cannot be resumed. The
+// generation is abandoned once the context is done.
+func ResumeDKG(
+	ctx context.Context,
+	checkpoint *Checkpoint,
+	blockCounter chain.BlockCounter,
+	relayChain relayChain.Interface,
+	signing chain.Signing,
+	channel net.BroadcastChannel,
+	checkpointStorage *CheckpointStorage,
+) (*ThresholdSigner, error) {
+	operatorLabel := checkpoint.operatorLabel()
+	dkgStarted.WithLabelValues(operatorLabel).Inc()
+
+	signer, err := executeDKG(
+		ctx,
+		checkpoint,
+		blockCounter,
+		relayChain,
+		signing,
+		channel,
+		checkpointStorage,
+		func(
+			membershipValidator group.MembershipValidator,
+			checkpointHandler gjkr.CheckpointHandler,
+		) (*gjkr.Result, uint64, error) {
+			return gjkr.Resume(
+				ctx,
+				checkpoint.gjkrCheckpoint,
+				blockCounter,
+				channel,
+				membershipValidator,
+				checkpointHandler,
+			)
+		},
+	)
+	if err != nil {
+		dkgFailed.WithLabelValues(operatorLabel).Inc()
+		return nil, err
+	}
+
+	dkgSucceeded.WithLabelValues(operatorLabel).Inc()
+	return signer, nil
+}
+
+// gjkrExecution executes or resumes the GJKR protocol.
+type gjkrExecution func(
+	membershipValidator group.MembershipValidator,
+	checkpointHandler gjkr.CheckpointHandler,
+) (*gjkr.Result, uint64, error)
+
+func executeDKG(
+	ctx context.Context,
+	checkpoint *Checkpoint,
+	blockCounter chain.BlockCounter,
+	relayChain relayChain.Interface,
+	signing chain.Signing,
+	channel net.BroadcastChannel,
+	checkpointStorage *CheckpointStorage,
+	executeGJKR gjkrExecution,
 ) (*ThresholdSigner, error) {
 	// The staker index should begin with 1
-	playerIndex := group.MemberIndex(index + 1)
+	playerIndex := group.MemberIndex(checkpoint.Index + 1)
+
+	dkgLogger := fieldlog.New(
+		loggerSubsystem,
+		fieldlog.Fields{
+			fieldlog.MemberIndex: playerIndex,
+			fieldlog.Channel:     channel.Name(),
+			fieldlog.Seed:        fmt.Sprintf("0x%x", checkpoint.Seed),
+		},
+	)
+
+	membershipValidator := group.NewStakersMembershipValidator(
+		checkpoint.SelectedStakers,
+		signing,
+	)
 
 	gjkr.RegisterUnmarshallers(channel)
 	dkgResult.RegisterUnmarshallers(channel)
 
-	gjkrResult, gjkrEndBlockHeight, err := gjkr.Execute(
-		playerIndex,
-		groupSize,
-		blockCounter,
-		channel,
-		dishonestThreshold,
-		seed,
+	var checkpointHandler gjkr.CheckpointHandler
+	if checkpointStorage != nil {
+		checkpointHandler = func(gjkrCheckpoint []byte) {
+			checkpoint.gjkrCheckpoint = gjkrCheckpoint
+			if err := checkpointStorage.save(checkpoint); err != nil {
+				dkgLogger.Warningf("could not save checkpoint: [%v]", err)
+			}
+		}
+	}
+
+	gjkrResult, gjkrEndBlockHeight, err := executeGJKR(
 		membershipValidator,
-		startBlockHeight,
+		checkpointHandler,
 	)
+
+	// Checkpoints are taken only during the GJKR protocol execution. Once it
+	// is over, there is nothing to resume.
+	if checkpointStorage != nil && checkpoint.gjkrCheckpoint != nil {
+		if err := checkpointStorage.Archive(checkpoint); err != nil {
+			dkgLogger.Warningf("could not archive checkpoint: [%v]", err)
+		}
+	}
+
 	if err != nil {
+		if phaseError, ok := err.(*gjkr.PhaseError); ok {
+			phaseFailures.WithLabelValues(
+				phaseError.Phase,
+				checkpoint.operatorLabel(),
+			).Inc()
+		}
+
 		return nil, fmt.Errorf(
 			"[member:%v] GJKR execution failed [%v]",
 			playerIndex,
@@ -73,6 +254,7 @@ func ExecuteDKG(
 	defer dkgResultSubscription.Unsubscribe()
 
 	err = dkgResult.Publish(
+		ctx,
 		playerIndex,
 		gjkrResult.Group,
 		membershipValidator,
@@ -83,6 +265,13 @@ func ExecuteDKG(
 		blockCounter,
 		startPublicationBlockHeight,
 	)
+	if err != nil && ctx.Err() != nil {
+		return nil, fmt.Errorf(
+			"[member:%v] DKG result publication cancelled [%v]",
+			playerIndex,
+			err,
+		)
+	}
 	if err != nil {
 		// Result publication failed. It means that either the result this
 		// member proposed is not supported by the majority of group members or
@@ -90,9 +279,8 @@ func ExecuteDKG(
 		// chain for the result published by any other group member and based
 		// on that, we decide whether we should stay in the final group
 		// or drop our membership.
-		logger.Warningf(
-			"[member:%v] DKG result publication process failed [%v]",
-			playerIndex,
+		dkgLogger.Warningf(
+			"DKG result publication process failed [%v]",
 			err,
 		)
 
@@ -1,6 +1,7 @@
 package result
 
 import (
+	"context"
 	"fmt"
 
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
@@ -25,8 +26,10 @@ func RegisterUnmarshallers(channel net.BroadcastChannel) {
 // chosen result is hashed, signed, and sent over a broadcast channel. Then, all
 // other signatures and results are received and accounted for. Those that match
 // our own result and added to the list of votes. Finally, we submit the result
-// along with everyone's votes.
+// along with everyone's votes. The publication is abandoned once the context
+// is done.
 func Publish(
+	ctx context.Context,
 	memberIndex group.MemberIndex,
 	dkgGroup *group.Group,
 	membershipValidator group.MembershipValidator,
@@ -49,6 +52,7 @@ func Publish(
 	}
 
 	stateMachine := state.NewMachine(channel, blockCounter, initialState)
+	stateMachine.SetContext(ctx)
 
 	lastState, _, err := stateMachine.Execute(startBlockHeight)
 	if err != nil {
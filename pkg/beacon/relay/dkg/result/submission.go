@@ -110,11 +110,14 @@ func (sm *SubmittingMember) SubmitDKGResult(
 	}
 
 	// Wait until the current member is eligible to submit the result.
-	eligibleToSubmitWaiter, err := sm.waitForSubmissionEligibility(
-		blockCounter,
+	eligibleBlockHeight := sm.eligibleBlockHeight(
 		startBlockHeight,
 		config.ResultPublicationBlockStep,
 	)
+	eligibleToSubmitWaiter, err := sm.waitForSubmissionEligibility(
+		blockCounter,
+		eligibleBlockHeight,
+	)
 	if err != nil {
 		return returnWithError(
 			fmt.Errorf("wait for eligibility failure: [%v]", err),
@@ -143,6 +146,7 @@ func (sm *SubmittingMember) SubmitDKGResult(
 				sm.index,
 				result,
 				signatures,
+				eligibleBlockHeight,
 			).
 				OnComplete(func(
 					dkgResultPublishedEvent *event.DKGResultSubmission,
@@ -164,18 +168,26 @@ func (sm *SubmittingMember) SubmitDKGResult(
 	}
 }
 
-// waitForSubmissionEligibility waits until the current member is eligible to
-// submit a result to the blockchain. First member is eligible to submit straight
-// away, each following member is eligible after pre-defined block step.
-func (sm *SubmittingMember) waitForSubmissionEligibility(
-	blockCounter chain.BlockCounter,
+// eligibleBlockHeight returns the block at which the current member becomes
+// eligible to submit a result to the blockchain. First member is eligible to
+// submit straight away, each following member is eligible after pre-defined
+// block step.
+func (sm *SubmittingMember) eligibleBlockHeight(
 	startBlockHeight uint64,
 	blockStep uint64,
-) (<-chan uint64, error) {
+) uint64 {
 	// T_init + (member_index - 1) * T_step
 	blockWaitTime := (uint64(sm.index) - 1) * blockStep
 
-	eligibleBlockHeight := startBlockHeight + blockWaitTime
+	return startBlockHeight + blockWaitTime
+}
+
+// waitForSubmissionEligibility waits until the current member is eligible to
+// submit a result to the blockchain, at the given block.
+func (sm *SubmittingMember) waitForSubmissionEligibility(
+	blockCounter chain.BlockCounter,
+	eligibleBlockHeight uint64,
+) (<-chan uint64, error) {
 	logger.Infof(
 		"[member:%v] waiting for block [%v] to submit",
 		sm.index,
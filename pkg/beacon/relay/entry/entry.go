@@ -3,6 +3,7 @@ package entry
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 
@@ -13,10 +14,41 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/bls"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/fieldlog"
+	"github.com/keep-network/keep-core/pkg/metrics"
 	"github.com/keep-network/keep-core/pkg/net"
 )
 
-var logger = log.Logger("keep-entry")
+const loggerSubsystem = "keep-entry"
+
+var logger = log.Logger(loggerSubsystem)
+
+var (
+	signingStarted = metrics.NewCounterVec(
+		"keep_relay_entry_signing_started_total",
+		"Number of relay entry signing processes started.",
+		metrics.OperatorLabel,
+	)
+	receivedShares = metrics.NewCounterVec(
+		"keep_relay_entry_signature_shares_total",
+		"Number of signature shares received from other group members, "+
+			"partitioned by validation result.",
+		"result",
+		metrics.OperatorLabel,
+	)
+	thresholdDuration = metrics.NewHistogramVec(
+		"keep_relay_entry_threshold_seconds",
+		"Time from the start of relay entry signing until the honest "+
+			"threshold of valid signature shares has been collected.",
+		[]float64{1, 2, 5, 10, 20, 30, 60, 120, 300},
+		metrics.OperatorLabel,
+	)
+	relayEntryTimeouts = metrics.NewCounterVec(
+		"keep_relay_entry_timeouts_total",
+		"Number of relay entry signing processes which timed out.",
+		metrics.OperatorLabel,
+	)
+)
 
 // RegisterUnmarshallers initializes the given broadcast channel to be able to
 // perform relay entry signing protocol interactions by registering all the
@@ -29,17 +61,43 @@ func RegisterUnmarshallers(channel net.BroadcastChannel) {
 
 // SignAndSubmit triggers the threshold signature process for the
 // previous relay entry and publishes the signature to the chain as
-// a new relay entry.
+// a new relay entry. All messages logged during the process carry the member
+// index, the channel name, the group public key and the block of the relay
+// entry request.
+//
+// The signing is abandoned once the context is done, for example because the
+// relay entry request has been removed by a chain reorganization. The relay
+// entry is not submitted then.
+//
+// Metrics of the signing are labelled with the address of the operator
+// holding the signer's membership.
 func SignAndSubmit(
+	ctx context.Context,
 	blockCounter chain.BlockCounter,
 	channel net.BroadcastChannel,
 	relayChain relayChain.Interface,
 	previousEntryBytes []byte,
 	honestThreshold int,
 	signer *dkg.ThresholdSigner,
+	operator relayChain.StakerAddress,
 	startBlockHeight uint64,
 ) error {
-	ctx, cancelCtx := context.WithCancel(context.Background())
+	operatorLabel := metrics.OperatorLabelValue(operator)
+
+	signingStarted.WithLabelValues(operatorLabel).Inc()
+	signingStartTime := time.Now()
+
+	signingLogger := fieldlog.New(
+		loggerSubsystem,
+		fieldlog.Fields{
+			fieldlog.MemberIndex:    signer.MemberID(),
+			fieldlog.Channel:        channel.Name(),
+			fieldlog.GroupPublicKey: fmt.Sprintf("0x%x", signer.GroupPublicKeyBytes()),
+			fieldlog.RequestBlock:   startBlockHeight,
+		},
+	)
+
+	ctx, cancelCtx := context.WithCancel(ctx)
 	defer cancelCtx()
 
 	relayEntrySubmittedChannel := make(chan uint64)
@@ -73,7 +131,7 @@ func SignAndSubmit(
 
 	selfShare := signer.CalculateSignatureShare(previousEntry)
 
-	go broadcastShare(ctx, signer.MemberID(), selfShare, channel)
+	go broadcastShare(ctx, signer.MemberID(), selfShare, channel, signingLogger)
 
 	receiveChannel := make(chan net.Message, 64)
 	channel.Recv(ctx, func(netMessage net.Message) {
@@ -102,41 +160,53 @@ func SignAndSubmit(
 				previousEntry,
 			)
 			if err != nil {
-				logger.Warningf(
-					"[member:%v] rejecting signature share from "+
-						"member [%v]: [%v]",
-					signer.MemberID(),
+				receivedShares.WithLabelValues("rejected", operatorLabel).Inc()
+				signingLogger.Warningf(
+					"rejecting signature share from member [%v]: [%v]",
 					message.senderID,
 					err,
 				)
 				continue
 			}
 
-			logger.Debugf(
-				"[member:%v] accepting signature share from member [%v]",
-				signer.MemberID(),
+			receivedShares.WithLabelValues("accepted", operatorLabel).Inc()
+			signingLogger.Debugf(
+				"accepting signature share from member [%v]",
 				message.senderID,
 			)
 
 			receivedValidShares[message.senderID] = share
 		case blockNumber := <-relayEntrySubmittedChannel:
-			logger.Infof(
-				"[member:%v] leaving message loop; "+
+			signingLogger.Infof(
+				"leaving message loop; "+
 					"relay entry submitted by other member at block [%v]",
-				signer.MemberID(),
 				blockNumber,
 			)
 			return nil
 		case blockNumber := <-relayEntryTimeoutChannel:
+			relayEntryTimeouts.WithLabelValues(operatorLabel).Inc()
 			return fmt.Errorf(
 				"relay entry timed out at block [%v]; received [%v] valid signature shares",
 				blockNumber,
 				len(receivedValidShares),
 			)
+		case <-ctx.Done():
+			return fmt.Errorf(
+				"signing cancelled; received [%v] valid signature shares: [%v]",
+				len(receivedValidShares),
+				ctx.Err(),
+			)
 		}
 	}
 
-	signature, err := completeSignature(signer, receivedValidShares, honestThreshold)
+	thresholdDuration.WithLabelValues(operatorLabel).Observe(time.Since(signingStartTime).Seconds())
+
+	signature, err := completeSignature(
+		signer,
+		receivedValidShares,
+		honestThreshold,
+		signingLogger,
+	)
 	if err != nil {
 		return err
 	}
@@ -145,6 +215,7 @@ func SignAndSubmit(
 		chain:        relayChain,
 		blockCounter: blockCounter,
 		index:        signer.MemberID(),
+		logger:       signingLogger,
 	}
 
 	// relayEntrySubmittedChannel and relayEntryTimeoutChannel are passed to
@@ -153,6 +224,7 @@ func SignAndSubmit(
 	// still a possibility those signals appear in the future so the submitter
 	// must be aware of them and break the execution if they occur.
 	return submitter.submitRelayEntry(
+		ctx,
 		signature.Marshal(),
 		signer.GroupPublicKeyBytes(),
 		startBlockHeight,
@@ -166,6 +238,7 @@ func broadcastShare(
 	memberID group.MemberIndex,
 	share *bn256.G1,
 	channel net.BroadcastChannel,
+	signingLogger *fieldlog.Logger,
 ) {
 	message := &SignatureShareMessage{
 		memberID,
@@ -173,11 +246,7 @@ func broadcastShare(
 	}
 
 	if err := channel.Send(ctx, message); err != nil {
-		logger.Errorf(
-			"[member:%v] could not send signature share: [%v]",
-			memberID,
-			err,
-		)
+		signingLogger.Errorf("could not send signature share: [%v]", err)
 	}
 }
 
@@ -214,6 +283,7 @@ func completeSignature(
 	signer *dkg.ThresholdSigner,
 	shares map[group.MemberIndex]*bn256.G1,
 	honestThreshold int,
+	signingLogger *fieldlog.Logger,
 ) (*bn256.G1, error) {
 	signatureShares := make([]*bls.SignatureShare, 0)
 	for memberID, share := range shares {
@@ -221,9 +291,8 @@ func completeSignature(
 		signatureShares = append(signatureShares, signatureShare)
 	}
 
-	logger.Infof(
-		"[member:%v] restoring signature from [%v] shares",
-		signer.MemberID(),
+	signingLogger.Infof(
+		"restoring signature from [%v] shares",
 		len(signatureShares),
 	)
 
@@ -22,3 +22,8 @@ func NewSignatureShareMessage(
 func (ssm *SignatureShareMessage) SenderID() group.MemberIndex {
 	return ssm.senderID
 }
+
+// ShareBytes returns the marshalled signature share carried by the message.
+func (ssm *SignatureShareMessage) ShareBytes() []byte {
+	return ssm.shareBytes
+}
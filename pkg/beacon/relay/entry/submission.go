@@ -1,12 +1,14 @@
 package entry
 
 import (
+	"context"
 	"fmt"
 
 	relayChain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/fieldlog"
 )
 
 type relayEntrySubmitter struct {
@@ -14,6 +16,8 @@ type relayEntrySubmitter struct {
 	blockCounter chain.BlockCounter
 
 	index group.MemberIndex
+
+	logger *fieldlog.Logger
 }
 
 // submitRelayEntry submits the provided relay entry data to the chain.
@@ -21,8 +25,9 @@ type relayEntrySubmitter struct {
 // Group member with index 1 tries to submit as the first one, group member 2
 // tries to submit after a few blocks if member 1 did not submit and so on.
 // Relay entry submit process starts at block height defined by startBlockheight
-// parameter.
+// parameter. The member stops waiting for its turn once the context is done.
 func (res *relayEntrySubmitter) submitRelayEntry(
+	ctx context.Context,
 	newEntry []byte,
 	groupPublicKey []byte,
 	startBlockHeight uint64,
@@ -56,10 +61,9 @@ func (res *relayEntrySubmitter) submitRelayEntry(
 			errorChannel := make(chan error)
 			defer close(errorChannel)
 
-			logger.Infof(
-				"[member:%v] submitting relay entry [0x%x] on behalf of group "+
+			res.logger.Infof(
+				"submitting relay entry [0x%x] on behalf of group "+
 					"[0x%x] at block [%v]",
-				res.index,
 				newEntry,
 				groupPublicKey,
 				blockNumber,
@@ -68,10 +72,8 @@ func (res *relayEntrySubmitter) submitRelayEntry(
 			res.chain.SubmitRelayEntry(newEntry).OnComplete(
 				func(entry *event.EntrySubmitted, err error) {
 					if err == nil {
-						logger.Infof(
-							"[member:%v] successfully submitted "+
-								"relay entry at block: [%v]",
-							res.index,
+						res.logger.Infof(
+							"successfully submitted relay entry at block: [%v]",
 							entry.BlockNumber,
 						)
 					}
@@ -83,11 +85,10 @@ func (res *relayEntrySubmitter) submitRelayEntry(
 			if entryErr != nil {
 				isEntryInProgress, err := res.chain.IsEntryInProgress()
 				if err != nil {
-					logger.Errorf(
-						"[member:%v] could not check entry status after "+
+					res.logger.Errorf(
+						"could not check entry status after "+
 							"relay entry submission error: [%v]; "+
 							"original error will be returned",
-						res.index,
 						err,
 					)
 					return entryErr
@@ -97,20 +98,16 @@ func (res *relayEntrySubmitter) submitRelayEntry(
 				// meantime or because something wrong happened with
 				// our transaction.
 				if !isEntryInProgress {
-					logger.Infof(
-						"[member:%v] relay entry already submitted",
-						res.index,
-					)
+					res.logger.Infof("relay entry already submitted")
 					return nil
 				}
 			}
 
 			return entryErr
 		case blockNumber := <-relayEntrySubmittedChannel:
-			logger.Infof(
-				"[member:%v] leaving submitter; "+
+			res.logger.Infof(
+				"leaving submitter; "+
 					"relay entry submitted by other member at block [%v]",
-				res.index,
 				blockNumber,
 			)
 			return nil
@@ -119,6 +116,11 @@ func (res *relayEntrySubmitter) submitRelayEntry(
 				"relay entry timed out at block [%v]",
 				blockNumber,
 			)
+		case <-ctx.Done():
+			return fmt.Errorf(
+				"relay entry submission cancelled: [%v]",
+				ctx.Err(),
+			)
 		}
 	}
 }
@@ -134,9 +136,8 @@ func (res *relayEntrySubmitter) waitForSubmissionEligibility(
 	blockWaitTime := (uint64(res.index) - 1) * blockStep
 
 	eligibleBlockHeight := startBlockHeight + blockWaitTime
-	logger.Infof(
-		"[member:%v] waiting for block [%v] to submit",
-		res.index,
+	res.logger.Infof(
+		"waiting for block [%v] to submit",
 		eligibleBlockHeight,
 	)
 
@@ -0,0 +1,43 @@
+package entry
+
+import (
+	"fmt"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/bls"
+)
+
+// Verify checks whether the given relay entry is a valid BLS signature over
+// the previous entry created by the group with the given public key. All
+// values are expected in the form they are stored on-chain: the group public
+// key as a marshalled G2 point and both entries as marshalled G1 points.
+//
+// Verification is performed entirely offline. An error is returned if the
+// group public key, the previous entry or the entry could not be
+// unmarshalled. The operator contract verifies entries over the previous
+// entry being a G1 point as well so there is no valid entry over a previous
+// entry which is not a G1 point.
+func Verify(groupPublicKey, previousEntry, entry []byte) (bool, error) {
+	publicKey := new(bn256.G2)
+	if _, err := publicKey.Unmarshal(groupPublicKey); err != nil {
+		return false, fmt.Errorf(
+			"could not unmarshal group public key: [%v]",
+			err,
+		)
+	}
+
+	signature := new(bn256.G1)
+	if _, err := signature.Unmarshal(entry); err != nil {
+		return false, fmt.Errorf("could not unmarshal entry: [%v]", err)
+	}
+
+	message := new(bn256.G1)
+	if _, err := message.Unmarshal(previousEntry); err != nil {
+		return false, fmt.Errorf(
+			"could not unmarshal previous entry: [%v]",
+			err,
+		)
+	}
+
+	return bls.VerifyG1(publicKey, message, signature), nil
+}
@@ -0,0 +1,94 @@
+package entry
+
+import (
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/bls"
+)
+
+func TestVerify(t *testing.T) {
+	secretKey := big.NewInt(123)
+	groupPublicKey := new(bn256.G2).ScalarBaseMult(secretKey).Marshal()
+	otherGroupPublicKey := new(bn256.G2).ScalarBaseMult(big.NewInt(321)).Marshal()
+
+	previousEntry := new(bn256.G1).ScalarBaseMult(big.NewInt(10))
+	entry := bls.SignG1(secretKey, previousEntry).Marshal()
+
+	seed := []byte("beacon seed")
+
+	var tests = map[string]struct {
+		groupPublicKey []byte
+		previousEntry  []byte
+		entry          []byte
+		expectedResult bool
+		expectError    bool
+	}{
+		"valid entry": {
+			groupPublicKey: groupPublicKey,
+			previousEntry:  previousEntry.Marshal(),
+			entry:          entry,
+			expectedResult: true,
+		},
+		"previous entry which is not a G1 point": {
+			groupPublicKey: groupPublicKey,
+			previousEntry:  seed,
+			entry:          entry,
+			expectError:    true,
+		},
+		"entry signed by another group": {
+			groupPublicKey: otherGroupPublicKey,
+			previousEntry:  previousEntry.Marshal(),
+			entry:          entry,
+			expectedResult: false,
+		},
+		"entry over another previous entry": {
+			groupPublicKey: groupPublicKey,
+			previousEntry:  new(bn256.G1).ScalarBaseMult(big.NewInt(11)).Marshal(),
+			entry:          entry,
+			expectedResult: false,
+		},
+		"malformed entry": {
+			groupPublicKey: groupPublicKey,
+			previousEntry:  previousEntry.Marshal(),
+			entry:          []byte{0x01, 0x02},
+			expectError:    true,
+		},
+		"malformed group public key": {
+			groupPublicKey: []byte{0x01, 0x02},
+			previousEntry:  previousEntry.Marshal(),
+			entry:          entry,
+			expectError:    true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			result, err := Verify(
+				test.groupPublicKey,
+				test.previousEntry,
+				test.entry,
+			)
+
+			if test.expectError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.expectedResult != result {
+				t.Errorf(
+					"unexpected verification result\nexpected: [%v]\nactual:   [%v]",
+					test.expectedResult,
+					result,
+				)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 // Package event contains data structures that are attached to events in the
 // relay. Though many of these events are triggered on-chain, that is not an
 // inherent requirement of structures in this package.
+//
+// Events triggered on-chain carry the hash of the transaction which emitted
+// them, if the chain implementation knows it. The hash is empty otherwise.
 package event
 
 import (
@@ -10,28 +13,49 @@ import (
 // EntrySubmitted indicates that valid relay entry has been submitted to the
 // chain for the currently processed relay request. This event is intended to
 // be used by operators for tracking entry generation and submission progress.
+//
+// RequestID is the id of the relay request the entry has been submitted for.
+// It is nil if the chain implementation could not determine it.
 type EntrySubmitted struct {
-	BlockNumber uint64
+	RequestID       *big.Int
+	BlockNumber     uint64
+	TransactionHash string
 }
 
 // EntryGenerated indicates that new relay entry has ben generated by threshold
 // relay. This event is intended to be used by threshold relay consumers.
 type EntryGenerated struct {
+	RequestID   *big.Int
 	Value       *big.Int
 	BlockNumber uint64
 }
 
+// EntryRequested indicates that a new relay entry has been requested from the
+// threshold relay by a relay consumer. This event is intended to be used by
+// threshold relay consumers.
+type EntryRequested struct {
+	RequestID   *big.Int
+	BlockNumber uint64
+}
+
 // Request represents a request for an entry in the threshold relay.
+//
+// RequestID is the id of the relay request. A request repeated because the
+// previously selected group did not submit the entry on time keeps its id.
+// It is nil if the chain implementation could not determine it.
 type Request struct {
-	PreviousEntry  []byte
-	GroupPublicKey []byte
-	BlockNumber    uint64
+	RequestID       *big.Int
+	PreviousEntry   []byte
+	GroupPublicKey  []byte
+	BlockNumber     uint64
+	TransactionHash string
 }
 
 // GroupSelectionStart represents a group selection start event.
 type GroupSelectionStart struct {
-	NewEntry    *big.Int
-	BlockNumber uint64
+	NewEntry        *big.Int
+	BlockNumber     uint64
+	TransactionHash string
 }
 
 // GroupTicketSubmission represents a group selection ticket submission event.
@@ -46,7 +70,8 @@ type GroupTicketSubmission struct {
 type GroupRegistration struct {
 	GroupPublicKey []byte
 
-	BlockNumber uint64
+	BlockNumber     uint64
+	TransactionHash string
 }
 
 // DKGResultSubmission represents a DKG result submission event. It is emitted
@@ -58,5 +83,71 @@ type DKGResultSubmission struct {
 	GroupPublicKey []byte
 	Misbehaved     []byte
 
+	BlockNumber     uint64
+	TransactionHash string
+}
+
+// GroupMemberRewardsWithdrawal represents a withdrawal of rewards the
+// operator earned for all members it held in the group with the given public
+// key. Rewards are transferred to the beneficiary of the operator.
+type GroupMemberRewardsWithdrawal struct {
+	Beneficiary    []byte
+	Operator       []byte
+	Amount         *big.Int
+	GroupPublicKey []byte
+
 	BlockNumber uint64
 }
+
+// TokensSlashed represents slashing of the given amount of tokens from the
+// stake of the operator as a punishment for misbehavior.
+type TokensSlashed struct {
+	Operator []byte
+	Amount   *big.Int
+
+	BlockNumber     uint64
+	TransactionHash string
+}
+
+// TokensSeized represents seizing of the given amount of tokens from the
+// stake of the operator as a punishment for misbehavior. Part of seized
+// tokens is usually awarded to the party which reported the misbehavior.
+type TokensSeized struct {
+	Operator []byte
+	Amount   *big.Int
+
+	BlockNumber     uint64
+	TransactionHash string
+}
+
+// Undelegated represents undelegation of the stake of the operator. The
+// stake stops being eligible for work selection at the given timestamp.
+type Undelegated struct {
+	Operator      []byte
+	UndelegatedAt *big.Int
+
+	BlockNumber     uint64
+	TransactionHash string
+}
+
+// StakeLocked represents locking of the stake of the operator by the given
+// lock creator, usually an operator contract, until the given timestamp.
+// Locked stake cannot be withdrawn.
+type StakeLocked struct {
+	Operator    []byte
+	LockCreator []byte
+	Until       *big.Int
+
+	BlockNumber     uint64
+	TransactionHash string
+}
+
+// LockReleased represents release of the lock put on the stake of the
+// operator by the given lock creator.
+type LockReleased struct {
+	Operator    []byte
+	LockCreator []byte
+
+	BlockNumber     uint64
+	TransactionHash string
+}
@@ -38,6 +38,20 @@ func (gst *GroupSelectionTrack) Remove(entry string) {
 	delete(gst.Data, entry)
 }
 
+// Entries returns all entries used as seeds for group selections which are
+// currently in progress.
+func (gst *GroupSelectionTrack) Entries() []string {
+	gst.Mutex.Lock()
+	defer gst.Mutex.Unlock()
+
+	entries := make([]string, 0, len(gst.Data))
+	for entry := range gst.Data {
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
 // RelayRequestTrack is used to track requests for new entries after RelayEntryRequested
 // event is received. It is used to ensure that the process execution
 // is not duplicated, i.e. when the client receives the same event multiple times.
@@ -71,3 +85,17 @@ func (rrt *RelayRequestTrack) Remove(previousEntry string) {
 
 	delete(rrt.Data, previousEntry)
 }
+
+// PreviousEntries returns previous entries of all relay requests which are
+// currently being processed.
+func (rrt *RelayRequestTrack) PreviousEntries() []string {
+	rrt.Mutex.Lock()
+	defer rrt.Mutex.Unlock()
+
+	previousEntries := make([]string, 0, len(rrt.Data))
+	for previousEntry := range rrt.Data {
+		previousEntries = append(previousEntries, previousEntry)
+	}
+
+	return previousEntries
+}
@@ -142,3 +142,41 @@ func TestRelayRequestTrack_WhenEmpty(t *testing.T) {
 		t.Error("RelayEntryRequested event wasn't emitted before; should be added successfully")
 	}
 }
+
+func TestGroupSelectionTrack_Entries(t *testing.T) {
+	entry1 := "0x12345"
+	entry2 := "0x67891"
+
+	gst := &GroupSelectionTrack{
+		Data:  make(map[string]bool),
+		Mutex: &sync.Mutex{},
+	}
+
+	gst.Add(entry1)
+	gst.Add(entry2)
+	gst.Remove(entry1)
+
+	entries := gst.Entries()
+	if len(entries) != 1 || entries[0] != entry2 {
+		t.Errorf("unexpected entries: [%v]", entries)
+	}
+}
+
+func TestRelayRequestTrack_PreviousEntries(t *testing.T) {
+	previousEntry1 := "0x12345"
+	previousEntry2 := "0x67891"
+
+	rrt := &RelayRequestTrack{
+		Data:  make(map[string]bool),
+		Mutex: &sync.Mutex{},
+	}
+
+	rrt.Add(previousEntry1)
+	rrt.Add(previousEntry2)
+	rrt.Remove(previousEntry2)
+
+	previousEntries := rrt.PreviousEntries()
+	if len(previousEntries) != 1 || previousEntries[0] != previousEntry1 {
+		t.Errorf("unexpected previous entries: [%v]", previousEntries)
+	}
+}
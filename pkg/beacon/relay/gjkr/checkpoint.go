@@ -0,0 +1,1101 @@
+package gjkr
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr/gen/pb"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/ephemeral"
+)
+
+// A checkpoint is taken at the end of every protocol phase, from phase 1 up to
+// phase 11, and holds the member's state along with messages received in the
+// completed phase and processed at the beginning of the next one. Phases 12
+// and 13 are not checkpointed: the group public key shares of phase 12 are
+// computed asynchronously and resuming the protocol from phase 11 computes
+// them again.
+//
+// A checkpoint is also taken once a phase in which the member broadcasts
+// messages is initiated. It holds the member's state along with the messages
+// the member sent so the phase can be resumed by broadcasting the same
+// messages again instead of generating new ones, which other members could
+// consider as misbehavior.
+
+// CheckpointHandler is called with a checkpoint of the member's state taken
+// at the end of a protocol phase or once a phase is initiated. The checkpoint
+// can be passed to Resume to continue the protocol after a client restart.
+type CheckpointHandler func(checkpoint []byte)
+
+// phaseBlocks holds the number of blocks, delay and active ones, every phase
+// of the protocol takes. Phases are numbered from 1, as in the protocol
+// specification.
+var phaseBlocks = []uint64{
+	0, // there is no phase 0
+	ephemeralKeyPairStateDelayBlocks + ephemeralKeyPairStateActiveBlocks,
+	silentStateDelayBlocks + silentStateActiveBlocks,
+	commitmentStateDelayBlocks + commitmentStateActiveBlocks,
+	commitmentVerificationStateDelayBlocks + commitmentVerificationStateActiveBlocks,
+	silentStateDelayBlocks + silentStateActiveBlocks,
+	silentStateDelayBlocks + silentStateActiveBlocks,
+	pointsShareStateDelayBlocks + pointsShareStateActiveBlocks,
+	pointsValidationStateDelayBlocks + pointsValidationStateActiveBlocks,
+	silentStateDelayBlocks + silentStateActiveBlocks,
+	keyRevealStateDelayBlocks + keyRevealStateActiveBlocks,
+	silentStateDelayBlocks + silentStateActiveBlocks,
+	combinationStateDelayBlocks + combinationStateActiveBlocks,
+}
+
+// ProtocolBlocks returns the number of blocks it takes to execute all phases
+// of the protocol.
+func ProtocolBlocks() uint64 {
+	blocks := uint64(0)
+	for _, phase := range phaseBlocks {
+		blocks += phase
+	}
+	return blocks
+}
+
+// phaseDeadline is the block at which a protocol phase ends.
+type phaseDeadline struct {
+	phase          uint32
+	endBlockHeight uint64
+}
+
+// remainingPhaseDeadlines returns the blocks at which the given phase and all
+// phases following it end if the given phase starts at the given block.
+// Once the block at which a phase ends is reached, the member can no longer
+// exchange messages of that phase with other members. Silent phases end at the
+// block they start, they do not exchange any messages and are executed as soon
+// as the previous phase ends so they have no deadlines of their own.
+func remainingPhaseDeadlines(
+	firstPhase uint32,
+	startBlockHeight uint64,
+) []phaseDeadline {
+	var deadlines []phaseDeadline
+
+	endBlockHeight := startBlockHeight
+	for phase := int(firstPhase); phase < len(phaseBlocks); phase++ {
+		endBlockHeight += phaseBlocks[phase]
+		if phaseBlocks[phase] > 0 {
+			deadlines = append(deadlines, phaseDeadline{
+				phase:          uint32(phase),
+				endBlockHeight: endBlockHeight,
+			})
+		}
+	}
+
+	return deadlines
+}
+
+// checkpoint is a completed state of the protocol along with the block at
+// which it ended or an initiated state of the protocol along with messages
+// sent by the member and the block at which the previous state ended.
+type checkpoint struct {
+	phase          uint32
+	endBlockHeight uint64
+	seed           *big.Int
+	initiated      bool
+
+	member        *memberLayers
+	phaseMessages []net.TaggedMarshaler
+	sentMessages  []net.TaggedMarshaler
+}
+
+// memberLayers gives access to the fields of the member introduced in
+// subsequent protocol phases. Layers introduced in phases which have not been
+// executed yet are nil. Layers without any fields of their own are omitted.
+type memberLayers struct {
+	ephemeralKeyPairGenerating *EphemeralKeyPairGeneratingMember
+	committing                 *CommittingMember
+	commitmentsVerifying       *CommitmentsVerifyingMember
+	qualified                  *QualifiedMember
+	sharing                    *SharingMember
+	revealing                  *RevealingMember
+	reconstructing             *ReconstructingMember
+}
+
+func (ml *memberLayers) setEphemeralKeyPairGenerating(
+	member *EphemeralKeyPairGeneratingMember,
+) {
+	ml.ephemeralKeyPairGenerating = member
+}
+
+func (ml *memberLayers) setCommitting(member *CommittingMember) {
+	ml.committing = member
+	ml.setEphemeralKeyPairGenerating(member.EphemeralKeyPairGeneratingMember)
+}
+
+func (ml *memberLayers) setCommitmentsVerifying(
+	member *CommitmentsVerifyingMember,
+) {
+	ml.commitmentsVerifying = member
+	ml.setCommitting(member.CommittingMember)
+}
+
+func (ml *memberLayers) setQualified(member *QualifiedMember) {
+	ml.qualified = member
+	ml.setCommitmentsVerifying(member.CommitmentsVerifyingMember)
+}
+
+func (ml *memberLayers) setSharing(member *SharingMember) {
+	ml.sharing = member
+	ml.setQualified(member.QualifiedMember)
+}
+
+func (ml *memberLayers) setRevealing(member *RevealingMember) {
+	ml.revealing = member
+	ml.setSharing(member.SharingMember)
+}
+
+func (ml *memberLayers) setReconstructing(member *ReconstructingMember) {
+	ml.reconstructing = member
+	ml.setRevealing(member.RevealingMember)
+}
+
+// newCheckpoint creates a checkpoint of the given completed state. It returns
+// false if the state is not checkpointed.
+func newCheckpoint(
+	completedState keyGenerationState,
+	seed *big.Int,
+	endBlockHeight uint64,
+) (*checkpoint, bool) {
+	if resumed, ok := completedState.(*resumedState); ok {
+		completedState = resumed.Unwrap()
+	}
+
+	layers := &memberLayers{}
+	var phase uint32
+	var phaseMessages []net.TaggedMarshaler
+
+	switch completedState := completedState.(type) {
+	case *ephemeralKeyPairGenerationState:
+		phase = 1
+		layers.setEphemeralKeyPairGenerating(completedState.member)
+		for _, message := range completedState.phaseMessages {
+			phaseMessages = append(phaseMessages, message)
+		}
+	case *symmetricKeyGenerationState:
+		phase = 2
+		layers.setEphemeralKeyPairGenerating(
+			completedState.member.EphemeralKeyPairGeneratingMember,
+		)
+	case *commitmentState:
+		phase = 3
+		layers.setCommitting(completedState.member)
+		for _, message := range completedState.phaseSharesMessages {
+			phaseMessages = append(phaseMessages, message)
+		}
+		for _, message := range completedState.phaseCommitmentsMessages {
+			phaseMessages = append(phaseMessages, message)
+		}
+	case *commitmentsVerificationState:
+		phase = 4
+		layers.setCommitmentsVerifying(completedState.member)
+		for _, message := range completedState.phaseAccusationsMessages {
+			phaseMessages = append(phaseMessages, message)
+		}
+	case *sharesJustificationState:
+		phase = 5
+		layers.setCommitmentsVerifying(
+			completedState.member.CommitmentsVerifyingMember,
+		)
+	case *qualificationState:
+		phase = 6
+		layers.setQualified(completedState.member)
+	case *pointsShareState:
+		phase = 7
+		layers.setSharing(completedState.member)
+		for _, message := range completedState.phaseMessages {
+			phaseMessages = append(phaseMessages, message)
+		}
+	case *pointsValidationState:
+		phase = 8
+		layers.setSharing(completedState.member)
+		for _, message := range completedState.phaseMessages {
+			phaseMessages = append(phaseMessages, message)
+		}
+	case *pointsJustificationState:
+		phase = 9
+		layers.setSharing(completedState.member.SharingMember)
+	case *keyRevealState:
+		phase = 10
+		layers.setRevealing(completedState.member)
+		for _, message := range completedState.phaseMessages {
+			phaseMessages = append(phaseMessages, message)
+		}
+	case *reconstructionState:
+		phase = 11
+		layers.setReconstructing(completedState.member)
+	default:
+		return nil, false
+	}
+
+	return &checkpoint{
+		phase:          phase,
+		endBlockHeight: endBlockHeight,
+		seed:           seed,
+		member:         layers,
+		phaseMessages:  phaseMessages,
+	}, true
+}
+
+// newInitiationCheckpoint creates a checkpoint of the given initiated state
+// holding messages sent by the member when the state was initiated. It returns
+// false if the state is not checkpointed or no messages were sent.
+func newInitiationCheckpoint(
+	initiatedState keyGenerationState,
+	seed *big.Int,
+	startBlockHeight uint64,
+	sentMessages []net.TaggedMarshaler,
+) (*checkpoint, bool) {
+	if len(sentMessages) == 0 {
+		return nil, false
+	}
+
+	// Messages are received only once the state is initiated so the member
+	// and phase messages of the state are checkpointed the same way as for
+	// a completed state, with no phase messages yet.
+	checkpoint, ok := newCheckpoint(initiatedState, seed, startBlockHeight)
+	if !ok {
+		return nil, false
+	}
+
+	checkpoint.initiated = true
+	checkpoint.sentMessages = sentMessages
+
+	return checkpoint, true
+}
+
+// Marshal converts the checkpoint to a byte array.
+func (c *checkpoint) Marshal() ([]byte, error) {
+	member, err := c.member.marshal(c.seed)
+	if err != nil {
+		return nil, err
+	}
+
+	phaseMessages, err := marshalPhaseMessages(c.phaseMessages)
+	if err != nil {
+		return nil, err
+	}
+
+	sentMessages, err := marshalPhaseMessages(c.sentMessages)
+	if err != nil {
+		return nil, err
+	}
+
+	return (&pb.Checkpoint{
+		Phase:          c.phase,
+		EndBlockHeight: c.endBlockHeight,
+		Member:         member,
+		PhaseMessages:  phaseMessages,
+		Initiated:      c.initiated,
+		SentMessages:   sentMessages,
+	}).Marshal()
+}
+
+func marshalPhaseMessages(
+	messages []net.TaggedMarshaler,
+) ([]*pb.Checkpoint_PhaseMessage, error) {
+	pbMessages := make([]*pb.Checkpoint_PhaseMessage, 0, len(messages))
+	for _, message := range messages {
+		payload, err := message.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not marshal phase message [%v]: [%v]",
+				message.Type(),
+				err,
+			)
+		}
+
+		pbMessages = append(pbMessages, &pb.Checkpoint_PhaseMessage{
+			Type:    message.Type(),
+			Payload: payload,
+		})
+	}
+
+	return pbMessages, nil
+}
+
+func (ml *memberLayers) marshal(seed *big.Int) (*pb.Member, error) {
+	core := ml.ephemeralKeyPairGenerating.memberCore
+
+	pbMember := &pb.Member{
+		MemberID:           uint32(core.ID),
+		GroupSize:          uint32(core.group.GroupSize()),
+		DishonestThreshold: uint32(core.group.DishonestThreshold()),
+		Seed:               seed.Bytes(),
+		DisqualifiedMemberIDs: marshalMemberIndexes(
+			core.group.DisqualifiedMemberIDs(),
+		),
+		InactiveMemberIDs: marshalMemberIndexes(
+			core.group.InactiveMemberIDs(),
+		),
+	}
+
+	for _, memberID := range core.group.MemberIDs() {
+		if message := core.evidenceLog.ephemeralPublicKeyMessage(memberID); message != nil {
+			messageBytes, err := message.Marshal()
+			if err != nil {
+				return nil, err
+			}
+			pbMember.EphemeralPublicKeyMessages = append(
+				pbMember.EphemeralPublicKeyMessages,
+				messageBytes,
+			)
+		}
+
+		if message := core.evidenceLog.peerSharesMessage(memberID); message != nil {
+			messageBytes, err := message.Marshal()
+			if err != nil {
+				return nil, err
+			}
+			pbMember.PeerSharesMessages = append(
+				pbMember.PeerSharesMessages,
+				messageBytes,
+			)
+		}
+	}
+
+	pbMember.EphemeralKeyPairs = make(map[uint32]*pb.Member_EphemeralKeyPair)
+	for memberID, keyPair := range ml.ephemeralKeyPairGenerating.ephemeralKeyPairs {
+		pbMember.EphemeralKeyPairs[uint32(memberID)] = &pb.Member_EphemeralKeyPair{
+			PrivateKey: keyPair.PrivateKey.Marshal(),
+			PublicKey:  keyPair.PublicKey.Marshal(),
+		}
+	}
+
+	if ml.committing != nil {
+		pbMember.SecretCoefficients = marshalBigInts(
+			ml.committing.secretCoefficients,
+		)
+		pbMember.SelfSecretShareS = marshalBigInt(
+			ml.committing.selfSecretShareS,
+		)
+		pbMember.SelfSecretShareT = marshalBigInt(
+			ml.committing.selfSecretShareT,
+		)
+	}
+
+	if ml.commitmentsVerifying != nil {
+		pbMember.ReceivedQualifiedSharesS = marshalBigIntMap(
+			ml.commitmentsVerifying.receivedQualifiedSharesS,
+		)
+		pbMember.ReceivedQualifiedSharesT = marshalBigIntMap(
+			ml.commitmentsVerifying.receivedQualifiedSharesT,
+		)
+
+		pbMember.ReceivedPeerCommitments = make(map[uint32]*pb.Member_Points)
+		for memberID, commitments := range ml.commitmentsVerifying.receivedPeerCommitments {
+			points := make([][]byte, 0, len(commitments))
+			for _, commitment := range commitments {
+				points = append(points, commitment.Marshal())
+			}
+			pbMember.ReceivedPeerCommitments[uint32(memberID)] =
+				&pb.Member_Points{Points: points}
+		}
+	}
+
+	if ml.qualified != nil {
+		pbMember.GroupPrivateKeyShare = marshalBigInt(
+			ml.qualified.groupPrivateKeyShare,
+		)
+	}
+
+	if ml.sharing != nil {
+		pbMember.PublicKeySharePoints = marshalG2Points(
+			ml.sharing.publicKeySharePoints,
+		)
+
+		pbMember.ReceivedValidPeerPublicKeySharePoints =
+			make(map[uint32]*pb.Member_Points)
+		for memberID, points := range ml.sharing.receivedValidPeerPublicKeySharePoints {
+			pbMember.ReceivedValidPeerPublicKeySharePoints[uint32(memberID)] =
+				&pb.Member_Points{Points: marshalG2Points(points)}
+		}
+	}
+
+	if ml.revealing != nil {
+		pbMember.ExpectedMembersForReconstruction = marshalMemberIndexes(
+			ml.revealing.expectedMembersForReconstruction,
+		)
+	}
+
+	if ml.reconstructing != nil {
+		for _, shares := range ml.reconstructing.revealedMisbehavedMembersShares {
+			pbMember.RevealedMisbehavedMembersShares = append(
+				pbMember.RevealedMisbehavedMembersShares,
+				&pb.Member_MisbehavedShares{
+					MisbehavedMemberID: uint32(shares.misbehavedMemberID),
+					PeerSharesS:        marshalBigIntMap(shares.peerSharesS),
+				},
+			)
+		}
+
+		pbMember.ReconstructedIndividualPrivateKeys = marshalBigIntMap(
+			ml.reconstructing.reconstructedIndividualPrivateKeys,
+		)
+
+		pbMember.ReconstructedIndividualPublicKeys = make(map[uint32][]byte)
+		for memberID, publicKey := range ml.reconstructing.reconstructedIndividualPublicKeys {
+			pbMember.ReconstructedIndividualPublicKeys[uint32(memberID)] =
+				publicKey.Marshal()
+		}
+	}
+
+	return pbMember, nil
+}
+
+// restoredCheckpoint is a completed or initiated state of the protocol
+// restored from a checkpoint. For an initiated state, the end block height is
+// the block at which the previous state ended.
+type restoredCheckpoint struct {
+	phase          uint32
+	endBlockHeight uint64
+	seed           *big.Int
+	initiated      bool
+
+	phaseState   keyGenerationState
+	sentMessages []net.TaggedMarshaler
+}
+
+// restoreCheckpoint restores the completed or initiated state of the protocol
+// from the given checkpoint bytes. The restored state communicates over the
+// given channel and validates the membership of message senders with the
+// given validator.
+func restoreCheckpoint(
+	checkpointBytes []byte,
+	channel net.BroadcastChannel,
+	membershipValidator group.MembershipValidator,
+) (*restoredCheckpoint, error) {
+	pbCheckpoint := pb.Checkpoint{}
+	if err := pbCheckpoint.Unmarshal(checkpointBytes); err != nil {
+		return nil, err
+	}
+
+	if pbCheckpoint.Member == nil {
+		return nil, fmt.Errorf("checkpoint has no member")
+	}
+
+	messages, err := unmarshalPhaseMessages(pbCheckpoint.PhaseMessages)
+	if err != nil {
+		return nil, err
+	}
+
+	sentMessages, err := unmarshalSentMessages(pbCheckpoint.SentMessages)
+	if err != nil {
+		return nil, err
+	}
+
+	seed := new(big.Int).SetBytes(pbCheckpoint.Member.Seed)
+
+	phaseState, err := restoreState(
+		pbCheckpoint.Phase,
+		pbCheckpoint.Member,
+		seed,
+		messages,
+		channel,
+		membershipValidator,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &restoredCheckpoint{
+		phase:          pbCheckpoint.Phase,
+		endBlockHeight: pbCheckpoint.EndBlockHeight,
+		seed:           seed,
+		initiated:      pbCheckpoint.Initiated,
+		phaseState:     phaseState,
+		sentMessages:   sentMessages,
+	}, nil
+}
+
+// recordingChannel is a broadcast channel recording messages sent by the
+// member so they can be checkpointed once the phase is initiated.
+type recordingChannel struct {
+	net.BroadcastChannel
+
+	sentMessagesMutex sync.Mutex
+	sentMessages      []net.TaggedMarshaler
+}
+
+func newRecordingChannel(channel net.BroadcastChannel) *recordingChannel {
+	return &recordingChannel{BroadcastChannel: channel}
+}
+
+func (rc *recordingChannel) Send(
+	ctx context.Context,
+	message net.TaggedMarshaler,
+) error {
+	rc.sentMessagesMutex.Lock()
+	rc.sentMessages = append(rc.sentMessages, message)
+	rc.sentMessagesMutex.Unlock()
+
+	return rc.BroadcastChannel.Send(ctx, message)
+}
+
+// takeSentMessages returns messages sent since the last call and forgets them.
+func (rc *recordingChannel) takeSentMessages() []net.TaggedMarshaler {
+	rc.sentMessagesMutex.Lock()
+	defer rc.sentMessagesMutex.Unlock()
+
+	sentMessages := rc.sentMessages
+	rc.sentMessages = nil
+	return sentMessages
+}
+
+// resumedState is a state restored from an initiation checkpoint. Instead of
+// initiating the state again, which would generate new keys, shares or
+// accusations, it broadcasts again the messages sent by the member when the
+// state was initiated before the client restart. Other members receive the
+// same messages they could have already received and the member's state stays
+// consistent with the messages it sent.
+type resumedState struct {
+	keyGenerationState
+
+	channel      net.BroadcastChannel
+	sentMessages []net.TaggedMarshaler
+}
+
+func (rs *resumedState) Initiate(ctx context.Context) error {
+	for _, message := range rs.sentMessages {
+		if err := rs.channel.Send(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unwrap returns the restored state.
+func (rs *resumedState) Unwrap() keyGenerationState {
+	return rs.keyGenerationState
+}
+
+// restoreState recreates the member the same way protocol states do, phase
+// after phase, restoring the fields introduced in every phase, up to the
+// given completed phase. It returns the state of the completed phase.
+func restoreState(
+	phase uint32,
+	pbMember *pb.Member,
+	seed *big.Int,
+	messages *phaseMessages,
+	channel net.BroadcastChannel,
+	membershipValidator group.MembershipValidator,
+) (keyGenerationState, error) {
+	if phase < 1 || phase > 11 {
+		return nil, fmt.Errorf("phase [%v] is not checkpointed", phase)
+	}
+
+	if err := validateMemberIndex(pbMember.MemberID); err != nil {
+		return nil, err
+	}
+
+	localMember, err := NewMember(
+		group.MemberIndex(pbMember.MemberID),
+		int(pbMember.GroupSize),
+		int(pbMember.DishonestThreshold),
+		membershipValidator,
+		seed,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, memberID := range pbMember.DisqualifiedMemberIDs {
+		localMember.group.MarkMemberAsDisqualified(group.MemberIndex(memberID))
+	}
+	for _, memberID := range pbMember.InactiveMemberIDs {
+		localMember.group.MarkMemberAsInactive(group.MemberIndex(memberID))
+	}
+
+	for _, messageBytes := range pbMember.EphemeralPublicKeyMessages {
+		message := &EphemeralPublicKeyMessage{}
+		if err := message.Unmarshal(messageBytes); err != nil {
+			return nil, err
+		}
+		if err := localMember.evidenceLog.PutEphemeralMessage(message); err != nil {
+			return nil, err
+		}
+	}
+	for _, messageBytes := range pbMember.PeerSharesMessages {
+		message := &PeerSharesMessage{}
+		if err := message.Unmarshal(messageBytes); err != nil {
+			return nil, err
+		}
+		if err := localMember.evidenceLog.PutPeerSharesMessage(message); err != nil {
+			return nil, err
+		}
+	}
+
+	// Phase 1
+	ephemeralKeyPairGeneratingMember := localMember.InitializeEphemeralKeysGeneration()
+	for memberID, pbKeyPair := range pbMember.EphemeralKeyPairs {
+		if err := validateMemberIndex(memberID); err != nil {
+			return nil, err
+		}
+
+		publicKey, err := ephemeral.UnmarshalPublicKey(pbKeyPair.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		ephemeralKeyPairGeneratingMember.ephemeralKeyPairs[group.MemberIndex(memberID)] =
+			&ephemeral.KeyPair{
+				PrivateKey: ephemeral.UnmarshalPrivateKey(pbKeyPair.PrivateKey),
+				PublicKey:  publicKey,
+			}
+	}
+	if phase == 1 {
+		return &ephemeralKeyPairGenerationState{
+			channel:       channel,
+			member:        ephemeralKeyPairGeneratingMember,
+			phaseMessages: messages.ephemeralPublicKey,
+		}, nil
+	}
+
+	// Phase 2
+	symmetricKeyGeneratingMember :=
+		ephemeralKeyPairGeneratingMember.InitializeSymmetricKeyGeneration()
+	if err := symmetricKeyGeneratingMember.restoreSymmetricKeys(); err != nil {
+		return nil, err
+	}
+	if phase == 2 {
+		return &symmetricKeyGenerationState{
+			channel: channel,
+			member:  symmetricKeyGeneratingMember,
+		}, nil
+	}
+
+	// Phase 3
+	committingMember := symmetricKeyGeneratingMember.InitializeCommitting()
+	committingMember.secretCoefficients = unmarshalBigInts(
+		pbMember.SecretCoefficients,
+	)
+	committingMember.selfSecretShareS = unmarshalBigInt(pbMember.SelfSecretShareS)
+	committingMember.selfSecretShareT = unmarshalBigInt(pbMember.SelfSecretShareT)
+	if phase == 3 {
+		return &commitmentState{
+			channel:                  channel,
+			member:                   committingMember,
+			phaseSharesMessages:      messages.peerShares,
+			phaseCommitmentsMessages: messages.memberCommitments,
+		}, nil
+	}
+
+	// Phase 4
+	commitmentsVerifyingMember := committingMember.InitializeCommitmentsVerification()
+	if err := unmarshalBigIntMap(
+		pbMember.ReceivedQualifiedSharesS,
+		commitmentsVerifyingMember.receivedQualifiedSharesS,
+	); err != nil {
+		return nil, err
+	}
+	if err := unmarshalBigIntMap(
+		pbMember.ReceivedQualifiedSharesT,
+		commitmentsVerifyingMember.receivedQualifiedSharesT,
+	); err != nil {
+		return nil, err
+	}
+	for memberID, pbPoints := range pbMember.ReceivedPeerCommitments {
+		if err := validateMemberIndex(memberID); err != nil {
+			return nil, err
+		}
+
+		commitments := make([]*bn256.G1, 0, len(pbPoints.Points))
+		for _, pointBytes := range pbPoints.Points {
+			commitment := new(bn256.G1)
+			if _, err := commitment.Unmarshal(pointBytes); err != nil {
+				return nil, fmt.Errorf(
+					"could not unmarshal commitment [%v]",
+					err,
+				)
+			}
+			commitments = append(commitments, commitment)
+		}
+
+		commitmentsVerifyingMember.receivedPeerCommitments[group.MemberIndex(memberID)] =
+			commitments
+	}
+	if phase == 4 {
+		return &commitmentsVerificationState{
+			channel:                  channel,
+			member:                   commitmentsVerifyingMember,
+			phaseAccusationsMessages: messages.secretSharesAccusations,
+		}, nil
+	}
+
+	// Phase 5
+	sharesJustifyingMember := commitmentsVerifyingMember.InitializeSharesJustification()
+	if phase == 5 {
+		return &sharesJustificationState{
+			channel: channel,
+			member:  sharesJustifyingMember,
+		}, nil
+	}
+
+	// Phase 6
+	qualifiedMember := sharesJustifyingMember.InitializeQualified()
+	qualifiedMember.groupPrivateKeyShare = unmarshalBigInt(
+		pbMember.GroupPrivateKeyShare,
+	)
+	if phase == 6 {
+		return &qualificationState{
+			channel: channel,
+			member:  qualifiedMember,
+		}, nil
+	}
+
+	// Phases 7 and 8
+	sharingMember := qualifiedMember.InitializeSharing()
+	sharingMember.publicKeySharePoints, err = unmarshalG2Points(
+		pbMember.PublicKeySharePoints,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for memberID, pbPoints := range pbMember.ReceivedValidPeerPublicKeySharePoints {
+		if err := validateMemberIndex(memberID); err != nil {
+			return nil, err
+		}
+
+		points, err := unmarshalG2Points(pbPoints.Points)
+		if err != nil {
+			return nil, err
+		}
+
+		sharingMember.receivedValidPeerPublicKeySharePoints[group.MemberIndex(memberID)] =
+			points
+	}
+	if phase == 7 {
+		return &pointsShareState{
+			channel:       channel,
+			member:        sharingMember,
+			phaseMessages: messages.memberPublicKeySharePoints,
+		}, nil
+	}
+	if phase == 8 {
+		return &pointsValidationState{
+			channel:       channel,
+			member:        sharingMember,
+			phaseMessages: messages.pointsAccusations,
+		}, nil
+	}
+
+	// Phase 9
+	pointsJustifyingMember := sharingMember.InitializePointsJustification()
+	if phase == 9 {
+		return &pointsJustificationState{
+			channel: channel,
+			member:  pointsJustifyingMember,
+		}, nil
+	}
+
+	// Phase 10
+	revealingMember := pointsJustifyingMember.InitializeRevealing()
+	for _, memberID := range pbMember.ExpectedMembersForReconstruction {
+		revealingMember.expectedMembersForReconstruction = append(
+			revealingMember.expectedMembersForReconstruction,
+			group.MemberIndex(memberID),
+		)
+	}
+	if phase == 10 {
+		return &keyRevealState{
+			channel:       channel,
+			member:        revealingMember,
+			phaseMessages: messages.misbehavedEphemeralKeys,
+		}, nil
+	}
+
+	// Phase 11
+	reconstructingMember := revealingMember.InitializeReconstruction()
+	for _, pbShares := range pbMember.RevealedMisbehavedMembersShares {
+		if err := validateMemberIndex(pbShares.MisbehavedMemberID); err != nil {
+			return nil, err
+		}
+
+		peerSharesS := make(map[group.MemberIndex]*big.Int)
+		if err := unmarshalBigIntMap(pbShares.PeerSharesS, peerSharesS); err != nil {
+			return nil, err
+		}
+
+		reconstructingMember.revealedMisbehavedMembersShares = append(
+			reconstructingMember.revealedMisbehavedMembersShares,
+			&misbehavedShares{
+				misbehavedMemberID: group.MemberIndex(pbShares.MisbehavedMemberID),
+				peerSharesS:        peerSharesS,
+			},
+		)
+	}
+	if err := unmarshalBigIntMap(
+		pbMember.ReconstructedIndividualPrivateKeys,
+		reconstructingMember.reconstructedIndividualPrivateKeys,
+	); err != nil {
+		return nil, err
+	}
+	for memberID, publicKeyBytes := range pbMember.ReconstructedIndividualPublicKeys {
+		if err := validateMemberIndex(memberID); err != nil {
+			return nil, err
+		}
+
+		publicKey := new(bn256.G2)
+		if _, err := publicKey.Unmarshal(publicKeyBytes); err != nil {
+			return nil, fmt.Errorf(
+				"could not unmarshal individual public key [%v]",
+				err,
+			)
+		}
+
+		reconstructingMember.reconstructedIndividualPublicKeys[group.MemberIndex(memberID)] =
+			publicKey
+	}
+
+	return &reconstructionState{
+		channel: channel,
+		member:  reconstructingMember,
+	}, nil
+}
+
+// restoreSymmetricKeys computes symmetric keys again from the ephemeral public
+// key messages kept in the evidence log. Only messages which passed the
+// validation in phase 2 are kept in the evidence log, so the computed keys are
+// the same as the ones computed in phase 2.
+func (sm *SymmetricKeyGeneratingMember) restoreSymmetricKeys() error {
+	for _, memberID := range sm.group.MemberIDs() {
+		if memberID == sm.ID {
+			continue
+		}
+
+		message := sm.evidenceLog.ephemeralPublicKeyMessage(memberID)
+		if message == nil {
+			continue
+		}
+
+		ephemeralKeyPair, ok := sm.ephemeralKeyPairs[memberID]
+		if !ok {
+			return fmt.Errorf(
+				"ephemeral key pair does not exist for member %v",
+				memberID,
+			)
+		}
+
+		sm.symmetricKeys[memberID] = ephemeralKeyPair.PrivateKey.Ecdh(
+			message.ephemeralPublicKeys[sm.ID],
+		)
+	}
+
+	return nil
+}
+
+// phaseMessages holds messages received in the checkpointed phase.
+type phaseMessages struct {
+	ephemeralPublicKey         []*EphemeralPublicKeyMessage
+	peerShares                 []*PeerSharesMessage
+	memberCommitments          []*MemberCommitmentsMessage
+	secretSharesAccusations    []*SecretSharesAccusationsMessage
+	memberPublicKeySharePoints []*MemberPublicKeySharePointsMessage
+	pointsAccusations          []*PointsAccusationsMessage
+	misbehavedEphemeralKeys    []*MisbehavedEphemeralKeysMessage
+}
+
+func unmarshalPhaseMessages(
+	pbMessages []*pb.Checkpoint_PhaseMessage,
+) (*phaseMessages, error) {
+	messages := &phaseMessages{}
+
+	for _, pbMessage := range pbMessages {
+		message, err := unmarshalPhaseMessage(pbMessage)
+		if err != nil {
+			return nil, err
+		}
+
+		switch message := message.(type) {
+		case *EphemeralPublicKeyMessage:
+			messages.ephemeralPublicKey = append(
+				messages.ephemeralPublicKey,
+				message,
+			)
+		case *PeerSharesMessage:
+			messages.peerShares = append(messages.peerShares, message)
+		case *MemberCommitmentsMessage:
+			messages.memberCommitments = append(
+				messages.memberCommitments,
+				message,
+			)
+		case *SecretSharesAccusationsMessage:
+			messages.secretSharesAccusations = append(
+				messages.secretSharesAccusations,
+				message,
+			)
+		case *MemberPublicKeySharePointsMessage:
+			messages.memberPublicKeySharePoints = append(
+				messages.memberPublicKeySharePoints,
+				message,
+			)
+		case *PointsAccusationsMessage:
+			messages.pointsAccusations = append(
+				messages.pointsAccusations,
+				message,
+			)
+		case *MisbehavedEphemeralKeysMessage:
+			messages.misbehavedEphemeralKeys = append(
+				messages.misbehavedEphemeralKeys,
+				message,
+			)
+		}
+	}
+
+	return messages, nil
+}
+
+func unmarshalSentMessages(
+	pbMessages []*pb.Checkpoint_PhaseMessage,
+) ([]net.TaggedMarshaler, error) {
+	messages := make([]net.TaggedMarshaler, 0, len(pbMessages))
+
+	for _, pbMessage := range pbMessages {
+		message, err := unmarshalPhaseMessage(pbMessage)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+func unmarshalPhaseMessage(
+	pbMessage *pb.Checkpoint_PhaseMessage,
+) (net.TaggedMarshaler, error) {
+	var message interface {
+		net.TaggedMarshaler
+		Unmarshal([]byte) error
+	}
+
+	switch pbMessage.Type {
+	case (&EphemeralPublicKeyMessage{}).Type():
+		message = &EphemeralPublicKeyMessage{}
+	case (&PeerSharesMessage{}).Type():
+		message = &PeerSharesMessage{}
+	case (&MemberCommitmentsMessage{}).Type():
+		message = &MemberCommitmentsMessage{}
+	case (&SecretSharesAccusationsMessage{}).Type():
+		message = &SecretSharesAccusationsMessage{}
+	case (&MemberPublicKeySharePointsMessage{}).Type():
+		message = &MemberPublicKeySharePointsMessage{}
+	case (&PointsAccusationsMessage{}).Type():
+		message = &PointsAccusationsMessage{}
+	case (&MisbehavedEphemeralKeysMessage{}).Type():
+		message = &MisbehavedEphemeralKeysMessage{}
+	default:
+		return nil, fmt.Errorf(
+			"unknown phase message type [%v]",
+			pbMessage.Type,
+		)
+	}
+
+	if err := message.Unmarshal(pbMessage.Payload); err != nil {
+		return nil, fmt.Errorf(
+			"could not unmarshal phase message [%v]: [%v]",
+			pbMessage.Type,
+			err,
+		)
+	}
+
+	return message, nil
+}
+
+func marshalMemberIndexes(memberIndexes []group.MemberIndex) []uint32 {
+	marshalled := make([]uint32, 0, len(memberIndexes))
+	for _, memberIndex := range memberIndexes {
+		marshalled = append(marshalled, uint32(memberIndex))
+	}
+	return marshalled
+}
+
+// All big integers of the protocol are non-negative, so only their absolute
+// values are marshalled. Nil values, not set yet by the protocol, are
+// marshalled to empty byte slices.
+func marshalBigInt(value *big.Int) []byte {
+	if value == nil {
+		return nil
+	}
+	return value.Bytes()
+}
+
+func unmarshalBigInt(bytes []byte) *big.Int {
+	if len(bytes) == 0 {
+		return nil
+	}
+	return new(big.Int).SetBytes(bytes)
+}
+
+func marshalBigInts(values []*big.Int) [][]byte {
+	marshalled := make([][]byte, 0, len(values))
+	for _, value := range values {
+		marshalled = append(marshalled, marshalBigInt(value))
+	}
+	return marshalled
+}
+
+func unmarshalBigInts(bytes [][]byte) []*big.Int {
+	if len(bytes) == 0 {
+		return nil
+	}
+
+	unmarshalled := make([]*big.Int, 0, len(bytes))
+	for _, valueBytes := range bytes {
+		unmarshalled = append(unmarshalled, unmarshalBigInt(valueBytes))
+	}
+	return unmarshalled
+}
+
+func marshalBigIntMap(values map[group.MemberIndex]*big.Int) map[uint32][]byte {
+	marshalled := make(map[uint32][]byte, len(values))
+	for memberID, value := range values {
+		marshalled[uint32(memberID)] = marshalBigInt(value)
+	}
+	return marshalled
+}
+
+func unmarshalBigIntMap(
+	bytes map[uint32][]byte,
+	unmarshalled map[group.MemberIndex]*big.Int,
+) error {
+	for memberID, valueBytes := range bytes {
+		if err := validateMemberIndex(memberID); err != nil {
+			return err
+		}
+		unmarshalled[group.MemberIndex(memberID)] = unmarshalBigInt(valueBytes)
+	}
+	return nil
+}
+
+func marshalG2Points(points []*bn256.G2) [][]byte {
+	marshalled := make([][]byte, 0, len(points))
+	for _, point := range points {
+		marshalled = append(marshalled, point.Marshal())
+	}
+	return marshalled
+}
+
+func unmarshalG2Points(bytes [][]byte) ([]*bn256.G2, error) {
+	if len(bytes) == 0 {
+		return nil, nil
+	}
+
+	points := make([]*bn256.G2, 0, len(bytes))
+	for _, pointBytes := range bytes {
+		point := new(bn256.G2)
+		if _, err := point.Unmarshal(pointBytes); err != nil {
+			return nil, fmt.Errorf("could not unmarshal G2 point [%v]", err)
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
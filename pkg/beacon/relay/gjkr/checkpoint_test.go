@@ -0,0 +1,359 @@
+package gjkr
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+func TestCheckpointRoundtrip_ReconstructionState(t *testing.T) {
+	dishonestThreshold := 2
+	groupSize := 6
+
+	members, err := initializeReconstructingMembersGroup(
+		dishonestThreshold,
+		groupSize,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	member := members[0]
+	disqualifiedMembersIDs := []group.MemberIndex{5, 6}
+
+	member.group.MarkMemberAsInactive(6)
+	for _, disqualifiedMemberID := range disqualifiedMembersIDs {
+		member.group.MarkMemberAsDisqualified(disqualifiedMemberID)
+		member.expectedMembersForReconstruction = append(
+			member.expectedMembersForReconstruction,
+			disqualifiedMemberID,
+		)
+	}
+
+	member.revealedMisbehavedMembersShares = disqualifyMembers(
+		members,
+		disqualifiedMembersIDs,
+	)
+	member.reconstructIndividualPrivateKeys(member.revealedMisbehavedMembersShares)
+	member.reconstructIndividualPublicKeys()
+
+	completedState := &reconstructionState{member: member}
+
+	restored := checkpointRoundtrip(t, completedState, 11, 125)
+
+	restoredState, ok := restored.phaseState.(*reconstructionState)
+	if !ok {
+		t.Fatalf("unexpected restored state [%T]", restored.phaseState)
+	}
+
+	if !reflect.DeepEqual(member, restoredState.member) {
+		t.Errorf(
+			"unexpected restored member\nexpected: [%+v]\nactual:   [%+v]",
+			member,
+			restoredState.member,
+		)
+	}
+}
+
+func TestCheckpointRoundtrip_CommitmentState(t *testing.T) {
+	dishonestThreshold := 2
+	groupSize := 5
+
+	members, err := initializeCommittingMembersGroup(
+		dishonestThreshold,
+		groupSize,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	completedState := &commitmentState{member: members[0]}
+	for _, member := range members {
+		sharesMessage, commitmentsMessage, err :=
+			member.CalculateMembersSharesAndCommitments()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if member.ID != members[0].ID {
+			completedState.phaseSharesMessages = append(
+				completedState.phaseSharesMessages,
+				sharesMessage,
+			)
+			completedState.phaseCommitmentsMessages = append(
+				completedState.phaseCommitmentsMessages,
+				commitmentsMessage,
+			)
+		}
+	}
+
+	restored := checkpointRoundtrip(t, completedState, 3, 14)
+
+	restoredState, ok := restored.phaseState.(*commitmentState)
+	if !ok {
+		t.Fatalf("unexpected restored state [%T]", restored.phaseState)
+	}
+
+	if !reflect.DeepEqual(completedState, restoredState) {
+		t.Errorf(
+			"unexpected restored state\nexpected: [%+v]\nactual:   [%+v]",
+			completedState,
+			restoredState,
+		)
+	}
+}
+
+func TestCheckpointNotTaken(t *testing.T) {
+	members, err := initializeCombiningMembersGroup(2, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, ok := newCheckpoint(
+		&combinationState{member: members[0]},
+		big.NewInt(18313131145),
+		140,
+	)
+	if ok {
+		t.Errorf("checkpoint of the combination state should not be taken")
+	}
+}
+
+func TestInitiationCheckpoint(t *testing.T) {
+	members := initializeEphemeralKeyPairMembersGroup(2, 5)
+	seed := big.NewInt(18313131145)
+
+	recorder := newRecordingChannel(&testChannel{})
+	initiatedState := &ephemeralKeyPairGenerationState{
+		channel: recorder,
+		member:  members[0],
+	}
+	if err := initiatedState.Initiate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	sentMessages := recorder.takeSentMessages()
+	if len(sentMessages) != 1 {
+		t.Fatalf(
+			"unexpected number of sent messages\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(sentMessages),
+		)
+	}
+
+	checkpoint, ok := newInitiationCheckpoint(
+		initiatedState,
+		seed,
+		7,
+		sentMessages,
+	)
+	if !ok {
+		t.Fatalf("initiation checkpoint not taken")
+	}
+
+	checkpointBytes, err := checkpoint.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := restoreCheckpoint(checkpointBytes, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !restored.initiated {
+		t.Errorf("restored checkpoint should be initiated")
+	}
+	if restored.phase != 1 {
+		t.Errorf(
+			"unexpected phase\nexpected: [%v]\nactual:   [%v]",
+			1,
+			restored.phase,
+		)
+	}
+	if restored.endBlockHeight != 7 {
+		t.Errorf(
+			"unexpected end block height\nexpected: [%v]\nactual:   [%v]",
+			7,
+			restored.endBlockHeight,
+		)
+	}
+
+	restoredState, ok := restored.phaseState.(*ephemeralKeyPairGenerationState)
+	if !ok {
+		t.Fatalf("unexpected restored state [%T]", restored.phaseState)
+	}
+	if !reflect.DeepEqual(
+		members[0].ephemeralKeyPairs,
+		restoredState.member.ephemeralKeyPairs,
+	) {
+		t.Errorf(
+			"unexpected ephemeral key pairs\nexpected: [%+v]\nactual:   [%+v]",
+			members[0].ephemeralKeyPairs,
+			restoredState.member.ephemeralKeyPairs,
+		)
+	}
+
+	// The resumed state broadcasts again the message sent before the restart
+	// instead of generating new ephemeral keys.
+	channel := &testChannel{}
+	resumed := &resumedState{
+		keyGenerationState: restored.phaseState,
+		channel:            channel,
+		sentMessages:       restored.sentMessages,
+	}
+	if err := resumed.Initiate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(sentMessages, channel.sentMessages) {
+		t.Errorf(
+			"unexpected broadcast messages\nexpected: [%+v]\nactual:   [%+v]",
+			sentMessages,
+			channel.sentMessages,
+		)
+	}
+	if !reflect.DeepEqual(
+		members[0].ephemeralKeyPairs,
+		restoredState.member.ephemeralKeyPairs,
+	) {
+		t.Errorf("ephemeral key pairs changed when resuming the phase")
+	}
+
+	// The resumed state is checkpointed as the restored one.
+	if _, ok := newCheckpoint(resumed, seed, 13); !ok {
+		t.Errorf("checkpoint of the resumed state should be taken")
+	}
+}
+
+func TestInitiationCheckpointNotTakenWithoutMessages(t *testing.T) {
+	members := initializeEphemeralKeyPairMembersGroup(2, 5)
+
+	_, ok := newInitiationCheckpoint(
+		&ephemeralKeyPairGenerationState{member: members[0]},
+		big.NewInt(18313131145),
+		7,
+		nil,
+	)
+	if ok {
+		t.Errorf("checkpoint of a state which sent no messages should not be taken")
+	}
+}
+
+func TestRemainingPhaseDeadlines(t *testing.T) {
+	var tests = map[string]struct {
+		firstPhase        uint32
+		expectedDeadlines []phaseDeadline
+	}{
+		"from a non-silent phase": {
+			firstPhase: 7,
+			expectedDeadlines: []phaseDeadline{
+				{7, 100 + phaseBlocks[7]},
+				{8, 100 + phaseBlocks[7] + phaseBlocks[8]},
+				{10, 100 + phaseBlocks[7] + phaseBlocks[8] + phaseBlocks[10]},
+				{12, 100 + phaseBlocks[7] + phaseBlocks[8] + phaseBlocks[10] +
+					phaseBlocks[12]},
+			},
+		},
+		"from silent phases": {
+			firstPhase: 9,
+			expectedDeadlines: []phaseDeadline{
+				{10, 100 + phaseBlocks[10]},
+				{12, 100 + phaseBlocks[10] + phaseBlocks[12]},
+			},
+		},
+		"from the last phase": {
+			firstPhase: 12,
+			expectedDeadlines: []phaseDeadline{
+				{12, 100 + phaseBlocks[12]},
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			deadlines := remainingPhaseDeadlines(test.firstPhase, 100)
+
+			if !reflect.DeepEqual(test.expectedDeadlines, deadlines) {
+				t.Errorf(
+					"unexpected deadlines\nexpected: [%v]\nactual:   [%v]",
+					test.expectedDeadlines,
+					deadlines,
+				)
+			}
+		})
+	}
+}
+
+func checkpointRoundtrip(
+	t *testing.T,
+	completedState keyGenerationState,
+	expectedPhase uint32,
+	endBlockHeight uint64,
+) *restoredCheckpoint {
+	seed := big.NewInt(18313131145)
+
+	checkpoint, ok := newCheckpoint(completedState, seed, endBlockHeight)
+	if !ok {
+		t.Fatalf("checkpoint of [%T] not taken", completedState)
+	}
+
+	checkpointBytes, err := checkpoint.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := restoreCheckpoint(checkpointBytes, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expectedPhase != restored.phase {
+		t.Errorf(
+			"unexpected phase\nexpected: [%v]\nactual:   [%v]",
+			expectedPhase,
+			restored.phase,
+		)
+	}
+	if endBlockHeight != restored.endBlockHeight {
+		t.Errorf(
+			"unexpected end block height\nexpected: [%v]\nactual:   [%v]",
+			endBlockHeight,
+			restored.endBlockHeight,
+		)
+	}
+	if seed.Cmp(restored.seed) != 0 {
+		t.Errorf(
+			"unexpected seed\nexpected: [%v]\nactual:   [%v]",
+			seed,
+			restored.seed,
+		)
+	}
+	if completedState.MemberIndex() != restored.phaseState.MemberIndex() {
+		t.Errorf(
+			"unexpected member index\nexpected: [%v]\nactual:   [%v]",
+			completedState.MemberIndex(),
+			restored.phaseState.MemberIndex(),
+		)
+	}
+
+	return restored
+}
+
+type testChannel struct {
+	net.BroadcastChannel
+
+	sentMessages []net.TaggedMarshaler
+}
+
+func (tc *testChannel) Send(
+	ctx context.Context,
+	message net.TaggedMarshaler,
+) error {
+	tc.sentMessages = append(tc.sentMessages, message)
+	return nil
+}
@@ -0,0 +1,4433 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: pb/checkpoint.proto
+
+package pb
+
+import (
+	bytes "bytes"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	reflect "reflect"
+	strings "strings"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type Checkpoint struct {
+	Phase          uint32                     `protobuf:"varint,1,opt,name=phase,proto3" json:"phase,omitempty"`
+	EndBlockHeight uint64                     `protobuf:"varint,2,opt,name=endBlockHeight,proto3" json:"endBlockHeight,omitempty"`
+	Member         *Member                    `protobuf:"bytes,3,opt,name=member,proto3" json:"member,omitempty"`
+	PhaseMessages  []*Checkpoint_PhaseMessage `protobuf:"bytes,4,rep,name=phaseMessages,proto3" json:"phaseMessages,omitempty"`
+	Initiated      bool                       `protobuf:"varint,5,opt,name=initiated,proto3" json:"initiated,omitempty"`
+	SentMessages   []*Checkpoint_PhaseMessage `protobuf:"bytes,6,rep,name=sentMessages,proto3" json:"sentMessages,omitempty"`
+}
+
+func (m *Checkpoint) Reset()      { *m = Checkpoint{} }
+func (*Checkpoint) ProtoMessage() {}
+func (*Checkpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ed4d4b848f0d729, []int{0}
+}
+func (m *Checkpoint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Checkpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Checkpoint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Checkpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Checkpoint.Merge(m, src)
+}
+func (m *Checkpoint) XXX_Size() int {
+	return m.Size()
+}
+func (m *Checkpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_Checkpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Checkpoint proto.InternalMessageInfo
+
+func (m *Checkpoint) GetPhase() uint32 {
+	if m != nil {
+		return m.Phase
+	}
+	return 0
+}
+
+func (m *Checkpoint) GetEndBlockHeight() uint64 {
+	if m != nil {
+		return m.EndBlockHeight
+	}
+	return 0
+}
+
+func (m *Checkpoint) GetMember() *Member {
+	if m != nil {
+		return m.Member
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetPhaseMessages() []*Checkpoint_PhaseMessage {
+	if m != nil {
+		return m.PhaseMessages
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetInitiated() bool {
+	if m != nil {
+		return m.Initiated
+	}
+	return false
+}
+
+func (m *Checkpoint) GetSentMessages() []*Checkpoint_PhaseMessage {
+	if m != nil {
+		return m.SentMessages
+	}
+	return nil
+}
+
+type Checkpoint_PhaseMessage struct {
+	Type    string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Payload []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (m *Checkpoint_PhaseMessage) Reset()      { *m = Checkpoint_PhaseMessage{} }
+func (*Checkpoint_PhaseMessage) ProtoMessage() {}
+func (*Checkpoint_PhaseMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ed4d4b848f0d729, []int{0, 0}
+}
+func (m *Checkpoint_PhaseMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Checkpoint_PhaseMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Checkpoint_PhaseMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Checkpoint_PhaseMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Checkpoint_PhaseMessage.Merge(m, src)
+}
+func (m *Checkpoint_PhaseMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *Checkpoint_PhaseMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_Checkpoint_PhaseMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Checkpoint_PhaseMessage proto.InternalMessageInfo
+
+func (m *Checkpoint_PhaseMessage) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Checkpoint_PhaseMessage) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+type Member struct {
+	MemberID                              uint32                              `protobuf:"varint,1,opt,name=memberID,proto3" json:"memberID,omitempty"`
+	GroupSize                             uint32                              `protobuf:"varint,2,opt,name=groupSize,proto3" json:"groupSize,omitempty"`
+	DishonestThreshold                    uint32                              `protobuf:"varint,3,opt,name=dishonestThreshold,proto3" json:"dishonestThreshold,omitempty"`
+	Seed                                  []byte                              `protobuf:"bytes,4,opt,name=seed,proto3" json:"seed,omitempty"`
+	DisqualifiedMemberIDs                 []uint32                            `protobuf:"varint,5,rep,packed,name=disqualifiedMemberIDs,proto3" json:"disqualifiedMemberIDs,omitempty"`
+	InactiveMemberIDs                     []uint32                            `protobuf:"varint,6,rep,packed,name=inactiveMemberIDs,proto3" json:"inactiveMemberIDs,omitempty"`
+	EphemeralPublicKeyMessages            [][]byte                            `protobuf:"bytes,7,rep,name=ephemeralPublicKeyMessages,proto3" json:"ephemeralPublicKeyMessages,omitempty"`
+	PeerSharesMessages                    [][]byte                            `protobuf:"bytes,8,rep,name=peerSharesMessages,proto3" json:"peerSharesMessages,omitempty"`
+	EphemeralKeyPairs                     map[uint32]*Member_EphemeralKeyPair `protobuf:"bytes,9,rep,name=ephemeralKeyPairs,proto3" json:"ephemeralKeyPairs,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SecretCoefficients                    [][]byte                            `protobuf:"bytes,10,rep,name=secretCoefficients,proto3" json:"secretCoefficients,omitempty"`
+	SelfSecretShareS                      []byte                              `protobuf:"bytes,11,opt,name=selfSecretShareS,proto3" json:"selfSecretShareS,omitempty"`
+	SelfSecretShareT                      []byte                              `protobuf:"bytes,12,opt,name=selfSecretShareT,proto3" json:"selfSecretShareT,omitempty"`
+	ReceivedQualifiedSharesS              map[uint32][]byte                   `protobuf:"bytes,13,rep,name=receivedQualifiedSharesS,proto3" json:"receivedQualifiedSharesS,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ReceivedQualifiedSharesT              map[uint32][]byte                   `protobuf:"bytes,14,rep,name=receivedQualifiedSharesT,proto3" json:"receivedQualifiedSharesT,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ReceivedPeerCommitments               map[uint32]*Member_Points           `protobuf:"bytes,15,rep,name=receivedPeerCommitments,proto3" json:"receivedPeerCommitments,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	GroupPrivateKeyShare                  []byte                              `protobuf:"bytes,16,opt,name=groupPrivateKeyShare,proto3" json:"groupPrivateKeyShare,omitempty"`
+	PublicKeySharePoints                  [][]byte                            `protobuf:"bytes,17,rep,name=publicKeySharePoints,proto3" json:"publicKeySharePoints,omitempty"`
+	ReceivedValidPeerPublicKeySharePoints map[uint32]*Member_Points           `protobuf:"bytes,18,rep,name=receivedValidPeerPublicKeySharePoints,proto3" json:"receivedValidPeerPublicKeySharePoints,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ExpectedMembersForReconstruction      []uint32                            `protobuf:"varint,19,rep,packed,name=expectedMembersForReconstruction,proto3" json:"expectedMembersForReconstruction,omitempty"`
+	RevealedMisbehavedMembersShares       []*Member_MisbehavedShares          `protobuf:"bytes,20,rep,name=revealedMisbehavedMembersShares,proto3" json:"revealedMisbehavedMembersShares,omitempty"`
+	ReconstructedIndividualPrivateKeys    map[uint32][]byte                   `protobuf:"bytes,21,rep,name=reconstructedIndividualPrivateKeys,proto3" json:"reconstructedIndividualPrivateKeys,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ReconstructedIndividualPublicKeys     map[uint32][]byte                   `protobuf:"bytes,22,rep,name=reconstructedIndividualPublicKeys,proto3" json:"reconstructedIndividualPublicKeys,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *Member) Reset()      { *m = Member{} }
+func (*Member) ProtoMessage() {}
+func (*Member) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ed4d4b848f0d729, []int{1}
+}
+func (m *Member) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Member) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Member.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Member) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Member.Merge(m, src)
+}
+func (m *Member) XXX_Size() int {
+	return m.Size()
+}
+func (m *Member) XXX_DiscardUnknown() {
+	xxx_messageInfo_Member.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Member proto.InternalMessageInfo
+
+func (m *Member) GetMemberID() uint32 {
+	if m != nil {
+		return m.MemberID
+	}
+	return 0
+}
+
+func (m *Member) GetGroupSize() uint32 {
+	if m != nil {
+		return m.GroupSize
+	}
+	return 0
+}
+
+func (m *Member) GetDishonestThreshold() uint32 {
+	if m != nil {
+		return m.DishonestThreshold
+	}
+	return 0
+}
+
+func (m *Member) GetSeed() []byte {
+	if m != nil {
+		return m.Seed
+	}
+	return nil
+}
+
+func (m *Member) GetDisqualifiedMemberIDs() []uint32 {
+	if m != nil {
+		return m.DisqualifiedMemberIDs
+	}
+	return nil
+}
+
+func (m *Member) GetInactiveMemberIDs() []uint32 {
+	if m != nil {
+		return m.InactiveMemberIDs
+	}
+	return nil
+}
+
+func (m *Member) GetEphemeralPublicKeyMessages() [][]byte {
+	if m != nil {
+		return m.EphemeralPublicKeyMessages
+	}
+	return nil
+}
+
+func (m *Member) GetPeerSharesMessages() [][]byte {
+	if m != nil {
+		return m.PeerSharesMessages
+	}
+	return nil
+}
+
+func (m *Member) GetEphemeralKeyPairs() map[uint32]*Member_EphemeralKeyPair {
+	if m != nil {
+		return m.EphemeralKeyPairs
+	}
+	return nil
+}
+
+func (m *Member) GetSecretCoefficients() [][]byte {
+	if m != nil {
+		return m.SecretCoefficients
+	}
+	return nil
+}
+
+func (m *Member) GetSelfSecretShareS() []byte {
+	if m != nil {
+		return m.SelfSecretShareS
+	}
+	return nil
+}
+
+func (m *Member) GetSelfSecretShareT() []byte {
+	if m != nil {
+		return m.SelfSecretShareT
+	}
+	return nil
+}
+
+func (m *Member) GetReceivedQualifiedSharesS() map[uint32][]byte {
+	if m != nil {
+		return m.ReceivedQualifiedSharesS
+	}
+	return nil
+}
+
+func (m *Member) GetReceivedQualifiedSharesT() map[uint32][]byte {
+	if m != nil {
+		return m.ReceivedQualifiedSharesT
+	}
+	return nil
+}
+
+func (m *Member) GetReceivedPeerCommitments() map[uint32]*Member_Points {
+	if m != nil {
+		return m.ReceivedPeerCommitments
+	}
+	return nil
+}
+
+func (m *Member) GetGroupPrivateKeyShare() []byte {
+	if m != nil {
+		return m.GroupPrivateKeyShare
+	}
+	return nil
+}
+
+func (m *Member) GetPublicKeySharePoints() [][]byte {
+	if m != nil {
+		return m.PublicKeySharePoints
+	}
+	return nil
+}
+
+func (m *Member) GetReceivedValidPeerPublicKeySharePoints() map[uint32]*Member_Points {
+	if m != nil {
+		return m.ReceivedValidPeerPublicKeySharePoints
+	}
+	return nil
+}
+
+func (m *Member) GetExpectedMembersForReconstruction() []uint32 {
+	if m != nil {
+		return m.ExpectedMembersForReconstruction
+	}
+	return nil
+}
+
+func (m *Member) GetRevealedMisbehavedMembersShares() []*Member_MisbehavedShares {
+	if m != nil {
+		return m.RevealedMisbehavedMembersShares
+	}
+	return nil
+}
+
+func (m *Member) GetReconstructedIndividualPrivateKeys() map[uint32][]byte {
+	if m != nil {
+		return m.ReconstructedIndividualPrivateKeys
+	}
+	return nil
+}
+
+func (m *Member) GetReconstructedIndividualPublicKeys() map[uint32][]byte {
+	if m != nil {
+		return m.ReconstructedIndividualPublicKeys
+	}
+	return nil
+}
+
+type Member_EphemeralKeyPair struct {
+	PrivateKey []byte `protobuf:"bytes,1,opt,name=privateKey,proto3" json:"privateKey,omitempty"`
+	PublicKey  []byte `protobuf:"bytes,2,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+}
+
+func (m *Member_EphemeralKeyPair) Reset()      { *m = Member_EphemeralKeyPair{} }
+func (*Member_EphemeralKeyPair) ProtoMessage() {}
+func (*Member_EphemeralKeyPair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ed4d4b848f0d729, []int{1, 0}
+}
+func (m *Member_EphemeralKeyPair) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Member_EphemeralKeyPair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Member_EphemeralKeyPair.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Member_EphemeralKeyPair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Member_EphemeralKeyPair.Merge(m, src)
+}
+func (m *Member_EphemeralKeyPair) XXX_Size() int {
+	return m.Size()
+}
+func (m *Member_EphemeralKeyPair) XXX_DiscardUnknown() {
+	xxx_messageInfo_Member_EphemeralKeyPair.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Member_EphemeralKeyPair proto.InternalMessageInfo
+
+func (m *Member_EphemeralKeyPair) GetPrivateKey() []byte {
+	if m != nil {
+		return m.PrivateKey
+	}
+	return nil
+}
+
+func (m *Member_EphemeralKeyPair) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+type Member_Points struct {
+	Points [][]byte `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+}
+
+func (m *Member_Points) Reset()      { *m = Member_Points{} }
+func (*Member_Points) ProtoMessage() {}
+func (*Member_Points) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ed4d4b848f0d729, []int{1, 1}
+}
+func (m *Member_Points) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Member_Points) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Member_Points.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Member_Points) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Member_Points.Merge(m, src)
+}
+func (m *Member_Points) XXX_Size() int {
+	return m.Size()
+}
+func (m *Member_Points) XXX_DiscardUnknown() {
+	xxx_messageInfo_Member_Points.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Member_Points proto.InternalMessageInfo
+
+func (m *Member_Points) GetPoints() [][]byte {
+	if m != nil {
+		return m.Points
+	}
+	return nil
+}
+
+type Member_MisbehavedShares struct {
+	MisbehavedMemberID uint32            `protobuf:"varint,1,opt,name=misbehavedMemberID,proto3" json:"misbehavedMemberID,omitempty"`
+	PeerSharesS        map[uint32][]byte `protobuf:"bytes,2,rep,name=peerSharesS,proto3" json:"peerSharesS,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *Member_MisbehavedShares) Reset()      { *m = Member_MisbehavedShares{} }
+func (*Member_MisbehavedShares) ProtoMessage() {}
+func (*Member_MisbehavedShares) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ed4d4b848f0d729, []int{1, 2}
+}
+func (m *Member_MisbehavedShares) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Member_MisbehavedShares) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Member_MisbehavedShares.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Member_MisbehavedShares) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Member_MisbehavedShares.Merge(m, src)
+}
+func (m *Member_MisbehavedShares) XXX_Size() int {
+	return m.Size()
+}
+func (m *Member_MisbehavedShares) XXX_DiscardUnknown() {
+	xxx_messageInfo_Member_MisbehavedShares.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Member_MisbehavedShares proto.InternalMessageInfo
+
+func (m *Member_MisbehavedShares) GetMisbehavedMemberID() uint32 {
+	if m != nil {
+		return m.MisbehavedMemberID
+	}
+	return 0
+}
+
+func (m *Member_MisbehavedShares) GetPeerSharesS() map[uint32][]byte {
+	if m != nil {
+		return m.PeerSharesS
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Checkpoint)(nil), "gjkr.Checkpoint")
+	proto.RegisterType((*Checkpoint_PhaseMessage)(nil), "gjkr.Checkpoint.PhaseMessage")
+	proto.RegisterType((*Member)(nil), "gjkr.Member")
+	proto.RegisterMapType((map[uint32]*Member_EphemeralKeyPair)(nil), "gjkr.Member.EphemeralKeyPairsEntry")
+	proto.RegisterMapType((map[uint32]*Member_Points)(nil), "gjkr.Member.ReceivedPeerCommitmentsEntry")
+	proto.RegisterMapType((map[uint32][]byte)(nil), "gjkr.Member.ReceivedQualifiedSharesSEntry")
+	proto.RegisterMapType((map[uint32][]byte)(nil), "gjkr.Member.ReceivedQualifiedSharesTEntry")
+	proto.RegisterMapType((map[uint32]*Member_Points)(nil), "gjkr.Member.ReceivedValidPeerPublicKeySharePointsEntry")
+	proto.RegisterMapType((map[uint32][]byte)(nil), "gjkr.Member.ReconstructedIndividualPrivateKeysEntry")
+	proto.RegisterMapType((map[uint32][]byte)(nil), "gjkr.Member.ReconstructedIndividualPublicKeysEntry")
+	proto.RegisterType((*Member_EphemeralKeyPair)(nil), "gjkr.Member.EphemeralKeyPair")
+	proto.RegisterType((*Member_Points)(nil), "gjkr.Member.Points")
+	proto.RegisterType((*Member_MisbehavedShares)(nil), "gjkr.Member.MisbehavedShares")
+	proto.RegisterMapType((map[uint32][]byte)(nil), "gjkr.Member.MisbehavedShares.PeerSharesSEntry")
+}
+
+func init() { proto.RegisterFile("pb/checkpoint.proto", fileDescriptor_9ed4d4b848f0d729) }
+
+var fileDescriptor_9ed4d4b848f0d729 = []byte{
+	// 981 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x41, 0x6f, 0xe3, 0x44,
+	0x14, 0xce, 0x24, 0x69, 0xb6, 0x7d, 0x4d, 0x96, 0x74, 0xda, 0x2d, 0xa3, 0x68, 0xd7, 0x84, 0x02,
+	0x4b, 0xb6, 0x42, 0x41, 0x6a, 0x57, 0x62, 0x85, 0xd0, 0x4a, 0x6c, 0x59, 0xc4, 0x52, 0x55, 0xf2,
+	0x3a, 0x81, 0x03, 0x17, 0xe4, 0xd8, 0x2f, 0xc9, 0x50, 0xc7, 0x36, 0x33, 0x4e, 0x44, 0x38, 0x81,
+	0xc4, 0x15, 0x89, 0x9f, 0xc1, 0x4f, 0xe1, 0xd8, 0xc3, 0x1e, 0xf6, 0x48, 0xd3, 0x0b, 0xdc, 0xf6,
+	0x27, 0x20, 0x8f, 0x13, 0x3b, 0x71, 0x9d, 0x34, 0x15, 0x37, 0xcf, 0xbc, 0xf7, 0xbe, 0xef, 0xbd,
+	0x6f, 0xde, 0xbc, 0x31, 0xec, 0xfa, 0x9d, 0x8f, 0xad, 0x3e, 0x5a, 0xe7, 0xbe, 0xc7, 0xdd, 0xa0,
+	0xe9, 0x0b, 0x2f, 0xf0, 0x68, 0xb1, 0xf7, 0xc3, 0xb9, 0x38, 0x78, 0x95, 0x07, 0x38, 0x89, 0x4d,
+	0x74, 0x0f, 0x36, 0xfc, 0xbe, 0x29, 0x91, 0x91, 0x3a, 0x69, 0x54, 0x8c, 0x68, 0x41, 0x1f, 0xc2,
+	0x5d, 0x74, 0xed, 0x67, 0x8e, 0x67, 0x9d, 0x7f, 0x85, 0xbc, 0xd7, 0x0f, 0x58, 0xbe, 0x4e, 0x1a,
+	0x45, 0x23, 0xb5, 0x4b, 0xdf, 0x87, 0xd2, 0x00, 0x07, 0x1d, 0x14, 0xac, 0x50, 0x27, 0x8d, 0xed,
+	0xa3, 0x72, 0x33, 0xe4, 0x68, 0x9e, 0xa9, 0x3d, 0x63, 0x6a, 0xa3, 0x27, 0x50, 0x51, 0xb0, 0x67,
+	0x28, 0xa5, 0xd9, 0x43, 0xc9, 0x8a, 0xf5, 0x42, 0x63, 0xfb, 0xe8, 0x41, 0xe4, 0x9c, 0x24, 0xd3,
+	0xd4, 0xe7, 0xbc, 0x8c, 0xc5, 0x18, 0x7a, 0x1f, 0xb6, 0xb8, 0xcb, 0x03, 0x6e, 0x06, 0x68, 0xb3,
+	0x8d, 0x3a, 0x69, 0x6c, 0x1a, 0xc9, 0x06, 0xfd, 0x1c, 0xca, 0x12, 0xdd, 0x20, 0x66, 0x28, 0xad,
+	0xc3, 0xb0, 0x10, 0x52, 0xfb, 0x0c, 0xca, 0xf3, 0x56, 0x4a, 0xa1, 0x18, 0x8c, 0xfd, 0x48, 0x98,
+	0x2d, 0x43, 0x7d, 0x53, 0x06, 0x77, 0x7c, 0x73, 0xec, 0x78, 0xa6, 0xad, 0x04, 0x29, 0x1b, 0xb3,
+	0xe5, 0xc1, 0xbf, 0xbb, 0x50, 0x8a, 0xca, 0xa6, 0x35, 0xd8, 0x8c, 0x0a, 0x7f, 0xf1, 0xc5, 0x54,
+	0xd5, 0x78, 0x1d, 0x56, 0xd1, 0x13, 0xde, 0xd0, 0x6f, 0xf1, 0x9f, 0x51, 0x41, 0x54, 0x8c, 0x64,
+	0x83, 0x36, 0x81, 0xda, 0x5c, 0xf6, 0x3d, 0x17, 0x65, 0xd0, 0xee, 0x0b, 0x94, 0x7d, 0xcf, 0xb1,
+	0x95, 0xb4, 0x15, 0x23, 0xc3, 0x12, 0xa6, 0x28, 0x11, 0x6d, 0x56, 0x54, 0xb9, 0xa8, 0x6f, 0xfa,
+	0x18, 0xee, 0xd9, 0x5c, 0xfe, 0x38, 0x34, 0x1d, 0xde, 0xe5, 0x68, 0x9f, 0x4d, 0x99, 0x25, 0xdb,
+	0xa8, 0x17, 0x1a, 0x15, 0x23, 0xdb, 0x48, 0x3f, 0x82, 0x1d, 0xee, 0x9a, 0x56, 0xc0, 0x47, 0x98,
+	0x44, 0x94, 0x54, 0xc4, 0x75, 0x03, 0x7d, 0x0a, 0x35, 0xf4, 0xfb, 0x38, 0x40, 0x61, 0x3a, 0xfa,
+	0xb0, 0xe3, 0x70, 0xeb, 0x14, 0xc7, 0xb1, 0xf6, 0x77, 0xea, 0x85, 0x46, 0xd9, 0x58, 0xe1, 0x11,
+	0xd6, 0xe9, 0x23, 0x8a, 0x56, 0xdf, 0x14, 0x28, 0xe3, 0xb8, 0x4d, 0x15, 0x97, 0x61, 0xa1, 0x2f,
+	0x61, 0x27, 0x46, 0x3b, 0xc5, 0xb1, 0x6e, 0x72, 0x21, 0xd9, 0x96, 0x3a, 0xe2, 0xf7, 0xe6, 0x3b,
+	0xae, 0xf9, 0x3c, 0xed, 0xf5, 0xdc, 0x0d, 0xc4, 0xd8, 0xb8, 0x1e, 0x1d, 0xa6, 0x20, 0xd1, 0x12,
+	0x18, 0x9c, 0x78, 0xd8, 0xed, 0x72, 0x8b, 0xa3, 0x1b, 0x48, 0x06, 0x51, 0x0a, 0xd7, 0x2d, 0xf4,
+	0x10, 0xaa, 0x12, 0x9d, 0x6e, 0x4b, 0x59, 0x54, 0x7a, 0x2d, 0xb6, 0xad, 0x64, 0xbf, 0xb6, 0x9f,
+	0xe1, 0xdb, 0x66, 0xe5, 0x4c, 0xdf, 0x36, 0xed, 0x02, 0x13, 0x68, 0x21, 0x1f, 0xa1, 0xfd, 0x72,
+	0x76, 0x2c, 0x51, 0xf5, 0x2d, 0x56, 0x51, 0x15, 0x1e, 0x2e, 0x54, 0x68, 0x2c, 0x71, 0x8e, 0x0a,
+	0x5d, 0x8a, 0xb5, 0x82, 0xa7, 0xcd, 0xee, 0xae, 0xcf, 0xd3, 0x5e, 0xcd, 0xd3, 0xa6, 0x16, 0xbc,
+	0x3d, 0xb3, 0xe9, 0x88, 0xe2, 0xc4, 0x1b, 0x0c, 0x78, 0x30, 0x50, 0xe2, 0xbe, 0xa5, 0x68, 0x1e,
+	0x65, 0xd2, 0xa4, 0x7c, 0x23, 0x96, 0x65, 0x48, 0xf4, 0x08, 0xf6, 0xd4, 0xa5, 0xd1, 0x05, 0x1f,
+	0x99, 0x01, 0x9e, 0xe2, 0x58, 0xd1, 0xb3, 0xaa, 0x12, 0x39, 0xd3, 0x16, 0xc6, 0xf8, 0xb3, 0x46,
+	0x54, 0x3b, 0x7a, 0x38, 0x10, 0x24, 0xdb, 0x51, 0x47, 0x9e, 0x69, 0xa3, 0xbf, 0x13, 0xf8, 0x60,
+	0x96, 0xc3, 0xb7, 0xa6, 0xc3, 0x55, 0x22, 0x7a, 0x16, 0x0a, 0x55, 0xb5, 0x7d, 0x92, 0x59, 0xdb,
+	0xca, 0xc8, 0xa8, 0xd2, 0xf5, 0x58, 0xe8, 0xd7, 0x50, 0xc7, 0x9f, 0x7c, 0xb4, 0x82, 0xd9, 0xd5,
+	0x95, 0x5f, 0x7a, 0xc2, 0x40, 0xcb, 0x73, 0x65, 0x20, 0x86, 0x56, 0xc0, 0x3d, 0x97, 0xed, 0xaa,
+	0x4b, 0x7b, 0xa3, 0x1f, 0xed, 0xc1, 0x3b, 0x02, 0x47, 0x68, 0x3a, 0x68, 0x9f, 0x71, 0xd9, 0xc1,
+	0xbe, 0x39, 0x8a, 0xbd, 0xa3, 0xc3, 0x64, 0x7b, 0xf3, 0x43, 0x74, 0x5a, 0x54, 0xe2, 0x1b, 0x39,
+	0x19, 0x37, 0xa1, 0xd0, 0xdf, 0x08, 0x1c, 0x88, 0x84, 0x1b, 0xed, 0x17, 0xae, 0xcd, 0x47, 0xdc,
+	0x1e, 0x9a, 0x4e, 0x72, 0x46, 0x92, 0xdd, 0x53, 0x64, 0x8f, 0xd3, 0x0a, 0xde, 0x10, 0x16, 0xc9,
+	0xb7, 0x06, 0x3e, 0xfd, 0x95, 0xc0, 0xbb, 0xcb, 0xdc, 0x66, 0x5a, 0x4b, 0xb6, 0xaf, 0xb2, 0x38,
+	0x5e, 0x2b, 0x8b, 0x38, 0x2a, 0x4a, 0xe2, 0x66, 0xf4, 0x9a, 0x0e, 0xd5, 0xf4, 0x84, 0xa2, 0x1a,
+	0x80, 0x1f, 0xa7, 0xa9, 0xde, 0x8b, 0xb2, 0x31, 0xb7, 0x13, 0xbe, 0x18, 0x71, 0x6f, 0x4e, 0x1f,
+	0x9d, 0x64, 0xa3, 0x56, 0x87, 0xd2, 0xb4, 0x37, 0xf6, 0xa1, 0xa4, 0x9e, 0x38, 0xc9, 0x88, 0xea,
+	0xe8, 0xe9, 0xaa, 0xf6, 0x8a, 0x40, 0x35, 0x7d, 0x68, 0xe1, 0xf4, 0x1b, 0xa4, 0x8e, 0x2b, 0x7e,
+	0xac, 0x32, 0x2c, 0x54, 0x87, 0xed, 0x64, 0x2c, 0xb7, 0x58, 0x5e, 0xa9, 0xd4, 0x5c, 0xd9, 0x18,
+	0x4d, 0x3d, 0x09, 0x88, 0x04, 0x9a, 0x87, 0xa8, 0x3d, 0x85, 0x6a, 0xda, 0x81, 0x56, 0xa1, 0x70,
+	0x3e, 0xd5, 0xa0, 0x62, 0x84, 0x9f, 0xe1, 0xdf, 0xc9, 0xc8, 0x74, 0x86, 0x38, 0x2d, 0x3c, 0x5a,
+	0x7c, 0x9a, 0x7f, 0x42, 0x6a, 0x16, 0xec, 0x67, 0x0f, 0xfb, 0x0c, 0x94, 0xe3, 0x79, 0x94, 0x74,
+	0x43, 0xa7, 0x51, 0xe6, 0x49, 0x4e, 0xe1, 0xc1, 0xca, 0x79, 0x7b, 0xab, 0x8c, 0x97, 0x83, 0xb5,
+	0x6f, 0x0f, 0xf6, 0x3d, 0xdc, 0x5f, 0x35, 0x3a, 0x33, 0xb0, 0x1e, 0x2d, 0x8a, 0xb0, 0xbb, 0x20,
+	0x42, 0xd4, 0x43, 0xf3, 0x04, 0x03, 0x38, 0x5c, 0x7f, 0x7e, 0xfd, 0x7f, 0xba, 0x6f, 0xe0, 0xc3,
+	0x35, 0x2f, 0xfb, 0xad, 0x64, 0x6a, 0xc3, 0xc3, 0xf5, 0x6e, 0xef, 0x6d, 0x50, 0x9f, 0x3d, 0xb9,
+	0xb8, 0xd4, 0x72, 0xaf, 0x2f, 0xb5, 0xdc, 0x9b, 0x4b, 0x8d, 0xfc, 0x32, 0xd1, 0xc8, 0x9f, 0x13,
+	0x8d, 0xfc, 0x35, 0xd1, 0xc8, 0xc5, 0x44, 0x23, 0x7f, 0x4f, 0x34, 0xf2, 0xcf, 0x44, 0xcb, 0xbd,
+	0x99, 0x68, 0xe4, 0x8f, 0x2b, 0x2d, 0x77, 0x71, 0xa5, 0xe5, 0x5e, 0x5f, 0x69, 0xb9, 0xef, 0xf2,
+	0x7e, 0xa7, 0x53, 0x52, 0x7f, 0xe2, 0xc7, 0xff, 0x05, 0x00, 0x00, 0xff, 0xff, 0x92, 0x3e, 0x56,
+	0x4a, 0xa0, 0x0b, 0x00, 0x00,
+}
+
+func (this *Checkpoint) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Checkpoint)
+	if !ok {
+		that2, ok := that.(Checkpoint)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Phase != that1.Phase {
+		return false
+	}
+	if this.EndBlockHeight != that1.EndBlockHeight {
+		return false
+	}
+	if !this.Member.Equal(that1.Member) {
+		return false
+	}
+	if len(this.PhaseMessages) != len(that1.PhaseMessages) {
+		return false
+	}
+	for i := range this.PhaseMessages {
+		if !this.PhaseMessages[i].Equal(that1.PhaseMessages[i]) {
+			return false
+		}
+	}
+	if this.Initiated != that1.Initiated {
+		return false
+	}
+	if len(this.SentMessages) != len(that1.SentMessages) {
+		return false
+	}
+	for i := range this.SentMessages {
+		if !this.SentMessages[i].Equal(that1.SentMessages[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *Checkpoint_PhaseMessage) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Checkpoint_PhaseMessage)
+	if !ok {
+		that2, ok := that.(Checkpoint_PhaseMessage)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Type != that1.Type {
+		return false
+	}
+	if !bytes.Equal(this.Payload, that1.Payload) {
+		return false
+	}
+	return true
+}
+func (this *Member) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Member)
+	if !ok {
+		that2, ok := that.(Member)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.MemberID != that1.MemberID {
+		return false
+	}
+	if this.GroupSize != that1.GroupSize {
+		return false
+	}
+	if this.DishonestThreshold != that1.DishonestThreshold {
+		return false
+	}
+	if !bytes.Equal(this.Seed, that1.Seed) {
+		return false
+	}
+	if len(this.DisqualifiedMemberIDs) != len(that1.DisqualifiedMemberIDs) {
+		return false
+	}
+	for i := range this.DisqualifiedMemberIDs {
+		if this.DisqualifiedMemberIDs[i] != that1.DisqualifiedMemberIDs[i] {
+			return false
+		}
+	}
+	if len(this.InactiveMemberIDs) != len(that1.InactiveMemberIDs) {
+		return false
+	}
+	for i := range this.InactiveMemberIDs {
+		if this.InactiveMemberIDs[i] != that1.InactiveMemberIDs[i] {
+			return false
+		}
+	}
+	if len(this.EphemeralPublicKeyMessages) != len(that1.EphemeralPublicKeyMessages) {
+		return false
+	}
+	for i := range this.EphemeralPublicKeyMessages {
+		if !bytes.Equal(this.EphemeralPublicKeyMessages[i], that1.EphemeralPublicKeyMessages[i]) {
+			return false
+		}
+	}
+	if len(this.PeerSharesMessages) != len(that1.PeerSharesMessages) {
+		return false
+	}
+	for i := range this.PeerSharesMessages {
+		if !bytes.Equal(this.PeerSharesMessages[i], that1.PeerSharesMessages[i]) {
+			return false
+		}
+	}
+	if len(this.EphemeralKeyPairs) != len(that1.EphemeralKeyPairs) {
+		return false
+	}
+	for i := range this.EphemeralKeyPairs {
+		if !this.EphemeralKeyPairs[i].Equal(that1.EphemeralKeyPairs[i]) {
+			return false
+		}
+	}
+	if len(this.SecretCoefficients) != len(that1.SecretCoefficients) {
+		return false
+	}
+	for i := range this.SecretCoefficients {
+		if !bytes.Equal(this.SecretCoefficients[i], that1.SecretCoefficients[i]) {
+			return false
+		}
+	}
+	if !bytes.Equal(this.SelfSecretShareS, that1.SelfSecretShareS) {
+		return false
+	}
+	if !bytes.Equal(this.SelfSecretShareT, that1.SelfSecretShareT) {
+		return false
+	}
+	if len(this.ReceivedQualifiedSharesS) != len(that1.ReceivedQualifiedSharesS) {
+		return false
+	}
+	for i := range this.ReceivedQualifiedSharesS {
+		if !bytes.Equal(this.ReceivedQualifiedSharesS[i], that1.ReceivedQualifiedSharesS[i]) {
+			return false
+		}
+	}
+	if len(this.ReceivedQualifiedSharesT) != len(that1.ReceivedQualifiedSharesT) {
+		return false
+	}
+	for i := range this.ReceivedQualifiedSharesT {
+		if !bytes.Equal(this.ReceivedQualifiedSharesT[i], that1.ReceivedQualifiedSharesT[i]) {
+			return false
+		}
+	}
+	if len(this.ReceivedPeerCommitments) != len(that1.ReceivedPeerCommitments) {
+		return false
+	}
+	for i := range this.ReceivedPeerCommitments {
+		if !this.ReceivedPeerCommitments[i].Equal(that1.ReceivedPeerCommitments[i]) {
+			return false
+		}
+	}
+	if !bytes.Equal(this.GroupPrivateKeyShare, that1.GroupPrivateKeyShare) {
+		return false
+	}
+	if len(this.PublicKeySharePoints) != len(that1.PublicKeySharePoints) {
+		return false
+	}
+	for i := range this.PublicKeySharePoints {
+		if !bytes.Equal(this.PublicKeySharePoints[i], that1.PublicKeySharePoints[i]) {
+			return false
+		}
+	}
+	if len(this.ReceivedValidPeerPublicKeySharePoints) != len(that1.ReceivedValidPeerPublicKeySharePoints) {
+		return false
+	}
+	for i := range this.ReceivedValidPeerPublicKeySharePoints {
+		if !this.ReceivedValidPeerPublicKeySharePoints[i].Equal(that1.ReceivedValidPeerPublicKeySharePoints[i]) {
+			return false
+		}
+	}
+	if len(this.ExpectedMembersForReconstruction) != len(that1.ExpectedMembersForReconstruction) {
+		return false
+	}
+	for i := range this.ExpectedMembersForReconstruction {
+		if this.ExpectedMembersForReconstruction[i] != that1.ExpectedMembersForReconstruction[i] {
+			return false
+		}
+	}
+	if len(this.RevealedMisbehavedMembersShares) != len(that1.RevealedMisbehavedMembersShares) {
+		return false
+	}
+	for i := range this.RevealedMisbehavedMembersShares {
+		if !this.RevealedMisbehavedMembersShares[i].Equal(that1.RevealedMisbehavedMembersShares[i]) {
+			return false
+		}
+	}
+	if len(this.ReconstructedIndividualPrivateKeys) != len(that1.ReconstructedIndividualPrivateKeys) {
+		return false
+	}
+	for i := range this.ReconstructedIndividualPrivateKeys {
+		if !bytes.Equal(this.ReconstructedIndividualPrivateKeys[i], that1.ReconstructedIndividualPrivateKeys[i]) {
+			return false
+		}
+	}
+	if len(this.ReconstructedIndividualPublicKeys) != len(that1.ReconstructedIndividualPublicKeys) {
+		return false
+	}
+	for i := range this.ReconstructedIndividualPublicKeys {
+		if !bytes.Equal(this.ReconstructedIndividualPublicKeys[i], that1.ReconstructedIndividualPublicKeys[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *Member_EphemeralKeyPair) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Member_EphemeralKeyPair)
+	if !ok {
+		that2, ok := that.(Member_EphemeralKeyPair)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.PrivateKey, that1.PrivateKey) {
+		return false
+	}
+	if !bytes.Equal(this.PublicKey, that1.PublicKey) {
+		return false
+	}
+	return true
+}
+func (this *Member_Points) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Member_Points)
+	if !ok {
+		that2, ok := that.(Member_Points)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Points) != len(that1.Points) {
+		return false
+	}
+	for i := range this.Points {
+		if !bytes.Equal(this.Points[i], that1.Points[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *Member_MisbehavedShares) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Member_MisbehavedShares)
+	if !ok {
+		that2, ok := that.(Member_MisbehavedShares)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.MisbehavedMemberID != that1.MisbehavedMemberID {
+		return false
+	}
+	if len(this.PeerSharesS) != len(that1.PeerSharesS) {
+		return false
+	}
+	for i := range this.PeerSharesS {
+		if !bytes.Equal(this.PeerSharesS[i], that1.PeerSharesS[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *Checkpoint) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 10)
+	s = append(s, "&pb.Checkpoint{")
+	s = append(s, "Phase: "+fmt.Sprintf("%#v", this.Phase)+",\n")
+	s = append(s, "EndBlockHeight: "+fmt.Sprintf("%#v", this.EndBlockHeight)+",\n")
+	if this.Member != nil {
+		s = append(s, "Member: "+fmt.Sprintf("%#v", this.Member)+",\n")
+	}
+	if this.PhaseMessages != nil {
+		s = append(s, "PhaseMessages: "+fmt.Sprintf("%#v", this.PhaseMessages)+",\n")
+	}
+	s = append(s, "Initiated: "+fmt.Sprintf("%#v", this.Initiated)+",\n")
+	if this.SentMessages != nil {
+		s = append(s, "SentMessages: "+fmt.Sprintf("%#v", this.SentMessages)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Checkpoint_PhaseMessage) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.Checkpoint_PhaseMessage{")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	s = append(s, "Payload: "+fmt.Sprintf("%#v", this.Payload)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Member) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 26)
+	s = append(s, "&pb.Member{")
+	s = append(s, "MemberID: "+fmt.Sprintf("%#v", this.MemberID)+",\n")
+	s = append(s, "GroupSize: "+fmt.Sprintf("%#v", this.GroupSize)+",\n")
+	s = append(s, "DishonestThreshold: "+fmt.Sprintf("%#v", this.DishonestThreshold)+",\n")
+	s = append(s, "Seed: "+fmt.Sprintf("%#v", this.Seed)+",\n")
+	s = append(s, "DisqualifiedMemberIDs: "+fmt.Sprintf("%#v", this.DisqualifiedMemberIDs)+",\n")
+	s = append(s, "InactiveMemberIDs: "+fmt.Sprintf("%#v", this.InactiveMemberIDs)+",\n")
+	s = append(s, "EphemeralPublicKeyMessages: "+fmt.Sprintf("%#v", this.EphemeralPublicKeyMessages)+",\n")
+	s = append(s, "PeerSharesMessages: "+fmt.Sprintf("%#v", this.PeerSharesMessages)+",\n")
+	keysForEphemeralKeyPairs := make([]uint32, 0, len(this.EphemeralKeyPairs))
+	for k, _ := range this.EphemeralKeyPairs {
+		keysForEphemeralKeyPairs = append(keysForEphemeralKeyPairs, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForEphemeralKeyPairs)
+	mapStringForEphemeralKeyPairs := "map[uint32]*Member_EphemeralKeyPair{"
+	for _, k := range keysForEphemeralKeyPairs {
+		mapStringForEphemeralKeyPairs += fmt.Sprintf("%#v: %#v,", k, this.EphemeralKeyPairs[k])
+	}
+	mapStringForEphemeralKeyPairs += "}"
+	if this.EphemeralKeyPairs != nil {
+		s = append(s, "EphemeralKeyPairs: "+mapStringForEphemeralKeyPairs+",\n")
+	}
+	s = append(s, "SecretCoefficients: "+fmt.Sprintf("%#v", this.SecretCoefficients)+",\n")
+	s = append(s, "SelfSecretShareS: "+fmt.Sprintf("%#v", this.SelfSecretShareS)+",\n")
+	s = append(s, "SelfSecretShareT: "+fmt.Sprintf("%#v", this.SelfSecretShareT)+",\n")
+	keysForReceivedQualifiedSharesS := make([]uint32, 0, len(this.ReceivedQualifiedSharesS))
+	for k, _ := range this.ReceivedQualifiedSharesS {
+		keysForReceivedQualifiedSharesS = append(keysForReceivedQualifiedSharesS, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReceivedQualifiedSharesS)
+	mapStringForReceivedQualifiedSharesS := "map[uint32][]byte{"
+	for _, k := range keysForReceivedQualifiedSharesS {
+		mapStringForReceivedQualifiedSharesS += fmt.Sprintf("%#v: %#v,", k, this.ReceivedQualifiedSharesS[k])
+	}
+	mapStringForReceivedQualifiedSharesS += "}"
+	if this.ReceivedQualifiedSharesS != nil {
+		s = append(s, "ReceivedQualifiedSharesS: "+mapStringForReceivedQualifiedSharesS+",\n")
+	}
+	keysForReceivedQualifiedSharesT := make([]uint32, 0, len(this.ReceivedQualifiedSharesT))
+	for k, _ := range this.ReceivedQualifiedSharesT {
+		keysForReceivedQualifiedSharesT = append(keysForReceivedQualifiedSharesT, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReceivedQualifiedSharesT)
+	mapStringForReceivedQualifiedSharesT := "map[uint32][]byte{"
+	for _, k := range keysForReceivedQualifiedSharesT {
+		mapStringForReceivedQualifiedSharesT += fmt.Sprintf("%#v: %#v,", k, this.ReceivedQualifiedSharesT[k])
+	}
+	mapStringForReceivedQualifiedSharesT += "}"
+	if this.ReceivedQualifiedSharesT != nil {
+		s = append(s, "ReceivedQualifiedSharesT: "+mapStringForReceivedQualifiedSharesT+",\n")
+	}
+	keysForReceivedPeerCommitments := make([]uint32, 0, len(this.ReceivedPeerCommitments))
+	for k, _ := range this.ReceivedPeerCommitments {
+		keysForReceivedPeerCommitments = append(keysForReceivedPeerCommitments, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReceivedPeerCommitments)
+	mapStringForReceivedPeerCommitments := "map[uint32]*Member_Points{"
+	for _, k := range keysForReceivedPeerCommitments {
+		mapStringForReceivedPeerCommitments += fmt.Sprintf("%#v: %#v,", k, this.ReceivedPeerCommitments[k])
+	}
+	mapStringForReceivedPeerCommitments += "}"
+	if this.ReceivedPeerCommitments != nil {
+		s = append(s, "ReceivedPeerCommitments: "+mapStringForReceivedPeerCommitments+",\n")
+	}
+	s = append(s, "GroupPrivateKeyShare: "+fmt.Sprintf("%#v", this.GroupPrivateKeyShare)+",\n")
+	s = append(s, "PublicKeySharePoints: "+fmt.Sprintf("%#v", this.PublicKeySharePoints)+",\n")
+	keysForReceivedValidPeerPublicKeySharePoints := make([]uint32, 0, len(this.ReceivedValidPeerPublicKeySharePoints))
+	for k, _ := range this.ReceivedValidPeerPublicKeySharePoints {
+		keysForReceivedValidPeerPublicKeySharePoints = append(keysForReceivedValidPeerPublicKeySharePoints, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReceivedValidPeerPublicKeySharePoints)
+	mapStringForReceivedValidPeerPublicKeySharePoints := "map[uint32]*Member_Points{"
+	for _, k := range keysForReceivedValidPeerPublicKeySharePoints {
+		mapStringForReceivedValidPeerPublicKeySharePoints += fmt.Sprintf("%#v: %#v,", k, this.ReceivedValidPeerPublicKeySharePoints[k])
+	}
+	mapStringForReceivedValidPeerPublicKeySharePoints += "}"
+	if this.ReceivedValidPeerPublicKeySharePoints != nil {
+		s = append(s, "ReceivedValidPeerPublicKeySharePoints: "+mapStringForReceivedValidPeerPublicKeySharePoints+",\n")
+	}
+	s = append(s, "ExpectedMembersForReconstruction: "+fmt.Sprintf("%#v", this.ExpectedMembersForReconstruction)+",\n")
+	if this.RevealedMisbehavedMembersShares != nil {
+		s = append(s, "RevealedMisbehavedMembersShares: "+fmt.Sprintf("%#v", this.RevealedMisbehavedMembersShares)+",\n")
+	}
+	keysForReconstructedIndividualPrivateKeys := make([]uint32, 0, len(this.ReconstructedIndividualPrivateKeys))
+	for k, _ := range this.ReconstructedIndividualPrivateKeys {
+		keysForReconstructedIndividualPrivateKeys = append(keysForReconstructedIndividualPrivateKeys, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReconstructedIndividualPrivateKeys)
+	mapStringForReconstructedIndividualPrivateKeys := "map[uint32][]byte{"
+	for _, k := range keysForReconstructedIndividualPrivateKeys {
+		mapStringForReconstructedIndividualPrivateKeys += fmt.Sprintf("%#v: %#v,", k, this.ReconstructedIndividualPrivateKeys[k])
+	}
+	mapStringForReconstructedIndividualPrivateKeys += "}"
+	if this.ReconstructedIndividualPrivateKeys != nil {
+		s = append(s, "ReconstructedIndividualPrivateKeys: "+mapStringForReconstructedIndividualPrivateKeys+",\n")
+	}
+	keysForReconstructedIndividualPublicKeys := make([]uint32, 0, len(this.ReconstructedIndividualPublicKeys))
+	for k, _ := range this.ReconstructedIndividualPublicKeys {
+		keysForReconstructedIndividualPublicKeys = append(keysForReconstructedIndividualPublicKeys, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReconstructedIndividualPublicKeys)
+	mapStringForReconstructedIndividualPublicKeys := "map[uint32][]byte{"
+	for _, k := range keysForReconstructedIndividualPublicKeys {
+		mapStringForReconstructedIndividualPublicKeys += fmt.Sprintf("%#v: %#v,", k, this.ReconstructedIndividualPublicKeys[k])
+	}
+	mapStringForReconstructedIndividualPublicKeys += "}"
+	if this.ReconstructedIndividualPublicKeys != nil {
+		s = append(s, "ReconstructedIndividualPublicKeys: "+mapStringForReconstructedIndividualPublicKeys+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Member_EphemeralKeyPair) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.Member_EphemeralKeyPair{")
+	s = append(s, "PrivateKey: "+fmt.Sprintf("%#v", this.PrivateKey)+",\n")
+	s = append(s, "PublicKey: "+fmt.Sprintf("%#v", this.PublicKey)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Member_Points) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.Member_Points{")
+	s = append(s, "Points: "+fmt.Sprintf("%#v", this.Points)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Member_MisbehavedShares) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.Member_MisbehavedShares{")
+	s = append(s, "MisbehavedMemberID: "+fmt.Sprintf("%#v", this.MisbehavedMemberID)+",\n")
+	keysForPeerSharesS := make([]uint32, 0, len(this.PeerSharesS))
+	for k, _ := range this.PeerSharesS {
+		keysForPeerSharesS = append(keysForPeerSharesS, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForPeerSharesS)
+	mapStringForPeerSharesS := "map[uint32][]byte{"
+	for _, k := range keysForPeerSharesS {
+		mapStringForPeerSharesS += fmt.Sprintf("%#v: %#v,", k, this.PeerSharesS[k])
+	}
+	mapStringForPeerSharesS += "}"
+	if this.PeerSharesS != nil {
+		s = append(s, "PeerSharesS: "+mapStringForPeerSharesS+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringCheckpoint(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *Checkpoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Checkpoint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Checkpoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.SentMessages) > 0 {
+		for iNdEx := len(m.SentMessages) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SentMessages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCheckpoint(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.Initiated {
+		i--
+		if m.Initiated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.PhaseMessages) > 0 {
+		for iNdEx := len(m.PhaseMessages) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PhaseMessages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCheckpoint(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Member != nil {
+		{
+			size, err := m.Member.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCheckpoint(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.EndBlockHeight != 0 {
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.EndBlockHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Phase != 0 {
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.Phase))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Checkpoint_PhaseMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Checkpoint_PhaseMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Checkpoint_PhaseMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Member) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Member) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Member) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ReconstructedIndividualPublicKeys) > 0 {
+		for k := range m.ReconstructedIndividualPublicKeys {
+			v := m.ReconstructedIndividualPublicKeys[k]
+			baseI := i
+			if len(v) > 0 {
+				i -= len(v)
+				copy(dAtA[i:], v)
+				i = encodeVarintCheckpoint(dAtA, i, uint64(len(v)))
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintCheckpoint(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintCheckpoint(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xb2
+		}
+	}
+	if len(m.ReconstructedIndividualPrivateKeys) > 0 {
+		for k := range m.ReconstructedIndividualPrivateKeys {
+			v := m.ReconstructedIndividualPrivateKeys[k]
+			baseI := i
+			if len(v) > 0 {
+				i -= len(v)
+				copy(dAtA[i:], v)
+				i = encodeVarintCheckpoint(dAtA, i, uint64(len(v)))
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintCheckpoint(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintCheckpoint(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xaa
+		}
+	}
+	if len(m.RevealedMisbehavedMembersShares) > 0 {
+		for iNdEx := len(m.RevealedMisbehavedMembersShares) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.RevealedMisbehavedMembersShares[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCheckpoint(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xa2
+		}
+	}
+	if len(m.ExpectedMembersForReconstruction) > 0 {
+		dAtA3 := make([]byte, len(m.ExpectedMembersForReconstruction)*10)
+		var j2 int
+		for _, num := range m.ExpectedMembersForReconstruction {
+			for num >= 1<<7 {
+				dAtA3[j2] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j2++
+			}
+			dAtA3[j2] = uint8(num)
+			j2++
+		}
+		i -= j2
+		copy(dAtA[i:], dAtA3[:j2])
+		i = encodeVarintCheckpoint(dAtA, i, uint64(j2))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	if len(m.ReceivedValidPeerPublicKeySharePoints) > 0 {
+		for k := range m.ReceivedValidPeerPublicKeySharePoints {
+			v := m.ReceivedValidPeerPublicKeySharePoints[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintCheckpoint(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintCheckpoint(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintCheckpoint(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x92
+		}
+	}
+	if len(m.PublicKeySharePoints) > 0 {
+		for iNdEx := len(m.PublicKeySharePoints) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PublicKeySharePoints[iNdEx])
+			copy(dAtA[i:], m.PublicKeySharePoints[iNdEx])
+			i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.PublicKeySharePoints[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x8a
+		}
+	}
+	if len(m.GroupPrivateKeyShare) > 0 {
+		i -= len(m.GroupPrivateKeyShare)
+		copy(dAtA[i:], m.GroupPrivateKeyShare)
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.GroupPrivateKeyShare)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	if len(m.ReceivedPeerCommitments) > 0 {
+		for k := range m.ReceivedPeerCommitments {
+			v := m.ReceivedPeerCommitments[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintCheckpoint(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintCheckpoint(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintCheckpoint(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x7a
+		}
+	}
+	if len(m.ReceivedQualifiedSharesT) > 0 {
+		for k := range m.ReceivedQualifiedSharesT {
+			v := m.ReceivedQualifiedSharesT[k]
+			baseI := i
+			if len(v) > 0 {
+				i -= len(v)
+				copy(dAtA[i:], v)
+				i = encodeVarintCheckpoint(dAtA, i, uint64(len(v)))
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintCheckpoint(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintCheckpoint(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x72
+		}
+	}
+	if len(m.ReceivedQualifiedSharesS) > 0 {
+		for k := range m.ReceivedQualifiedSharesS {
+			v := m.ReceivedQualifiedSharesS[k]
+			baseI := i
+			if len(v) > 0 {
+				i -= len(v)
+				copy(dAtA[i:], v)
+				i = encodeVarintCheckpoint(dAtA, i, uint64(len(v)))
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintCheckpoint(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintCheckpoint(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x6a
+		}
+	}
+	if len(m.SelfSecretShareT) > 0 {
+		i -= len(m.SelfSecretShareT)
+		copy(dAtA[i:], m.SelfSecretShareT)
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.SelfSecretShareT)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if len(m.SelfSecretShareS) > 0 {
+		i -= len(m.SelfSecretShareS)
+		copy(dAtA[i:], m.SelfSecretShareS)
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.SelfSecretShareS)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.SecretCoefficients) > 0 {
+		for iNdEx := len(m.SecretCoefficients) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.SecretCoefficients[iNdEx])
+			copy(dAtA[i:], m.SecretCoefficients[iNdEx])
+			i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.SecretCoefficients[iNdEx])))
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.EphemeralKeyPairs) > 0 {
+		for k := range m.EphemeralKeyPairs {
+			v := m.EphemeralKeyPairs[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintCheckpoint(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintCheckpoint(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintCheckpoint(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if len(m.PeerSharesMessages) > 0 {
+		for iNdEx := len(m.PeerSharesMessages) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PeerSharesMessages[iNdEx])
+			copy(dAtA[i:], m.PeerSharesMessages[iNdEx])
+			i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.PeerSharesMessages[iNdEx])))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.EphemeralPublicKeyMessages) > 0 {
+		for iNdEx := len(m.EphemeralPublicKeyMessages) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.EphemeralPublicKeyMessages[iNdEx])
+			copy(dAtA[i:], m.EphemeralPublicKeyMessages[iNdEx])
+			i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.EphemeralPublicKeyMessages[iNdEx])))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.InactiveMemberIDs) > 0 {
+		dAtA8 := make([]byte, len(m.InactiveMemberIDs)*10)
+		var j7 int
+		for _, num := range m.InactiveMemberIDs {
+			for num >= 1<<7 {
+				dAtA8[j7] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j7++
+			}
+			dAtA8[j7] = uint8(num)
+			j7++
+		}
+		i -= j7
+		copy(dAtA[i:], dAtA8[:j7])
+		i = encodeVarintCheckpoint(dAtA, i, uint64(j7))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.DisqualifiedMemberIDs) > 0 {
+		dAtA10 := make([]byte, len(m.DisqualifiedMemberIDs)*10)
+		var j9 int
+		for _, num := range m.DisqualifiedMemberIDs {
+			for num >= 1<<7 {
+				dAtA10[j9] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j9++
+			}
+			dAtA10[j9] = uint8(num)
+			j9++
+		}
+		i -= j9
+		copy(dAtA[i:], dAtA10[:j9])
+		i = encodeVarintCheckpoint(dAtA, i, uint64(j9))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Seed) > 0 {
+		i -= len(m.Seed)
+		copy(dAtA[i:], m.Seed)
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.Seed)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.DishonestThreshold != 0 {
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.DishonestThreshold))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.GroupSize != 0 {
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.GroupSize))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.MemberID != 0 {
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.MemberID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Member_EphemeralKeyPair) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Member_EphemeralKeyPair) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Member_EphemeralKeyPair) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PublicKey) > 0 {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.PrivateKey) > 0 {
+		i -= len(m.PrivateKey)
+		copy(dAtA[i:], m.PrivateKey)
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.PrivateKey)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Member_Points) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Member_Points) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Member_Points) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Points) > 0 {
+		for iNdEx := len(m.Points) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Points[iNdEx])
+			copy(dAtA[i:], m.Points[iNdEx])
+			i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.Points[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Member_MisbehavedShares) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Member_MisbehavedShares) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Member_MisbehavedShares) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PeerSharesS) > 0 {
+		for k := range m.PeerSharesS {
+			v := m.PeerSharesS[k]
+			baseI := i
+			if len(v) > 0 {
+				i -= len(v)
+				copy(dAtA[i:], v)
+				i = encodeVarintCheckpoint(dAtA, i, uint64(len(v)))
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintCheckpoint(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintCheckpoint(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.MisbehavedMemberID != 0 {
+		i = encodeVarintCheckpoint(dAtA, i, uint64(m.MisbehavedMemberID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintCheckpoint(dAtA []byte, offset int, v uint64) int {
+	offset -= sovCheckpoint(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Checkpoint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Phase != 0 {
+		n += 1 + sovCheckpoint(uint64(m.Phase))
+	}
+	if m.EndBlockHeight != 0 {
+		n += 1 + sovCheckpoint(uint64(m.EndBlockHeight))
+	}
+	if m.Member != nil {
+		l = m.Member.Size()
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	if len(m.PhaseMessages) > 0 {
+		for _, e := range m.PhaseMessages {
+			l = e.Size()
+			n += 1 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	if m.Initiated {
+		n += 2
+	}
+	if len(m.SentMessages) > 0 {
+		for _, e := range m.SentMessages {
+			l = e.Size()
+			n += 1 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Checkpoint_PhaseMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	return n
+}
+
+func (m *Member) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemberID != 0 {
+		n += 1 + sovCheckpoint(uint64(m.MemberID))
+	}
+	if m.GroupSize != 0 {
+		n += 1 + sovCheckpoint(uint64(m.GroupSize))
+	}
+	if m.DishonestThreshold != 0 {
+		n += 1 + sovCheckpoint(uint64(m.DishonestThreshold))
+	}
+	l = len(m.Seed)
+	if l > 0 {
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	if len(m.DisqualifiedMemberIDs) > 0 {
+		l = 0
+		for _, e := range m.DisqualifiedMemberIDs {
+			l += sovCheckpoint(uint64(e))
+		}
+		n += 1 + sovCheckpoint(uint64(l)) + l
+	}
+	if len(m.InactiveMemberIDs) > 0 {
+		l = 0
+		for _, e := range m.InactiveMemberIDs {
+			l += sovCheckpoint(uint64(e))
+		}
+		n += 1 + sovCheckpoint(uint64(l)) + l
+	}
+	if len(m.EphemeralPublicKeyMessages) > 0 {
+		for _, b := range m.EphemeralPublicKeyMessages {
+			l = len(b)
+			n += 1 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	if len(m.PeerSharesMessages) > 0 {
+		for _, b := range m.PeerSharesMessages {
+			l = len(b)
+			n += 1 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	if len(m.EphemeralKeyPairs) > 0 {
+		for k, v := range m.EphemeralKeyPairs {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovCheckpoint(uint64(l))
+			}
+			mapEntrySize := 1 + sovCheckpoint(uint64(k)) + l
+			n += mapEntrySize + 1 + sovCheckpoint(uint64(mapEntrySize))
+		}
+	}
+	if len(m.SecretCoefficients) > 0 {
+		for _, b := range m.SecretCoefficients {
+			l = len(b)
+			n += 1 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	l = len(m.SelfSecretShareS)
+	if l > 0 {
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	l = len(m.SelfSecretShareT)
+	if l > 0 {
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	if len(m.ReceivedQualifiedSharesS) > 0 {
+		for k, v := range m.ReceivedQualifiedSharesS {
+			_ = k
+			_ = v
+			l = 0
+			if len(v) > 0 {
+				l = 1 + len(v) + sovCheckpoint(uint64(len(v)))
+			}
+			mapEntrySize := 1 + sovCheckpoint(uint64(k)) + l
+			n += mapEntrySize + 1 + sovCheckpoint(uint64(mapEntrySize))
+		}
+	}
+	if len(m.ReceivedQualifiedSharesT) > 0 {
+		for k, v := range m.ReceivedQualifiedSharesT {
+			_ = k
+			_ = v
+			l = 0
+			if len(v) > 0 {
+				l = 1 + len(v) + sovCheckpoint(uint64(len(v)))
+			}
+			mapEntrySize := 1 + sovCheckpoint(uint64(k)) + l
+			n += mapEntrySize + 1 + sovCheckpoint(uint64(mapEntrySize))
+		}
+	}
+	if len(m.ReceivedPeerCommitments) > 0 {
+		for k, v := range m.ReceivedPeerCommitments {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovCheckpoint(uint64(l))
+			}
+			mapEntrySize := 1 + sovCheckpoint(uint64(k)) + l
+			n += mapEntrySize + 1 + sovCheckpoint(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.GroupPrivateKeyShare)
+	if l > 0 {
+		n += 2 + l + sovCheckpoint(uint64(l))
+	}
+	if len(m.PublicKeySharePoints) > 0 {
+		for _, b := range m.PublicKeySharePoints {
+			l = len(b)
+			n += 2 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	if len(m.ReceivedValidPeerPublicKeySharePoints) > 0 {
+		for k, v := range m.ReceivedValidPeerPublicKeySharePoints {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovCheckpoint(uint64(l))
+			}
+			mapEntrySize := 1 + sovCheckpoint(uint64(k)) + l
+			n += mapEntrySize + 2 + sovCheckpoint(uint64(mapEntrySize))
+		}
+	}
+	if len(m.ExpectedMembersForReconstruction) > 0 {
+		l = 0
+		for _, e := range m.ExpectedMembersForReconstruction {
+			l += sovCheckpoint(uint64(e))
+		}
+		n += 2 + sovCheckpoint(uint64(l)) + l
+	}
+	if len(m.RevealedMisbehavedMembersShares) > 0 {
+		for _, e := range m.RevealedMisbehavedMembersShares {
+			l = e.Size()
+			n += 2 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	if len(m.ReconstructedIndividualPrivateKeys) > 0 {
+		for k, v := range m.ReconstructedIndividualPrivateKeys {
+			_ = k
+			_ = v
+			l = 0
+			if len(v) > 0 {
+				l = 1 + len(v) + sovCheckpoint(uint64(len(v)))
+			}
+			mapEntrySize := 1 + sovCheckpoint(uint64(k)) + l
+			n += mapEntrySize + 2 + sovCheckpoint(uint64(mapEntrySize))
+		}
+	}
+	if len(m.ReconstructedIndividualPublicKeys) > 0 {
+		for k, v := range m.ReconstructedIndividualPublicKeys {
+			_ = k
+			_ = v
+			l = 0
+			if len(v) > 0 {
+				l = 1 + len(v) + sovCheckpoint(uint64(len(v)))
+			}
+			mapEntrySize := 1 + sovCheckpoint(uint64(k)) + l
+			n += mapEntrySize + 2 + sovCheckpoint(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *Member_EphemeralKeyPair) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PrivateKey)
+	if l > 0 {
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
+	return n
+}
+
+func (m *Member_Points) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Points) > 0 {
+		for _, b := range m.Points {
+			l = len(b)
+			n += 1 + l + sovCheckpoint(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Member_MisbehavedShares) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MisbehavedMemberID != 0 {
+		n += 1 + sovCheckpoint(uint64(m.MisbehavedMemberID))
+	}
+	if len(m.PeerSharesS) > 0 {
+		for k, v := range m.PeerSharesS {
+			_ = k
+			_ = v
+			l = 0
+			if len(v) > 0 {
+				l = 1 + len(v) + sovCheckpoint(uint64(len(v)))
+			}
+			mapEntrySize := 1 + sovCheckpoint(uint64(k)) + l
+			n += mapEntrySize + 1 + sovCheckpoint(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func sovCheckpoint(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozCheckpoint(x uint64) (n int) {
+	return sovCheckpoint(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *Checkpoint) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForPhaseMessages := "[]*Checkpoint_PhaseMessage{"
+	for _, f := range this.PhaseMessages {
+		repeatedStringForPhaseMessages += strings.Replace(fmt.Sprintf("%v", f), "Checkpoint_PhaseMessage", "Checkpoint_PhaseMessage", 1) + ","
+	}
+	repeatedStringForPhaseMessages += "}"
+	repeatedStringForSentMessages := "[]*Checkpoint_PhaseMessage{"
+	for _, f := range this.SentMessages {
+		repeatedStringForSentMessages += strings.Replace(fmt.Sprintf("%v", f), "Checkpoint_PhaseMessage", "Checkpoint_PhaseMessage", 1) + ","
+	}
+	repeatedStringForSentMessages += "}"
+	s := strings.Join([]string{`&Checkpoint{`,
+		`Phase:` + fmt.Sprintf("%v", this.Phase) + `,`,
+		`EndBlockHeight:` + fmt.Sprintf("%v", this.EndBlockHeight) + `,`,
+		`Member:` + strings.Replace(this.Member.String(), "Member", "Member", 1) + `,`,
+		`PhaseMessages:` + repeatedStringForPhaseMessages + `,`,
+		`Initiated:` + fmt.Sprintf("%v", this.Initiated) + `,`,
+		`SentMessages:` + repeatedStringForSentMessages + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Checkpoint_PhaseMessage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Checkpoint_PhaseMessage{`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Member) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForRevealedMisbehavedMembersShares := "[]*Member_MisbehavedShares{"
+	for _, f := range this.RevealedMisbehavedMembersShares {
+		repeatedStringForRevealedMisbehavedMembersShares += strings.Replace(fmt.Sprintf("%v", f), "Member_MisbehavedShares", "Member_MisbehavedShares", 1) + ","
+	}
+	repeatedStringForRevealedMisbehavedMembersShares += "}"
+	keysForEphemeralKeyPairs := make([]uint32, 0, len(this.EphemeralKeyPairs))
+	for k, _ := range this.EphemeralKeyPairs {
+		keysForEphemeralKeyPairs = append(keysForEphemeralKeyPairs, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForEphemeralKeyPairs)
+	mapStringForEphemeralKeyPairs := "map[uint32]*Member_EphemeralKeyPair{"
+	for _, k := range keysForEphemeralKeyPairs {
+		mapStringForEphemeralKeyPairs += fmt.Sprintf("%v: %v,", k, this.EphemeralKeyPairs[k])
+	}
+	mapStringForEphemeralKeyPairs += "}"
+	keysForReceivedQualifiedSharesS := make([]uint32, 0, len(this.ReceivedQualifiedSharesS))
+	for k, _ := range this.ReceivedQualifiedSharesS {
+		keysForReceivedQualifiedSharesS = append(keysForReceivedQualifiedSharesS, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReceivedQualifiedSharesS)
+	mapStringForReceivedQualifiedSharesS := "map[uint32][]byte{"
+	for _, k := range keysForReceivedQualifiedSharesS {
+		mapStringForReceivedQualifiedSharesS += fmt.Sprintf("%v: %v,", k, this.ReceivedQualifiedSharesS[k])
+	}
+	mapStringForReceivedQualifiedSharesS += "}"
+	keysForReceivedQualifiedSharesT := make([]uint32, 0, len(this.ReceivedQualifiedSharesT))
+	for k, _ := range this.ReceivedQualifiedSharesT {
+		keysForReceivedQualifiedSharesT = append(keysForReceivedQualifiedSharesT, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReceivedQualifiedSharesT)
+	mapStringForReceivedQualifiedSharesT := "map[uint32][]byte{"
+	for _, k := range keysForReceivedQualifiedSharesT {
+		mapStringForReceivedQualifiedSharesT += fmt.Sprintf("%v: %v,", k, this.ReceivedQualifiedSharesT[k])
+	}
+	mapStringForReceivedQualifiedSharesT += "}"
+	keysForReceivedPeerCommitments := make([]uint32, 0, len(this.ReceivedPeerCommitments))
+	for k, _ := range this.ReceivedPeerCommitments {
+		keysForReceivedPeerCommitments = append(keysForReceivedPeerCommitments, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReceivedPeerCommitments)
+	mapStringForReceivedPeerCommitments := "map[uint32]*Member_Points{"
+	for _, k := range keysForReceivedPeerCommitments {
+		mapStringForReceivedPeerCommitments += fmt.Sprintf("%v: %v,", k, this.ReceivedPeerCommitments[k])
+	}
+	mapStringForReceivedPeerCommitments += "}"
+	keysForReceivedValidPeerPublicKeySharePoints := make([]uint32, 0, len(this.ReceivedValidPeerPublicKeySharePoints))
+	for k, _ := range this.ReceivedValidPeerPublicKeySharePoints {
+		keysForReceivedValidPeerPublicKeySharePoints = append(keysForReceivedValidPeerPublicKeySharePoints, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReceivedValidPeerPublicKeySharePoints)
+	mapStringForReceivedValidPeerPublicKeySharePoints := "map[uint32]*Member_Points{"
+	for _, k := range keysForReceivedValidPeerPublicKeySharePoints {
+		mapStringForReceivedValidPeerPublicKeySharePoints += fmt.Sprintf("%v: %v,", k, this.ReceivedValidPeerPublicKeySharePoints[k])
+	}
+	mapStringForReceivedValidPeerPublicKeySharePoints += "}"
+	keysForReconstructedIndividualPrivateKeys := make([]uint32, 0, len(this.ReconstructedIndividualPrivateKeys))
+	for k, _ := range this.ReconstructedIndividualPrivateKeys {
+		keysForReconstructedIndividualPrivateKeys = append(keysForReconstructedIndividualPrivateKeys, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReconstructedIndividualPrivateKeys)
+	mapStringForReconstructedIndividualPrivateKeys := "map[uint32][]byte{"
+	for _, k := range keysForReconstructedIndividualPrivateKeys {
+		mapStringForReconstructedIndividualPrivateKeys += fmt.Sprintf("%v: %v,", k, this.ReconstructedIndividualPrivateKeys[k])
+	}
+	mapStringForReconstructedIndividualPrivateKeys += "}"
+	keysForReconstructedIndividualPublicKeys := make([]uint32, 0, len(this.ReconstructedIndividualPublicKeys))
+	for k, _ := range this.ReconstructedIndividualPublicKeys {
+		keysForReconstructedIndividualPublicKeys = append(keysForReconstructedIndividualPublicKeys, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForReconstructedIndividualPublicKeys)
+	mapStringForReconstructedIndividualPublicKeys := "map[uint32][]byte{"
+	for _, k := range keysForReconstructedIndividualPublicKeys {
+		mapStringForReconstructedIndividualPublicKeys += fmt.Sprintf("%v: %v,", k, this.ReconstructedIndividualPublicKeys[k])
+	}
+	mapStringForReconstructedIndividualPublicKeys += "}"
+	s := strings.Join([]string{`&Member{`,
+		`MemberID:` + fmt.Sprintf("%v", this.MemberID) + `,`,
+		`GroupSize:` + fmt.Sprintf("%v", this.GroupSize) + `,`,
+		`DishonestThreshold:` + fmt.Sprintf("%v", this.DishonestThreshold) + `,`,
+		`Seed:` + fmt.Sprintf("%v", this.Seed) + `,`,
+		`DisqualifiedMemberIDs:` + fmt.Sprintf("%v", this.DisqualifiedMemberIDs) + `,`,
+		`InactiveMemberIDs:` + fmt.Sprintf("%v", this.InactiveMemberIDs) + `,`,
+		`EphemeralPublicKeyMessages:` + fmt.Sprintf("%v", this.EphemeralPublicKeyMessages) + `,`,
+		`PeerSharesMessages:` + fmt.Sprintf("%v", this.PeerSharesMessages) + `,`,
+		`EphemeralKeyPairs:` + mapStringForEphemeralKeyPairs + `,`,
+		`SecretCoefficients:` + fmt.Sprintf("%v", this.SecretCoefficients) + `,`,
+		`SelfSecretShareS:` + fmt.Sprintf("%v", this.SelfSecretShareS) + `,`,
+		`SelfSecretShareT:` + fmt.Sprintf("%v", this.SelfSecretShareT) + `,`,
+		`ReceivedQualifiedSharesS:` + mapStringForReceivedQualifiedSharesS + `,`,
+		`ReceivedQualifiedSharesT:` + mapStringForReceivedQualifiedSharesT + `,`,
+		`ReceivedPeerCommitments:` + mapStringForReceivedPeerCommitments + `,`,
+		`GroupPrivateKeyShare:` + fmt.Sprintf("%v", this.GroupPrivateKeyShare) + `,`,
+		`PublicKeySharePoints:` + fmt.Sprintf("%v", this.PublicKeySharePoints) + `,`,
+		`ReceivedValidPeerPublicKeySharePoints:` + mapStringForReceivedValidPeerPublicKeySharePoints + `,`,
+		`ExpectedMembersForReconstruction:` + fmt.Sprintf("%v", this.ExpectedMembersForReconstruction) + `,`,
+		`RevealedMisbehavedMembersShares:` + repeatedStringForRevealedMisbehavedMembersShares + `,`,
+		`ReconstructedIndividualPrivateKeys:` + mapStringForReconstructedIndividualPrivateKeys + `,`,
+		`ReconstructedIndividualPublicKeys:` + mapStringForReconstructedIndividualPublicKeys + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Member_EphemeralKeyPair) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Member_EphemeralKeyPair{`,
+		`PrivateKey:` + fmt.Sprintf("%v", this.PrivateKey) + `,`,
+		`PublicKey:` + fmt.Sprintf("%v", this.PublicKey) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Member_Points) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Member_Points{`,
+		`Points:` + fmt.Sprintf("%v", this.Points) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Member_MisbehavedShares) String() string {
+	if this == nil {
+		return "nil"
+	}
+	keysForPeerSharesS := make([]uint32, 0, len(this.PeerSharesS))
+	for k, _ := range this.PeerSharesS {
+		keysForPeerSharesS = append(keysForPeerSharesS, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Uint32s(keysForPeerSharesS)
+	mapStringForPeerSharesS := "map[uint32][]byte{"
+	for _, k := range keysForPeerSharesS {
+		mapStringForPeerSharesS += fmt.Sprintf("%v: %v,", k, this.PeerSharesS[k])
+	}
+	mapStringForPeerSharesS += "}"
+	s := strings.Join([]string{`&Member_MisbehavedShares{`,
+		`MisbehavedMemberID:` + fmt.Sprintf("%v", this.MisbehavedMemberID) + `,`,
+		`PeerSharesS:` + mapStringForPeerSharesS + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringCheckpoint(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *Checkpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Checkpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Checkpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Phase", wireType)
+			}
+			m.Phase = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Phase |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlockHeight", wireType)
+			}
+			m.EndBlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndBlockHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Member", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Member == nil {
+				m.Member = &Member{}
+			}
+			if err := m.Member.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PhaseMessages", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PhaseMessages = append(m.PhaseMessages, &Checkpoint_PhaseMessage{})
+			if err := m.PhaseMessages[len(m.PhaseMessages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Initiated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Initiated = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SentMessages", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SentMessages = append(m.SentMessages, &Checkpoint_PhaseMessage{})
+			if err := m.SentMessages[len(m.SentMessages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Checkpoint_PhaseMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PhaseMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PhaseMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Member) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Member: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Member: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
+			}
+			m.MemberID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemberID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupSize", wireType)
+			}
+			m.GroupSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DishonestThreshold", wireType)
+			}
+			m.DishonestThreshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DishonestThreshold |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seed", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Seed = append(m.Seed[:0], dAtA[iNdEx:postIndex]...)
+			if m.Seed == nil {
+				m.Seed = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.DisqualifiedMemberIDs = append(m.DisqualifiedMemberIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthCheckpoint
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthCheckpoint
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.DisqualifiedMemberIDs) == 0 {
+					m.DisqualifiedMemberIDs = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.DisqualifiedMemberIDs = append(m.DisqualifiedMemberIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisqualifiedMemberIDs", wireType)
+			}
+		case 6:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.InactiveMemberIDs = append(m.InactiveMemberIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthCheckpoint
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthCheckpoint
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.InactiveMemberIDs) == 0 {
+					m.InactiveMemberIDs = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.InactiveMemberIDs = append(m.InactiveMemberIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field InactiveMemberIDs", wireType)
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EphemeralPublicKeyMessages", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EphemeralPublicKeyMessages = append(m.EphemeralPublicKeyMessages, make([]byte, postIndex-iNdEx))
+			copy(m.EphemeralPublicKeyMessages[len(m.EphemeralPublicKeyMessages)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerSharesMessages", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerSharesMessages = append(m.PeerSharesMessages, make([]byte, postIndex-iNdEx))
+			copy(m.PeerSharesMessages[len(m.PeerSharesMessages)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EphemeralKeyPairs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EphemeralKeyPairs == nil {
+				m.EphemeralKeyPairs = make(map[uint32]*Member_EphemeralKeyPair)
+			}
+			var mapkey uint32
+			var mapvalue *Member_EphemeralKeyPair
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &Member_EphemeralKeyPair{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCheckpoint(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.EphemeralKeyPairs[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SecretCoefficients", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SecretCoefficients = append(m.SecretCoefficients, make([]byte, postIndex-iNdEx))
+			copy(m.SecretCoefficients[len(m.SecretCoefficients)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SelfSecretShareS", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SelfSecretShareS = append(m.SelfSecretShareS[:0], dAtA[iNdEx:postIndex]...)
+			if m.SelfSecretShareS == nil {
+				m.SelfSecretShareS = []byte{}
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SelfSecretShareT", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SelfSecretShareT = append(m.SelfSecretShareT[:0], dAtA[iNdEx:postIndex]...)
+			if m.SelfSecretShareT == nil {
+				m.SelfSecretShareT = []byte{}
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReceivedQualifiedSharesS", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReceivedQualifiedSharesS == nil {
+				m.ReceivedQualifiedSharesS = make(map[uint32][]byte)
+			}
+			var mapkey uint32
+			mapvalue := []byte{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapbyteLen uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapbyteLen |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intMapbyteLen := int(mapbyteLen)
+					if intMapbyteLen < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					postbytesIndex := iNdEx + intMapbyteLen
+					if postbytesIndex < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if postbytesIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = make([]byte, mapbyteLen)
+					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
+					iNdEx = postbytesIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCheckpoint(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ReceivedQualifiedSharesS[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReceivedQualifiedSharesT", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReceivedQualifiedSharesT == nil {
+				m.ReceivedQualifiedSharesT = make(map[uint32][]byte)
+			}
+			var mapkey uint32
+			mapvalue := []byte{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapbyteLen uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapbyteLen |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intMapbyteLen := int(mapbyteLen)
+					if intMapbyteLen < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					postbytesIndex := iNdEx + intMapbyteLen
+					if postbytesIndex < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if postbytesIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = make([]byte, mapbyteLen)
+					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
+					iNdEx = postbytesIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCheckpoint(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ReceivedQualifiedSharesT[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReceivedPeerCommitments", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReceivedPeerCommitments == nil {
+				m.ReceivedPeerCommitments = make(map[uint32]*Member_Points)
+			}
+			var mapkey uint32
+			var mapvalue *Member_Points
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &Member_Points{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCheckpoint(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ReceivedPeerCommitments[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupPrivateKeyShare", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GroupPrivateKeyShare = append(m.GroupPrivateKeyShare[:0], dAtA[iNdEx:postIndex]...)
+			if m.GroupPrivateKeyShare == nil {
+				m.GroupPrivateKeyShare = []byte{}
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKeySharePoints", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKeySharePoints = append(m.PublicKeySharePoints, make([]byte, postIndex-iNdEx))
+			copy(m.PublicKeySharePoints[len(m.PublicKeySharePoints)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReceivedValidPeerPublicKeySharePoints", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReceivedValidPeerPublicKeySharePoints == nil {
+				m.ReceivedValidPeerPublicKeySharePoints = make(map[uint32]*Member_Points)
+			}
+			var mapkey uint32
+			var mapvalue *Member_Points
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &Member_Points{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCheckpoint(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ReceivedValidPeerPublicKeySharePoints[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 19:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ExpectedMembersForReconstruction = append(m.ExpectedMembersForReconstruction, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthCheckpoint
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthCheckpoint
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.ExpectedMembersForReconstruction) == 0 {
+					m.ExpectedMembersForReconstruction = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ExpectedMembersForReconstruction = append(m.ExpectedMembersForReconstruction, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedMembersForReconstruction", wireType)
+			}
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RevealedMisbehavedMembersShares", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RevealedMisbehavedMembersShares = append(m.RevealedMisbehavedMembersShares, &Member_MisbehavedShares{})
+			if err := m.RevealedMisbehavedMembersShares[len(m.RevealedMisbehavedMembersShares)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReconstructedIndividualPrivateKeys", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReconstructedIndividualPrivateKeys == nil {
+				m.ReconstructedIndividualPrivateKeys = make(map[uint32][]byte)
+			}
+			var mapkey uint32
+			mapvalue := []byte{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapbyteLen uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapbyteLen |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intMapbyteLen := int(mapbyteLen)
+					if intMapbyteLen < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					postbytesIndex := iNdEx + intMapbyteLen
+					if postbytesIndex < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if postbytesIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = make([]byte, mapbyteLen)
+					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
+					iNdEx = postbytesIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCheckpoint(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ReconstructedIndividualPrivateKeys[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReconstructedIndividualPublicKeys", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReconstructedIndividualPublicKeys == nil {
+				m.ReconstructedIndividualPublicKeys = make(map[uint32][]byte)
+			}
+			var mapkey uint32
+			mapvalue := []byte{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapbyteLen uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapbyteLen |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intMapbyteLen := int(mapbyteLen)
+					if intMapbyteLen < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					postbytesIndex := iNdEx + intMapbyteLen
+					if postbytesIndex < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if postbytesIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = make([]byte, mapbyteLen)
+					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
+					iNdEx = postbytesIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCheckpoint(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ReconstructedIndividualPublicKeys[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Member_EphemeralKeyPair) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EphemeralKeyPair: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EphemeralKeyPair: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrivateKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PrivateKey = append(m.PrivateKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PrivateKey == nil {
+				m.PrivateKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PublicKey == nil {
+				m.PublicKey = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Member_Points) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Points: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Points: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Points", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Points = append(m.Points, make([]byte, postIndex-iNdEx))
+			copy(m.Points[len(m.Points)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Member_MisbehavedShares) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MisbehavedShares: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MisbehavedShares: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MisbehavedMemberID", wireType)
+			}
+			m.MisbehavedMemberID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MisbehavedMemberID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerSharesS", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PeerSharesS == nil {
+				m.PeerSharesS = make(map[uint32][]byte)
+			}
+			var mapkey uint32
+			mapvalue := []byte{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCheckpoint
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapbyteLen uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCheckpoint
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapbyteLen |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intMapbyteLen := int(mapbyteLen)
+					if intMapbyteLen < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					postbytesIndex := iNdEx + intMapbyteLen
+					if postbytesIndex < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if postbytesIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = make([]byte, mapbyteLen)
+					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
+					iNdEx = postbytesIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCheckpoint(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthCheckpoint
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.PeerSharesS[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheckpoint(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipCheckpoint(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowCheckpoint
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthCheckpoint
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupCheckpoint
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthCheckpoint
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthCheckpoint        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowCheckpoint          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupCheckpoint = fmt.Errorf("proto: unexpected end of group")
+)
@@ -1,6 +1,7 @@
 package gjkr
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
@@ -9,10 +10,27 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/fieldlog"
 	"github.com/keep-network/keep-core/pkg/net"
 )
 
-var logger = log.Logger("keep-gjkr")
+const loggerSubsystem = "keep-gjkr"
+
+var logger = log.Logger(loggerSubsystem)
+
+// PhaseError is returned by Execute and Resume if the protocol fails.
+type PhaseError struct {
+	// Phase is the name of the phase in which the protocol failed, as
+	// returned by state.PhaseName, or `initialization` if the member could
+	// not be created.
+	Phase string
+
+	err error
+}
+
+func (pe *PhaseError) Error() string {
+	return pe.err.Error()
+}
 
 // RegisterUnmarshallers initializes the given broadcast channel to be able to
 // perform DKG protocol interactions by registering all the required protocol
@@ -49,7 +67,12 @@ func RegisterUnmarshallers(channel net.BroadcastChannel) {
 // If the generation is successful, it returns a threshold group member which
 // can participate in the signing group; if the generation fails, it returns an
 // error.
+// If the checkpoint handler is not nil, it is called with a checkpoint of the
+// member's state at the end of every phase and once every phase in which the
+// member broadcasts messages is initiated. The checkpoint can be resumed with
+// Resume. The generation is abandoned once the context is done.
 func Execute(
+	ctx context.Context,
 	memberIndex group.MemberIndex,
 	groupSize int,
 	blockCounter chain.BlockCounter,
@@ -58,6 +81,7 @@ func Execute(
 	seed *big.Int,
 	membershipValidator group.MembershipValidator,
 	startBlockHeight uint64,
+	checkpointHandler CheckpointHandler,
 ) (*Result, uint64, error) {
 	logger.Debugf("[member:%v] initializing member", memberIndex)
 
@@ -69,7 +93,16 @@ func Execute(
 		seed,
 	)
 	if err != nil {
-		return nil, 0, fmt.Errorf("cannot create a new member: [%v]", err)
+		return nil, 0, &PhaseError{
+			Phase: "initialization",
+			err:   fmt.Errorf("cannot create a new member: [%v]", err),
+		}
+	}
+
+	var recorder *recordingChannel
+	if checkpointHandler != nil {
+		recorder = newRecordingChannel(channel)
+		channel = recorder
 	}
 
 	initialState := &ephemeralKeyPairGenerationState{
@@ -77,17 +110,228 @@ func Execute(
 		member:  member.InitializeEphemeralKeysGeneration(),
 	}
 
+	return execute(
+		ctx,
+		initialState,
+		seed,
+		blockCounter,
+		channel,
+		startBlockHeight,
+		checkpointHandler,
+		recorder,
+	)
+}
+
+// Resume continues the GJKR distributed key generation protocol from the
+// given checkpoint, taken by Execute or Resume before a client restart. If the
+// checkpoint was taken at the end of a phase, the protocol is continued from
+// the following phase. If the checkpoint was taken once a phase was
+// initiated, the phase is continued and messages the member sent in that phase
+// are broadcast again.
+// Resume returns an error if any of the remaining phases in which the member
+// has to exchange messages with other members is already over.
+//
+// Messages broadcast by other members before the restart are not delivered
+// again so the member may consider some of its peers inactive if it resumes
+// in the middle of a phase.
+//
+// The generation is abandoned once the context is done.
+func Resume(
+	ctx context.Context,
+	checkpoint []byte,
+	blockCounter chain.BlockCounter,
+	channel net.BroadcastChannel,
+	membershipValidator group.MembershipValidator,
+	checkpointHandler CheckpointHandler,
+) (*Result, uint64, error) {
+	var recorder *recordingChannel
+	if checkpointHandler != nil {
+		recorder = newRecordingChannel(channel)
+		channel = recorder
+	}
+
+	restored, err := restoreCheckpoint(checkpoint, channel, membershipValidator)
+	if err != nil {
+		return nil, 0, fmt.Errorf("cannot restore checkpoint: [%v]", err)
+	}
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, 0, fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	initialState := restored.phaseState.Next()
+	firstPhase := restored.phase + 1
+	if restored.initiated {
+		initialState = &resumedState{
+			keyGenerationState: restored.phaseState,
+			channel:            channel,
+			sentMessages:       restored.sentMessages,
+		}
+		firstPhase = restored.phase
+	}
+
+	for _, deadline := range remainingPhaseDeadlines(
+		firstPhase,
+		restored.endBlockHeight,
+	) {
+		if currentBlock >= deadline.endBlockHeight {
+			return nil, 0, fmt.Errorf(
+				"too late to resume from phase [%v]; phase [%v] "+
+					"ended at block [%v], current block [%v]",
+				restored.phase,
+				deadline.phase,
+				deadline.endBlockHeight,
+				currentBlock,
+			)
+		}
+	}
+
+	resumeLogger := fieldlog.New(
+		loggerSubsystem,
+		fieldlog.Fields{
+			fieldlog.MemberIndex: restored.phaseState.MemberIndex(),
+			fieldlog.Channel:     channel.Name(),
+			fieldlog.Seed:        fmt.Sprintf("0x%x", restored.seed),
+			fieldlog.Phase:       state.PhaseName(restored.phaseState),
+		},
+	)
+
+	if restored.initiated {
+		// The end block height of an initiated checkpoint is the block at
+		// which the phase preceding the initiated one ended.
+		previousPhaseEndBlockHeight := restored.endBlockHeight
+
+		resumeLogger.Infof(
+			"resuming protocol in initiated phase [%v]; "+
+				"previous phase ended at block [%v]",
+			restored.phase,
+			previousPhaseEndBlockHeight,
+		)
+	} else {
+		resumeLogger.Infof(
+			"resuming protocol after phase [%v] ended at block [%v]",
+			restored.phase,
+			restored.endBlockHeight,
+		)
+	}
+
+	return execute(
+		ctx,
+		initialState,
+		restored.seed,
+		blockCounter,
+		channel,
+		restored.endBlockHeight,
+		checkpointHandler,
+		recorder,
+	)
+}
+
+func execute(
+	ctx context.Context,
+	initialState keyGenerationState,
+	seed *big.Int,
+	blockCounter chain.BlockCounter,
+	channel net.BroadcastChannel,
+	startBlockHeight uint64,
+	checkpointHandler CheckpointHandler,
+	recorder *recordingChannel,
+) (*Result, uint64, error) {
 	stateMachine := state.NewMachine(channel, blockCounter, initialState)
+	stateMachine.SetContext(ctx)
+	stateMachine.SetLogFields(fieldlog.Fields{
+		fieldlog.Seed: fmt.Sprintf("0x%x", seed),
+	})
+
+	if checkpointHandler != nil {
+		stateMachine.SetCheckpointer(
+			func(completedState state.State, endBlockHeight uint64) {
+				checkpoint, ok := newCheckpoint(
+					completedState,
+					seed,
+					endBlockHeight,
+				)
+				if !ok {
+					return
+				}
+
+				handleCheckpoint(
+					checkpoint,
+					checkpointHandler,
+					checkpointLogger(completedState, seed, channel),
+				)
+			},
+		)
+		stateMachine.SetInitiationCheckpointer(
+			func(initiatedState state.State, startBlockHeight uint64) {
+				checkpoint, ok := newInitiationCheckpoint(
+					initiatedState,
+					seed,
+					startBlockHeight,
+					recorder.takeSentMessages(),
+				)
+				if !ok {
+					return
+				}
+
+				handleCheckpoint(
+					checkpoint,
+					checkpointHandler,
+					checkpointLogger(initiatedState, seed, channel),
+				)
+			},
+		)
+	}
 
 	lastState, endBlockHeight, err := stateMachine.Execute(startBlockHeight)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, &PhaseError{Phase: state.PhaseName(lastState), err: err}
 	}
 
 	finalizationState, ok := lastState.(*finalizationState)
 	if !ok {
-		return nil, 0, fmt.Errorf("execution ended on state: %T", lastState)
+		return nil, 0, &PhaseError{
+			Phase: state.PhaseName(lastState),
+			err:   fmt.Errorf("execution ended on state: %T", lastState),
+		}
 	}
 
 	return finalizationState.result(), endBlockHeight, nil
 }
+
+func handleCheckpoint(
+	checkpoint *checkpoint,
+	checkpointHandler CheckpointHandler,
+	checkpointLogger *fieldlog.Logger,
+) {
+	checkpointBytes, err := checkpoint.Marshal()
+	if err != nil {
+		checkpointLogger.Warningf(
+			"could not marshal checkpoint of phase [%v]: [%v]",
+			checkpoint.phase,
+			err,
+		)
+		return
+	}
+
+	checkpointHandler(checkpointBytes)
+}
+
+// checkpointLogger returns a logger with the same fields as the state machine
+// logger of the checkpointed state.
+func checkpointLogger(
+	checkpointedState state.State,
+	seed *big.Int,
+	channel net.BroadcastChannel,
+) *fieldlog.Logger {
+	return fieldlog.New(
+		loggerSubsystem,
+		fieldlog.Fields{
+			fieldlog.MemberIndex: checkpointedState.MemberIndex(),
+			fieldlog.Channel:     channel.Name(),
+			fieldlog.Seed:        fmt.Sprintf("0x%x", seed),
+			fieldlog.Phase:       state.PhaseName(checkpointedState),
+		},
+	)
+}
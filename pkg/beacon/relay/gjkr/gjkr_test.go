@@ -0,0 +1,46 @@
+package gjkr
+
+import (
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/state"
+)
+
+func TestPhaseName(t *testing.T) {
+	var tests = map[string]struct {
+		state        state.State
+		expectedName string
+	}{
+		"ephemeral key pair generation": {
+			state:        &ephemeralKeyPairGenerationState{},
+			expectedName: "ephemeralKeyPairGeneration",
+		},
+		"resumed ephemeral key pair generation": {
+			state: &resumedState{
+				keyGenerationState: &ephemeralKeyPairGenerationState{},
+			},
+			expectedName: "ephemeralKeyPairGeneration",
+		},
+		"finalization": {
+			state:        &finalizationState{},
+			expectedName: "finalization",
+		},
+		"no state": {
+			state:        nil,
+			expectedName: "unknown",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			actualName := state.PhaseName(test.state)
+			if test.expectedName != actualName {
+				t.Errorf(
+					"unexpected phase name\nexpected: [%v]\nactual:   [%v]",
+					test.expectedName,
+					actualName,
+				)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package groupselection
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"sort"
@@ -13,9 +14,26 @@ import (
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/fieldlog"
+	"github.com/keep-network/keep-core/pkg/metrics"
 )
 
-var logger = log.Logger("keep-groupselection")
+const loggerSubsystem = "keep-groupselection"
+
+var logger = log.Logger(loggerSubsystem)
+
+var (
+	ticketsSubmitted = metrics.NewCounterVec(
+		"keep_groupselection_tickets_submitted_total",
+		"Number of group selection tickets successfully submitted to the chain.",
+		metrics.OperatorLabel,
+	)
+	ticketSubmissionFailures = metrics.NewCounterVec(
+		"keep_groupselection_ticket_submission_failures_total",
+		"Number of group selection tickets which could not be submitted to the chain.",
+		metrics.OperatorLabel,
+	)
+)
 
 // Recommended parameters all clients should use to minimize their expenses.
 // It is not a must to obey but it is nice and polite. And being nice to others
@@ -60,7 +78,20 @@ type Result struct {
 // After the last round, there is a 12 blocks mining lag allowing all
 // outstanding ticket submissions to have a higher chance of being
 // mined before the deadline.
+//
+// If the ticket submission is already in progress, for example because the
+// client has been restarted, the candidate joins it in the current round,
+// submitting all its still competitive tickets which are not on-chain yet.
+//
+// Once the provided context is done, no more tickets are submitted, e.g.
+// because the stake of the candidate dropped below the minimum. Whether the
+// candidate still has the minimum stake is also checked with hasMinimumStake
+// before each ticket is submitted. The check is called for every ticket so it
+// should not query the chain. The candidate still waits for the end of the ticket submission,
+// as tickets submitted before could have qualified it to the group.
 func CandidateToNewGroup(
+	ctx context.Context,
+	hasMinimumStake func() bool,
 	relayChain relaychain.Interface,
 	blockCounter chain.BlockCounter,
 	chainConfig *config.Chain,
@@ -84,17 +115,33 @@ func CandidateToNewGroup(
 		return err
 	}
 
-	logger.Infof("starting ticket submission with [%v] tickets", len(tickets))
+	selectionLogger := fieldlog.New(
+		loggerSubsystem,
+		fieldlog.Fields{
+			fieldlog.Seed: fmt.Sprintf("0x%x", newEntry),
+		},
+	)
+
+	selectionLogger.Infof(
+		"starting ticket submission with [%v] tickets",
+		len(tickets),
+	)
 
 	err = submitTickets(
+		ctx,
+		hasMinimumStake,
 		tickets,
 		relayChain,
 		blockCounter,
 		chainConfig,
 		startBlockHeight,
+		selectionLogger,
 	)
 	if err != nil {
-		logger.Errorf("ticket submission terminated with error: [%v]", err)
+		selectionLogger.Errorf(
+			"ticket submission terminated with error: [%v]",
+			err,
+		)
 	}
 
 	// Wait till the end of the ticket submission in case submitTickets failed
@@ -108,7 +155,7 @@ func CandidateToNewGroup(
 
 	ticketSubmissionEndBlockHeight := <-ticketSubmissionTimeoutChannel
 
-	logger.Infof(
+	selectionLogger.Infof(
 		"ticket submission ended at block [%v]",
 		ticketSubmissionEndBlockHeight,
 	)
@@ -131,23 +178,48 @@ func CandidateToNewGroup(
 }
 
 func submitTickets(
+	ctx context.Context,
+	hasMinimumStake func() bool,
 	tickets []*ticket,
 	relayChain relaychain.GroupSelectionInterface,
 	blockCounter chain.BlockCounter,
 	chainConfig *config.Chain,
 	startBlockHeight uint64,
+	selectionLogger *fieldlog.Logger,
 ) error {
 	rounds, err := calculateRoundsCount(chainConfig.TicketSubmissionTimeout)
 	if err != nil {
 		return err
 	}
 
-	for roundIndex := uint64(0); roundIndex <= rounds; roundIndex++ {
+	// If the ticket submission started a while ago, e.g. because the client
+	// has been restarted in the middle of it, there is no point in waiting
+	// for rounds which are already over. Tickets of all past rounds are
+	// submitted in the current one.
+	firstRoundIndex, err := currentRoundIndex(blockCounter, startBlockHeight)
+	if err != nil {
+		return err
+	}
+	if firstRoundIndex > rounds {
+		selectionLogger.Warningf(
+			"all ticket submission rounds are already over; " +
+				"no tickets will be submitted",
+		)
+		return nil
+	}
+	if firstRoundIndex > 0 {
+		selectionLogger.Infof(
+			"joining ticket submission in round [%v]",
+			firstRoundIndex,
+		)
+	}
+
+	for roundIndex := firstRoundIndex; roundIndex <= rounds; roundIndex++ {
 		roundStartDelay := roundIndex * roundDuration
 		roundStartBlock := startBlockHeight + roundStartDelay
 		roundLeadingZeros := rounds - roundIndex
 
-		logger.Infof(
+		selectionLogger.Infof(
 			"ticket submission round [%v] will start at "+
 				"block [%v] and cover tickets with [%v] leading zeros",
 			roundIndex,
@@ -160,10 +232,19 @@ func submitTickets(
 			return err
 		}
 
+		if ctx.Err() != nil {
+			selectionLogger.Warningf(
+				"ticket submission stopped before round [%v]",
+				roundIndex,
+			)
+			return nil
+		}
+
 		candidateTickets, err := roundCandidateTickets(
 			relayChain,
 			tickets,
 			roundIndex,
+			firstRoundIndex,
 			roundLeadingZeros,
 			chainConfig.GroupSize,
 		)
@@ -171,19 +252,44 @@ func submitTickets(
 			return err
 		}
 
-		logger.Infof(
+		selectionLogger.Infof(
 			"ticket submission round [%v] submitting "+
 				"[%v] tickets",
 			roundIndex,
 			len(candidateTickets),
 		)
 
-		submitTicketsOnChain(candidateTickets, relayChain)
+		submitTicketsOnChain(
+			ctx,
+			hasMinimumStake,
+			candidateTickets,
+			relayChain,
+			selectionLogger,
+		)
 	}
 
 	return nil
 }
 
+// currentRoundIndex returns the index of the ticket submission round
+// in progress at the current block, for the ticket submission started at the
+// given block.
+func currentRoundIndex(
+	blockCounter chain.BlockCounter,
+	startBlockHeight uint64,
+) (uint64, error) {
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return 0, fmt.Errorf("could not read current block: [%v]", err)
+	}
+
+	if currentBlock <= startBlockHeight {
+		return 0, nil
+	}
+
+	return (currentBlock - startBlockHeight) / roundDuration, nil
+}
+
 // calculateRoundsCount takes the on-chain ticket submission timeout
 // and calculates the number of rounds for ticket submission. If it is not
 // possible to use the recommended round duration and mining lag because the
@@ -197,7 +303,9 @@ func calculateRoundsCount(submissionTimeout uint64) (uint64, error) {
 }
 
 // roundCandidateTickets returns tickets which should be submitted in
-// the given ticket submission round.
+// the given ticket submission round. The first round the member takes part
+// in covers also tickets of all previous rounds. Tickets which are already
+// on-chain are never returned.
 //
 // Bear in mind that member tickets slice should be sorted in ascending
 // order by their value.
@@ -205,6 +313,7 @@ func roundCandidateTickets(
 	relayChain relaychain.GroupSelectionInterface,
 	memberTickets []*ticket,
 	roundIndex uint64,
+	firstRoundIndex uint64,
 	roundLeadingZeros uint64,
 	groupSize int,
 ) ([]*ticket, error) {
@@ -229,7 +338,7 @@ func roundCandidateTickets(
 
 		// Check if the given candidate ticket should be proceeded in
 		// the current round.
-		if roundIndex == 0 {
+		if roundIndex == firstRoundIndex {
 			if candidateTicketLeadingZeros < roundLeadingZeros {
 				continue
 			}
@@ -247,9 +356,15 @@ func roundCandidateTickets(
 			},
 		)
 
-		shouldBeSubmitted := false
 		candidateTicketValue := candidateTicket.intValue().Uint64()
 
+		// The ticket could have been submitted before the client restart.
+		if containsTicket(submittedTickets, candidateTicketValue) {
+			continue
+		}
+
+		shouldBeSubmitted := false
+
 		if len(submittedTickets) < groupSize {
 			// If the submitted tickets count is less than the group
 			// size the candidate ticket can be added unconditionally.
@@ -287,3 +402,13 @@ func roundCandidateTickets(
 
 	return candidateTickets, nil
 }
+
+func containsTicket(tickets []uint64, ticket uint64) bool {
+	for _, t := range tickets {
+		if t == ticket {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,6 +1,7 @@
 package groupselection
 
 import (
+	"context"
 	"encoding/binary"
 	"math/big"
 	"reflect"
@@ -11,6 +12,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/fieldlog"
 	"github.com/keep-network/keep-core/pkg/gen/async"
 	"github.com/keep-network/keep-core/pkg/subscription"
 )
@@ -74,11 +76,14 @@ func TestSubmitTickets(t *testing.T) {
 			}
 
 			err = submitTickets(
+				context.Background(),
+				hasMinimumStake,
 				test.tickets,
 				chain,
 				blockCounter,
 				chainConfig,
 				0, // start block height
+				fieldlog.New(loggerSubsystem, nil),
 			)
 			if err != nil {
 				t.Fatal(err)
@@ -107,6 +112,55 @@ func TestSubmitTickets(t *testing.T) {
 	}
 }
 
+func TestSubmitTicketsStopped(t *testing.T) {
+	chainConfig := &config.Chain{
+		GroupSize:               4,
+		TicketSubmissionTimeout: 24,
+	}
+
+	chain := &stubGroupInterface{
+		groupSize: chainConfig.GroupSize,
+	}
+
+	blockCounter, err := local.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+
+	err = submitTickets(
+		ctx,
+		hasMinimumStake,
+		[]*ticket{
+			newTestTicket(1, 1001),
+			newTestTicket(2, 1002),
+		},
+		chain,
+		blockCounter,
+		chainConfig,
+		0, // start block height
+		fieldlog.New(loggerSubsystem, nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	submittedTickets, err := chain.GetSubmittedTickets()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(submittedTickets) != 0 {
+		t.Errorf(
+			"unexpected number of submitted tickets\nexpected: [%v]\nactual:   [%v]",
+			0,
+			len(submittedTickets),
+		)
+	}
+}
+
 func TestRoundCandidateTickets(t *testing.T) {
 	groupSize := 9
 	rounds := uint64(7)
@@ -279,6 +333,7 @@ func TestRoundCandidateTickets(t *testing.T) {
 					relayChain,
 					tickets,
 					roundIndex,
+					0, // first round index
 					roundLeadingZeros,
 					groupSize,
 				)
@@ -316,6 +371,84 @@ func TestRoundCandidateTickets(t *testing.T) {
 	}
 }
 
+func TestRoundCandidateTickets_FirstRoundAfterRestart(t *testing.T) {
+	groupSize := 9
+	rounds := uint64(7)
+	firstRoundIndex := uint64(3)
+
+	tickets := []*ticket{
+		newTestTicket(1, 36028797018963968),
+		newTestTicket(2, 72057594037927936),
+		newTestTicket(3, 144115188075855872),
+		newTestTicket(4, 288230376151711744),
+		newTestTicket(5, 576460752303423488),
+		newTestTicket(6, 1152921504606846976),
+	}
+
+	var tests = map[string]struct {
+		existingChainTickets     []*ticket
+		expectedCandidateTickets []*ticket
+	}{
+		"no existing chain tickets - tickets of all past rounds " +
+			"should be submitted": {
+			existingChainTickets: []*ticket{},
+			expectedCandidateTickets: []*ticket{
+				tickets[0], tickets[1], tickets[2], tickets[3], tickets[4],
+			},
+		},
+		"member tickets submitted before the restart - " +
+			"they should not be submitted again": {
+			existingChainTickets: []*ticket{tickets[1], tickets[3]},
+			expectedCandidateTickets: []*ticket{
+				tickets[0], tickets[2], tickets[4],
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			existingChainTickets := make([]*chain.Ticket, 0)
+			for _, existingChainTicket := range test.existingChainTickets {
+				chainTicket, err := toChainTicket(existingChainTicket)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				existingChainTickets = append(existingChainTickets, chainTicket)
+			}
+
+			relayChain := &stubGroupInterface{
+				groupSize:        groupSize,
+				submittedTickets: existingChainTickets,
+			}
+
+			candidateTickets, err := roundCandidateTickets(
+				relayChain,
+				tickets,
+				firstRoundIndex,
+				firstRoundIndex,
+				rounds-firstRoundIndex,
+				groupSize,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(
+				test.expectedCandidateTickets,
+				candidateTickets,
+			) {
+				t.Fatalf(
+					"unexpected candidate tickets\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedCandidateTickets,
+					candidateTickets,
+				)
+			}
+		})
+	}
+}
+
 type stubGroupInterface struct {
 	groupSize        int
 	submittedTickets []*chain.Ticket
@@ -371,3 +504,20 @@ func (stg *stubGroupInterface) OnGroupSelectionStarted(
 ) (subscription.EventSubscription, error) {
 	panic("not implemented")
 }
+
+func (stg *stubGroupInterface) OnGroupSelectionStartRemoved(
+	func(groupSelectionStart *event.GroupSelectionStart),
+) (subscription.EventSubscription, error) {
+	panic("not implemented")
+}
+
+func (stg *stubGroupInterface) IsGroupSelectionPossible() (bool, error) {
+	panic("not implemented")
+}
+
+func (stg *stubGroupInterface) PastGroupSelectionStarts(
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	panic("not implemented")
+}
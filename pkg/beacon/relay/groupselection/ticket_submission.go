@@ -1,29 +1,63 @@
 package groupselection
 
 import (
+	"context"
 	"math/big"
 
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/fieldlog"
+	"github.com/keep-network/keep-core/pkg/metrics"
 )
 
-// submitTicketsOnChain submits tickets to the chain.
+// submitTicketsOnChain submits tickets to the chain. Tickets are not
+// submitted anymore once the context is done or the candidate no longer has
+// the minimum stake.
 func submitTicketsOnChain(
+	ctx context.Context,
+	hasMinimumStake func() bool,
 	tickets []*ticket,
 	relayChain relaychain.GroupSelectionInterface,
+	selectionLogger *fieldlog.Logger,
 ) {
-	for _, ticket := range tickets {
+	for i, ticket := range tickets {
+		if ctx.Err() != nil {
+			selectionLogger.Warningf(
+				"ticket submission stopped; [%v] tickets not submitted",
+				len(tickets)-i,
+			)
+			return
+		}
+
+		if !hasMinimumStake() {
+			selectionLogger.Warningf(
+				"candidate no longer has the minimum stake; "+
+					"[%v] tickets not submitted",
+				len(tickets)-i,
+			)
+			return
+		}
+
+		operatorLabel := metrics.OperatorLabelValue(ticket.proof.stakerValue)
+
 		chainTicket, err := toChainTicket(ticket)
 		if err != nil {
-			logger.Errorf(
+			selectionLogger.Errorf(
 				"could not transform ticket to chain format: [%v]",
 				err,
 			)
+			ticketSubmissionFailures.WithLabelValues(operatorLabel).Inc()
 			continue
 		}
 
-		relayChain.SubmitTicket(chainTicket).OnFailure(
+		relayChain.SubmitTicket(chainTicket).OnSuccess(
+			func(*event.GroupTicketSubmission) {
+				ticketsSubmitted.WithLabelValues(operatorLabel).Inc()
+			},
+		).OnFailure(
 			func(err error) {
-				logger.Errorf(
+				ticketSubmissionFailures.WithLabelValues(operatorLabel).Inc()
+				selectionLogger.Errorf(
 					"ticket submission failed: [%v]",
 					err,
 				)
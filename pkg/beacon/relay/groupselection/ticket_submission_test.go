@@ -1,14 +1,20 @@
 package groupselection
 
 import (
+	"context"
+	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/fieldlog"
 	"github.com/keep-network/keep-core/pkg/gen/async"
+	"github.com/keep-network/keep-core/pkg/metrics"
 	"github.com/keep-network/keep-core/pkg/subscription"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func TestSubmitTicketsOnChain(t *testing.T) {
@@ -35,7 +41,13 @@ func TestSubmitTicketsOnChain(t *testing.T) {
 		},
 	}
 
-	submitTicketsOnChain(tickets, mockInterface)
+	submitTicketsOnChain(
+		context.Background(),
+		hasMinimumStake,
+		tickets,
+		mockInterface,
+		fieldlog.New(loggerSubsystem, nil),
+	)
 
 	if len(tickets) != len(submittedTickets) {
 		t.Errorf(
@@ -59,6 +71,174 @@ func TestSubmitTicketsOnChain(t *testing.T) {
 	}
 }
 
+func TestSubmitTicketsOnChainStopsWhenContextDone(t *testing.T) {
+	beaconOutput := big.NewInt(10).Bytes()
+	stakerValue := []byte("StakerValue1001")
+
+	tickets := make([]*ticket, 0)
+	for i := 1; i <= 4; i++ {
+		ticket, _ := newTicket(beaconOutput, stakerValue, big.NewInt(int64(i)))
+		tickets = append(tickets, ticket)
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	submissions := 0
+	mockInterface := &mockGroupInterface{
+		mockSubmitTicketFn: func(t *chain.Ticket) *async.EventGroupTicketSubmissionPromise {
+			submissions++
+			if submissions == 2 {
+				cancelCtx()
+			}
+			promise := &async.EventGroupTicketSubmissionPromise{}
+			promise.Fulfill(&event.GroupTicketSubmission{
+				TicketValue: new(big.Int).SetBytes(t.Value[:]),
+				BlockNumber: 111,
+			})
+			return promise
+		},
+	}
+
+	submitTicketsOnChain(
+		ctx,
+		hasMinimumStake,
+		tickets,
+		mockInterface,
+		fieldlog.New(loggerSubsystem, nil),
+	)
+
+	if submissions != 2 {
+		t.Errorf(
+			"unexpected number of tickets submitted\nexpected: [%v]\nactual:   [%v]",
+			2,
+			submissions,
+		)
+	}
+}
+
+func TestSubmitTicketsOnChainStopsWithoutMinimumStake(t *testing.T) {
+	beaconOutput := big.NewInt(10).Bytes()
+	stakerValue := []byte("StakerValue1001")
+
+	tickets := make([]*ticket, 0)
+	for i := 1; i <= 4; i++ {
+		ticket, _ := newTicket(beaconOutput, stakerValue, big.NewInt(int64(i)))
+		tickets = append(tickets, ticket)
+	}
+
+	submissions := 0
+	mockInterface := &mockGroupInterface{
+		mockSubmitTicketFn: func(t *chain.Ticket) *async.EventGroupTicketSubmissionPromise {
+			submissions++
+			promise := &async.EventGroupTicketSubmissionPromise{}
+			promise.Fulfill(&event.GroupTicketSubmission{
+				TicketValue: new(big.Int).SetBytes(t.Value[:]),
+				BlockNumber: 111,
+			})
+			return promise
+		},
+	}
+
+	// the stake drops below the minimum after the second ticket is submitted
+	stakeChecks := 0
+	hasMinimumStake := func() bool {
+		stakeChecks++
+		return stakeChecks <= 2
+	}
+
+	submitTicketsOnChain(
+		context.Background(),
+		hasMinimumStake,
+		tickets,
+		mockInterface,
+		fieldlog.New(loggerSubsystem, nil),
+	)
+
+	if submissions != 2 {
+		t.Errorf(
+			"unexpected number of tickets submitted\nexpected: [%v]\nactual:   [%v]",
+			2,
+			submissions,
+		)
+	}
+}
+
+func TestSubmitTicketsOnChainMetrics(t *testing.T) {
+	beaconOutput := big.NewInt(10).Bytes()
+	stakerValue := []byte("StakerValue1001")
+
+	tickets := make([]*ticket, 0)
+	for i := 1; i <= 3; i++ {
+		ticket, _ := newTicket(beaconOutput, stakerValue, big.NewInt(int64(i)))
+		tickets = append(tickets, ticket)
+	}
+
+	submissions := 0
+	mockInterface := &mockGroupInterface{
+		mockSubmitTicketFn: func(t *chain.Ticket) *async.EventGroupTicketSubmissionPromise {
+			submissions++
+			promise := &async.EventGroupTicketSubmissionPromise{}
+			if submissions == 2 {
+				promise.Fail(fmt.Errorf("submission reverted"))
+			} else {
+				promise.Fulfill(&event.GroupTicketSubmission{
+					TicketValue: new(big.Int).SetBytes(t.Value[:]),
+					BlockNumber: 111,
+				})
+			}
+			return promise
+		},
+	}
+
+	// Promise callbacks are called asynchronously; let callbacks of tickets
+	// submitted by other tests complete before the metrics are read.
+	time.Sleep(100 * time.Millisecond)
+
+	operatorLabel := metrics.OperatorLabelValue(stakerValue)
+	submittedBefore := testutil.ToFloat64(
+		ticketsSubmitted.WithLabelValues(operatorLabel),
+	)
+	failedBefore := testutil.ToFloat64(
+		ticketSubmissionFailures.WithLabelValues(operatorLabel),
+	)
+
+	submitTicketsOnChain(
+		context.Background(),
+		hasMinimumStake,
+		tickets,
+		mockInterface,
+		fieldlog.New(loggerSubsystem, nil),
+	)
+
+	time.Sleep(100 * time.Millisecond)
+
+	submitted := testutil.ToFloat64(
+		ticketsSubmitted.WithLabelValues(operatorLabel),
+	) - submittedBefore
+	if submitted != 2 {
+		t.Errorf(
+			"unexpected number of submitted tickets\nexpected: [%v]\nactual:   [%v]",
+			2,
+			submitted,
+		)
+	}
+	failed := testutil.ToFloat64(
+		ticketSubmissionFailures.WithLabelValues(operatorLabel),
+	) - failedBefore
+	if failed != 1 {
+		t.Errorf(
+			"unexpected number of failed submissions\nexpected: [%v]\nactual:   [%v]",
+			1,
+			failed,
+		)
+	}
+}
+
+func hasMinimumStake() bool {
+	return true
+}
+
 func fromChainTicket(chainTicket *chain.Ticket) *ticket {
 	return &ticket{
 		value: chainTicket.Value,
@@ -96,3 +276,20 @@ func (mgi *mockGroupInterface) OnGroupSelectionStarted(
 ) (subscription.EventSubscription, error) {
 	panic("not implemented")
 }
+
+func (mgi *mockGroupInterface) OnGroupSelectionStartRemoved(
+	func(groupSelectionStart *event.GroupSelectionStart),
+) (subscription.EventSubscription, error) {
+	panic("not implemented")
+}
+
+func (mgi *mockGroupInterface) IsGroupSelectionPossible() (bool, error) {
+	panic("not implemented")
+}
+
+func (mgi *mockGroupInterface) PastGroupSelectionStarts(
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	panic("not implemented")
+}
@@ -0,0 +1,266 @@
+// Package journal records threshold relay events seen on-chain in an
+// append-only local file and replays recorded events so that the behaviour of
+// the client in a production incident can be reproduced deterministically
+// with a local chain and a local network.
+//
+// The journal is a file with one JSON record per line. Every record contains
+// the type of the event, the block number and the hash of the transaction
+// which emitted it, if known, and the event itself. Once the journal file
+// grows over the maximum size, it is rotated: the file replaces the previously
+// rotated one and records are appended to a new file.
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ipfs/go-log"
+)
+
+var logger = log.Logger("keep-journal")
+
+// FileName is the name of the journal file in the data directory.
+const FileName = "events.journal"
+
+// RotatedFileName is the name of the rotated journal file in the data
+// directory. It holds records appended before those in the journal file.
+const RotatedFileName = FileName + ".1"
+
+// DefaultMaxFileSize is the size in bytes above which the journal file is
+// rotated. The journal takes at most twice as much disk space.
+const DefaultMaxFileSize = 64 * 1024 * 1024
+
+// Types of recorded events.
+const (
+	RelayEntryRequested   = "RelayEntryRequested"
+	RelayEntrySubmitted   = "RelayEntrySubmitted"
+	GroupSelectionStarted = "GroupSelectionStarted"
+	GroupRegistered       = "GroupRegistered"
+	DKGResultSubmitted    = "DKGResultSubmitted"
+)
+
+// maxRecordSize is the maximum size of a single record in bytes. Records are
+// far smaller; the limit only guards against reading a corrupted file.
+const maxRecordSize = 1024 * 1024
+
+// Record is a single event recorded in the journal.
+type Record struct {
+	Type            string          `json:"type"`
+	BlockNumber     uint64          `json:"blockNumber"`
+	TransactionHash string          `json:"transactionHash,omitempty"`
+	Event           json.RawMessage `json:"event"`
+}
+
+// newRecord creates a record of the given event.
+func newRecord(
+	eventType string,
+	blockNumber uint64,
+	transactionHash string,
+	event interface{},
+) (*Record, error) {
+	marshalled, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal event: [%v]", err)
+	}
+
+	return &Record{
+		Type:            eventType,
+		BlockNumber:     blockNumber,
+		TransactionHash: transactionHash,
+		Event:           marshalled,
+	}, nil
+}
+
+// Journal is an append-only journal file. It is safe for concurrent use.
+type Journal struct {
+	dataDir     string
+	maxFileSize int64
+
+	mutex    sync.Mutex
+	file     *os.File
+	fileSize int64
+}
+
+// Open opens the journal in the given data directory for appending. The
+// journal file is created if it does not exist yet and rotated once it grows
+// over DefaultMaxFileSize.
+func Open(dataDir string) (*Journal, error) {
+	journal := &Journal{
+		dataDir:     dataDir,
+		maxFileSize: DefaultMaxFileSize,
+	}
+
+	if err := journal.openFile(); err != nil {
+		return nil, err
+	}
+
+	return journal, nil
+}
+
+func (j *Journal) openFile() error {
+	file, err := os.OpenFile(
+		filepath.Join(j.dataDir, FileName),
+		os.O_APPEND|os.O_CREATE|os.O_WRONLY,
+		0600,
+	)
+	if err != nil {
+		return fmt.Errorf("could not open journal file: [%v]", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("could not check journal file: [%v]", err)
+	}
+
+	j.file = file
+	j.fileSize = info.Size()
+
+	return nil
+}
+
+// Append writes the record at the end of the journal. The record is synced
+// to the disk before Append returns. The journal file is rotated before the
+// record is written if it has grown over the maximum size.
+func (j *Journal) Append(record *Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("could not marshal record: [%v]", err)
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if j.fileSize >= j.maxFileSize {
+		if err := j.rotate(); err != nil {
+			return err
+		}
+	}
+
+	written, err := j.file.Write(append(line, '\n'))
+	j.fileSize += int64(written)
+	if err != nil {
+		return fmt.Errorf("could not write record: [%v]", err)
+	}
+
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("could not sync journal file: [%v]", err)
+	}
+
+	return nil
+}
+
+// rotate replaces the rotated journal file with the journal file and opens
+// a new journal file.
+func (j *Journal) rotate() error {
+	if err := j.file.Close(); err != nil {
+		return fmt.Errorf("could not close journal file: [%v]", err)
+	}
+
+	if err := os.Rename(
+		filepath.Join(j.dataDir, FileName),
+		filepath.Join(j.dataDir, RotatedFileName),
+	); err != nil {
+		// The journal file is opened again so that records are still
+		// appended, even though it is not rotated.
+		if openErr := j.openFile(); openErr != nil {
+			return openErr
+		}
+		return fmt.Errorf("could not rotate journal file: [%v]", err)
+	}
+
+	logger.Infof(
+		"journal file rotated after reaching [%v] bytes",
+		j.fileSize,
+	)
+
+	return j.openFile()
+}
+
+// Close closes the journal file.
+func (j *Journal) Close() error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	return j.file.Close()
+}
+
+// Read reads all records of the journal in the order they were appended.
+// A truncated last record, left by a client stopped while appending it, is
+// skipped.
+func Read(reader io.Reader) ([]*Record, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+
+	records := make([]*Record, 0)
+	var lastErr error
+	for line := 1; scanner.Scan(); line++ {
+		if lastErr != nil {
+			return nil, lastErr
+		}
+
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		record := &Record{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			lastErr = fmt.Errorf(
+				"could not unmarshal record at line [%v]: [%v]",
+				line,
+				err,
+			)
+			continue
+		}
+
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read journal: [%v]", err)
+	}
+
+	if lastErr != nil {
+		logger.Warningf("skipping truncated last record: [%v]", lastErr)
+	}
+
+	return records, nil
+}
+
+// ReadDataDir reads all records of the journal in the given data directory:
+// records of the rotated journal file, if there is one, followed by records
+// of the journal file.
+func ReadDataDir(dataDir string) ([]*Record, error) {
+	records := make([]*Record, 0)
+
+	for _, fileName := range []string{RotatedFileName, FileName} {
+		path := filepath.Join(dataDir, fileName)
+		if _, err := os.Stat(path); os.IsNotExist(err) && fileName == RotatedFileName {
+			continue
+		}
+
+		fileRecords, err := ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, fileRecords...)
+	}
+
+	return records, nil
+}
+
+// ReadFile reads all records of the journal file at the given path.
+func ReadFile(path string) ([]*Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open journal file: [%v]", err)
+	}
+	defer file.Close()
+
+	return Read(file)
+}
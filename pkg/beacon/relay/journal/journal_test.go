@@ -0,0 +1,160 @@
+package journal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "journal-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	records := []*Record{
+		{
+			Type:            RelayEntryRequested,
+			BlockNumber:     100,
+			TransactionHash: "0x01",
+			Event:           []byte(`{"BlockNumber":100}`),
+		},
+		{
+			Type:        GroupRegistered,
+			BlockNumber: 105,
+			Event:       []byte(`{"BlockNumber":105}`),
+		},
+	}
+
+	// The journal is opened twice to check records are appended to the
+	// existing file.
+	for _, record := range records {
+		journal, err := Open(dataDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := journal.Append(record); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := journal.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	readRecords, err := ReadFile(filepath.Join(dataDir, FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(records, readRecords) {
+		t.Errorf(
+			"unexpected records\nexpected: [%v]\nactual:   [%v]",
+			records,
+			readRecords,
+		)
+	}
+}
+
+func TestRead(t *testing.T) {
+	var tests = map[string]struct {
+		journal         string
+		expectedRecords int
+		expectedError   error
+	}{
+		"all records are read": {
+			journal: `{"type":"RelayEntryRequested","blockNumber":1,"event":{}}
+{"type":"RelayEntrySubmitted","blockNumber":2,"event":{}}
+`,
+			expectedRecords: 2,
+		},
+		"truncated last record is skipped": {
+			journal: `{"type":"RelayEntryRequested","blockNumber":1,"event":{}}
+{"type":"RelayEntrySubm`,
+			expectedRecords: 1,
+		},
+		"corrupted record is reported": {
+			journal: `{"type":"RelayEntryRequested","blockNumber":1,"event":{}}
+{"type":"RelayEntrySubm
+{"type":"RelayEntrySubmitted","blockNumber":2,"event":{}}
+`,
+			expectedError: fmt.Errorf(
+				"could not unmarshal record at line [2]: " +
+					"[unexpected end of JSON input]",
+			),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			records, err := Read(strings.NewReader(test.journal))
+			if !reflect.DeepEqual(test.expectedError, err) {
+				t.Errorf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+
+			if len(records) != test.expectedRecords {
+				t.Errorf(
+					"unexpected number of records\nexpected: [%v]\nactual:   [%v]",
+					test.expectedRecords,
+					len(records),
+				)
+			}
+		})
+	}
+}
+
+func TestAppendRotatesJournal(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "journal-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	journal, err := Open(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer journal.Close()
+
+	// Every record takes more than the maximum size, so the journal is
+	// rotated before every record but the first one.
+	journal.maxFileSize = 10
+
+	records := make([]*Record, 0)
+	for blockNumber := uint64(100); blockNumber < 103; blockNumber++ {
+		record := &Record{
+			Type:        GroupRegistered,
+			BlockNumber: blockNumber,
+			Event:       []byte(fmt.Sprintf(`{"BlockNumber":%v}`, blockNumber)),
+		}
+		records = append(records, record)
+
+		if err := journal.Append(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	readRecords, err := ReadDataDir(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first record has been rotated out twice.
+	if !reflect.DeepEqual(records[1:], readRecords) {
+		t.Errorf(
+			"unexpected records\nexpected: [%v]\nactual:   [%v]",
+			records[1:],
+			readRecords,
+		)
+	}
+}
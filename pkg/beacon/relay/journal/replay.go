@@ -0,0 +1,324 @@
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+// ReplayChain is a relay chain passing recorded events to handlers of relay
+// events recorded in the journal instead of events of the wrapped chain. The
+// current relay request is the last replayed one until a relay entry
+// submission is replayed. All other calls are passed to the wrapped chain.
+type ReplayChain struct {
+	relaychain.Interface
+
+	handlerMutex                  sync.Mutex
+	currentRequest                *event.Request
+	relayEntryRequestedHandlers   handlers
+	relayEntrySubmittedHandlers   handlers
+	groupSelectionStartedHandlers handlers
+	groupRegisteredHandlers       handlers
+	dkgResultSubmittedHandlers    handlers
+}
+
+// handlers holds handlers of a single event type in the order they have been
+// registered in, so that every replay calls them in the same order. Handlers
+// are guarded by the handler mutex of the replay chain.
+type handlers struct {
+	lastID  int
+	entries []handlerEntry
+}
+
+type handlerEntry struct {
+	id      int
+	handler interface{}
+}
+
+// add registers the handler and returns its identifier.
+func (h *handlers) add(handler interface{}) int {
+	h.lastID++
+	h.entries = append(h.entries, handlerEntry{h.lastID, handler})
+	return h.lastID
+}
+
+// remove unregisters the handler with the given identifier.
+func (h *handlers) remove(id int) {
+	for i, entry := range h.entries {
+		if entry.id == id {
+			h.entries = append(h.entries[:i], h.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+// NewReplayChain creates a replay chain wrapping the given relay chain.
+func NewReplayChain(relayChain relaychain.Interface) *ReplayChain {
+	return &ReplayChain{Interface: relayChain}
+}
+
+// subscribe registers the handler with the given handlers of an event type.
+func (rc *ReplayChain) subscribe(
+	eventHandlers *handlers,
+	handler interface{},
+) subscription.EventSubscription {
+	rc.handlerMutex.Lock()
+	defer rc.handlerMutex.Unlock()
+
+	handlerID := eventHandlers.add(handler)
+
+	return subscription.NewEventSubscription(func() {
+		rc.handlerMutex.Lock()
+		defer rc.handlerMutex.Unlock()
+
+		eventHandlers.remove(handlerID)
+	})
+}
+
+// Replay passes recorded events to the registered handlers, ordered by the
+// block they have been recorded at. The first event is replayed at the
+// current block and every following one the same number of blocks later as it
+// has been recorded after the first one. Block numbers of replayed events are
+// set to the blocks they are replayed at. Handlers of a single event are
+// called one after another and the next event is not replayed until all of
+// them return.
+//
+// Replay returns once all events are replayed or the context is done.
+func (rc *ReplayChain) Replay(
+	ctx context.Context,
+	records []*Record,
+	blockCounter chain.BlockCounter,
+) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	sorted := make([]*Record, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].BlockNumber < sorted[j].BlockNumber
+	})
+
+	startBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return fmt.Errorf("could not get current block: [%v]", err)
+	}
+
+	firstRecordedBlock := sorted[0].BlockNumber
+
+	for _, record := range sorted {
+		replayBlock := startBlock + record.BlockNumber - firstRecordedBlock
+
+		waiter, err := blockCounter.BlockHeightWaiter(replayBlock)
+		if err != nil {
+			return fmt.Errorf(
+				"could not wait for block [%v]: [%v]",
+				replayBlock,
+				err,
+			)
+		}
+
+		select {
+		case <-waiter:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		logger.Infof(
+			"replaying [%v] event recorded at block [%v] with "+
+				"transaction [%v] at block [%v]",
+			record.Type,
+			record.BlockNumber,
+			record.TransactionHash,
+			replayBlock,
+		)
+
+		if err := rc.replay(record, replayBlock); err != nil {
+			return fmt.Errorf(
+				"could not replay [%v] event recorded at block [%v]: [%v]",
+				record.Type,
+				record.BlockNumber,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+// replay passes the recorded event to its handlers as emitted at the given
+// block.
+func (rc *ReplayChain) replay(record *Record, blockNumber uint64) error {
+	// Handlers are called without the lock held so that they can register
+	// and unregister handlers.
+	calls := make([]func(), 0)
+
+	rc.handlerMutex.Lock()
+	switch record.Type {
+	case RelayEntryRequested:
+		request := &event.Request{}
+		if err := json.Unmarshal(record.Event, request); err != nil {
+			rc.handlerMutex.Unlock()
+			return err
+		}
+		request.BlockNumber = blockNumber
+		rc.currentRequest = request
+
+		for _, registered := range rc.relayEntryRequestedHandlers.entries {
+			handler := registered.handler.(func(request *event.Request))
+			calls = append(calls, func() { handler(request) })
+		}
+	case RelayEntrySubmitted:
+		entry := &event.EntrySubmitted{}
+		if err := json.Unmarshal(record.Event, entry); err != nil {
+			rc.handlerMutex.Unlock()
+			return err
+		}
+		entry.BlockNumber = blockNumber
+		rc.currentRequest = nil
+
+		for _, registered := range rc.relayEntrySubmittedHandlers.entries {
+			handler := registered.handler.(func(entry *event.EntrySubmitted))
+			calls = append(calls, func() { handler(entry) })
+		}
+	case GroupSelectionStarted:
+		groupSelectionStart := &event.GroupSelectionStart{}
+		if err := json.Unmarshal(record.Event, groupSelectionStart); err != nil {
+			rc.handlerMutex.Unlock()
+			return err
+		}
+		groupSelectionStart.BlockNumber = blockNumber
+
+		for _, registered := range rc.groupSelectionStartedHandlers.entries {
+			handler := registered.handler.(func(groupSelectionStart *event.GroupSelectionStart))
+			calls = append(calls, func() { handler(groupSelectionStart) })
+		}
+	case GroupRegistered:
+		groupRegistration := &event.GroupRegistration{}
+		if err := json.Unmarshal(record.Event, groupRegistration); err != nil {
+			rc.handlerMutex.Unlock()
+			return err
+		}
+		groupRegistration.BlockNumber = blockNumber
+
+		for _, registered := range rc.groupRegisteredHandlers.entries {
+			handler := registered.handler.(func(groupRegistration *event.GroupRegistration))
+			calls = append(calls, func() { handler(groupRegistration) })
+		}
+	case DKGResultSubmitted:
+		dkgResultSubmission := &event.DKGResultSubmission{}
+		if err := json.Unmarshal(record.Event, dkgResultSubmission); err != nil {
+			rc.handlerMutex.Unlock()
+			return err
+		}
+		dkgResultSubmission.BlockNumber = blockNumber
+
+		for _, registered := range rc.dkgResultSubmittedHandlers.entries {
+			handler := registered.handler.(func(submission *event.DKGResultSubmission))
+			calls = append(calls, func() { handler(dkgResultSubmission) })
+		}
+	default:
+		logger.Warningf("skipping event of unknown type [%v]", record.Type)
+	}
+	rc.handlerMutex.Unlock()
+
+	for _, call := range calls {
+		call()
+	}
+
+	return nil
+}
+
+// IsEntryInProgress checks if a replayed relay request has not been followed
+// by a replayed relay entry submission yet.
+func (rc *ReplayChain) IsEntryInProgress() (bool, error) {
+	rc.handlerMutex.Lock()
+	defer rc.handlerMutex.Unlock()
+
+	return rc.currentRequest != nil, nil
+}
+
+// CurrentRequestStartBlock returns the block at which the current relay
+// request has been replayed or zero if there is no relay request in progress.
+func (rc *ReplayChain) CurrentRequestStartBlock() (*big.Int, error) {
+	rc.handlerMutex.Lock()
+	defer rc.handlerMutex.Unlock()
+
+	if rc.currentRequest == nil {
+		return big.NewInt(0), nil
+	}
+
+	return new(big.Int).SetUint64(rc.currentRequest.BlockNumber), nil
+}
+
+// CurrentRequestPreviousEntry returns the previous entry of the current relay
+// request or nil if there is no relay request in progress.
+func (rc *ReplayChain) CurrentRequestPreviousEntry() ([]byte, error) {
+	rc.handlerMutex.Lock()
+	defer rc.handlerMutex.Unlock()
+
+	if rc.currentRequest == nil {
+		return nil, nil
+	}
+
+	return rc.currentRequest.PreviousEntry, nil
+}
+
+// CurrentRequestGroupPublicKey returns the public key of the group serving
+// the current relay request or nil if there is no relay request in progress.
+func (rc *ReplayChain) CurrentRequestGroupPublicKey() ([]byte, error) {
+	rc.handlerMutex.Lock()
+	defer rc.handlerMutex.Unlock()
+
+	if rc.currentRequest == nil {
+		return nil, nil
+	}
+
+	return rc.currentRequest.GroupPublicKey, nil
+}
+
+// OnRelayEntryRequested registers a handler of recorded relay requests.
+func (rc *ReplayChain) OnRelayEntryRequested(
+	handler func(request *event.Request),
+) (subscription.EventSubscription, error) {
+	return rc.subscribe(&rc.relayEntryRequestedHandlers, handler), nil
+}
+
+// OnRelayEntrySubmitted registers a handler of recorded relay entry
+// submissions.
+func (rc *ReplayChain) OnRelayEntrySubmitted(
+	handler func(entry *event.EntrySubmitted),
+) (subscription.EventSubscription, error) {
+	return rc.subscribe(&rc.relayEntrySubmittedHandlers, handler), nil
+}
+
+// OnGroupSelectionStarted registers a handler of recorded group selection
+// starts.
+func (rc *ReplayChain) OnGroupSelectionStarted(
+	handler func(groupSelectionStart *event.GroupSelectionStart),
+) (subscription.EventSubscription, error) {
+	return rc.subscribe(&rc.groupSelectionStartedHandlers, handler), nil
+}
+
+// OnGroupRegistered registers a handler of recorded group registrations.
+func (rc *ReplayChain) OnGroupRegistered(
+	handler func(groupRegistration *event.GroupRegistration),
+) (subscription.EventSubscription, error) {
+	return rc.subscribe(&rc.groupRegisteredHandlers, handler), nil
+}
+
+// OnDKGResultSubmitted registers a handler of recorded DKG result
+// submissions.
+func (rc *ReplayChain) OnDKGResultSubmitted(
+	handler func(submission *event.DKGResultSubmission),
+) (subscription.EventSubscription, error) {
+	return rc.subscribe(&rc.dkgResultSubmittedHandlers, handler), nil
+}
@@ -0,0 +1,307 @@
+package journal
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain/local"
+)
+
+func TestReplayIsRecordedByWatch(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "journal-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	events := []struct {
+		eventType       string
+		blockNumber     uint64
+		transactionHash string
+		event           interface{}
+	}{
+		{
+			GroupSelectionStarted,
+			1000,
+			"0x01",
+			&event.GroupSelectionStart{
+				NewEntry:        big.NewInt(1),
+				BlockNumber:     1000,
+				TransactionHash: "0x01",
+			},
+		},
+		{
+			DKGResultSubmitted,
+			1002,
+			"0x02",
+			&event.DKGResultSubmission{
+				MemberIndex:     1,
+				GroupPublicKey:  []byte{0x02},
+				BlockNumber:     1002,
+				TransactionHash: "0x02",
+			},
+		},
+		{
+			GroupRegistered,
+			1002,
+			"0x02",
+			&event.GroupRegistration{
+				GroupPublicKey:  []byte{0x02},
+				BlockNumber:     1002,
+				TransactionHash: "0x02",
+			},
+		},
+		{
+			RelayEntryRequested,
+			1003,
+			"0x03",
+			&event.Request{
+				PreviousEntry:   []byte{0x03},
+				GroupPublicKey:  []byte{0x02},
+				BlockNumber:     1003,
+				TransactionHash: "0x03",
+			},
+		},
+		{
+			RelayEntrySubmitted,
+			1004,
+			"0x04",
+			&event.EntrySubmitted{
+				BlockNumber:     1004,
+				TransactionHash: "0x04",
+			},
+		},
+	}
+
+	records := make([]*Record, 0)
+	for _, recorded := range events {
+		record, err := newRecord(
+			recorded.eventType,
+			recorded.blockNumber,
+			recorded.transactionHash,
+			recorded.event,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+
+	// Records are replayed ordered by their blocks, not by the order they
+	// have been recorded in.
+	records[0], records[len(records)-1] = records[len(records)-1], records[0]
+
+	localChain := local.Connect(5, 3, big.NewInt(200))
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayChain := NewReplayChain(localChain.ThresholdRelay())
+
+	journal, err := Open(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer journal.Close()
+
+	subscription, err := Watch(replayChain, journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subscription.Unsubscribe()
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	if err := replayChain.Replay(ctx, records, blockCounter); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed, err := ReadFile(filepath.Join(dataDir, FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(replayed) != len(events) {
+		t.Fatalf(
+			"unexpected number of replayed events\nexpected: [%v]\nactual:   [%v]",
+			len(events),
+			len(replayed),
+		)
+	}
+
+	startBlock := replayed[0].BlockNumber
+	for i, record := range replayed {
+		if record.Type != events[i].eventType {
+			t.Errorf(
+				"unexpected type of event [%v]\nexpected: [%v]\nactual:   [%v]",
+				i,
+				events[i].eventType,
+				record.Type,
+			)
+		}
+
+		if record.TransactionHash != events[i].transactionHash {
+			t.Errorf(
+				"unexpected transaction hash of event [%v]\n"+
+					"expected: [%v]\nactual:   [%v]",
+				i,
+				events[i].transactionHash,
+				record.TransactionHash,
+			)
+		}
+
+		expectedOffset := events[i].blockNumber - events[0].blockNumber
+		if record.BlockNumber-startBlock != expectedOffset {
+			t.Errorf(
+				"unexpected block offset of event [%v]\n"+
+					"expected: [%v]\nactual:   [%v]",
+				i,
+				expectedOffset,
+				record.BlockNumber-startBlock,
+			)
+		}
+	}
+}
+
+func TestReplayTracksCurrentRequest(t *testing.T) {
+	request := &event.Request{
+		PreviousEntry:  []byte{0x01},
+		GroupPublicKey: []byte{0x02},
+		BlockNumber:    1000,
+	}
+
+	record, err := newRecord(RelayEntryRequested, 1000, "", request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localChain := local.Connect(5, 3, big.NewInt(200))
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayChain := NewReplayChain(localChain.ThresholdRelay())
+
+	replayedBlocks := make(chan uint64, 1)
+	_, err = replayChain.OnRelayEntryRequested(func(request *event.Request) {
+		replayedBlocks <- request.BlockNumber
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	isEntryInProgress, err := replayChain.IsEntryInProgress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isEntryInProgress {
+		t.Errorf("entry should not be in progress before the replay")
+	}
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	if err := replayChain.Replay(ctx, []*Record{record}, blockCounter); err != nil {
+		t.Fatal(err)
+	}
+
+	replayedBlock := <-replayedBlocks
+
+	isEntryInProgress, err = replayChain.IsEntryInProgress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isEntryInProgress {
+		t.Errorf("entry should be in progress after the replayed request")
+	}
+
+	startBlock, err := replayChain.CurrentRequestStartBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if startBlock.Uint64() != replayedBlock {
+		t.Errorf(
+			"unexpected current request start block\n"+
+				"expected: [%v]\nactual:   [%v]",
+			replayedBlock,
+			startBlock,
+		)
+	}
+
+	previousEntry, err := replayChain.CurrentRequestPreviousEntry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(previousEntry, request.PreviousEntry) {
+		t.Errorf(
+			"unexpected current request previous entry\n"+
+				"expected: [%x]\nactual:   [%x]",
+			request.PreviousEntry,
+			previousEntry,
+		)
+	}
+}
+
+func TestReplayCallsHandlersInRegistrationOrder(t *testing.T) {
+	record, err := newRecord(
+		GroupRegistered,
+		1000,
+		"",
+		&event.GroupRegistration{GroupPublicKey: []byte{0x01}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localChain := local.Connect(5, 3, big.NewInt(200))
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayChain := NewReplayChain(localChain.ThresholdRelay())
+
+	called := make([]int, 0)
+	for i := 0; i < 5; i++ {
+		index := i
+		subscription, err := replayChain.OnGroupRegistered(
+			func(*event.GroupRegistration) {
+				called = append(called, index)
+			},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if index == 2 {
+			subscription.Unsubscribe()
+		}
+	}
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	if err := replayChain.Replay(ctx, []*Record{record}, blockCounter); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []int{0, 1, 3, 4}
+	if !reflect.DeepEqual(expected, called) {
+		t.Errorf(
+			"unexpected order of handlers\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			called,
+		)
+	}
+}
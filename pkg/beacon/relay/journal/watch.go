@@ -0,0 +1,140 @@
+package journal
+
+import (
+	"fmt"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+// Watch subscribes for relay events of the chain and appends every event
+// seen to the journal until the returned subscription is unsubscribed.
+// Events which could not be appended are logged and dropped.
+func Watch(
+	relayChain relaychain.Interface,
+	journal *Journal,
+) (subscription.EventSubscription, error) {
+	subscriptions := make([]subscription.EventSubscription, 0)
+	unsubscribeAll := func() {
+		for _, eventSubscription := range subscriptions {
+			eventSubscription.Unsubscribe()
+		}
+	}
+
+	subscribe := func(
+		eventType string,
+		onEvent func() (subscription.EventSubscription, error),
+	) error {
+		eventSubscription, err := onEvent()
+		if err != nil {
+			unsubscribeAll()
+			return fmt.Errorf(
+				"could not subscribe for [%v] events: [%v]",
+				eventType,
+				err,
+			)
+		}
+
+		subscriptions = append(subscriptions, eventSubscription)
+		return nil
+	}
+
+	recordEvent := func(
+		eventType string,
+		blockNumber uint64,
+		transactionHash string,
+		event interface{},
+	) {
+		record, err := newRecord(eventType, blockNumber, transactionHash, event)
+		if err == nil {
+			err = journal.Append(record)
+		}
+		if err != nil {
+			logger.Errorf(
+				"could not record [%v] event from block [%v]: [%v]",
+				eventType,
+				blockNumber,
+				err,
+			)
+		}
+	}
+
+	err := subscribe(RelayEntryRequested, func() (subscription.EventSubscription, error) {
+		return relayChain.OnRelayEntryRequested(func(request *event.Request) {
+			recordEvent(
+				RelayEntryRequested,
+				request.BlockNumber,
+				request.TransactionHash,
+				request,
+			)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = subscribe(RelayEntrySubmitted, func() (subscription.EventSubscription, error) {
+		return relayChain.OnRelayEntrySubmitted(func(entry *event.EntrySubmitted) {
+			recordEvent(
+				RelayEntrySubmitted,
+				entry.BlockNumber,
+				entry.TransactionHash,
+				entry,
+			)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = subscribe(GroupSelectionStarted, func() (subscription.EventSubscription, error) {
+		return relayChain.OnGroupSelectionStarted(
+			func(groupSelectionStart *event.GroupSelectionStart) {
+				recordEvent(
+					GroupSelectionStarted,
+					groupSelectionStart.BlockNumber,
+					groupSelectionStart.TransactionHash,
+					groupSelectionStart,
+				)
+			},
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = subscribe(GroupRegistered, func() (subscription.EventSubscription, error) {
+		return relayChain.OnGroupRegistered(
+			func(groupRegistration *event.GroupRegistration) {
+				recordEvent(
+					GroupRegistered,
+					groupRegistration.BlockNumber,
+					groupRegistration.TransactionHash,
+					groupRegistration,
+				)
+			},
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = subscribe(DKGResultSubmitted, func() (subscription.EventSubscription, error) {
+		return relayChain.OnDKGResultSubmitted(
+			func(dkgResultSubmission *event.DKGResultSubmission) {
+				recordEvent(
+					DKGResultSubmitted,
+					dkgResultSubmission.BlockNumber,
+					dkgResultSubmission.TransactionHash,
+					dkgResultSubmission,
+				)
+			},
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription.NewEventSubscription(unsubscribeAll), nil
+}
@@ -2,10 +2,12 @@ package relay
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"math/big"
 	"sync"
+	"time"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 	"github.com/keep-network/keep-core/pkg/altbn128"
@@ -16,9 +18,15 @@ import (
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	dkgResult "github.com/keep-network/keep-core/pkg/beacon/relay/dkg/result"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/gjkr"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/groupselection"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/unauthorizedsigning"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/fieldlog"
 	"github.com/keep-network/keep-core/pkg/net"
 )
 
@@ -35,7 +43,21 @@ type Node struct {
 	blockCounter chain.BlockCounter
 	chainConfig  *config.Chain
 
-	groupRegistry *registry.Groups
+	groupRegistry  *registry.Groups
+	dkgCheckpoints *dkg.CheckpointStorage
+
+	// monitoringCtx is set once the node starts monitoring groups for
+	// unauthorized signing and is done when the monitoring should stop.
+	// monitoredGroups holds groups already monitored. Both are guarded by
+	// mutex.
+	monitoringCtx   context.Context
+	monitoredGroups map[string]bool
+
+	// workMutex guards the stopped flag and additions to workInProgress so
+	// that no new work can be started once the node is shutting down.
+	workMutex      sync.Mutex
+	stopped        bool
+	workInProgress sync.WaitGroup
 }
 
 // IsInGroup checks if this node is a member of the group which was selected to
@@ -52,7 +74,10 @@ func (n *Node) IsInGroup(groupPublicKey []byte) bool {
 //
 // Indirectly, the completion of the process is signaled by the formation of an
 // on-chain group containing at least one of this node's virtual stakers.
+//
+// The distributed key generation is abandoned once the context is done.
 func (n *Node) JoinGroupIfEligible(
+	ctx context.Context,
 	relayChain relaychain.Interface,
 	signing chain.Signing,
 	groupSelectionResult *groupselection.Result,
@@ -81,9 +106,17 @@ func (n *Node) JoinGroupIfEligible(
 	channelName := newEntry.Text(16)
 
 	if len(indexes) > 0 {
+		dkgLogger := fieldlog.New(
+			loggerSubsystem,
+			fieldlog.Fields{
+				fieldlog.Channel: channelName,
+				fieldlog.Seed:    fmt.Sprintf("0x%x", newEntry),
+			},
+		)
+
 		broadcastChannel, err := n.netProvider.BroadcastChannelFor(channelName)
 		if err != nil {
-			logger.Errorf("failed to get broadcast channel: [%v]", err)
+			dkgLogger.Errorf("failed to get broadcast channel: [%v]", err)
 			return
 		}
 
@@ -94,50 +127,41 @@ func (n *Node) JoinGroupIfEligible(
 
 		err = broadcastChannel.SetFilter(membershipValidator.IsInGroup)
 		if err != nil {
-			logger.Errorf(
-				"could not set filter for channel [%v]: [%v]",
-				broadcastChannel.Name(),
-				err,
-			)
+			dkgLogger.Errorf("could not set filter for channel: [%v]", err)
 		}
 
 		for _, index := range indexes {
 			// capture player index for goroutine
 			playerIndex := index
 
+			if !n.beginWork() {
+				dkgLogger.Warningf("node is shutting down; not joining group")
+				return
+			}
+
 			go func() {
+				defer n.workInProgress.Done()
+
 				signer, err := dkg.ExecuteDKG(
+					ctx,
 					newEntry,
 					playerIndex,
 					n.chainConfig.GroupSize,
 					n.chainConfig.DishonestThreshold(),
-					membershipValidator,
+					groupSelectionResult.SelectedStakers,
 					dkgStartBlockHeight,
 					n.blockCounter,
 					relayChain,
 					signing,
 					broadcastChannel,
+					n.dkgCheckpoints,
 				)
 				if err != nil {
-					logger.Errorf("failed to execute dkg: [%v]", err)
+					dkgLogger.Errorf("failed to execute dkg: [%v]", err)
 					return
 				}
 
-				// final broadcast channel name for group is the compressed
-				// public key of the group
-				channelName := hex.EncodeToString(
-					signer.GroupPublicKeyBytesCompressed(),
-				)
-
-				err = n.groupRegistry.RegisterGroup(signer, channelName)
-				if err != nil {
-					logger.Errorf("failed to register a group: [%v]", err)
-				}
-
-				logger.Infof(
-					"[member:%v] ready to operate in the group",
-					signer.MemberID(),
-				)
+				n.registerGroup(relayChain, signer, dkgLogger)
 			}()
 		}
 	}
@@ -145,6 +169,375 @@ func (n *Node) JoinGroupIfEligible(
 	return
 }
 
+// ResumeDKGIfEligible enables a client to rejoin the distributed key
+// generation processes it was executing when it crashed or was restarted.
+// The distributed key generation is resumed from the last checkpoint if the
+// protocol has not timed out yet. Checkpoints of distributed key generations
+// which cannot be resumed anymore are archived.
+//
+// A resumed distributed key generation is abandoned once the context returned
+// by seedContext for the seed of its group selection is done.
+func (n *Node) ResumeDKGIfEligible(
+	relayChain relaychain.Interface,
+	signing chain.Signing,
+	seedContext func(seed *big.Int) context.Context,
+) {
+	if n.dkgCheckpoints == nil {
+		return
+	}
+
+	currentBlock, err := n.blockCounter.CurrentBlock()
+	if err != nil {
+		logger.Errorf("failed to get the current block: [%v]", err)
+		return
+	}
+
+	checkpoints, errors := n.dkgCheckpoints.ReadAll()
+
+	var resumable []*dkg.Checkpoint
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for checkpoint := range checkpoints {
+			resumable = append(resumable, checkpoint)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for err := range errors {
+			logger.Errorf("could not read DKG checkpoint: [%v]", err)
+		}
+	}()
+
+	wg.Wait()
+
+	for _, checkpoint := range resumable {
+		n.resumeDKG(relayChain, signing, seedContext, checkpoint, currentBlock)
+	}
+}
+
+func (n *Node) resumeDKG(
+	relayChain relaychain.Interface,
+	signing chain.Signing,
+	seedContext func(seed *big.Int) context.Context,
+	checkpoint *dkg.Checkpoint,
+	currentBlock uint64,
+) {
+	channelName := checkpoint.Seed.Text(16)
+
+	dkgLogger := fieldlog.New(
+		loggerSubsystem,
+		fieldlog.Fields{
+			fieldlog.Channel:     channelName,
+			fieldlog.Seed:        fmt.Sprintf("0x%x", checkpoint.Seed),
+			fieldlog.MemberIndex: group.MemberIndex(checkpoint.Index + 1),
+		},
+	)
+
+	// The distributed key generation, including the result publication,
+	// must complete within this block. Once it is reached, there is
+	// nothing to resume.
+	deadlineBlock := checkpoint.StartBlockHeight + n.dkgBlocks()
+
+	if currentBlock >= deadlineBlock {
+		dkgLogger.Warningf(
+			"DKG started at block [%v] timed out at block [%v]; "+
+				"archiving its checkpoint",
+			checkpoint.StartBlockHeight,
+			deadlineBlock,
+		)
+		if err := n.dkgCheckpoints.Archive(checkpoint); err != nil {
+			dkgLogger.Errorf("could not archive DKG checkpoint: [%v]", err)
+		}
+		return
+	}
+
+	broadcastChannel, err := n.netProvider.BroadcastChannelFor(channelName)
+	if err != nil {
+		dkgLogger.Errorf("failed to get broadcast channel: [%v]", err)
+		return
+	}
+
+	membershipValidator := group.NewStakersMembershipValidator(
+		checkpoint.SelectedStakers,
+		signing,
+	)
+
+	err = broadcastChannel.SetFilter(membershipValidator.IsInGroup)
+	if err != nil {
+		dkgLogger.Errorf("could not set filter for channel: [%v]", err)
+	}
+
+	if !n.beginWork() {
+		dkgLogger.Warningf("node is shutting down; not resuming DKG")
+		return
+	}
+
+	dkgLogger.Infof(
+		"attempting to resume DKG started at block [%v]",
+		checkpoint.StartBlockHeight,
+	)
+
+	ctx := seedContext(checkpoint.Seed)
+
+	go func() {
+		defer n.workInProgress.Done()
+
+		signer, err := dkg.ResumeDKG(
+			ctx,
+			checkpoint,
+			n.blockCounter,
+			relayChain,
+			signing,
+			broadcastChannel,
+			n.dkgCheckpoints,
+		)
+		if err != nil {
+			dkgLogger.Errorf("failed to resume dkg: [%v]", err)
+			return
+		}
+
+		n.registerGroup(relayChain, signer, dkgLogger)
+	}()
+}
+
+// registerGroup registers the group created in the distributed key
+// generation in the group registry.
+func (n *Node) registerGroup(
+	relayChain relaychain.Interface,
+	signer *dkg.ThresholdSigner,
+	dkgLogger *fieldlog.Logger,
+) {
+	memberLogger := dkgLogger.With(fieldlog.Fields{
+		fieldlog.MemberIndex: signer.MemberID(),
+		fieldlog.GroupPublicKey: fmt.Sprintf(
+			"0x%x",
+			signer.GroupPublicKeyBytes(),
+		),
+	})
+
+	// final broadcast channel name for group is the compressed
+	// public key of the group
+	channelName := hex.EncodeToString(
+		signer.GroupPublicKeyBytesCompressed(),
+	)
+
+	err := n.groupRegistry.RegisterGroup(signer, channelName)
+	if err != nil {
+		memberLogger.Errorf("failed to register a group: [%v]", err)
+	}
+
+	memberLogger.Infof("ready to operate in the group")
+
+	n.monitorGroup(relayChain, signer)
+}
+
+// MonitorUnauthorizedSigning makes the node watch broadcast channels of all
+// groups it is a member of for signatures over the operator's address made
+// with group private key shares. Leaks of group private keys are reported to
+// the chain. Groups the node joins later are monitored as well. Monitoring
+// stops when the context is done.
+func (n *Node) MonitorUnauthorizedSigning(
+	ctx context.Context,
+	relayChain relaychain.Interface,
+) {
+	n.mutex.Lock()
+	n.monitoringCtx = ctx
+	n.mutex.Unlock()
+
+	for _, memberships := range n.groupRegistry.GetGroups() {
+		if len(memberships) == 0 {
+			continue
+		}
+
+		n.monitorGroup(relayChain, memberships[0].Signer)
+	}
+}
+
+// monitorGroup starts monitoring the group of the given signer for
+// unauthorized signing unless monitoring is not enabled or the group is
+// already monitored. All members of the group controlled by this node share
+// the group public key shares, so one monitor per group is enough.
+func (n *Node) monitorGroup(
+	relayChain relaychain.Interface,
+	signer *dkg.ThresholdSigner,
+) {
+	groupPublicKey := hex.EncodeToString(signer.GroupPublicKeyBytes())
+
+	n.mutex.Lock()
+	ctx := n.monitoringCtx
+	if ctx == nil || n.monitoredGroups[groupPublicKey] {
+		n.mutex.Unlock()
+		return
+	}
+	if n.monitoredGroups == nil {
+		n.monitoredGroups = make(map[string]bool)
+	}
+	n.monitoredGroups[groupPublicKey] = true
+	n.mutex.Unlock()
+
+	channelName := hex.EncodeToString(signer.GroupPublicKeyBytesCompressed())
+
+	monitorLogger := fieldlog.New(
+		loggerSubsystem,
+		fieldlog.Fields{
+			fieldlog.Channel:        channelName,
+			fieldlog.GroupPublicKey: "0x" + groupPublicKey,
+		},
+	)
+
+	channel, err := n.netProvider.BroadcastChannelFor(channelName)
+	if err != nil {
+		monitorLogger.Errorf("could not create broadcast channel: [%v]", err)
+		return
+	}
+
+	entry.RegisterUnmarshallers(channel)
+
+	go func() {
+		err := unauthorizedsigning.Monitor(
+			ctx,
+			channel,
+			relayChain,
+			signer,
+			n.chainConfig.HonestThreshold,
+			n.Staker.Address(),
+		)
+		if err != nil {
+			monitorLogger.Errorf(
+				"unauthorized signing monitoring failed: [%v]",
+				err,
+			)
+		}
+	}()
+}
+
+// Shutdown stops the node from accepting new relay entry signing and
+// distributed key generation work and waits for the processes already in
+// progress to complete. The processes are waited for as long as they are
+// allowed to last: a distributed key generation until its result is published
+// and a relay entry signing until the relay entry timeout, counted in blocks
+// from the current block. The wait does not last longer than the given
+// timeout though, so that the node can be stopped in a reasonable time. It
+// returns an error if the processes did not complete in time.
+func (n *Node) Shutdown(timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	// A nil channel never delivers, so only the timeout applies if the
+	// deadline block is not known.
+	var deadlineBlockReached <-chan uint64
+
+	currentBlock, err := n.blockCounter.CurrentBlock()
+	if err != nil {
+		logger.Warningf(
+			"could not get the current block; waiting [%v] for work "+
+				"in progress to complete: [%v]",
+			timeout,
+			err,
+		)
+	} else {
+		deadlineBlock := currentBlock + n.workBlocks()
+
+		deadlineBlockReached, err = n.blockCounter.BlockHeightWaiter(
+			deadlineBlock,
+		)
+		if err != nil {
+			logger.Warningf(
+				"could not wait for block [%v]; waiting [%v] for work "+
+					"in progress to complete: [%v]",
+				deadlineBlock,
+				timeout,
+				err,
+			)
+		} else {
+			logger.Infof(
+				"waiting until block [%v], but no longer than [%v], "+
+					"for work in progress to complete",
+				deadlineBlock,
+				timeout,
+			)
+		}
+	}
+
+	deadline := make(chan struct{})
+	waitDone := make(chan struct{})
+	defer close(waitDone)
+
+	go func() {
+		select {
+		case <-timer.C:
+		case <-deadlineBlockReached:
+		case <-waitDone:
+			return
+		}
+		close(deadline)
+	}()
+
+	if !n.stopAndWait(deadline) {
+		return fmt.Errorf("work in progress did not complete in time")
+	}
+
+	return nil
+}
+
+// stopAndWait stops the node from accepting new work and waits for the work
+// in progress to complete until the deadline channel is closed. It returns
+// true if all work completed.
+func (n *Node) stopAndWait(deadline <-chan struct{}) bool {
+	n.workMutex.Lock()
+	n.stopped = true
+	n.workMutex.Unlock()
+
+	workDone := make(chan struct{})
+	go func() {
+		n.workInProgress.Wait()
+		close(workDone)
+	}()
+
+	select {
+	case <-workDone:
+		return true
+	case <-deadline:
+		return false
+	}
+}
+
+// dkgBlocks returns the number of blocks the distributed key generation,
+// including the result publication, takes at most.
+func (n *Node) dkgBlocks() uint64 {
+	return gjkr.ProtocolBlocks() +
+		dkgResult.PrePublicationBlocks() +
+		uint64(n.chainConfig.GroupSize)*n.chainConfig.ResultPublicationBlockStep
+}
+
+// workBlocks returns the number of blocks any relay entry signing or
+// distributed key generation process of the node takes at most.
+func (n *Node) workBlocks() uint64 {
+	if n.chainConfig.RelayEntryTimeout > n.dkgBlocks() {
+		return n.chainConfig.RelayEntryTimeout
+	}
+
+	return n.dkgBlocks()
+}
+
+// beginWork registers a new background process of the node. It returns false
+// if the node is shutting down and the process should not be started.
+func (n *Node) beginWork() bool {
+	n.workMutex.Lock()
+	defer n.workMutex.Unlock()
+
+	if n.stopped {
+		return false
+	}
+
+	n.workInProgress.Add(1)
+	return true
+}
+
 // ForwardSignatureShares enables the ability to forward signature shares
 // messages to other nodes even if this node is not a part of the group which
 // signs the relay entry.
@@ -160,9 +553,12 @@ func (n *Node) ForwardSignatureShares(groupPublicKeyBytes []byte) {
 
 // ResumeSigningIfEligible enables a client to rejoin the ongoing signing process
 // after it was crashed or restarted and if it belongs to the signing group.
+// The signing is abandoned once the context returned by requestContext for
+// the current relay request is done.
 func (n *Node) ResumeSigningIfEligible(
 	relayChain relayChain.Interface,
 	signing chain.Signing,
+	requestContext func(request *event.Request) context.Context,
 ) {
 	isEntryInProgress, err := relayChain.IsEntryInProgress()
 	if err != nil {
@@ -204,6 +600,11 @@ func (n *Node) ResumeSigningIfEligible(
 			groupPublicKey,
 		)
 		n.GenerateRelayEntry(
+			requestContext(&event.Request{
+				PreviousEntry:  previousEntry,
+				BlockNumber:    entryStartBlock.Uint64(),
+				GroupPublicKey: groupPublicKey,
+			}),
 			previousEntry,
 			relayChain,
 			signing,
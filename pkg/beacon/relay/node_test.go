@@ -0,0 +1,60 @@
+package relay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
+	"github.com/keep-network/keep-core/pkg/chain/local"
+)
+
+func TestShutdownWaitsForWorkInProgress(t *testing.T) {
+	node := newShutdownTestNode(t)
+
+	if !node.beginWork() {
+		t.Fatal("expected work to be accepted before shutdown")
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		node.workInProgress.Done()
+	}()
+
+	if err := node.Shutdown(time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	if node.beginWork() {
+		t.Fatal("expected work to be rejected after shutdown")
+	}
+}
+
+func TestShutdownTimesOut(t *testing.T) {
+	node := newShutdownTestNode(t)
+
+	if !node.beginWork() {
+		t.Fatal("expected work to be accepted before shutdown")
+	}
+	defer node.workInProgress.Done()
+
+	// The work is allowed to last many blocks longer than the timeout.
+	if err := node.Shutdown(50 * time.Millisecond); err == nil {
+		t.Fatal("expected shutdown to time out")
+	}
+}
+
+func newShutdownTestNode(t *testing.T) *Node {
+	blockCounter, err := local.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Node{
+		blockCounter: blockCounter,
+		chainConfig: &config.Chain{
+			GroupSize:                  5,
+			RelayEntryTimeout:          24,
+			ResultPublicationBlockStep: 3,
+		},
+	}
+}
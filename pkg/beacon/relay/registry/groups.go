@@ -22,6 +22,8 @@ type Groups struct {
 	relayChain relaychain.GroupRegistrationInterface
 
 	storage storage
+
+	archivedGroupHandlers []func(groupPublicKey []byte, memberships []*Membership)
 }
 
 // Membership represents a member of a group
@@ -77,6 +79,61 @@ func (g *Groups) GetGroup(groupPublicKey []byte) []*Membership {
 	return g.myGroups[groupKeyToString(groupPublicKey)]
 }
 
+// GetGroups returns a snapshot of all groups this client is a member of,
+// keyed by the uncompressed group public key in hexadecimal form.
+func (g *Groups) GetGroups() map[string][]*Membership {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	groups := make(map[string][]*Membership, len(g.myGroups))
+	for groupPublicKey, memberships := range g.myGroups {
+		groups[groupPublicKey] = append([]*Membership{}, memberships...)
+	}
+
+	return groups
+}
+
+// Flush writes all groups this client is a member of to the underlying
+// storage. It returns an error if any of the memberships could not be
+// persisted; the remaining memberships are still written.
+func (g *Groups) Flush() error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	failures := 0
+	for groupPublicKey, memberships := range g.myGroups {
+		for _, membership := range memberships {
+			if err := g.storage.save(membership); err != nil {
+				logger.Errorf(
+					"could not persist membership of group [0x%v]: [%v]",
+					groupPublicKey,
+					err,
+				)
+				failures++
+			}
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("could not persist [%v] memberships", failures)
+	}
+
+	return nil
+}
+
+// OnGroupArchived registers a handler invoked in a separate goroutine
+// whenever a stale group is archived by UnregisterStaleGroups. The handler
+// receives the uncompressed group public key and all memberships this client
+// held in the group.
+func (g *Groups) OnGroupArchived(
+	handler func(groupPublicKey []byte, memberships []*Membership),
+) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.archivedGroupHandlers = append(g.archivedGroupHandlers, handler)
+}
+
 // UnregisterStaleGroups lookup for groups that have been marked as stale
 // on-chain. A stale group is a group that has expired and a certain time passed
 // after the group expiration. This guarantees the group will not be selected to
@@ -132,6 +189,10 @@ func (g *Groups) UnregisterStaleGroups() {
 			)
 
 			delete(g.myGroups, publicKey)
+
+			for _, handler := range g.archivedGroupHandlers {
+				go handler(publicKeyBytes, memberships)
+			}
 		}
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"reflect"
 	"testing"
+	"time"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 	"github.com/keep-network/keep-common/pkg/persistence"
@@ -75,6 +76,60 @@ func TestRegisterGroup(t *testing.T) {
 	}
 }
 
+func TestGetGroups(t *testing.T) {
+	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
+
+	gr := NewGroupRegistry(chain, persistenceMock)
+
+	gr.RegisterGroup(signer1, channelName1)
+	gr.RegisterGroup(signer2, channelName2)
+	gr.RegisterGroup(signer4, channelName2)
+
+	groups := gr.GetGroups()
+
+	if len(groups) != 2 {
+		t.Fatalf(
+			"Unexpected number of groups \nExpected: [%+v]\nActual:   [%+v]",
+			2,
+			len(groups),
+		)
+	}
+
+	memberships := groups[hex.EncodeToString(signer2.GroupPublicKeyBytes())]
+	if len(memberships) != 2 {
+		t.Fatalf(
+			"Unexpected number of group memberships \nExpected: [%+v]\nActual:   [%+v]",
+			2,
+			len(memberships),
+		)
+	}
+}
+
+func TestFlush(t *testing.T) {
+	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
+
+	persistence := &persistenceHandleMock{}
+	gr := NewGroupRegistry(chain, persistence)
+
+	gr.RegisterGroup(signer1, channelName1)
+	gr.RegisterGroup(signer2, channelName2)
+	gr.RegisterGroup(signer4, channelName2)
+
+	persistence.savedMemberships = nil
+
+	if err := gr.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(persistence.savedMemberships) != 3 {
+		t.Fatalf(
+			"Unexpected number of persisted memberships \nExpected: [%+v]\nActual:   [%+v]",
+			3,
+			len(persistence.savedMemberships),
+		)
+	}
+}
+
 func TestLoadGroup(t *testing.T) {
 	chain := chainLocal.Connect(5, 3, big.NewInt(200)).ThresholdRelay()
 	gr := NewGroupRegistry(chain, persistenceMock)
@@ -152,6 +207,57 @@ func TestUnregisterStaleGroups(t *testing.T) {
 
 }
 
+func TestUnregisterStaleGroupsNotifiesArchivedGroupHandlers(t *testing.T) {
+	mockChain := &mockGroupRegistrationInterface{
+		groupsToRemove: [][]byte{},
+	}
+
+	gr := NewGroupRegistry(mockChain, &persistenceHandleMock{})
+
+	gr.RegisterGroup(signer1, channelName1)
+	gr.RegisterGroup(signer2, channelName2)
+	gr.RegisterGroup(signer4, channelName2)
+
+	type archivedGroup struct {
+		groupPublicKey []byte
+		memberships    []*Membership
+	}
+	archivedGroups := make(chan *archivedGroup, 2)
+	gr.OnGroupArchived(func(groupPublicKey []byte, memberships []*Membership) {
+		archivedGroups <- &archivedGroup{groupPublicKey, memberships}
+	})
+
+	mockChain.markAsStale(signer2.GroupPublicKeyBytes())
+
+	gr.UnregisterStaleGroups()
+
+	select {
+	case archived := <-archivedGroups:
+		if !bytes.Equal(signer2.GroupPublicKeyBytes(), archived.groupPublicKey) {
+			t.Errorf(
+				"unexpected archived group\nexpected: [%x]\nactual:   [%x]",
+				signer2.GroupPublicKeyBytes(),
+				archived.groupPublicKey,
+			)
+		}
+		if len(archived.memberships) != 2 {
+			t.Errorf(
+				"unexpected number of memberships\nexpected: [%v]\nactual:   [%v]",
+				2,
+				len(archived.memberships),
+			)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("archived group handler not invoked")
+	}
+
+	select {
+	case archived := <-archivedGroups:
+		t.Errorf("unexpected archived group [%x]", archived.groupPublicKey)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 type mockGroupRegistrationInterface struct {
 	groupsToRemove [][]byte
 }
@@ -181,12 +287,20 @@ func (mgri *mockGroupRegistrationInterface) GetGroupMembers(
 	return nil, nil // no-op
 }
 
+func (mgri *mockGroupRegistrationInterface) PastGroupRegistrations(
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*event.GroupRegistration, error) {
+	panic("not implemented")
+}
+
 type persistenceHandleMock struct {
-	archivedGroups []string
+	archivedGroups   []string
+	savedMemberships []string
 }
 
 func (phm *persistenceHandleMock) Save(data []byte, directory string, name string) error {
-	// noop
+	phm.savedMemberships = append(phm.savedMemberships, directory+name)
 	return nil
 }
 
@@ -209,9 +323,9 @@ func (phm *persistenceHandleMock) ReadAll() (<-chan persistence.DataDescriptor,
 	outputData := make(chan persistence.DataDescriptor, 3)
 	outputErrors := make(chan error)
 
-	outputData <- &testDataDescriptor{"1", "dir", membershipBytes1}
-	outputData <- &testDataDescriptor{"2", "dir", membershipBytes2}
-	outputData <- &testDataDescriptor{"3", "dir", membershipBytes3}
+	outputData <- &testDataDescriptor{"membership_1", "dir", membershipBytes1}
+	outputData <- &testDataDescriptor{"membership_2", "dir", membershipBytes2}
+	outputData <- &testDataDescriptor{"membership_3", "dir", membershipBytes3}
 
 	close(outputData)
 	close(outputErrors)
@@ -2,6 +2,7 @@ package registry
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/keep-network/keep-common/pkg/persistence"
@@ -9,6 +10,10 @@ import (
 	"encoding/hex"
 )
 
+// MembershipFilePrefix is the prefix of names of files holding group
+// memberships in the group directories of the data directory.
+const MembershipFilePrefix = "membership_"
+
 type storage interface {
 	save(membership *Membership) error
 	readAll() (<-chan *Membership, <-chan error)
@@ -33,7 +38,7 @@ func (ps *persistentStorage) save(membership *Membership) error {
 
 	hexGroupPublicKey := hex.EncodeToString(membership.Signer.GroupPublicKeyBytesCompressed())
 
-	return ps.handle.Save(membershipBytes, hexGroupPublicKey, "/membership_"+fmt.Sprint(membership.Signer.MemberID()))
+	return ps.handle.Save(membershipBytes, hexGroupPublicKey, "/"+MembershipFilePrefix+fmt.Sprint(membership.Signer.MemberID()))
 }
 
 func (ps *persistentStorage) archive(groupPublicKeyCompressed []byte) error {
@@ -79,6 +84,14 @@ func (ps *persistentStorage) readAll() (<-chan *Membership, <-chan error) {
 	// error to an output errors channel.
 	go func() {
 		for descriptor := range inputData {
+			// The persistence handle is shared with the distributed key
+			// generation which saves its checkpoints there and the rewards
+			// service which saves postponed withdrawals there; skip all
+			// files which are not memberships.
+			if !strings.HasPrefix(descriptor.Name(), MembershipFilePrefix) {
+				continue
+			}
+
 			content, err := descriptor.Content()
 			if err != nil {
 				outputErrors <- fmt.Errorf(
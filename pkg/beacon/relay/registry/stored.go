@@ -0,0 +1,162 @@
+package registry
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+const (
+	currentDirectory = "current"
+	archiveDirectory = "archive"
+)
+
+// StoredGroup holds all memberships of the client in a single group read from
+// the persistence data directory.
+type StoredGroup struct {
+	Memberships []*Membership
+	// IsArchived is true if the group has been archived once it became stale.
+	IsArchived bool
+}
+
+// ReadStoredGroups reads memberships of all groups persisted in the given data
+// directory, both the current and the archived ones, without modifying the
+// directory. Memberships are decrypted with the given password. The returned
+// map is keyed by group public key in uncompressed form.
+func ReadStoredGroups(
+	dataDir string,
+	password string,
+) (map[string]*StoredGroup, error) {
+	groups := make(map[string]*StoredGroup)
+
+	for _, directory := range []string{currentDirectory, archiveDirectory} {
+		handle := persistence.NewEncryptedPersistence(
+			&directoryReader{path: filepath.Join(dataDir, directory)},
+			password,
+		)
+
+		memberships, errors := newStorage(handle).readAll()
+
+		// Errors are collected in a separate goroutine as the storage
+		// writes to both channels in no particular order.
+		readErrors := make(chan []error)
+		go func() {
+			all := make([]error, 0)
+			for err := range errors {
+				all = append(all, err)
+			}
+			readErrors <- all
+		}()
+
+		for membership := range memberships {
+			groupPublicKey := groupKeyToString(
+				membership.Signer.GroupPublicKeyBytes(),
+			)
+
+			group, ok := groups[groupPublicKey]
+			if !ok {
+				group = &StoredGroup{
+					Memberships: make([]*Membership, 0),
+					IsArchived:  directory == archiveDirectory,
+				}
+				groups[groupPublicKey] = group
+			}
+			group.Memberships = append(group.Memberships, membership)
+		}
+
+		if errs := <-readErrors; len(errs) > 0 {
+			return nil, fmt.Errorf(
+				"could not read memberships from [%v] directory: %v",
+				directory,
+				errs,
+			)
+		}
+	}
+
+	return groups, nil
+}
+
+// directoryReader is a read-only persistence handle reading files from all
+// subdirectories of the given path. A missing path is treated as empty.
+type directoryReader struct {
+	path string
+}
+
+func (dr *directoryReader) Save(data []byte, directory string, name string) error {
+	return fmt.Errorf("directory [%v] is read-only", dr.path)
+}
+
+func (dr *directoryReader) ReadAll() (<-chan persistence.DataDescriptor, <-chan error) {
+	dataChannel := make(chan persistence.DataDescriptor)
+	errorChannel := make(chan error)
+
+	go func() {
+		defer close(dataChannel)
+		defer close(errorChannel)
+
+		directories, err := ioutil.ReadDir(dr.path)
+		if os.IsNotExist(err) {
+			return
+		}
+		if err != nil {
+			errorChannel <- fmt.Errorf(
+				"could not read the directory [%v]: [%v]",
+				dr.path,
+				err,
+			)
+			return
+		}
+
+		for _, directory := range directories {
+			if !directory.IsDir() {
+				continue
+			}
+
+			directoryPath := filepath.Join(dr.path, directory.Name())
+			files, err := ioutil.ReadDir(directoryPath)
+			if err != nil {
+				errorChannel <- fmt.Errorf(
+					"could not read the directory [%v]: [%v]",
+					directoryPath,
+					err,
+				)
+				continue
+			}
+
+			for _, file := range files {
+				dataChannel <- &fileDescriptor{
+					name:      file.Name(),
+					directory: directory.Name(),
+					path:      filepath.Join(directoryPath, file.Name()),
+				}
+			}
+		}
+	}()
+
+	return dataChannel, errorChannel
+}
+
+func (dr *directoryReader) Archive(directory string) error {
+	return fmt.Errorf("directory [%v] is read-only", dr.path)
+}
+
+type fileDescriptor struct {
+	name      string
+	directory string
+	path      string
+}
+
+func (fd *fileDescriptor) Name() string {
+	return fd.name
+}
+
+func (fd *fileDescriptor) Directory() string {
+	return fd.directory
+}
+
+func (fd *fileDescriptor) Content() ([]byte, error) {
+	return ioutil.ReadFile(fd.path)
+}
@@ -0,0 +1,113 @@
+package registry
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+func TestReadStoredGroups(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "stored-groups")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	password := "password"
+
+	handle, err := persistence.NewDiskHandle(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	storage := newStorage(persistence.NewEncryptedPersistence(handle, password))
+
+	for _, membership := range []*Membership{
+		{Signer: signer1, ChannelName: channelName1},
+		{Signer: signer2, ChannelName: channelName2},
+		{Signer: signer4, ChannelName: channelName2},
+	} {
+		if err := storage.save(membership); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := storage.archive(signer1.GroupPublicKeyBytesCompressed()); err != nil {
+		t.Fatal(err)
+	}
+
+	groups, err := ReadStoredGroups(dataDir, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		groupPublicKey      []byte
+		expectedMemberships int
+		expectedArchived    bool
+	}{
+		"archived group": {
+			groupPublicKey:      signer1.GroupPublicKeyBytes(),
+			expectedMemberships: 1,
+			expectedArchived:    true,
+		},
+		"current group": {
+			groupPublicKey:      signer2.GroupPublicKeyBytes(),
+			expectedMemberships: 2,
+			expectedArchived:    false,
+		},
+	}
+
+	if len(groups) != len(tests) {
+		t.Fatalf(
+			"unexpected number of groups\nexpected: [%v]\nactual:   [%v]",
+			len(tests),
+			len(groups),
+		)
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			group, ok := groups[groupKeyToString(test.groupPublicKey)]
+			if !ok {
+				t.Fatalf("group not found")
+			}
+
+			if len(group.Memberships) != test.expectedMemberships {
+				t.Errorf(
+					"unexpected number of memberships\nexpected: [%v]\nactual:   [%v]",
+					test.expectedMemberships,
+					len(group.Memberships),
+				)
+			}
+			if group.IsArchived != test.expectedArchived {
+				t.Errorf(
+					"unexpected archived flag\nexpected: [%v]\nactual:   [%v]",
+					test.expectedArchived,
+					group.IsArchived,
+				)
+			}
+		})
+	}
+}
+
+func TestReadStoredGroupsWithWrongPassword(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "stored-groups")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	handle, err := persistence.NewDiskHandle(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	storage := newStorage(persistence.NewEncryptedPersistence(handle, "password"))
+	if err := storage.save(&Membership{Signer: signer1, ChannelName: channelName1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadStoredGroups(dataDir, "wrong password"); err == nil {
+		t.Fatal("expected an error")
+	}
+}
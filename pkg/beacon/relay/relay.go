@@ -1,6 +1,9 @@
 package relay
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
 
@@ -9,12 +12,16 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 
 	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/fieldlog"
 	"github.com/keep-network/keep-core/pkg/net"
 )
 
-var logger = log.Logger("keep-relay")
+const loggerSubsystem = "keep-relay"
+
+var logger = log.Logger(loggerSubsystem)
 
 const maxGroupSize = 255
 
@@ -26,13 +33,15 @@ func NewNode(
 	blockCounter chain.BlockCounter,
 	chainConfig *config.Chain,
 	groupRegistry *registry.Groups,
+	dkgCheckpoints *dkg.CheckpointStorage,
 ) Node {
 	return Node{
-		Staker:        staker,
-		netProvider:   netProvider,
-		blockCounter:  blockCounter,
-		chainConfig:   chainConfig,
-		groupRegistry: groupRegistry,
+		Staker:         staker,
+		netProvider:    netProvider,
+		blockCounter:   blockCounter,
+		chainConfig:    chainConfig,
+		groupRegistry:  groupRegistry,
+		dkgCheckpoints: dkgCheckpoints,
 	}
 }
 
@@ -95,8 +104,10 @@ func (n *Node) MonitorRelayEntry(
 // upon successfully completing it, submits the signature as a new relay entry.
 // Note that this function returns immediately after determining whether the
 // node is or is not a member of the requested group, and signature creation
-// and submission is performed in a background goroutine.
+// and submission is performed in a background goroutine. Signature creation
+// is abandoned once the context is done.
 func (n *Node) GenerateRelayEntry(
+	ctx context.Context,
 	previousEntry []byte,
 	relayChain relayChain.Interface,
 	signing chain.Signing,
@@ -109,9 +120,18 @@ func (n *Node) GenerateRelayEntry(
 		return
 	}
 
+	signingLogger := fieldlog.New(
+		loggerSubsystem,
+		fieldlog.Fields{
+			fieldlog.Channel:        memberships[0].ChannelName,
+			fieldlog.GroupPublicKey: fmt.Sprintf("0x%x", groupPublicKey),
+			fieldlog.RequestBlock:   startBlockHeight,
+		},
+	)
+
 	channel, err := n.netProvider.BroadcastChannelFor(memberships[0].ChannelName)
 	if err != nil {
-		logger.Errorf("could not create broadcast channel: [%v]", err)
+		signingLogger.Errorf("could not create broadcast channel: [%v]", err)
 		return
 	}
 
@@ -119,7 +139,7 @@ func (n *Node) GenerateRelayEntry(
 
 	groupMembers, err := relayChain.GetGroupMembers(groupPublicKey)
 	if err != nil {
-		logger.Errorf("could not get group members: [%v]", err)
+		signingLogger.Errorf("could not get group members: [%v]", err)
 		return
 	}
 
@@ -130,26 +150,35 @@ func (n *Node) GenerateRelayEntry(
 
 	err = channel.SetFilter(membershipValidator.IsInGroup)
 	if err != nil {
-		logger.Errorf(
-			"could not set filter for channel [%v]: [%v]",
-			channel.Name(),
-			err,
-		)
+		signingLogger.Errorf("could not set filter for channel: [%v]", err)
 	}
 
 	for _, member := range memberships {
+		if !n.beginWork() {
+			signingLogger.Warningf(
+				"node is shutting down; not signing relay entry",
+			)
+			return
+		}
+
 		go func(member *registry.Membership) {
-			err = entry.SignAndSubmit(
+			defer n.workInProgress.Done()
+
+			err := entry.SignAndSubmit(
+				ctx,
 				n.blockCounter,
 				channel,
 				relayChain,
 				previousEntry,
 				n.chainConfig.HonestThreshold,
 				member.Signer,
+				n.Staker.Address(),
 				startBlockHeight,
 			)
 			if err != nil {
-				logger.Errorf(
+				signingLogger.With(fieldlog.Fields{
+					fieldlog.MemberIndex: member.Signer.MemberID(),
+				}).Errorf(
 					"error creating threshold signature: [%v]",
 					err,
 				)
@@ -1,12 +1,18 @@
 package relay
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/operator"
 )
 
 var address = "0x65ea55c1f10491038425725dc00dffeab2a1e28a"
@@ -121,3 +127,154 @@ func TestMonitorRelayEntryOnChain_EntryNotSubmitted(t *testing.T) {
 		)
 	}
 }
+
+func TestMonitorRelayEntryOnChain_GroupPunished(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	minimumStake := big.NewInt(200)
+	otherAddress := "0x524f2e0176350d950fa630d9a5a59a0a190daf48"
+
+	operatorKey, _, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chain := chainLocal.ConnectWithConfig(
+		&chainLocal.Config{
+			GroupSize:       5,
+			HonestThreshold: 3,
+			MinimumStake:    minimumStake,
+			BlockTime:       20 * time.Millisecond,
+			GroupActiveTime: 1000,
+		},
+		operatorKey,
+	)
+	blockCounter, err := chain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node := &Node{
+		blockCounter: blockCounter,
+	}
+
+	relayChain := chain.ThresholdRelay()
+
+	stakeMonitor, err := chain.StakeMonitor()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groupMembers := map[string]string{"a": address, "b": otherAddress}
+	for groupPublicKey, member := range groupMembers {
+		err := stakeMonitor.(*chainLocal.StakeMonitor).StakeTokens(member)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		registerGroup(t, chain, []byte(groupPublicKey), member)
+	}
+
+	requests := make(chan *event.Request, 2)
+	_, err = relayChain.OnRelayEntryRequested(func(request *event.Request) {
+		requests <- request
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request, err := chain.RequestRelayEntry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-requests
+
+	chainConfig, err := relayChain.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go node.MonitorRelayEntry(relayChain, request.BlockNumber, chainConfig)
+
+	select {
+	case nextRequest := <-requests:
+		if bytes.Equal(nextRequest.GroupPublicKey, request.GroupPublicKey) {
+			t.Errorf("entry should be requested again from the other group")
+		}
+	case <-ctx.Done():
+		t.Fatal("expected entry to be requested again after the timeout")
+	}
+
+	punishment := new(big.Int).Div(minimumStake, big.NewInt(100))
+	for groupPublicKey, member := range groupMembers {
+		staker, err := stakeMonitor.StakerFor(member)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		stake, err := staker.Stake()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedStake := new(big.Int).Mul(minimumStake, big.NewInt(5))
+		if groupPublicKey == string(request.GroupPublicKey) {
+			expectedStake.Sub(expectedStake, punishment)
+		}
+
+		if stake.Cmp(expectedStake) != 0 {
+			t.Errorf(
+				"unexpected stake of member of group [%v]\n"+
+					"expected: [%v]\nactual:   [%v]",
+				groupPublicKey,
+				expectedStake,
+				stake,
+			)
+		}
+	}
+
+	memberReward, err := relayChain.GetGroupMemberRewards(request.GroupPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if memberReward.Sign() != 0 {
+		t.Errorf("members of the group which timed out should not be rewarded")
+	}
+}
+
+// registerGroup registers a group with the given public key and the given
+// member on the local chain by running the group selection with a single
+// ticket of the member and submitting the DKG result.
+func registerGroup(
+	t *testing.T,
+	chain chainLocal.Chain,
+	groupPublicKey []byte,
+	member string,
+) {
+	if err := chain.Genesis(); err != nil {
+		t.Fatal(err)
+	}
+
+	relayChain := chain.ThresholdRelay()
+
+	relayChain.SubmitTicket(&relaychain.Ticket{
+		Proof: &relaychain.TicketProof{
+			StakerValue:        new(big.Int).SetBytes([]byte(member)),
+			VirtualStakerIndex: big.NewInt(1),
+		},
+	})
+
+	submitted := make(chan error, 1)
+	relayChain.SubmitDKGResult(
+		1,
+		&relaychain.DKGResult{GroupPublicKey: groupPublicKey},
+		map[relaychain.GroupMemberIndex][]byte{1: {101}, 2: {102}, 3: {103}},
+		0,
+	).OnComplete(func(_ *event.DKGResultSubmission, err error) {
+		submitted <- err
+	})
+	if err := <-submitted; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,202 @@
+package rewards_test
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/rewards"
+	chainLocal "github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/diskpersistence"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+const operatorAddress = "0x65ea55c1f10491038425725dc00dffeab2a1e28a"
+
+func TestWithdrawOnLocalChain(t *testing.T) {
+	var tests = map[string]struct {
+		minimumPayout        *big.Int
+		expectedHasWithdrawn bool
+	}{
+		"rewards withdrawn": {
+			minimumPayout:        big.NewInt(1),
+			expectedHasWithdrawn: true,
+		},
+		"rewards lower than minimum payout": {
+			minimumPayout:        big.NewInt(1e18),
+			expectedHasWithdrawn: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			groupPublicKey := []byte("group public key")
+			member := relaychain.StakerAddress(operatorAddress)
+
+			chain := connectLocalChain(t)
+			relayChain := chain.ThresholdRelay()
+
+			blockCounter, err := chain.BlockCounter()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			registrationBlock := registerGroup(t, chain, groupPublicKey)
+
+			if _, err := chain.RequestRelayEntry(); err != nil {
+				t.Fatal(err)
+			}
+
+			submitted := make(chan error, 1)
+			relayChain.SubmitRelayEntry(big.NewInt(19).Bytes()).OnComplete(
+				func(_ *event.EntrySubmitted, err error) {
+					submitted <- err
+				},
+			)
+			if err := <-submitted; err != nil {
+				t.Fatal(err)
+			}
+
+			groupRewards := listGroupRewards(t, relayChain, member)
+			if groupRewards.MemberReward.Sign() <= 0 {
+				t.Errorf("members should be rewarded for the relay entry")
+			}
+			if groupRewards.IsStale {
+				t.Errorf("group should not be stale right after the entry")
+			}
+
+			chainConfig, err := relayChain.GetConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			staleBlock := registrationBlock +
+				localGroupActiveTime +
+				chainConfig.RelayEntryTimeout +
+				1
+			if err := blockCounter.WaitForBlockHeight(staleBlock); err != nil {
+				t.Fatal(err)
+			}
+
+			dataDir, err := ioutil.TempDir("", "rewards")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dataDir)
+
+			handle, err := diskpersistence.NewHandle(dataDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := rewards.NewService(
+				relayChain,
+				member,
+				&rewards.Config{
+					MinimumPayout:   test.minimumPayout,
+					GasPriceCeiling: big.NewInt(0),
+				},
+				handle,
+			)
+			service.Withdraw(groupPublicKey, len(groupRewards.MemberIndexes))
+
+			groupRewards = listGroupRewards(t, relayChain, member)
+			if !groupRewards.IsStale {
+				t.Errorf("group should be stale after the stale block")
+			}
+			if groupRewards.HasWithdrawn != test.expectedHasWithdrawn {
+				t.Errorf(
+					"unexpected withdrawal\nexpected: [%v]\nactual:   [%v]",
+					test.expectedHasWithdrawn,
+					groupRewards.HasWithdrawn,
+				)
+			}
+		})
+	}
+}
+
+const localGroupActiveTime = 5
+
+func connectLocalChain(t *testing.T) chainLocal.Chain {
+	operatorKey, _, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return chainLocal.ConnectWithConfig(
+		&chainLocal.Config{
+			GroupSize:       5,
+			HonestThreshold: 3,
+			MinimumStake:    big.NewInt(200),
+			BlockTime:       10 * time.Millisecond,
+			GroupActiveTime: localGroupActiveTime,
+		},
+		operatorKey,
+	)
+}
+
+// registerGroup registers a group with the given public key and the operator
+// as the only member on the local chain and returns the registration block.
+func registerGroup(
+	t *testing.T,
+	chain chainLocal.Chain,
+	groupPublicKey []byte,
+) uint64 {
+	if err := chain.Genesis(); err != nil {
+		t.Fatal(err)
+	}
+
+	relayChain := chain.ThresholdRelay()
+
+	relayChain.SubmitTicket(&relaychain.Ticket{
+		Proof: &relaychain.TicketProof{
+			StakerValue:        new(big.Int).SetBytes([]byte(operatorAddress)),
+			VirtualStakerIndex: big.NewInt(1),
+		},
+	})
+
+	submissions := make(chan *event.DKGResultSubmission, 1)
+	relayChain.SubmitDKGResult(
+		1,
+		&relaychain.DKGResult{GroupPublicKey: groupPublicKey},
+		map[relaychain.GroupMemberIndex][]byte{1: {101}, 2: {102}, 3: {103}},
+		0,
+	).OnComplete(func(submission *event.DKGResultSubmission, err error) {
+		if err != nil {
+			t.Error(err)
+		}
+		submissions <- submission
+	})
+
+	submission := <-submissions
+	if submission == nil {
+		t.FailNow()
+	}
+
+	return submission.BlockNumber
+}
+
+func listGroupRewards(
+	t *testing.T,
+	relayChain relaychain.Interface,
+	member relaychain.StakerAddress,
+) *rewards.GroupRewards {
+	groupRewards, err := rewards.ListGroupRewards(relayChain, member, 0, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(groupRewards) != 1 {
+		t.Fatalf(
+			"unexpected number of groups\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(groupRewards),
+		)
+	}
+
+	return groupRewards[0]
+}
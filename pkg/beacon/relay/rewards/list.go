@@ -0,0 +1,106 @@
+package rewards
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+)
+
+// GroupRewards describes rewards the operator earned in a single group.
+type GroupRewards struct {
+	GroupPublicKey    []byte
+	RegistrationBlock uint64
+	// MemberIndexes lists indexes of all members the operator held in the
+	// group, starting from 1.
+	MemberIndexes []relaychain.GroupMemberIndex
+	MemberReward  *big.Int
+	TotalReward   *big.Int
+	IsStale       bool
+	HasWithdrawn  bool
+}
+
+// ListGroupRewards returns rewards the operator earned in groups registered
+// between fromBlock and toBlock, inclusive, ordered by registration block.
+// Groups the operator was not a member of are omitted.
+func ListGroupRewards(
+	chain Chain,
+	operator relaychain.StakerAddress,
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*GroupRewards, error) {
+	groupRegistrations, err := chain.PastGroupRegistrations(fromBlock, toBlock)
+	if err != nil {
+		return nil, fmt.Errorf("could not get group registrations: [%v]", err)
+	}
+
+	groupRewards := make([]*GroupRewards, 0)
+	for _, groupRegistration := range groupRegistrations {
+		groupPublicKey := groupRegistration.GroupPublicKey
+
+		members, err := chain.GetGroupMembers(groupPublicKey)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not get members of group [0x%x]: [%v]",
+				groupPublicKey,
+				err,
+			)
+		}
+
+		memberIndexes := make([]relaychain.GroupMemberIndex, 0)
+		for i, member := range members {
+			if bytes.Equal(member, operator) {
+				memberIndexes = append(
+					memberIndexes,
+					relaychain.GroupMemberIndex(i+1),
+				)
+			}
+		}
+		if len(memberIndexes) == 0 {
+			continue
+		}
+
+		memberReward, err := chain.GetGroupMemberRewards(groupPublicKey)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not get member rewards of group [0x%x]: [%v]",
+				groupPublicKey,
+				err,
+			)
+		}
+
+		isStale, err := chain.IsStaleGroup(groupPublicKey)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not check if group [0x%x] is stale: [%v]",
+				groupPublicKey,
+				err,
+			)
+		}
+
+		hasWithdrawn, err := chain.HasWithdrawnRewards(operator, groupPublicKey)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not check if rewards of group [0x%x] were withdrawn: [%v]",
+				groupPublicKey,
+				err,
+			)
+		}
+
+		groupRewards = append(groupRewards, &GroupRewards{
+			GroupPublicKey:    groupPublicKey,
+			RegistrationBlock: groupRegistration.BlockNumber,
+			MemberIndexes:     memberIndexes,
+			MemberReward:      memberReward,
+			TotalReward: new(big.Int).Mul(
+				memberReward,
+				big.NewInt(int64(len(memberIndexes))),
+			),
+			IsStale:      isStale,
+			HasWithdrawn: hasWithdrawn,
+		})
+	}
+
+	return groupRewards, nil
+}
@@ -0,0 +1,325 @@
+package rewards
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+)
+
+// Period is the length of a period rewards are summed up for in the report.
+type Period string
+
+// Supported report periods. Periods start at midnight UTC; weeks start on
+// Monday.
+const (
+	Day   Period = "day"
+	Week  Period = "week"
+	Month Period = "month"
+)
+
+// ParsePeriod returns the report period with the given name.
+func ParsePeriod(name string) (Period, error) {
+	switch period := Period(name); period {
+	case Day, Week, Month:
+		return period, nil
+	default:
+		return "", fmt.Errorf(
+			"unknown period [%v]; expected one of [%v, %v, %v]",
+			name,
+			Day,
+			Week,
+			Month,
+		)
+	}
+}
+
+// start returns the beginning of the period containing the given time.
+func (p Period) start(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch p {
+	case Week:
+		// time.Weekday starts on Sunday
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case Month:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// SignedEntry is a relay entry submitted by the group with the given public
+// key.
+type SignedEntry struct {
+	GroupPublicKey []byte
+	BlockNumber    uint64
+}
+
+// ReportSources holds everything the rewards report is built from.
+type ReportSources struct {
+	// FromBlock and ToBlock delimit the reported block range, inclusive.
+	FromBlock uint64
+	ToBlock   uint64
+	// Groups are rewards of the operator in all groups registered up to the
+	// end of the reported block range, as returned by ListGroupRewards.
+	// Groups registered before the range are reported only if they submitted
+	// a relay entry or their rewards were withdrawn in the range.
+	Groups []*GroupRewards
+	// StoredGroups are memberships read from the persistence directory,
+	// keyed by group public key in uncompressed form.
+	StoredGroups map[string]*registry.StoredGroup
+	// Entries are relay entries submitted up to the current block, starting
+	// no later than the registration of the first of the groups. Entries
+	// submitted outside of the range are used only to estimate rewards, see
+	// PeriodReport.
+	Entries []*SignedEntry
+	// Withdrawals are group member rewards withdrawals seen in the reported
+	// block range.
+	Withdrawals []*event.GroupMemberRewardsWithdrawal
+	// BlockTime returns the time at which the block with the given number
+	// was mined.
+	BlockTime func(blockNumber uint64) (time.Time, error)
+}
+
+// Report summarizes rewards the operator earned per group and per period.
+type Report struct {
+	Groups  []*GroupReport
+	Periods []*PeriodReport
+}
+
+// GroupReport summarizes rewards the operator earned in a single group.
+type GroupReport struct {
+	GroupRewards
+
+	// IsStored is true if memberships of the group are present in the
+	// persistence directory.
+	IsStored bool
+	// IsArchived is true if the group has been archived in the persistence
+	// directory.
+	IsArchived bool
+	// EntryCount is the number of relay entries the group submitted in the
+	// reported block range.
+	EntryCount int
+	// WithdrawnReward is the amount withdrawn by the operator for the group
+	// in the reported block range.
+	WithdrawnReward *big.Int
+	// WithdrawalBlock is the block of the last withdrawal or zero if there
+	// was none.
+	WithdrawalBlock uint64
+}
+
+// PeriodReport summarizes rewards the operator earned in a single period.
+type PeriodReport struct {
+	Start time.Time
+	// EntryCount is the number of relay entries submitted in the period by
+	// groups the operator is a member of.
+	EntryCount int
+	// EstimatedReward is an estimate of the reward of the operator for
+	// entries submitted in the period. The chain does not record the reward
+	// of a single entry, so the current total reward of a group is spread
+	// evenly over all entries the group submitted so far, including entries
+	// submitted after the reported range. Rewards of groups which
+	// did not submit any entry are accounted to the period the group was
+	// registered in.
+	EstimatedReward *big.Int
+	// WithdrawnReward is the amount withdrawn by the operator in the period.
+	WithdrawnReward *big.Int
+}
+
+// NewReport builds the rewards report of the given operator from the provided
+// sources. Relay entries and withdrawals are matched against all groups of the
+// operator, no matter when they were registered. Group reports are ordered by
+// registration block and period reports by period start.
+func NewReport(
+	operator relaychain.StakerAddress,
+	sources *ReportSources,
+	period Period,
+) (*Report, error) {
+	blockTimes := make(map[uint64]time.Time)
+	periodStart := func(blockNumber uint64) (time.Time, error) {
+		blockTime, ok := blockTimes[blockNumber]
+		if !ok {
+			var err error
+			blockTime, err = sources.BlockTime(blockNumber)
+			if err != nil {
+				return time.Time{}, fmt.Errorf(
+					"could not get time of block [%v]: [%v]",
+					blockNumber,
+					err,
+				)
+			}
+			blockTimes[blockNumber] = blockTime
+		}
+		return period.start(blockTime), nil
+	}
+
+	periods := make(map[time.Time]*PeriodReport)
+	periodReport := func(blockNumber uint64) (*PeriodReport, error) {
+		start, err := periodStart(blockNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		report, ok := periods[start]
+		if !ok {
+			report = &PeriodReport{
+				Start:           start,
+				EstimatedReward: big.NewInt(0),
+				WithdrawnReward: big.NewInt(0),
+			}
+			periods[start] = report
+		}
+		return report, nil
+	}
+
+	entryBlocks := make(map[string][]uint64)
+	for _, entry := range sources.Entries {
+		groupPublicKey := hex.EncodeToString(entry.GroupPublicKey)
+		entryBlocks[groupPublicKey] = append(
+			entryBlocks[groupPublicKey],
+			entry.BlockNumber,
+		)
+	}
+
+	groups := make([]*GroupReport, 0, len(sources.Groups))
+	for _, group := range sources.Groups {
+		groupPublicKey := hex.EncodeToString(group.GroupPublicKey)
+
+		groupReport := &GroupReport{
+			GroupRewards:    *group,
+			WithdrawnReward: big.NewInt(0),
+		}
+		if storedGroup, ok := sources.StoredGroups[groupPublicKey]; ok {
+			groupReport.IsStored = true
+			groupReport.IsArchived = storedGroup.IsArchived
+		}
+
+		for _, blockNumber := range entryBlocks[groupPublicKey] {
+			if sources.inRange(blockNumber) {
+				groupReport.EntryCount++
+			}
+		}
+
+		groupWithdrawals := make([]*event.GroupMemberRewardsWithdrawal, 0)
+		for _, withdrawal := range sources.Withdrawals {
+			if bytes.Equal(withdrawal.Operator, operator) &&
+				bytes.Equal(withdrawal.GroupPublicKey, group.GroupPublicKey) &&
+				sources.inRange(withdrawal.BlockNumber) {
+				groupWithdrawals = append(groupWithdrawals, withdrawal)
+			}
+		}
+
+		if !sources.inRange(group.RegistrationBlock) &&
+			groupReport.EntryCount == 0 &&
+			len(groupWithdrawals) == 0 {
+			continue
+		}
+
+		for _, withdrawal := range groupWithdrawals {
+			groupReport.WithdrawnReward.Add(
+				groupReport.WithdrawnReward,
+				withdrawal.Amount,
+			)
+			if withdrawal.BlockNumber > groupReport.WithdrawalBlock {
+				groupReport.WithdrawalBlock = withdrawal.BlockNumber
+			}
+
+			report, err := periodReport(withdrawal.BlockNumber)
+			if err != nil {
+				return nil, err
+			}
+			report.WithdrawnReward.Add(report.WithdrawnReward, withdrawal.Amount)
+		}
+
+		if err := estimateReward(
+			group,
+			entryBlocks[groupPublicKey],
+			sources.inRange,
+			periodReport,
+		); err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, groupReport)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].RegistrationBlock < groups[j].RegistrationBlock
+	})
+
+	periodReports := make([]*PeriodReport, 0, len(periods))
+	for _, report := range periods {
+		periodReports = append(periodReports, report)
+	}
+	sort.Slice(periodReports, func(i, j int) bool {
+		return periodReports[i].Start.Before(periodReports[j].Start)
+	})
+
+	return &Report{
+		Groups:  groups,
+		Periods: periodReports,
+	}, nil
+}
+
+// inRange returns true if the block with the given number is in the reported
+// block range.
+func (rs *ReportSources) inRange(blockNumber uint64) bool {
+	return blockNumber >= rs.FromBlock && blockNumber <= rs.ToBlock
+}
+
+// estimateReward spreads the total reward of the group evenly over all
+// entries submitted by the group so far and accounts the shares of entries in the
+// reported block range to their periods. The remainder of the division is
+// accounted to the period of the last entry.
+func estimateReward(
+	group *GroupRewards,
+	entryBlocks []uint64,
+	inRange func(blockNumber uint64) bool,
+	periodReport func(blockNumber uint64) (*PeriodReport, error),
+) error {
+	if len(entryBlocks) == 0 {
+		if !inRange(group.RegistrationBlock) {
+			return nil
+		}
+
+		report, err := periodReport(group.RegistrationBlock)
+		if err != nil {
+			return err
+		}
+		report.EstimatedReward.Add(report.EstimatedReward, group.TotalReward)
+		return nil
+	}
+
+	entryReward, remainder := new(big.Int).QuoRem(
+		group.TotalReward,
+		big.NewInt(int64(len(entryBlocks))),
+		new(big.Int),
+	)
+
+	for i, blockNumber := range entryBlocks {
+		if !inRange(blockNumber) {
+			continue
+		}
+
+		report, err := periodReport(blockNumber)
+		if err != nil {
+			return err
+		}
+
+		report.EntryCount++
+		report.EstimatedReward.Add(report.EstimatedReward, entryReward)
+		if i == len(entryBlocks)-1 {
+			report.EstimatedReward.Add(report.EstimatedReward, remainder)
+		}
+	}
+
+	return nil
+}
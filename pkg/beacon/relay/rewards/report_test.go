@@ -0,0 +1,248 @@
+package rewards
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/registry"
+)
+
+func TestPeriodStart(t *testing.T) {
+	// Wednesday
+	blockTime := time.Date(2020, time.April, 15, 13, 45, 0, 0, time.UTC)
+
+	var tests = map[string]struct {
+		period        Period
+		expectedStart time.Time
+	}{
+		"day": {
+			period:        Day,
+			expectedStart: time.Date(2020, time.April, 15, 0, 0, 0, 0, time.UTC),
+		},
+		"week": {
+			period:        Week,
+			expectedStart: time.Date(2020, time.April, 13, 0, 0, 0, 0, time.UTC),
+		},
+		"month": {
+			period:        Month,
+			expectedStart: time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			start := test.period.start(blockTime)
+			if !start.Equal(test.expectedStart) {
+				t.Errorf(
+					"unexpected period start\nexpected: [%v]\nactual:   [%v]",
+					test.expectedStart,
+					start,
+				)
+			}
+		})
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	otherGroupPublicKey := []byte("other group public key")
+	idleGroupPublicKey := []byte("idle group public key")
+
+	// one block per day starting on April 1st
+	blockTime := func(blockNumber uint64) (time.Time, error) {
+		return time.Date(2020, time.April, int(blockNumber), 12, 0, 0, 0, time.UTC), nil
+	}
+
+	sources := &ReportSources{
+		FromBlock: 2,
+		ToBlock:   5,
+		Groups: []*GroupRewards{
+			{
+				// registered before the reported range, no events in range
+				GroupPublicKey:    idleGroupPublicKey,
+				RegistrationBlock: 1,
+				MemberIndexes:     []relaychain.GroupMemberIndex{4},
+				MemberReward:      big.NewInt(70),
+				TotalReward:       big.NewInt(70),
+			},
+			{
+				GroupPublicKey:    otherGroupPublicKey,
+				RegistrationBlock: 5,
+				MemberIndexes:     []relaychain.GroupMemberIndex{2},
+				MemberReward:      big.NewInt(50),
+				TotalReward:       big.NewInt(50),
+			},
+			{
+				GroupPublicKey:    groupPublicKey,
+				RegistrationBlock: 1,
+				MemberIndexes:     []relaychain.GroupMemberIndex{1, 3},
+				MemberReward:      big.NewInt(500),
+				TotalReward:       big.NewInt(1000),
+				IsStale:           true,
+				HasWithdrawn:      true,
+			},
+		},
+		StoredGroups: map[string]*registry.StoredGroup{
+			hex.EncodeToString(groupPublicKey): {IsArchived: true},
+		},
+		Entries: []*SignedEntry{
+			// submitted before the reported range
+			{GroupPublicKey: groupPublicKey, BlockNumber: 1},
+			{GroupPublicKey: groupPublicKey, BlockNumber: 2},
+			{GroupPublicKey: []byte("foreign group"), BlockNumber: 3},
+			{GroupPublicKey: groupPublicKey, BlockNumber: 3},
+			{GroupPublicKey: groupPublicKey, BlockNumber: 4},
+		},
+		Withdrawals: []*event.GroupMemberRewardsWithdrawal{
+			{
+				Operator:       otherOperator,
+				GroupPublicKey: groupPublicKey,
+				Amount:         big.NewInt(700),
+				BlockNumber:    4,
+			},
+			{
+				Operator:       operator,
+				GroupPublicKey: groupPublicKey,
+				Amount:         big.NewInt(1000),
+				BlockNumber:    5,
+			},
+		},
+		BlockTime: blockTime,
+	}
+
+	report, err := NewReport(operator, sources, Day)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedGroups := []*GroupReport{
+		{
+			GroupRewards:    *sources.Groups[2],
+			IsStored:        true,
+			IsArchived:      true,
+			EntryCount:      3,
+			WithdrawnReward: big.NewInt(1000),
+			WithdrawalBlock: 5,
+		},
+		{
+			GroupRewards:    *sources.Groups[1],
+			WithdrawnReward: big.NewInt(0),
+		},
+	}
+	if !reflect.DeepEqual(expectedGroups, report.Groups) {
+		t.Errorf(
+			"unexpected group reports\nexpected: [%+v]\nactual:   [%+v]",
+			expectedGroups,
+			report.Groups,
+		)
+	}
+
+	day := func(day int) time.Time {
+		return time.Date(2020, time.April, day, 0, 0, 0, 0, time.UTC)
+	}
+	expectedPeriods := []*PeriodReport{
+		{
+			Start:           day(2),
+			EntryCount:      1,
+			EstimatedReward: big.NewInt(250),
+			WithdrawnReward: big.NewInt(0),
+		},
+		{
+			Start:           day(3),
+			EntryCount:      1,
+			EstimatedReward: big.NewInt(250),
+			WithdrawnReward: big.NewInt(0),
+		},
+		{
+			Start:           day(4),
+			EntryCount:      1,
+			EstimatedReward: big.NewInt(250),
+			WithdrawnReward: big.NewInt(0),
+		},
+		{
+			Start:           day(5),
+			EntryCount:      0,
+			EstimatedReward: big.NewInt(50),
+			WithdrawnReward: big.NewInt(1000),
+		},
+	}
+	if !reflect.DeepEqual(expectedPeriods, report.Periods) {
+		t.Errorf(
+			"unexpected period reports\nexpected: [%+v]\nactual:   [%+v]",
+			expectedPeriods,
+			report.Periods,
+		)
+	}
+}
+
+func TestNewReportGroupSigningAfterRange(t *testing.T) {
+	// one block per day starting on April 1st
+	blockTime := func(blockNumber uint64) (time.Time, error) {
+		return time.Date(2020, time.April, int(blockNumber), 12, 0, 0, 0, time.UTC), nil
+	}
+
+	sources := &ReportSources{
+		FromBlock: 1,
+		ToBlock:   2,
+		Groups: []*GroupRewards{
+			{
+				GroupPublicKey:    groupPublicKey,
+				RegistrationBlock: 1,
+				MemberIndexes:     []relaychain.GroupMemberIndex{1},
+				MemberReward:      big.NewInt(400),
+				// includes rewards for entries submitted after the range
+				TotalReward: big.NewInt(400),
+			},
+		},
+		Entries: []*SignedEntry{
+			{GroupPublicKey: groupPublicKey, BlockNumber: 1},
+			{GroupPublicKey: groupPublicKey, BlockNumber: 2},
+			// submitted after the reported range
+			{GroupPublicKey: groupPublicKey, BlockNumber: 3},
+			{GroupPublicKey: groupPublicKey, BlockNumber: 4},
+		},
+		BlockTime: blockTime,
+	}
+
+	report, err := NewReport(operator, sources, Day)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Groups[0].EntryCount != 2 {
+		t.Errorf(
+			"unexpected group entry count\nexpected: [%v]\nactual:   [%v]",
+			2,
+			report.Groups[0].EntryCount,
+		)
+	}
+
+	day := func(day int) time.Time {
+		return time.Date(2020, time.April, day, 0, 0, 0, 0, time.UTC)
+	}
+	expectedPeriods := []*PeriodReport{
+		{
+			Start:           day(1),
+			EntryCount:      1,
+			EstimatedReward: big.NewInt(100),
+			WithdrawnReward: big.NewInt(0),
+		},
+		{
+			Start:           day(2),
+			EntryCount:      1,
+			EstimatedReward: big.NewInt(100),
+			WithdrawnReward: big.NewInt(0),
+		},
+	}
+	if !reflect.DeepEqual(expectedPeriods, report.Periods) {
+		t.Errorf(
+			"unexpected period reports\nexpected: [%+v]\nactual:   [%+v]",
+			expectedPeriods,
+			report.Periods,
+		)
+	}
+}
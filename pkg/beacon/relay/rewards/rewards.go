@@ -0,0 +1,312 @@
+// Package rewards withdraws rewards the operator earned as a member of relay
+// groups.
+//
+// Group member rewards can be withdrawn once the group becomes stale, which
+// is also when the group is archived by the group registry. The service
+// withdraws rewards of each archived group unless they are lower than the
+// configured minimum payout. Withdrawals are postponed while the gas price is
+// above the configured ceiling and retried periodically. Withdrawals are
+// persisted before they are attempted and archived once they are done so that
+// postponed withdrawals and withdrawals interrupted by a client shutdown are
+// retried after a client restart as well.
+package rewards
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/metrics"
+)
+
+var logger = log.Logger("keep-rewards")
+
+// retryInterval is the time between attempts to withdraw rewards postponed
+// because of a high gas price or a failed withdrawal.
+const retryInterval = 10 * time.Minute
+
+var (
+	withdrawals = metrics.NewCounterVec(
+		"keep_rewards_withdrawals_total",
+		"Number of group member rewards withdrawals mined successfully.",
+		metrics.OperatorLabel,
+	)
+	withdrawnRewards = metrics.NewCounterVec(
+		"keep_rewards_withdrawn_wei_total",
+		"Amount of group member rewards withdrawn, in wei.",
+		metrics.OperatorLabel,
+	)
+	pendingWithdrawals = metrics.NewGaugeVec(
+		"keep_rewards_pending_withdrawals",
+		"Number of group member rewards withdrawals postponed for a retry.",
+		metrics.OperatorLabel,
+	)
+)
+
+// Chain is the subset of the relay chain interface used to find and withdraw
+// group member rewards.
+type Chain interface {
+	relaychain.GroupRegistrationInterface
+	relaychain.GroupRewardsInterface
+}
+
+// Config holds the thresholds of the automatic rewards withdrawal, in wei.
+// A zero minimum payout or gas price ceiling disables the respective check.
+type Config struct {
+	// MinimumPayout is the minimum amount withdrawn for a single group.
+	// Lower rewards are left for a manual withdrawal.
+	MinimumPayout *big.Int
+	// GasPriceCeiling is the maximum gas price at which rewards are
+	// withdrawn. Withdrawals are postponed while the gas price is higher.
+	GasPriceCeiling *big.Int
+}
+
+// Service withdraws rewards of the operator for groups archived by the group
+// registry.
+type Service struct {
+	chain    Chain
+	operator relaychain.StakerAddress
+	config   *Config
+	storage  *storage
+
+	// operatorLabel is the value of the operator label of rewards metrics
+	operatorLabel string
+
+	mutex sync.Mutex
+	// key is group public key in uncompressed form, value is the number of
+	// members the operator held in the group
+	pending map[string]int
+}
+
+// NewService creates a service withdrawing rewards of the given operator
+// according to the provided config. Postponed withdrawals are persisted using
+// the given persistence handle.
+func NewService(
+	chain Chain,
+	operator relaychain.StakerAddress,
+	config *Config,
+	handle persistence.Handle,
+) *Service {
+	return &Service{
+		chain:    chain,
+		operator: operator,
+		config:   config,
+		storage:  &storage{handle},
+		pending:  make(map[string]int),
+
+		operatorLabel: metrics.OperatorLabelValue(operator),
+	}
+}
+
+// Withdraw withdraws rewards the operator earned for the given number of
+// members it held in the stale group with the given public key. If the gas
+// price is too high or the withdrawal fails, it is retried by Run.
+//
+// The group registry archives the group before rewards are withdrawn, so the
+// withdrawal is persisted before it is attempted. The persisted withdrawal is
+// archived only once the withdrawal is mined or turns out not to be needed.
+func (s *Service) Withdraw(groupPublicKey []byte, memberCount int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.storage.save(&pendingWithdrawal{groupPublicKey, memberCount})
+	if err != nil {
+		logger.Errorf(
+			"could not persist withdrawal of rewards for group [0x%x]; "+
+				"it will not be retried after a restart: [%v]",
+			groupPublicKey,
+			err,
+		)
+	}
+
+	if err := s.withdraw(groupPublicKey, memberCount); err != nil {
+		logger.Warningf(
+			"postponing withdrawal of rewards for group [0x%x]: [%v]",
+			groupPublicKey,
+			err,
+		)
+
+		s.postpone(groupPublicKey, memberCount)
+		return
+	}
+
+	s.archive(groupPublicKey)
+}
+
+// postpone schedules the persisted withdrawal for a retry.
+func (s *Service) postpone(groupPublicKey []byte, memberCount int) {
+	key := hex.EncodeToString(groupPublicKey)
+	if _, ok := s.pending[key]; !ok {
+		pendingWithdrawals.WithLabelValues(s.operatorLabel).Inc()
+	}
+	s.pending[key] = memberCount
+}
+
+// Run loads withdrawals postponed before the client restart and retries all
+// postponed withdrawals periodically until the context is done.
+func (s *Service) Run(ctx context.Context) {
+	s.loadPending()
+	s.retryPending()
+
+	ticker := time.NewTicker(retryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.retryPending()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Service) loadPending() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	storedWithdrawals, errors := s.storage.readAll()
+
+	done := make(chan struct{})
+	go func() {
+		for err := range errors {
+			logger.Errorf(
+				"could not load postponed withdrawal of rewards: [%v]",
+				err,
+			)
+		}
+		close(done)
+	}()
+
+	for withdrawal := range storedWithdrawals {
+		key := hex.EncodeToString(withdrawal.groupPublicKey)
+		if _, ok := s.pending[key]; !ok {
+			pendingWithdrawals.WithLabelValues(s.operatorLabel).Inc()
+		}
+		s.pending[key] = withdrawal.memberCount
+	}
+
+	<-done
+}
+
+func (s *Service) retryPending() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for groupPublicKey, memberCount := range s.pending {
+		groupPublicKeyBytes, err := hex.DecodeString(groupPublicKey)
+		if err != nil {
+			logger.Errorf(
+				"error occurred while decoding public key into bytes: [%v]",
+				err,
+			)
+			delete(s.pending, groupPublicKey)
+			pendingWithdrawals.WithLabelValues(s.operatorLabel).Dec()
+			continue
+		}
+
+		if err := s.withdraw(groupPublicKeyBytes, memberCount); err != nil {
+			logger.Warningf(
+				"postponing withdrawal of rewards for group [0x%v] again: [%v]",
+				groupPublicKey,
+				err,
+			)
+			continue
+		}
+
+		s.archive(groupPublicKeyBytes)
+
+		delete(s.pending, groupPublicKey)
+		pendingWithdrawals.WithLabelValues(s.operatorLabel).Dec()
+	}
+}
+
+// archive archives the persisted withdrawal for the given group so that it is
+// not retried anymore.
+func (s *Service) archive(groupPublicKey []byte) {
+	if err := s.storage.archive(groupPublicKey); err != nil {
+		logger.Errorf(
+			"could not archive withdrawal of rewards for group [0x%x]: [%v]",
+			groupPublicKey,
+			err,
+		)
+	}
+}
+
+// withdraw submits the rewards withdrawal for the given group if it is due.
+// An error is returned if the withdrawal should be retried later.
+func (s *Service) withdraw(groupPublicKey []byte, memberCount int) error {
+	hasWithdrawn, err := s.chain.HasWithdrawnRewards(s.operator, groupPublicKey)
+	if err != nil {
+		return fmt.Errorf("could not check if rewards were withdrawn: [%v]", err)
+	}
+	if hasWithdrawn {
+		logger.Infof(
+			"rewards for group [0x%x] have been already withdrawn",
+			groupPublicKey,
+		)
+		return nil
+	}
+
+	memberReward, err := s.chain.GetGroupMemberRewards(groupPublicKey)
+	if err != nil {
+		return fmt.Errorf("could not get group member rewards: [%v]", err)
+	}
+
+	totalReward := new(big.Int).Mul(memberReward, big.NewInt(int64(memberCount)))
+	if totalReward.Sign() == 0 {
+		logger.Infof("no rewards to withdraw for group [0x%x]", groupPublicKey)
+		return nil
+	}
+	if totalReward.Cmp(s.config.MinimumPayout) < 0 {
+		logger.Infof(
+			"rewards of [%v] wei for group [0x%x] are lower than the minimum "+
+				"payout of [%v] wei; leaving them for a manual withdrawal",
+			totalReward,
+			groupPublicKey,
+			s.config.MinimumPayout,
+		)
+		return nil
+	}
+
+	if s.config.GasPriceCeiling.Sign() > 0 {
+		gasPrice, err := s.chain.CurrentGasPrice()
+		if err != nil {
+			return fmt.Errorf("could not get current gas price: [%v]", err)
+		}
+		if gasPrice.Cmp(s.config.GasPriceCeiling) > 0 {
+			return fmt.Errorf(
+				"gas price of [%v] wei is higher than the ceiling of [%v] wei",
+				gasPrice,
+				s.config.GasPriceCeiling,
+			)
+		}
+	}
+
+	logger.Infof(
+		"withdrawing rewards of [%v] wei for [%v] members of group [0x%x]",
+		totalReward,
+		memberCount,
+		groupPublicKey,
+	)
+
+	// The withdrawal returns once the transaction is mined successfully so
+	// the metrics below count only withdrawals which took effect.
+	err = s.chain.WithdrawGroupMemberRewards(s.operator, groupPublicKey)
+	if err != nil {
+		return fmt.Errorf("could not withdraw rewards: [%v]", err)
+	}
+
+	withdrawals.WithLabelValues(s.operatorLabel).Inc()
+	withdrawnAmount, _ := new(big.Float).SetInt(totalReward).Float64()
+	withdrawnRewards.WithLabelValues(s.operatorLabel).Add(withdrawnAmount)
+
+	return nil
+}
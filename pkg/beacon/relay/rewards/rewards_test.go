@@ -0,0 +1,400 @@
+package rewards
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+var (
+	operator       = relaychain.StakerAddress("operator")
+	otherOperator  = relaychain.StakerAddress("other operator")
+	groupPublicKey = []byte("group public key")
+)
+
+func TestWithdraw(t *testing.T) {
+	var tests = map[string]struct {
+		memberReward        int64
+		hasWithdrawn        bool
+		gasPrice            int64
+		withdrawalError     error
+		expectedWithdrawals int
+		expectedPending     int
+	}{
+		"rewards withdrawn": {
+			memberReward:        1000,
+			gasPrice:            20,
+			expectedWithdrawals: 1,
+		},
+		"rewards already withdrawn": {
+			memberReward: 1000,
+			hasWithdrawn: true,
+			gasPrice:     20,
+		},
+		"no rewards": {
+			memberReward: 0,
+			gasPrice:     20,
+		},
+		"rewards lower than minimum payout": {
+			memberReward: 400,
+			gasPrice:     20,
+		},
+		"gas price higher than ceiling": {
+			memberReward:    1000,
+			gasPrice:        51,
+			expectedPending: 1,
+		},
+		"withdrawal failed": {
+			memberReward:    1000,
+			gasPrice:        20,
+			withdrawalError: fmt.Errorf("transaction failed"),
+			expectedPending: 1,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			chain := &mockChain{
+				memberReward:    big.NewInt(test.memberReward),
+				hasWithdrawn:    test.hasWithdrawn,
+				gasPrice:        big.NewInt(test.gasPrice),
+				withdrawalError: test.withdrawalError,
+			}
+
+			handle := newPersistenceHandleMock()
+			service := NewService(chain, operator, &Config{
+				MinimumPayout:   big.NewInt(1000),
+				GasPriceCeiling: big.NewInt(50),
+			}, handle)
+
+			service.Withdraw(groupPublicKey, 2)
+
+			if test.expectedWithdrawals != chain.withdrawals {
+				t.Errorf(
+					"unexpected number of withdrawals\nexpected: [%v]\nactual:   [%v]",
+					test.expectedWithdrawals,
+					chain.withdrawals,
+				)
+			}
+			if test.expectedPending != len(service.pending) {
+				t.Errorf(
+					"unexpected number of pending withdrawals\nexpected: [%v]\nactual:   [%v]",
+					test.expectedPending,
+					len(service.pending),
+				)
+			}
+			// Only withdrawals to retry are left in the storage.
+			if test.expectedPending != len(handle.data) {
+				t.Errorf(
+					"unexpected number of stored withdrawals\nexpected: [%v]\nactual:   [%v]",
+					test.expectedPending,
+					len(handle.data),
+				)
+			}
+		})
+	}
+}
+
+func TestWithdrawalPersistedBeforeSubmission(t *testing.T) {
+	handle := newPersistenceHandleMock()
+
+	storedOnSubmission := 0
+	chain := &mockChain{
+		memberReward: big.NewInt(1000),
+		gasPrice:     big.NewInt(20),
+		onWithdrawal: func() {
+			storedOnSubmission = len(handle.data)
+		},
+	}
+
+	service := NewService(chain, operator, &Config{
+		MinimumPayout:   big.NewInt(0),
+		GasPriceCeiling: big.NewInt(50),
+	}, handle)
+
+	service.Withdraw(groupPublicKey, 1)
+
+	if storedOnSubmission != 1 {
+		t.Errorf("withdrawal not persisted before submission")
+	}
+	if len(handle.data) != 0 {
+		t.Errorf("withdrawal not removed from the storage once mined")
+	}
+}
+
+func TestRetryPendingWithdrawal(t *testing.T) {
+	chain := &mockChain{
+		memberReward: big.NewInt(1000),
+		gasPrice:     big.NewInt(51),
+	}
+
+	service := NewService(chain, operator, &Config{
+		MinimumPayout:   big.NewInt(0),
+		GasPriceCeiling: big.NewInt(50),
+	}, newPersistenceHandleMock())
+
+	service.Withdraw(groupPublicKey, 1)
+	if len(service.pending) != 1 {
+		t.Fatalf("withdrawal not postponed")
+	}
+
+	service.retryPending()
+	if len(service.pending) != 1 || chain.withdrawals != 0 {
+		t.Fatalf("withdrawal not postponed again")
+	}
+
+	chain.gasPrice = big.NewInt(50)
+
+	service.retryPending()
+	if len(service.pending) != 0 {
+		t.Errorf("withdrawal still pending")
+	}
+	if chain.withdrawals != 1 {
+		t.Errorf(
+			"unexpected number of withdrawals\nexpected: [%v]\nactual:   [%v]",
+			1,
+			chain.withdrawals,
+		)
+	}
+}
+
+func TestRestorePendingWithdrawal(t *testing.T) {
+	chain := &mockChain{
+		memberReward: big.NewInt(1000),
+		gasPrice:     big.NewInt(51),
+	}
+	config := &Config{
+		MinimumPayout:   big.NewInt(0),
+		GasPriceCeiling: big.NewInt(50),
+	}
+	handle := newPersistenceHandleMock()
+
+	NewService(chain, operator, config, handle).Withdraw(groupPublicKey, 3)
+
+	// A new service created with the same persistence handle after a client
+	// restart.
+	service := NewService(chain, operator, config, handle)
+	service.loadPending()
+
+	expectedPending := map[string]int{hex.EncodeToString(groupPublicKey): 3}
+	if !reflect.DeepEqual(expectedPending, service.pending) {
+		t.Fatalf(
+			"unexpected pending withdrawals\nexpected: [%v]\nactual:   [%v]",
+			expectedPending,
+			service.pending,
+		)
+	}
+
+	chain.gasPrice = big.NewInt(50)
+
+	service.retryPending()
+	if chain.withdrawals != 1 {
+		t.Errorf(
+			"unexpected number of withdrawals\nexpected: [%v]\nactual:   [%v]",
+			1,
+			chain.withdrawals,
+		)
+	}
+
+	expectedArchived := []string{pendingWithdrawalDirectory(groupPublicKey)}
+	if !reflect.DeepEqual(expectedArchived, handle.archived) {
+		t.Errorf(
+			"unexpected archived directories\nexpected: [%v]\nactual:   [%v]",
+			expectedArchived,
+			handle.archived,
+		)
+	}
+	if len(handle.data) != 0 {
+		t.Errorf("pending withdrawal not removed from the storage")
+	}
+}
+
+func TestListGroupRewards(t *testing.T) {
+	otherGroupPublicKey := []byte("other group public key")
+
+	chain := &mockChain{
+		memberReward: big.NewInt(1000),
+		groupRegistrations: []*event.GroupRegistration{
+			{GroupPublicKey: groupPublicKey, BlockNumber: 10},
+			{GroupPublicKey: otherGroupPublicKey, BlockNumber: 20},
+		},
+		groupMembers: map[string][]relaychain.StakerAddress{
+			string(groupPublicKey): {
+				operator, otherOperator, operator,
+			},
+			string(otherGroupPublicKey): {
+				otherOperator, otherOperator, otherOperator,
+			},
+		},
+	}
+
+	groupRewards, err := ListGroupRewards(chain, operator, 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedGroupRewards := []*GroupRewards{
+		{
+			GroupPublicKey:    groupPublicKey,
+			RegistrationBlock: 10,
+			MemberIndexes:     []relaychain.GroupMemberIndex{1, 3},
+			MemberReward:      big.NewInt(1000),
+			TotalReward:       big.NewInt(2000),
+			IsStale:           true,
+			HasWithdrawn:      false,
+		},
+	}
+	if !reflect.DeepEqual(expectedGroupRewards, groupRewards) {
+		t.Errorf(
+			"unexpected group rewards\nexpected: [%+v]\nactual:   [%+v]",
+			expectedGroupRewards,
+			groupRewards,
+		)
+	}
+}
+
+type mockChain struct {
+	memberReward    *big.Int
+	hasWithdrawn    bool
+	gasPrice        *big.Int
+	withdrawalError error
+	withdrawals     int
+	// onWithdrawal is called when the withdrawal is submitted, if set
+	onWithdrawal func()
+
+	groupRegistrations []*event.GroupRegistration
+	groupMembers       map[string][]relaychain.StakerAddress
+}
+
+func (mc *mockChain) OnGroupRegistered(
+	func(groupRegistration *event.GroupRegistration),
+) (subscription.EventSubscription, error) {
+	panic("not implemented")
+}
+
+func (mc *mockChain) IsStaleGroup(groupPublicKey []byte) (bool, error) {
+	return true, nil
+}
+
+func (mc *mockChain) GetGroupMembers(
+	groupPublicKey []byte,
+) ([]relaychain.StakerAddress, error) {
+	return mc.groupMembers[string(groupPublicKey)], nil
+}
+
+func (mc *mockChain) PastGroupRegistrations(
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*event.GroupRegistration, error) {
+	return mc.groupRegistrations, nil
+}
+
+func (mc *mockChain) GetGroupMemberRewards(
+	groupPublicKey []byte,
+) (*big.Int, error) {
+	return mc.memberReward, nil
+}
+
+func (mc *mockChain) HasWithdrawnRewards(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+) (bool, error) {
+	return mc.hasWithdrawn, nil
+}
+
+func (mc *mockChain) WithdrawGroupMemberRewards(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+) error {
+	if mc.onWithdrawal != nil {
+		mc.onWithdrawal()
+	}
+
+	if mc.withdrawalError != nil {
+		return mc.withdrawalError
+	}
+
+	mc.withdrawals++
+	return nil
+}
+
+func (mc *mockChain) CurrentGasPrice() (*big.Int, error) {
+	return mc.gasPrice, nil
+}
+
+// persistenceHandleMock keeps the saved data in memory, keyed by directory
+// and file name.
+type persistenceHandleMock struct {
+	data     map[string]map[string][]byte
+	archived []string
+}
+
+func newPersistenceHandleMock() *persistenceHandleMock {
+	return &persistenceHandleMock{data: make(map[string]map[string][]byte)}
+}
+
+func (phm *persistenceHandleMock) Save(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	if _, ok := phm.data[directory]; !ok {
+		phm.data[directory] = make(map[string][]byte)
+	}
+	phm.data[directory][strings.TrimPrefix(name, "/")] = data
+	return nil
+}
+
+func (phm *persistenceHandleMock) ReadAll() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	outputData := make(chan persistence.DataDescriptor, len(phm.data)+1)
+	outputErrors := make(chan error)
+
+	// A membership saved by the group registry to the same handle.
+	outputData <- &testDataDescriptor{"membership_1", "ab12", []byte{0x01}}
+	for directory, files := range phm.data {
+		for name, content := range files {
+			outputData <- &testDataDescriptor{name, directory, content}
+		}
+	}
+
+	close(outputData)
+	close(outputErrors)
+
+	return outputData, outputErrors
+}
+
+func (phm *persistenceHandleMock) Archive(directory string) error {
+	delete(phm.data, directory)
+	phm.archived = append(phm.archived, directory)
+	return nil
+}
+
+type testDataDescriptor struct {
+	name      string
+	directory string
+	content   []byte
+}
+
+func (tdd *testDataDescriptor) Name() string {
+	return tdd.name
+}
+
+func (tdd *testDataDescriptor) Directory() string {
+	return tdd.directory
+}
+
+func (tdd *testDataDescriptor) Content() ([]byte, error) {
+	return tdd.content, nil
+}
@@ -0,0 +1,144 @@
+package rewards
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+const (
+	pendingWithdrawalDirectoryPrefix = "rewards_"
+	pendingWithdrawalFileName        = "pending_withdrawal"
+)
+
+// pendingWithdrawal is a rewards withdrawal not done yet.
+type pendingWithdrawal struct {
+	groupPublicKey []byte
+	memberCount    int
+}
+
+// marshal converts the pending withdrawal to a byte array holding the
+// member count as a 4-byte big-endian integer followed by the group public
+// key.
+func (pw *pendingWithdrawal) marshal() []byte {
+	bytes := make([]byte, 4, 4+len(pw.groupPublicKey))
+	binary.BigEndian.PutUint32(bytes, uint32(pw.memberCount))
+	return append(bytes, pw.groupPublicKey...)
+}
+
+// unmarshal converts a byte array back to the pending withdrawal.
+func (pw *pendingWithdrawal) unmarshal(bytes []byte) error {
+	if len(bytes) <= 4 {
+		return fmt.Errorf("invalid pending withdrawal length [%v]", len(bytes))
+	}
+
+	pw.memberCount = int(binary.BigEndian.Uint32(bytes[:4]))
+	pw.groupPublicKey = append([]byte{}, bytes[4:]...)
+
+	return nil
+}
+
+// pendingWithdrawalDirectory returns the name of the persistence directory
+// holding the pending withdrawal for the given group. The group public key is
+// hashed to keep the directory name short.
+func pendingWithdrawalDirectory(groupPublicKey []byte) string {
+	groupPublicKeyHash := sha256.Sum256(groupPublicKey)
+
+	return pendingWithdrawalDirectoryPrefix +
+		hex.EncodeToString(groupPublicKeyHash[:])
+}
+
+// IsPendingWithdrawalDirectory returns true if the given persistence directory
+// holds a rewards withdrawal not done yet.
+func IsPendingWithdrawalDirectory(directory string) bool {
+	return strings.HasPrefix(directory, pendingWithdrawalDirectoryPrefix)
+}
+
+// storage persists rewards withdrawals not done yet so that they are retried
+// after a client restart as well.
+type storage struct {
+	handle persistence.Handle
+}
+
+func (s *storage) save(withdrawal *pendingWithdrawal) error {
+	return s.handle.Save(
+		withdrawal.marshal(),
+		pendingWithdrawalDirectory(withdrawal.groupPublicKey),
+		"/"+pendingWithdrawalFileName,
+	)
+}
+
+// archive moves the pending withdrawal for the given group to the archive
+// so that it is not retried anymore.
+func (s *storage) archive(groupPublicKey []byte) error {
+	return s.handle.Archive(pendingWithdrawalDirectory(groupPublicKey))
+}
+
+// readAll reads all pending withdrawals which were not archived yet.
+func (s *storage) readAll() (<-chan *pendingWithdrawal, <-chan error) {
+	outputWithdrawals := make(chan *pendingWithdrawal)
+	outputErrors := make(chan error)
+
+	inputData, inputErrors := s.handle.ReadAll()
+
+	// The same as for the group registry storage, data and errors channels
+	// are read at the same time by two goroutines and the output channels
+	// are closed by the third one once both are done.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		wg.Wait()
+		close(outputWithdrawals)
+		close(outputErrors)
+	}()
+
+	go func() {
+		for err := range inputErrors {
+			outputErrors <- err
+		}
+		wg.Done()
+	}()
+
+	go func() {
+		for descriptor := range inputData {
+			// The persistence handle is shared with the group registry
+			// and the distributed key generation; skip all their files.
+			if !IsPendingWithdrawalDirectory(descriptor.Directory()) ||
+				descriptor.Name() != pendingWithdrawalFileName {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				outputErrors <- fmt.Errorf(
+					"could not read pending withdrawal from directory [%v]: [%v]",
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			withdrawal := &pendingWithdrawal{}
+			if err := withdrawal.unmarshal(content); err != nil {
+				outputErrors <- fmt.Errorf(
+					"could not unmarshal pending withdrawal from directory [%v]: [%v]",
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			outputWithdrawals <- withdrawal
+		}
+
+		wg.Done()
+	}()
+
+	return outputWithdrawals, outputErrors
+}
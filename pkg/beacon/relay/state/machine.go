@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/fieldlog"
 	"github.com/keep-network/keep-core/pkg/net"
 )
 
@@ -19,8 +20,26 @@ type Machine struct {
 	channel      net.BroadcastChannel
 	blockCounter chain.BlockCounter
 	initialState State // first state from which execution starts
+
+	ctx                    context.Context
+	logFields              fieldlog.Fields
+	checkpointer           Checkpointer
+	initiationCheckpointer InitiationCheckpointer
 }
 
+// Checkpointer is notified about every state completed by the machine, along
+// with the block height at which the state ended, before the machine moves to
+// the next state. It lets the protocol executed by the machine save its state
+// and resume the execution from the next state after a client restart.
+type Checkpointer func(completedState State, endBlockHeight uint64)
+
+// InitiationCheckpointer is notified about every state initiated by the
+// machine, along with the block height at which the previous state ended.
+// It lets the protocol executed by the machine save messages sent when the
+// state was initiated and send them again if the execution is resumed from
+// that state after a client restart.
+type InitiationCheckpointer func(initiatedState State, startBlockHeight uint64)
+
 // NewMachine returns a new state machine. It requires a broadcast channel and
 // an initialization function for the channel to be able to perform interactions.
 func NewMachine(
@@ -32,11 +51,41 @@ func NewMachine(
 		channel:      channel,
 		blockCounter: blockCounter,
 		initialState: initialState,
+		ctx:          context.Background(),
 	}
 }
 
+// SetContext sets the context of the execution. Once the context is done,
+// the execution is abandoned and an error is returned.
+func (m *Machine) SetContext(ctx context.Context) {
+	m.ctx = ctx
+}
+
+// SetLogFields sets fields, like the seed of the protocol execution, attached
+// to all messages logged during the execution in addition to the member
+// index, the channel name and the phase set by the machine itself.
+func (m *Machine) SetLogFields(fields fieldlog.Fields) {
+	m.logFields = fields
+}
+
+// SetCheckpointer sets the checkpointer notified about every completed state
+// of the execution.
+func (m *Machine) SetCheckpointer(checkpointer Checkpointer) {
+	m.checkpointer = checkpointer
+}
+
+// SetInitiationCheckpointer sets the checkpointer notified about every
+// initiated state of the execution.
+func (m *Machine) SetInitiationCheckpointer(
+	initiationCheckpointer InitiationCheckpointer,
+) {
+	m.initiationCheckpointer = initiationCheckpointer
+}
+
 // Execute state machine starting with initial state up to finalization. It
-// requires the broadcast channel to be pre-initialized.
+// requires the broadcast channel to be pre-initialized. If the execution
+// fails or is cancelled, the state in which the failure occurred is returned
+// along with the error.
 func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
 	recvChan := make(chan net.Message, receiveBuffer)
 	handler := func(msg net.Message) {
@@ -44,95 +93,114 @@ func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
 	}
 
 	currentState := m.initialState
-	ctx, cancelCtx := context.WithCancel(context.Background())
+	ctx, cancelCtx := context.WithCancel(m.ctx)
 	m.channel.Recv(ctx, handler)
 
-	logger.Infof(
-		"[member:%v,channel:%s] waiting for block %v to start execution",
-		currentState.MemberIndex(),
-		m.channel.Name()[:5],
+	machineLogger := fieldlog.New(
+		loggerSubsystem,
+		fieldlog.Fields{
+			fieldlog.MemberIndex: currentState.MemberIndex(),
+			fieldlog.Channel:     m.channel.Name(),
+		},
+	).With(m.logFields)
+
+	machineLogger.Infof(
+		"waiting for block %v to start execution",
 		startBlockHeight,
 	)
 	err := m.blockCounter.WaitForBlockHeight(startBlockHeight)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to wait for the execution start block")
+		return currentState, 0, fmt.Errorf("failed to wait for the execution start block")
 	}
 
 	lastStateEndBlockHeight := startBlockHeight
 
+	stateLogger := stateLoggerFor(machineLogger, currentState)
 	blockWaiter, err := stateTransition(
 		ctx,
 		currentState,
 		lastStateEndBlockHeight,
 		m.blockCounter,
-		m.channel.Name()[:5],
+		stateLogger,
 	)
 	if err != nil {
 		cancelCtx()
-		return nil, 0, err
+		return currentState, 0, err
 	}
+	m.initiated(currentState, lastStateEndBlockHeight)
 
 	for {
 		select {
 		case msg := <-recvChan:
 			err := currentState.Receive(msg)
 			if err != nil {
-				logger.Errorf(
-					"[member:%v,channel:%s, state: %T] failed to receive a message: [%v]",
-					currentState.MemberIndex(),
-					m.channel.Name()[:5],
-					currentState,
+				stateLogger.Errorf(
+					"failed to receive a message: [%v]",
 					err,
 				)
 			}
 
+		case <-m.ctx.Done():
+			cancelCtx()
+			stateLogger.Warningf("execution cancelled")
+			return currentState, 0, fmt.Errorf(
+				"execution cancelled: [%v]",
+				m.ctx.Err(),
+			)
+
 		case lastStateEndBlockHeight := <-blockWaiter:
 			cancelCtx()
+			if m.checkpointer != nil {
+				m.checkpointer(currentState, lastStateEndBlockHeight)
+			}
+
 			nextState := currentState.Next()
 			if nextState == nil {
-				logger.Infof(
-					"[member:%v,channel:%s,state:%T] reached final state at block: [%v]",
-					currentState.MemberIndex(),
-					m.channel.Name()[:5],
-					currentState,
+				stateLogger.Infof(
+					"reached final state at block: [%v]",
 					lastStateEndBlockHeight,
 				)
 				return currentState, lastStateEndBlockHeight, nil
 			}
 
 			currentState = nextState
-			ctx, cancelCtx = context.WithCancel(context.Background())
+			ctx, cancelCtx = context.WithCancel(m.ctx)
 			m.channel.Recv(ctx, handler)
 
+			stateLogger = stateLoggerFor(machineLogger, currentState)
 			blockWaiter, err = stateTransition(
 				ctx,
 				currentState,
 				lastStateEndBlockHeight,
 				m.blockCounter,
-				m.channel.Name()[:5],
+				stateLogger,
 			)
 			if err != nil {
 				cancelCtx()
-				return nil, 0, err
+				return currentState, 0, err
 			}
+			m.initiated(currentState, lastStateEndBlockHeight)
 
 			continue
 		}
 	}
 }
 
+func (m *Machine) initiated(initiatedState State, startBlockHeight uint64) {
+	if m.initiationCheckpointer != nil {
+		m.initiationCheckpointer(initiatedState, startBlockHeight)
+	}
+}
+
 func stateTransition(
 	ctx context.Context,
 	currentState State,
 	lastStateEndBlockHeight uint64,
 	blockCounter chain.BlockCounter,
-	channelName string,
+	stateLogger *fieldlog.Logger,
 ) (<-chan uint64, error) {
-	logger.Infof(
-		"[member:%v,channel:%s,state:%T] transitioning to a new state at block: [%v]",
-		currentState.MemberIndex(),
-		channelName,
-		currentState,
+	stateLogger.Infof(
+		"transitioning to a new state at block: [%v]",
 		lastStateEndBlockHeight,
 	)
 
@@ -167,12 +235,16 @@ func stateTransition(
 		)
 	}
 
-	logger.Infof(
-		"[member:%v,channel:%s,state:%T] transitioned to new state",
-		currentState.MemberIndex(),
-		channelName,
-		currentState,
-	)
+	stateLogger.Infof("transitioned to new state")
 
 	return blockWaiter, nil
 }
+
+func stateLoggerFor(
+	machineLogger *fieldlog.Logger,
+	currentState State,
+) *fieldlog.Logger {
+	return machineLogger.With(fieldlog.Fields{
+		fieldlog.Phase: PhaseName(currentState),
+	})
+}
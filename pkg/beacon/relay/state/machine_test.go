@@ -56,6 +56,24 @@ func TestExecute(t *testing.T) {
 
 	stateMachine := NewMachine(channel, blockCounter, initialState)
 
+	var checkpoints []string
+	stateMachine.SetCheckpointer(func(completedState State, endBlockHeight uint64) {
+		checkpoints = append(
+			checkpoints,
+			fmt.Sprintf("%v-%v", PhaseName(completedState), endBlockHeight),
+		)
+	})
+
+	var initiationCheckpoints []string
+	stateMachine.SetInitiationCheckpointer(
+		func(initiatedState State, startBlockHeight uint64) {
+			initiationCheckpoints = append(
+				initiationCheckpoints,
+				fmt.Sprintf("%v-%v", PhaseName(initiatedState), startBlockHeight),
+			)
+		},
+	)
+
 	finalState, endBlockHeight, err := stateMachine.Execute(1)
 	if err != nil {
 		t.Errorf("unexpected error [%v]", err)
@@ -91,6 +109,74 @@ func TestExecute(t *testing.T) {
 	if !reflect.DeepEqual(expectedTestLog, testLog) {
 		t.Errorf("\nexpected: %v\nactual:   %v\n", expectedTestLog, testLog)
 	}
+
+	expectedCheckpoints := []string{
+		"testState1-3",
+		"testState2-5",
+		"testState3-6",
+		"testState4-8",
+		"testState5-8",
+	}
+
+	if !reflect.DeepEqual(expectedCheckpoints, checkpoints) {
+		t.Errorf(
+			"unexpected checkpoints\nexpected: %v\nactual:   %v\n",
+			expectedCheckpoints,
+			checkpoints,
+		)
+	}
+
+	expectedInitiationCheckpoints := []string{
+		"testState1-1",
+		"testState2-3",
+		"testState3-5",
+		"testState4-6",
+		"testState5-8",
+	}
+
+	if !reflect.DeepEqual(expectedInitiationCheckpoints, initiationCheckpoints) {
+		t.Errorf(
+			"unexpected initiation checkpoints\nexpected: %v\nactual:   %v\n",
+			expectedInitiationCheckpoints,
+			initiationCheckpoints,
+		)
+	}
+}
+
+func TestExecuteCancelled(t *testing.T) {
+	testLog = make(map[uint64][]string)
+
+	localChain := chainLocal.Connect(10, 5, big.NewInt(200))
+	blockCounter, _ = localChain.BlockCounter()
+	provider := netLocal.Connect()
+	channel, err := provider.BroadcastChannelFor("cancellation_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initialState := testState1{
+		memberIndex: group.MemberIndex(1),
+		channel:     channel,
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	stateMachine := NewMachine(channel, blockCounter, initialState)
+	stateMachine.SetContext(ctx)
+
+	go func() {
+		blockCounter.WaitForBlockHeight(4)
+		cancelCtx()
+	}()
+
+	lastState, _, err := stateMachine.Execute(1)
+	if err == nil {
+		t.Fatal("expected execution to be cancelled")
+	}
+
+	if _, ok := lastState.(*testState2); !ok {
+		t.Errorf("unexpected last state [%T]", lastState)
+	}
 }
 
 func addToTestLog(testState State, functionName string) {
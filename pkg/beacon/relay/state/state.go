@@ -2,6 +2,8 @@ package state
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/ipfs/go-log"
 
@@ -9,7 +11,9 @@ import (
 	"github.com/keep-network/keep-core/pkg/net"
 )
 
-var logger = log.Logger("keep-relay-state")
+const loggerSubsystem = "keep-relay-state"
+
+var logger = log.Logger(loggerSubsystem)
 
 // State is and interface against which relay states should be implemented.
 type State interface {
@@ -58,3 +62,23 @@ const SilentStateDelayBlocks = 0
 // Such state is active only for a time needed to perform required computations
 // and not any longer.
 const SilentStateActiveBlocks = 0
+
+// PhaseName returns a human-readable name of the protocol phase represented
+// by the given state, e.g. `ephemeralKeyPairGeneration` for
+// `*gjkr.ephemeralKeyPairGenerationState`. A state wrapping another state,
+// exposing it with an `Unwrap() State` function, represents the phase of the
+// wrapped state.
+func PhaseName(currentState State) string {
+	if currentState == nil {
+		return "unknown"
+	}
+
+	if wrapper, ok := currentState.(interface{ Unwrap() State }); ok {
+		return PhaseName(wrapper.Unwrap())
+	}
+
+	name := fmt.Sprintf("%T", currentState)
+	name = name[strings.LastIndex(name, ".")+1:]
+
+	return strings.TrimSuffix(name, "State")
+}
@@ -0,0 +1,260 @@
+// Package unauthorizedsigning detects leaks of group private key shares and
+// reports them to the chain.
+//
+// A group private key share must never be used outside of the threshold
+// signing of relay entries. A signature share over the operator's address
+// broadcast by a group member proves the member's share is in hands which
+// use it to sign arbitrary messages. Once the honest threshold of such
+// shares is seen, the group signature over the operator's address can be
+// restored. The group signature proves the group private key is effectively
+// known outside of the group and, reported to the chain, lets the chain
+// terminate the group and reward the reporting operator.
+package unauthorizedsigning
+
+import (
+	"context"
+	"fmt"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-core/pkg/altbn128"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/bls"
+	"github.com/keep-network/keep-core/pkg/fieldlog"
+	"github.com/keep-network/keep-core/pkg/metrics"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+const loggerSubsystem = "keep-unauthorizedsigning"
+
+var logger = log.Logger(loggerSubsystem)
+
+var (
+	detectedShares = metrics.NewCounterVec(
+		"keep_unauthorized_signing_shares_total",
+		"Number of signature shares over the operator's address made with "+
+			"group private key shares.",
+		metrics.OperatorLabel,
+	)
+	submittedReports = metrics.NewCounterVec(
+		"keep_unauthorized_signing_reports_total",
+		"Number of unauthorized signing reports submitted to the chain.",
+		metrics.OperatorLabel,
+	)
+)
+
+// Monitor watches the broadcast channel of the group the signer is a member
+// of for signature shares over the operator's address. Once the honest
+// threshold of valid shares is collected, the group signature over the
+// operator's address is restored and reported to the chain as a proof of
+// the group private key leak.
+//
+// Monitor blocks until the report is submitted or the context is done.
+// The channel has to have relay entry unmarshallers registered.
+func Monitor(
+	ctx context.Context,
+	channel net.BroadcastChannel,
+	relayChain relaychain.Interface,
+	signer *dkg.ThresholdSigner,
+	honestThreshold int,
+	operatorAddress []byte,
+) error {
+	monitorLogger := fieldlog.New(
+		loggerSubsystem,
+		fieldlog.Fields{
+			fieldlog.Channel:        channel.Name(),
+			fieldlog.GroupPublicKey: fmt.Sprintf("0x%x", signer.GroupPublicKeyBytes()),
+		},
+	)
+
+	detector := newDetector(signer, honestThreshold, operatorAddress)
+	operatorLabel := metrics.OperatorLabelValue(operatorAddress)
+
+	// The handler is unregistered once the monitoring ends, no matter if the
+	// report was submitted or the monitoring failed.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	receiveChannel := make(chan net.Message, 64)
+	channel.Recv(ctx, func(netMessage net.Message) {
+		select {
+		case receiveChannel <- netMessage:
+		case <-ctx.Done():
+		}
+	})
+
+	for {
+		select {
+		case netMessage := <-receiveChannel:
+			message, ok := netMessage.Payload().(*entry.SignatureShareMessage)
+			if !ok {
+				continue
+			}
+
+			if !detector.addShare(message) {
+				continue
+			}
+
+			detectedShares.WithLabelValues(operatorLabel).Inc()
+			monitorLogger.Warningf(
+				"member [%v] signed the operator's address "+
+					"with its group private key share",
+				message.SenderID(),
+			)
+
+			if !detector.isThresholdReached() {
+				continue
+			}
+
+			proof, err := detector.proof()
+			if err != nil {
+				return fmt.Errorf(
+					"could not build unauthorized signing proof: [%v]",
+					err,
+				)
+			}
+
+			return report(
+				relayChain,
+				signer,
+				proof,
+				operatorLabel,
+				monitorLogger,
+			)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func report(
+	relayChain relaychain.Interface,
+	signer *dkg.ThresholdSigner,
+	proof []byte,
+	operatorLabel string,
+	monitorLogger *fieldlog.Logger,
+) error {
+	groupPublicKey := signer.GroupPublicKeyBytes()
+
+	// The chain accepts reports only for groups which are not stale.
+	isStale, err := relayChain.IsStaleGroup(groupPublicKey)
+	if err != nil {
+		return fmt.Errorf("could not check if group is stale: [%v]", err)
+	}
+	if isStale {
+		monitorLogger.Warningf(
+			"group private key leaked but the group is already stale; " +
+				"not reporting unauthorized signing",
+		)
+		return nil
+	}
+
+	monitorLogger.Infof("reporting unauthorized signing")
+
+	err = relayChain.ReportUnauthorizedSigning(groupPublicKey, proof)
+	if err != nil {
+		return fmt.Errorf(
+			"could not report unauthorized signing: [%v]",
+			err,
+		)
+	}
+
+	submittedReports.WithLabelValues(operatorLabel).Inc()
+	monitorLogger.Infof("unauthorized signing reported")
+
+	return nil
+}
+
+// detector collects signature shares over the operator's address and
+// restores the group signature from them.
+type detector struct {
+	signer          *dkg.ThresholdSigner
+	honestThreshold int
+	message         *bn256.G1
+	operatorAddress []byte
+
+	shares map[group.MemberIndex]*bn256.G1
+}
+
+func newDetector(
+	signer *dkg.ThresholdSigner,
+	honestThreshold int,
+	operatorAddress []byte,
+) *detector {
+	return &detector{
+		signer:          signer,
+		honestThreshold: honestThreshold,
+		message:         altbn128.G1HashToPoint(operatorAddress),
+		operatorAddress: operatorAddress,
+		shares:          make(map[group.MemberIndex]*bn256.G1),
+	}
+}
+
+// addShare validates the share carried by the message against the public key
+// share of the sender and the operator's address. It returns true if the
+// share is a valid signature over the operator's address not seen before.
+func (d *detector) addShare(message *entry.SignatureShareMessage) bool {
+	if _, ok := d.shares[message.SenderID()]; ok {
+		return false
+	}
+
+	publicKeyShare, ok := d.signer.GroupPublicKeyShares()[message.SenderID()]
+	if !ok {
+		return false
+	}
+
+	share := new(bn256.G1)
+	if _, err := share.Unmarshal(message.ShareBytes()); err != nil {
+		return false
+	}
+
+	// Signature shares over relay entries do not verify against the
+	// operator's address, only the unauthorized ones do.
+	if !bls.VerifyG1(publicKeyShare, d.message, share) {
+		return false
+	}
+
+	d.shares[message.SenderID()] = share
+	return true
+}
+
+func (d *detector) isThresholdReached() bool {
+	return len(d.shares) >= d.honestThreshold
+}
+
+// proof restores the group signature over the operator's address from the
+// collected shares and returns it in the form accepted by the chain.
+func (d *detector) proof() ([]byte, error) {
+	signatureShares := make([]*bls.SignatureShare, 0, len(d.shares))
+	for memberID, share := range d.shares {
+		signatureShares = append(
+			signatureShares,
+			&bls.SignatureShare{I: int(memberID), V: share},
+		)
+	}
+
+	signature, err := d.signer.CompleteSignature(
+		signatureShares,
+		d.honestThreshold,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	groupPublicKey := new(bn256.G2)
+	if _, err := groupPublicKey.Unmarshal(
+		d.signer.GroupPublicKeyBytes(),
+	); err != nil {
+		return nil, err
+	}
+
+	if !bls.Verify(groupPublicKey, d.operatorAddress, signature) {
+		return nil, fmt.Errorf("restored group signature is invalid")
+	}
+
+	return signature.Marshal(), nil
+}
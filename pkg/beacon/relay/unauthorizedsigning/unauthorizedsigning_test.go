@@ -0,0 +1,131 @@
+package unauthorizedsigning
+
+import (
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/entry"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/group"
+	"github.com/keep-network/keep-core/pkg/bls"
+)
+
+var operatorAddress = []byte{
+	0x65, 0xea, 0x55, 0xc1, 0xf1, 0x0e, 0xd3, 0x1b, 0x06, 0x16,
+	0x2c, 0x8b, 0x3f, 0x4b, 0x57, 0x58, 0x33, 0xc9, 0x2c, 0xb5,
+}
+
+func TestDetectUnauthorizedSigning(t *testing.T) {
+	groupSize := 5
+	honestThreshold := 3
+
+	groupPrivateKey := big.NewInt(8765432109)
+	signers, privateKeyShares := newTestSigners(
+		groupPrivateKey,
+		groupSize,
+		honestThreshold,
+	)
+
+	detector := newDetector(signers[0], honestThreshold, operatorAddress)
+
+	// Relay entry signature shares must not be taken as a proof.
+	previousEntry := new(bn256.G1).ScalarBaseMult(big.NewInt(1337))
+	relayEntryShare := signers[1].CalculateSignatureShare(previousEntry)
+	if detector.addShare(entry.NewSignatureShareMessage(
+		signers[1].MemberID(),
+		relayEntryShare.Marshal(),
+	)) {
+		t.Errorf("relay entry signature share accepted")
+	}
+
+	// Signature share over the operator's address made with the private
+	// key share of another member must not be accepted.
+	if detector.addShare(entry.NewSignatureShareMessage(
+		signers[1].MemberID(),
+		bls.Sign(privateKeyShares[2], operatorAddress).Marshal(),
+	)) {
+		t.Errorf("signature share of another member accepted")
+	}
+
+	for i := 1; i <= honestThreshold; i++ {
+		if detector.isThresholdReached() {
+			t.Fatalf("threshold reached after [%v] shares", i-1)
+		}
+
+		message := entry.NewSignatureShareMessage(
+			signers[i].MemberID(),
+			bls.Sign(privateKeyShares[i], operatorAddress).Marshal(),
+		)
+		if !detector.addShare(message) {
+			t.Fatalf("valid share of member [%v] rejected", signers[i].MemberID())
+		}
+		if detector.addShare(message) {
+			t.Fatalf("share of member [%v] accepted twice", signers[i].MemberID())
+		}
+	}
+
+	if !detector.isThresholdReached() {
+		t.Fatalf("threshold not reached")
+	}
+
+	proof, err := detector.proof()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedProof := bls.Sign(groupPrivateKey, operatorAddress).Marshal()
+	if string(expectedProof) != string(proof) {
+		t.Errorf(
+			"unexpected proof\nexpected: [%x]\nactual:   [%x]",
+			expectedProof,
+			proof,
+		)
+	}
+}
+
+// newTestSigners splits the group private key between members with
+// a random polynomial of degree honestThreshold - 1 and returns signers of
+// all members together with their private key shares.
+func newTestSigners(
+	groupPrivateKey *big.Int,
+	groupSize int,
+	honestThreshold int,
+) ([]*dkg.ThresholdSigner, []*big.Int) {
+	coefficients := []*big.Int{groupPrivateKey}
+	for i := 1; i < honestThreshold; i++ {
+		coefficients = append(coefficients, big.NewInt(int64(1000+i)))
+	}
+
+	privateKeyShares := make([]*big.Int, groupSize)
+	publicKeyShares := make(map[group.MemberIndex]*bn256.G2)
+	for i := 0; i < groupSize; i++ {
+		memberIndex := big.NewInt(int64(i + 1))
+
+		share := big.NewInt(0)
+		for j := len(coefficients) - 1; j >= 0; j-- {
+			share.Mul(share, memberIndex)
+			share.Add(share, coefficients[j])
+		}
+		share.Mod(share, bn256.Order)
+
+		privateKeyShares[i] = share
+		publicKeyShares[group.MemberIndex(i+1)] = new(bn256.G2).ScalarBaseMult(
+			share,
+		)
+	}
+
+	groupPublicKey := new(bn256.G2).ScalarBaseMult(groupPrivateKey)
+
+	signers := make([]*dkg.ThresholdSigner, groupSize)
+	for i := 0; i < groupSize; i++ {
+		signers[i] = dkg.NewThresholdSigner(
+			group.MemberIndex(i+1),
+			groupPublicKey,
+			privateKeyShares[i],
+			publicKeyShares,
+		)
+	}
+
+	return signers, privateKeyShares
+}
@@ -0,0 +1,298 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/metrics"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+var (
+	stakeSlashings = metrics.NewCounterVec(
+		"keep_stake_slashings_total",
+		"Number of times tokens have been slashed from the operator stake.",
+		metrics.OperatorLabel,
+	)
+	slashedTokens = metrics.NewCounterVec(
+		"keep_stake_slashed_tokens_total",
+		"Amount of tokens slashed from the operator stake.",
+		metrics.OperatorLabel,
+	)
+	stakeSeizures = metrics.NewCounterVec(
+		"keep_stake_seizures_total",
+		"Number of times tokens have been seized from the operator stake.",
+		metrics.OperatorLabel,
+	)
+	seizedTokens = metrics.NewCounterVec(
+		"keep_stake_seized_tokens_total",
+		"Amount of tokens seized from the operator stake.",
+		metrics.OperatorLabel,
+	)
+	stakeUndelegations = metrics.NewCounterVec(
+		"keep_stake_undelegations_total",
+		"Number of undelegations of the operator stake.",
+		metrics.OperatorLabel,
+	)
+	stakeLocks = metrics.NewCounterVec(
+		"keep_stake_locks_total",
+		"Number of locks put on the operator stake.",
+		metrics.OperatorLabel,
+	)
+	stakeLockReleases = metrics.NewCounterVec(
+		"keep_stake_lock_releases_total",
+		"Number of locks released from the operator stake.",
+		metrics.OperatorLabel,
+	)
+	operatorsWithMinimumStake = metrics.NewGaugeVec(
+		"keep_stake_operators_with_minimum_stake",
+		"Number of operators having the minimum stake.",
+		metrics.OperatorLabel,
+	)
+)
+
+// stakeStatus tracks whether the operator has the minimum stake required to
+// take part in group selections. The client is started only if the operator
+// has the minimum stake, so it is assumed to have it initially.
+type stakeStatus struct {
+	stakeMonitor chain.StakeMonitor
+	address      string
+	// operator is the value of the operator label of stake metrics
+	operator string
+
+	mutex           sync.Mutex
+	hasMinimumStake bool
+	ctx             context.Context
+	cancelCtx       context.CancelFunc
+}
+
+func newStakeStatus(
+	stakeMonitor chain.StakeMonitor,
+	address string,
+	operator relaychain.StakerAddress,
+) *stakeStatus {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	operatorLabel := metrics.OperatorLabelValue(operator)
+	operatorsWithMinimumStake.WithLabelValues(operatorLabel).Inc()
+
+	return &stakeStatus{
+		stakeMonitor:    stakeMonitor,
+		address:         address,
+		operator:        operatorLabel,
+		hasMinimumStake: true,
+		ctx:             ctx,
+		cancelCtx:       cancelCtx,
+	}
+}
+
+// ticketSubmissionContext returns a context which is done once the stake of
+// the operator drops below the minimum. The returned flag is false if the
+// operator does not have the minimum stake already.
+func (ss *stakeStatus) ticketSubmissionContext() (context.Context, bool) {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	return ss.ctx, ss.hasMinimumStake
+}
+
+// check checks whether the operator still has the minimum stake. Ticket
+// submission contexts are done once the stake drops below the minimum and
+// new ones are created if the operator has the minimum stake again.
+func (ss *stakeStatus) check() {
+	hasMinimumStake, err := ss.stakeMonitor.HasMinimumStake(ss.address)
+	if err != nil {
+		logger.Errorf(
+			"could not check the stake of operator [%v]: [%v]",
+			ss.address,
+			err,
+		)
+		return
+	}
+
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	if hasMinimumStake == ss.hasMinimumStake {
+		return
+	}
+
+	ss.hasMinimumStake = hasMinimumStake
+
+	if !hasMinimumStake {
+		logger.Errorf(
+			"operator [%v] no longer has the minimum stake; "+
+				"stopping ticket submission for new groups",
+			ss.address,
+		)
+		operatorsWithMinimumStake.WithLabelValues(ss.operator).Dec()
+		ss.cancelCtx()
+		return
+	}
+
+	logger.Infof(
+		"operator [%v] has the minimum stake again; "+
+			"resuming ticket submission for new groups",
+		ss.address,
+	)
+	operatorsWithMinimumStake.WithLabelValues(ss.operator).Inc()
+	ss.ctx, ss.cancelCtx = context.WithCancel(context.Background())
+}
+
+// hasMinimum returns whether the operator had the minimum stake when the
+// stake was last checked. It does not check the stake on-chain.
+func (ss *stakeStatus) hasMinimum() bool {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	return ss.hasMinimumStake
+}
+
+// watchStake subscribes to stake events of the operator. Slashing, seizing
+// and undelegation of the stake are logged as errors and make the stake
+// status check whether the operator still has the minimum stake. Locks put
+// on the stake and their releases are logged for information only.
+func watchStake(status *stakeStatus) ([]subscription.EventSubscription, error) {
+	stakeMonitor := status.stakeMonitor
+	address := status.address
+	operator := status.operator
+
+	subscriptions := make([]subscription.EventSubscription, 0)
+
+	slashedSubscription, err := stakeMonitor.OnTokensSlashed(
+		address,
+		func(slashed *event.TokensSlashed) {
+			logger.Errorf(
+				"[%v] tokens have been slashed from the stake of "+
+					"operator [%v] at block [%v] in transaction [%v]",
+				slashed.Amount,
+				address,
+				slashed.BlockNumber,
+				slashed.TransactionHash,
+			)
+			stakeSlashings.WithLabelValues(operator).Inc()
+			slashedTokens.WithLabelValues(operator).Add(tokens(slashed.Amount))
+			status.check()
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not subscribe for slashed tokens: [%v]",
+			err,
+		)
+	}
+	subscriptions = append(subscriptions, slashedSubscription)
+
+	seizedSubscription, err := stakeMonitor.OnTokensSeized(
+		address,
+		func(seized *event.TokensSeized) {
+			logger.Errorf(
+				"[%v] tokens have been seized from the stake of "+
+					"operator [%v] at block [%v] in transaction [%v]",
+				seized.Amount,
+				address,
+				seized.BlockNumber,
+				seized.TransactionHash,
+			)
+			stakeSeizures.WithLabelValues(operator).Inc()
+			seizedTokens.WithLabelValues(operator).Add(tokens(seized.Amount))
+			status.check()
+		},
+	)
+	if err != nil {
+		unsubscribeAll(subscriptions)
+		return nil, fmt.Errorf(
+			"could not subscribe for seized tokens: [%v]",
+			err,
+		)
+	}
+	subscriptions = append(subscriptions, seizedSubscription)
+
+	undelegatedSubscription, err := stakeMonitor.OnUndelegated(
+		address,
+		func(undelegated *event.Undelegated) {
+			logger.Errorf(
+				"stake of operator [%v] has been undelegated at block [%v] "+
+					"in transaction [%v]; undelegation timestamp is [%v]",
+				address,
+				undelegated.BlockNumber,
+				undelegated.TransactionHash,
+				undelegated.UndelegatedAt,
+			)
+			stakeUndelegations.WithLabelValues(operator).Inc()
+			status.check()
+		},
+	)
+	if err != nil {
+		unsubscribeAll(subscriptions)
+		return nil, fmt.Errorf(
+			"could not subscribe for stake undelegations: [%v]",
+			err,
+		)
+	}
+	subscriptions = append(subscriptions, undelegatedSubscription)
+
+	lockedSubscription, err := stakeMonitor.OnStakeLocked(
+		address,
+		func(locked *event.StakeLocked) {
+			logger.Infof(
+				"stake of operator [%v] has been locked by [0x%x] "+
+					"until [%v] at block [%v]",
+				address,
+				locked.LockCreator,
+				locked.Until,
+				locked.BlockNumber,
+			)
+			stakeLocks.WithLabelValues(operator).Inc()
+		},
+	)
+	if err != nil {
+		unsubscribeAll(subscriptions)
+		return nil, fmt.Errorf(
+			"could not subscribe for stake locks: [%v]",
+			err,
+		)
+	}
+	subscriptions = append(subscriptions, lockedSubscription)
+
+	releasedSubscription, err := stakeMonitor.OnLockReleased(
+		address,
+		func(released *event.LockReleased) {
+			logger.Infof(
+				"lock of [0x%x] on the stake of operator [%v] "+
+					"has been released at block [%v]",
+				released.LockCreator,
+				address,
+				released.BlockNumber,
+			)
+			stakeLockReleases.WithLabelValues(operator).Inc()
+		},
+	)
+	if err != nil {
+		unsubscribeAll(subscriptions)
+		return nil, fmt.Errorf(
+			"could not subscribe for stake lock releases: [%v]",
+			err,
+		)
+	}
+	subscriptions = append(subscriptions, releasedSubscription)
+
+	return subscriptions, nil
+}
+
+// tokens converts the amount of tokens to the metric value.
+func tokens(amount *big.Int) float64 {
+	value, _ := new(big.Float).SetInt(amount).Float64()
+	return value
+}
+
+func unsubscribeAll(subscriptions []subscription.EventSubscription) {
+	for _, eventSubscription := range subscriptions {
+		eventSubscription.Unsubscribe()
+	}
+}
@@ -0,0 +1,156 @@
+package beacon
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/chain/local"
+)
+
+func TestStakeStatus(t *testing.T) {
+	address := "0x524f2e0176350d950fa630d9a5a59a0a190daf48"
+	minimumStake := big.NewInt(200)
+
+	var tests = map[string]struct {
+		changeStake             func(stakeMonitor *local.StakeMonitor) error
+		expectedHasMinimumStake bool
+	}{
+		"stake slashed below the minimum": {
+			changeStake: func(stakeMonitor *local.StakeMonitor) error {
+				_, err := stakeMonitor.SlashTokens(address, big.NewInt(900))
+				return err
+			},
+			expectedHasMinimumStake: false,
+		},
+		"stake seized below the minimum": {
+			changeStake: func(stakeMonitor *local.StakeMonitor) error {
+				_, err := stakeMonitor.SeizeTokens(address, big.NewInt(801))
+				return err
+			},
+			expectedHasMinimumStake: false,
+		},
+		"stake slashed down to the minimum": {
+			changeStake: func(stakeMonitor *local.StakeMonitor) error {
+				_, err := stakeMonitor.SlashTokens(address, big.NewInt(800))
+				return err
+			},
+			expectedHasMinimumStake: true,
+		},
+		"stake undelegated": {
+			changeStake: func(stakeMonitor *local.StakeMonitor) error {
+				return stakeMonitor.UnstakeTokens(address)
+			},
+			expectedHasMinimumStake: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			stakeMonitor := local.NewStakeMonitor(minimumStake)
+			if err := stakeMonitor.StakeTokens(address); err != nil {
+				t.Fatal(err)
+			}
+
+			status := newStakeStatus(stakeMonitor, address, []byte(address))
+			subscriptions, err := watchStake(status)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer unsubscribeAll(subscriptions)
+
+			ticketSubmissionCtx, _ := status.ticketSubmissionContext()
+
+			if err := test.changeStake(stakeMonitor); err != nil {
+				t.Fatal(err)
+			}
+
+			if test.expectedHasMinimumStake {
+				// Give the status a moment to react to the event.
+				time.Sleep(100 * time.Millisecond)
+			} else {
+				select {
+				case <-ticketSubmissionCtx.Done():
+				case <-time.After(time.Second):
+					t.Fatal("expected ticket submission to be stopped")
+				}
+			}
+
+			_, hasMinimumStake := status.ticketSubmissionContext()
+			if hasMinimumStake != test.expectedHasMinimumStake {
+				t.Errorf(
+					"unexpected minimum stake status\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedHasMinimumStake,
+					hasMinimumStake,
+				)
+			}
+			if ticketSubmissionCtx.Err() == nil != test.expectedHasMinimumStake {
+				t.Errorf(
+					"unexpected ticket submission state\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedHasMinimumStake,
+					ticketSubmissionCtx.Err() == nil,
+				)
+			}
+		})
+	}
+}
+
+func TestStakeStatusRestored(t *testing.T) {
+	address := "0x524f2e0176350d950fa630d9a5a59a0a190daf48"
+
+	stakeMonitor := local.NewStakeMonitor(big.NewInt(200))
+	if err := stakeMonitor.StakeTokens(address); err != nil {
+		t.Fatal(err)
+	}
+
+	status := newStakeStatus(stakeMonitor, address, []byte(address))
+
+	if err := stakeMonitor.UnstakeTokens(address); err != nil {
+		t.Fatal(err)
+	}
+	status.check()
+
+	if _, hasMinimumStake := status.ticketSubmissionContext(); hasMinimumStake {
+		t.Fatal("operator should not have the minimum stake once unstaked")
+	}
+
+	if err := stakeMonitor.StakeTokens(address); err != nil {
+		t.Fatal(err)
+	}
+	status.check()
+
+	ticketSubmissionCtx, hasMinimumStake := status.ticketSubmissionContext()
+	if !hasMinimumStake {
+		t.Fatal("operator should have the minimum stake once staked again")
+	}
+	if ticketSubmissionCtx.Err() != nil {
+		t.Errorf("ticket submission should be possible once staked again")
+	}
+}
+
+func TestStakeStatusHasMinimum(t *testing.T) {
+	address := "0x524f2e0176350d950fa630d9a5a59a0a190daf48"
+
+	stakeMonitor := local.NewStakeMonitor(big.NewInt(200))
+	if err := stakeMonitor.StakeTokens(address); err != nil {
+		t.Fatal(err)
+	}
+
+	status := newStakeStatus(stakeMonitor, address, []byte(address))
+
+	if err := stakeMonitor.UnstakeTokens(address); err != nil {
+		t.Fatal(err)
+	}
+
+	if !status.hasMinimum() {
+		t.Fatal("stake status should not change until the stake is checked")
+	}
+
+	status.check()
+
+	if status.hasMinimum() {
+		t.Fatal("operator should not have the minimum stake once unstaked")
+	}
+}
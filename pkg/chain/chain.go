@@ -3,9 +3,13 @@ package chain
 import (
 	"context"
 	"crypto/ecdsa"
+	"math/big"
+	"time"
 
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/gen/async"
+	"github.com/keep-network/keep-core/pkg/subscription"
 )
 
 // BlockCounter is an interface that provides the ability to wait for a certain
@@ -49,6 +53,41 @@ type StakeMonitor interface {
 
 	// StakerFor returns a Staker for the given address.
 	StakerFor(address string) (Staker, error)
+
+	// OnTokensSlashed registers a handler of slashing of the stake of the
+	// operator with the given address.
+	OnTokensSlashed(
+		address string,
+		handler func(slashed *event.TokensSlashed),
+	) (subscription.EventSubscription, error)
+
+	// OnTokensSeized registers a handler of seizing of the stake of the
+	// operator with the given address.
+	OnTokensSeized(
+		address string,
+		handler func(seized *event.TokensSeized),
+	) (subscription.EventSubscription, error)
+
+	// OnUndelegated registers a handler of undelegation of the stake of the
+	// operator with the given address.
+	OnUndelegated(
+		address string,
+		handler func(undelegated *event.Undelegated),
+	) (subscription.EventSubscription, error)
+
+	// OnStakeLocked registers a handler of locks put on the stake of the
+	// operator with the given address.
+	OnStakeLocked(
+		address string,
+		handler func(locked *event.StakeLocked),
+	) (subscription.EventSubscription, error)
+
+	// OnLockReleased registers a handler of releases of locks put on the
+	// stake of the operator with the given address.
+	OnLockReleased(
+		address string,
+		handler func(released *event.LockReleased),
+	) (subscription.EventSubscription, error)
 }
 
 // Signing is an interface that provides ability to sign and verify
@@ -104,5 +143,70 @@ type Utility interface {
 	Handle
 
 	Genesis() error
-	RequestRelayEntry() *async.EventEntryGeneratedPromise
+	// RequestRelayEntry requests a new entry from the threshold relay and
+	// returns a promise fulfilled once the request has been seen on-chain.
+	// If the callback contract address is not empty, the new entry is passed
+	// to the `__beaconCallback(uint256)` method of that contract which is
+	// allowed to use at most callbackGas gas.
+	RequestRelayEntry(
+		callbackContract string,
+		callbackGas *big.Int,
+	) *async.EventEntryRequestedPromise
+	// OnRelayEntryGenerated registers a callback that is invoked when an
+	// on-chain notification of a new relay entry generated for a relay
+	// consumer is seen.
+	OnRelayEntryGenerated(
+		func(entry *event.EntryGenerated),
+	) (subscription.EventSubscription, error)
+	// EntryFeeEstimate returns the fee a relay consumer has to pay for a new
+	// relay entry with a callback using at most callbackGas gas.
+	EntryFeeEstimate(callbackGas *big.Int) (*big.Int, error)
+	// EntryFeeBreakdown returns the components of the relay entry fee.
+	EntryFeeBreakdown() (*EntryFeeBreakdown, error)
+	// PastRelayEntryRequests returns all relay entry requests seen on-chain
+	// between fromBlock and toBlock, inclusive, ordered by block number.
+	PastRelayEntryRequests(fromBlock, toBlock uint64) ([]*event.Request, error)
+	// RelayRequestID returns the id of the given relay request seen on-chain.
+	// The request id is not known for requests repeated after a relay entry
+	// timeout; an error is returned for them.
+	RelayRequestID(request *event.Request) (*big.Int, error)
+	// PastRelayEntries returns all relay entries submitted on-chain between
+	// fromBlock and toBlock, inclusive, ordered by block number.
+	PastRelayEntries(fromBlock, toBlock uint64) ([]*RelayEntry, error)
+	// SubmittedRelayEntry returns the relay entry submitted on-chain in the
+	// transaction which emitted the given submission event.
+	SubmittedRelayEntry(submission *event.EntrySubmitted) ([]byte, error)
+	// RequestGroupPublicKey returns the public key of the group which was
+	// serving the relay request at the given block. The lookup requires the
+	// chain state at that block to be available.
+	RequestGroupPublicKey(blockNumber uint64) ([]byte, error)
+	// PastGroupMemberRewardsWithdrawals returns all group member rewards
+	// withdrawals seen on-chain between fromBlock and toBlock, inclusive,
+	// ordered by block number.
+	PastGroupMemberRewardsWithdrawals(
+		fromBlock uint64,
+		toBlock uint64,
+	) ([]*event.GroupMemberRewardsWithdrawal, error)
+	// BlockTime returns the time at which the block with the given number
+	// was mined.
+	BlockTime(blockNumber uint64) (time.Time, error)
+}
+
+// RelayEntry represents a relay entry submitted to the chain. The entry is
+// the group signature over the previous entry in the form it has been
+// submitted on-chain. RequestID is nil if the id of the relay request the
+// entry has been submitted for could not be determined.
+type RelayEntry struct {
+	RequestID   *big.Int
+	Entry       []byte
+	BlockNumber uint64
+}
+
+// EntryFeeBreakdown represents the components of the fee paid for a new relay
+// entry, expressed in the smallest unit of the chain's currency.
+type EntryFeeBreakdown struct {
+	EntryVerificationFee *big.Int
+	DkgContributionFee   *big.Int
+	GroupProfitFee       *big.Int
+	GasPriceCeiling      *big.Int
 }
@@ -0,0 +1,755 @@
+package devchain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"net/rpc"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	relayconfig "github.com/keep-network/keep-core/pkg/beacon/relay/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/gen/async"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+// retryDelay is the time the client waits before polling the server again
+// after a failed poll.
+var retryDelay = time.Second
+
+// Handle represents a handle to the development chain. In addition to the
+// chain.Handle functionality, it lets request relay entries and start the
+// first group selection.
+type Handle interface {
+	chain.Handle
+
+	// RequestRelayEntry requests a new relay entry from one of the
+	// registered groups.
+	RequestRelayEntry() (*event.Request, error)
+
+	// Genesis starts the first group selection.
+	Genesis() error
+}
+
+type devChain struct {
+	client       *rpc.Client
+	operatorKey  *ecdsa.PrivateKey
+	blockCounter chain.BlockCounter
+
+	handlerMutex                  sync.Mutex
+	relayEntryHandlers            map[int]func(entry *event.EntrySubmitted)
+	relayRequestHandlers          map[int]func(request *event.Request)
+	groupSelectionStartedHandlers map[int]func(groupSelectionStart *event.GroupSelectionStart)
+	groupRegisteredHandlers       map[int]func(groupRegistration *event.GroupRegistration)
+	resultSubmissionHandlers      map[int]func(submission *event.DKGResultSubmission)
+	stakeHandlers                 map[int]*stakeHandler
+}
+
+// stakeHandler is a handler of stake events of the operator with the given
+// address.
+type stakeHandler struct {
+	operator common.Address
+	handle   func(e *Event)
+}
+
+// Connect connects to the development chain served on the given unix socket
+// on behalf of the operator with the given key. Events emitted by the chain
+// after the connection is established are delivered to the handlers
+// registered with the returned handle until the context is done.
+func Connect(
+	ctx context.Context,
+	socketPath string,
+	operatorKey *ecdsa.PrivateKey,
+) (Handle, error) {
+	client, err := rpc.Dial("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not connect to development chain on socket [%v]: [%v]",
+			socketPath,
+			err,
+		)
+	}
+
+	var currentBlock uint64
+	if err := client.Call(
+		serviceName+".CurrentBlock",
+		noArgs,
+		&currentBlock,
+	); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("could not get current block: [%v]", err)
+	}
+
+	var lastSequence uint64
+	if err := client.Call(
+		serviceName+".LastEventSequence",
+		noArgs,
+		&lastSequence,
+	); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("could not get last event: [%v]", err)
+	}
+
+	heights := make(chan uint64, 1)
+	heights <- currentBlock
+
+	dc := &devChain{
+		client:                        client,
+		operatorKey:                   operatorKey,
+		blockCounter:                  local.FollowingBlockCounter(heights),
+		relayEntryHandlers:            make(map[int]func(entry *event.EntrySubmitted)),
+		relayRequestHandlers:          make(map[int]func(request *event.Request)),
+		groupSelectionStartedHandlers: make(map[int]func(groupSelectionStart *event.GroupSelectionStart)),
+		groupRegisteredHandlers:       make(map[int]func(groupRegistration *event.GroupRegistration)),
+		resultSubmissionHandlers:      make(map[int]func(submission *event.DKGResultSubmission)),
+		stakeHandlers:                 make(map[int]*stakeHandler),
+	}
+
+	go func() {
+		<-ctx.Done()
+		client.Close()
+	}()
+
+	go dc.pollBlocks(ctx, currentBlock, heights)
+	go dc.pollEvents(ctx, lastSequence)
+
+	return dc, nil
+}
+
+// pollBlocks passes new block heights seen by the server to the block
+// counter until the context is done.
+func (dc *devChain) pollBlocks(
+	ctx context.Context,
+	currentBlock uint64,
+	heights chan<- uint64,
+) {
+	defer close(heights)
+
+	for ctx.Err() == nil {
+		var height uint64
+		if err := dc.client.Call(
+			serviceName+".NextBlock",
+			currentBlock,
+			&height,
+		); err != nil {
+			if !dc.shouldRetry(ctx, "blocks", err) {
+				return
+			}
+			continue
+		}
+
+		if height > currentBlock {
+			currentBlock = height
+			heights <- height
+		}
+	}
+}
+
+// pollEvents passes events emitted by the server after the event with the
+// given sequence number to registered handlers until the context is done.
+func (dc *devChain) pollEvents(ctx context.Context, lastSequence uint64) {
+	for ctx.Err() == nil {
+		var events []*Event
+		if err := dc.client.Call(
+			serviceName+".Events",
+			lastSequence,
+			&events,
+		); err != nil {
+			if !dc.shouldRetry(ctx, "events", err) {
+				return
+			}
+			continue
+		}
+
+		for _, event := range events {
+			if event.Sequence != lastSequence+1 {
+				logger.Warningf(
+					"missed [%v] events emitted by development chain",
+					event.Sequence-lastSequence-1,
+				)
+			}
+			lastSequence = event.Sequence
+
+			dc.dispatch(event)
+		}
+	}
+}
+
+// shouldRetry logs the polling error and waits before the next poll. It
+// returns false if polling should stop because the context is done or the
+// connection to the server has been closed.
+func (dc *devChain) shouldRetry(
+	ctx context.Context,
+	polled string,
+	err error,
+) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err == rpc.ErrShutdown {
+		logger.Errorf(
+			"connection to development chain closed; "+
+				"no longer polling %v",
+			polled,
+		)
+		return false
+	}
+
+	logger.Warningf("could not poll %v: [%v]", polled, err)
+	time.Sleep(retryDelay)
+	return true
+}
+
+func (dc *devChain) dispatch(e *Event) {
+	dc.handlerMutex.Lock()
+	defer dc.handlerMutex.Unlock()
+
+	switch {
+	case e.EntrySubmitted != nil:
+		for _, handler := range dc.relayEntryHandlers {
+			go handler(e.EntrySubmitted)
+		}
+	case e.Request != nil:
+		for _, handler := range dc.relayRequestHandlers {
+			go handler(e.Request)
+		}
+	case e.GroupSelectionStart != nil:
+		for _, handler := range dc.groupSelectionStartedHandlers {
+			go handler(e.GroupSelectionStart)
+		}
+	case e.GroupRegistration != nil:
+		for _, handler := range dc.groupRegisteredHandlers {
+			go handler(e.GroupRegistration)
+		}
+	case e.DKGResultSubmission != nil:
+		for _, handler := range dc.resultSubmissionHandlers {
+			go handler(e.DKGResultSubmission)
+		}
+	case e.operator() != nil:
+		operator := common.BytesToAddress(e.operator())
+		for _, handler := range dc.stakeHandlers {
+			if handler.operator == operator {
+				go handler.handle(e)
+			}
+		}
+	}
+}
+
+func (dc *devChain) call(method string, args interface{}, reply interface{}) error {
+	return dc.client.Call(serviceName+"."+method, args, reply)
+}
+
+func (dc *devChain) BlockCounter() (chain.BlockCounter, error) {
+	return dc.blockCounter, nil
+}
+
+func (dc *devChain) StakeMonitor() (chain.StakeMonitor, error) {
+	return &stakeMonitor{dc}, nil
+}
+
+func (dc *devChain) ThresholdRelay() relaychain.Interface {
+	return dc
+}
+
+func (dc *devChain) Signing() chain.Signing {
+	return newSigning(dc.operatorKey)
+}
+
+func (dc *devChain) RequestRelayEntry() (*event.Request, error) {
+	request := &event.Request{}
+	if err := dc.call("RequestRelayEntry", noArgs, request); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+func (dc *devChain) Genesis() error {
+	return dc.call("Genesis", noArgs, new(bool))
+}
+
+func (dc *devChain) GetConfig() (*relayconfig.Chain, error) {
+	config := &relayconfig.Chain{}
+	if err := dc.call("GetConfig", noArgs, config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (dc *devChain) GetKeys() (*operator.PrivateKey, *operator.PublicKey) {
+	return dc.operatorKey, &dc.operatorKey.PublicKey
+}
+
+func (dc *devChain) SubmitRelayEntry(
+	entry []byte,
+) *async.EventEntrySubmittedPromise {
+	promise := &async.EventEntrySubmittedPromise{}
+
+	go func() {
+		submitted := &event.EntrySubmitted{}
+		if err := dc.call("SubmitRelayEntry", entry, submitted); err != nil {
+			promise.Fail(err)
+			return
+		}
+
+		promise.Fulfill(submitted)
+	}()
+
+	return promise
+}
+
+func (dc *devChain) OnRelayEntrySubmitted(
+	handler func(entry *event.EntrySubmitted),
+) (subscription.EventSubscription, error) {
+	dc.handlerMutex.Lock()
+	defer dc.handlerMutex.Unlock()
+
+	handlerID := rand.Int()
+	dc.relayEntryHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		dc.handlerMutex.Lock()
+		defer dc.handlerMutex.Unlock()
+
+		delete(dc.relayEntryHandlers, handlerID)
+	}), nil
+}
+
+func (dc *devChain) OnRelayEntryRequested(
+	handler func(request *event.Request),
+) (subscription.EventSubscription, error) {
+	dc.handlerMutex.Lock()
+	defer dc.handlerMutex.Unlock()
+
+	handlerID := rand.Int()
+	dc.relayRequestHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		dc.handlerMutex.Lock()
+		defer dc.handlerMutex.Unlock()
+
+		delete(dc.relayRequestHandlers, handlerID)
+	}), nil
+}
+
+// OnRelayEntryRequestRemoved never calls the handler as the development
+// chain is never reorganized.
+func (dc *devChain) OnRelayEntryRequestRemoved(
+	handler func(request *event.Request),
+) (subscription.EventSubscription, error) {
+	return subscription.NewEventSubscription(func() {}), nil
+}
+
+func (dc *devChain) ReportRelayEntryTimeout() error {
+	return dc.call("ReportRelayEntryTimeout", noArgs, new(bool))
+}
+
+func (dc *devChain) IsEntryInProgress() (bool, error) {
+	var isEntryInProgress bool
+	err := dc.call("IsEntryInProgress", noArgs, &isEntryInProgress)
+	return isEntryInProgress, err
+}
+
+func (dc *devChain) CurrentRequestStartBlock() (*big.Int, error) {
+	startBlock := new(big.Int)
+	if err := dc.call("CurrentRequestStartBlock", noArgs, startBlock); err != nil {
+		return nil, err
+	}
+
+	return startBlock, nil
+}
+
+func (dc *devChain) CurrentRequestPreviousEntry() ([]byte, error) {
+	var previousEntry []byte
+	err := dc.call("CurrentRequestPreviousEntry", noArgs, &previousEntry)
+	return previousEntry, err
+}
+
+func (dc *devChain) CurrentRequestGroupPublicKey() ([]byte, error) {
+	var groupPublicKey []byte
+	err := dc.call("CurrentRequestGroupPublicKey", noArgs, &groupPublicKey)
+	return groupPublicKey, err
+}
+
+func (dc *devChain) OnGroupSelectionStarted(
+	handler func(groupSelectionStart *event.GroupSelectionStart),
+) (subscription.EventSubscription, error) {
+	dc.handlerMutex.Lock()
+	defer dc.handlerMutex.Unlock()
+
+	handlerID := rand.Int()
+	dc.groupSelectionStartedHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		dc.handlerMutex.Lock()
+		defer dc.handlerMutex.Unlock()
+
+		delete(dc.groupSelectionStartedHandlers, handlerID)
+	}), nil
+}
+
+// OnGroupSelectionStartRemoved never calls the handler as the development
+// chain is never reorganized.
+func (dc *devChain) OnGroupSelectionStartRemoved(
+	handler func(groupSelectionStart *event.GroupSelectionStart),
+) (subscription.EventSubscription, error) {
+	return subscription.NewEventSubscription(func() {}), nil
+}
+
+func (dc *devChain) SubmitTicket(
+	ticket *relaychain.Ticket,
+) *async.EventGroupTicketSubmissionPromise {
+	promise := &async.EventGroupTicketSubmissionPromise{}
+
+	go func() {
+		submission := &event.GroupTicketSubmission{}
+		if err := dc.call("SubmitTicket", ticket, submission); err != nil {
+			promise.Fail(err)
+			return
+		}
+
+		promise.Fulfill(submission)
+	}()
+
+	return promise
+}
+
+func (dc *devChain) GetSubmittedTickets() ([]uint64, error) {
+	var tickets []uint64
+	err := dc.call("GetSubmittedTickets", noArgs, &tickets)
+	return tickets, err
+}
+
+// GetSelectedParticipants returns addresses of selected stakers. Addresses
+// are restored from ticket staker values, so they are padded with leading
+// zeros lost in the conversion.
+func (dc *devChain) GetSelectedParticipants() ([]relaychain.StakerAddress, error) {
+	var participants []relaychain.StakerAddress
+	if err := dc.call("GetSelectedParticipants", noArgs, &participants); err != nil {
+		return nil, err
+	}
+
+	return toAddresses(participants), nil
+}
+
+func (dc *devChain) IsGroupSelectionPossible() (bool, error) {
+	var isGroupSelectionPossible bool
+	err := dc.call("IsGroupSelectionPossible", noArgs, &isGroupSelectionPossible)
+	return isGroupSelectionPossible, err
+}
+
+func (dc *devChain) PastGroupSelectionStarts(
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	var groupSelectionStarts []*event.GroupSelectionStart
+	err := dc.call(
+		"PastGroupSelectionStarts",
+		BlockRange{fromBlock, toBlock},
+		&groupSelectionStarts,
+	)
+	return groupSelectionStarts, err
+}
+
+func (dc *devChain) OnGroupRegistered(
+	handler func(groupRegistration *event.GroupRegistration),
+) (subscription.EventSubscription, error) {
+	dc.handlerMutex.Lock()
+	defer dc.handlerMutex.Unlock()
+
+	handlerID := rand.Int()
+	dc.groupRegisteredHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		dc.handlerMutex.Lock()
+		defer dc.handlerMutex.Unlock()
+
+		delete(dc.groupRegisteredHandlers, handlerID)
+	}), nil
+}
+
+func (dc *devChain) IsStaleGroup(groupPublicKey []byte) (bool, error) {
+	var isStale bool
+	err := dc.call("IsStaleGroup", groupPublicKey, &isStale)
+	return isStale, err
+}
+
+func (dc *devChain) GetGroupMembers(
+	groupPublicKey []byte,
+) ([]relaychain.StakerAddress, error) {
+	var members []relaychain.StakerAddress
+	if err := dc.call("GetGroupMembers", groupPublicKey, &members); err != nil {
+		return nil, err
+	}
+
+	return toAddresses(members), nil
+}
+
+func (dc *devChain) PastGroupRegistrations(
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*event.GroupRegistration, error) {
+	var groupRegistrations []*event.GroupRegistration
+	err := dc.call(
+		"PastGroupRegistrations",
+		BlockRange{fromBlock, toBlock},
+		&groupRegistrations,
+	)
+	return groupRegistrations, err
+}
+
+func (dc *devChain) GetGroupMemberRewards(groupPublicKey []byte) (*big.Int, error) {
+	rewards := new(big.Int)
+	if err := dc.call("GetGroupMemberRewards", groupPublicKey, rewards); err != nil {
+		return nil, err
+	}
+
+	return rewards, nil
+}
+
+func (dc *devChain) HasWithdrawnRewards(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+) (bool, error) {
+	var hasWithdrawn bool
+	err := dc.call(
+		"HasWithdrawnRewards",
+		RewardsArgs{operator, groupPublicKey},
+		&hasWithdrawn,
+	)
+	return hasWithdrawn, err
+}
+
+func (dc *devChain) WithdrawGroupMemberRewards(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+) error {
+	return dc.call(
+		"WithdrawGroupMemberRewards",
+		RewardsArgs{operator, groupPublicKey},
+		new(bool),
+	)
+}
+
+func (dc *devChain) CurrentGasPrice() (*big.Int, error) {
+	gasPrice := new(big.Int)
+	if err := dc.call("CurrentGasPrice", noArgs, gasPrice); err != nil {
+		return nil, err
+	}
+
+	return gasPrice, nil
+}
+
+func (dc *devChain) SubmitDKGResult(
+	participantIndex relaychain.GroupMemberIndex,
+	dkgResult *relaychain.DKGResult,
+	signatures map[relaychain.GroupMemberIndex][]byte,
+	publicationBlock uint64,
+) *async.EventDKGResultSubmissionPromise {
+	promise := &async.EventDKGResultSubmissionPromise{}
+
+	go func() {
+		submission := &event.DKGResultSubmission{}
+		if err := dc.call(
+			"SubmitDKGResult",
+			DKGResultArgs{
+				participantIndex,
+				dkgResult,
+				signatures,
+				publicationBlock,
+			},
+			submission,
+		); err != nil {
+			promise.Fail(err)
+			return
+		}
+
+		promise.Fulfill(submission)
+	}()
+
+	return promise
+}
+
+func (dc *devChain) OnDKGResultSubmitted(
+	handler func(submission *event.DKGResultSubmission),
+) (subscription.EventSubscription, error) {
+	dc.handlerMutex.Lock()
+	defer dc.handlerMutex.Unlock()
+
+	handlerID := rand.Int()
+	dc.resultSubmissionHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		dc.handlerMutex.Lock()
+		defer dc.handlerMutex.Unlock()
+
+		delete(dc.resultSubmissionHandlers, handlerID)
+	}), nil
+}
+
+func (dc *devChain) IsGroupRegistered(groupPublicKey []byte) (bool, error) {
+	var isRegistered bool
+	err := dc.call("IsGroupRegistered", groupPublicKey, &isRegistered)
+	return isRegistered, err
+}
+
+func (dc *devChain) CalculateDKGResultHash(
+	dkgResult *relaychain.DKGResult,
+) (relaychain.DKGResultHash, error) {
+	var hash relaychain.DKGResultHash
+	err := dc.call("CalculateDKGResultHash", dkgResult, &hash)
+	return hash, err
+}
+
+func (dc *devChain) ReportUnauthorizedSigning(
+	groupPublicKey []byte,
+	signedOperatorAddress []byte,
+) error {
+	return dc.call(
+		"ReportUnauthorizedSigning",
+		UnauthorizedSigningArgs{
+			dc.Signing().PublicKeyToAddress(dc.operatorKey.PublicKey),
+			groupPublicKey,
+			signedOperatorAddress,
+		},
+		new(bool),
+	)
+}
+
+func toAddresses(stakers []relaychain.StakerAddress) []relaychain.StakerAddress {
+	addresses := make([]relaychain.StakerAddress, len(stakers))
+	for i, staker := range stakers {
+		addresses[i] = common.BytesToAddress(staker).Bytes()
+	}
+
+	return addresses
+}
+
+// stakeMonitor checks stakes tracked by the development chain server.
+type stakeMonitor struct {
+	devChain *devChain
+}
+
+func (sm *stakeMonitor) HasMinimumStake(address string) (bool, error) {
+	var hasMinimumStake bool
+	err := sm.devChain.call("HasMinimumStake", address, &hasMinimumStake)
+	return hasMinimumStake, err
+}
+
+func (sm *stakeMonitor) StakerFor(address string) (chain.Staker, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("not a valid ethereum address: %v", address)
+	}
+
+	return &staker{address, sm.devChain}, nil
+}
+
+func (sm *stakeMonitor) OnTokensSlashed(
+	address string,
+	handler func(slashed *event.TokensSlashed),
+) (subscription.EventSubscription, error) {
+	return sm.devChain.onStakeEvent(address, func(e *Event) {
+		if e.TokensSlashed != nil {
+			handler(e.TokensSlashed)
+		}
+	})
+}
+
+func (sm *stakeMonitor) OnTokensSeized(
+	address string,
+	handler func(seized *event.TokensSeized),
+) (subscription.EventSubscription, error) {
+	return sm.devChain.onStakeEvent(address, func(e *Event) {
+		if e.TokensSeized != nil {
+			handler(e.TokensSeized)
+		}
+	})
+}
+
+func (sm *stakeMonitor) OnUndelegated(
+	address string,
+	handler func(undelegated *event.Undelegated),
+) (subscription.EventSubscription, error) {
+	return sm.devChain.onStakeEvent(address, func(e *Event) {
+		if e.Undelegated != nil {
+			handler(e.Undelegated)
+		}
+	})
+}
+
+func (sm *stakeMonitor) OnStakeLocked(
+	address string,
+	handler func(locked *event.StakeLocked),
+) (subscription.EventSubscription, error) {
+	return sm.devChain.onStakeEvent(address, func(e *Event) {
+		if e.StakeLocked != nil {
+			handler(e.StakeLocked)
+		}
+	})
+}
+
+func (sm *stakeMonitor) OnLockReleased(
+	address string,
+	handler func(released *event.LockReleased),
+) (subscription.EventSubscription, error) {
+	return sm.devChain.onStakeEvent(address, func(e *Event) {
+		if e.LockReleased != nil {
+			handler(e.LockReleased)
+		}
+	})
+}
+
+// onStakeEvent registers a handler of stake events of the operator with the
+// given address.
+func (dc *devChain) onStakeEvent(
+	address string,
+	handle func(e *Event),
+) (subscription.EventSubscription, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("not a valid ethereum address: %v", address)
+	}
+
+	dc.handlerMutex.Lock()
+	defer dc.handlerMutex.Unlock()
+
+	handlerID := rand.Int()
+	dc.stakeHandlers[handlerID] = &stakeHandler{
+		operator: common.HexToAddress(address),
+		handle:   handle,
+	}
+
+	return subscription.NewEventSubscription(func() {
+		dc.handlerMutex.Lock()
+		defer dc.handlerMutex.Unlock()
+
+		delete(dc.stakeHandlers, handlerID)
+	}), nil
+}
+
+type staker struct {
+	address  string
+	devChain *devChain
+}
+
+func (s *staker) Address() relaychain.StakerAddress {
+	return common.HexToAddress(s.address).Bytes()
+}
+
+func (s *staker) Stake() (*big.Int, error) {
+	stake := new(big.Int)
+	if err := s.devChain.call("Stake", s.address, stake); err != nil {
+		return nil, err
+	}
+
+	return stake, nil
+}
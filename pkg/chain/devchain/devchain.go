@@ -0,0 +1,104 @@
+// Package devchain serves a local chain, as implemented in the
+// `pkg/chain/local` package, to clients running in other processes over a
+// local RPC socket, so that several clients on one machine can run a full
+// random beacon on a shared chain without Ethereum.
+//
+// The server owns the chain state, produces blocks and tracks stakes.
+// Clients keep their operator keys and sign locally; all chain calls are
+// forwarded to the server, relay events and new blocks are long-polled from
+// it. Addresses on the development chain are Ethereum addresses.
+package devchain
+
+import (
+	"time"
+
+	"github.com/ipfs/go-log"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+)
+
+var logger = log.Logger("keep-devchain")
+
+// serviceName is the name under which the chain is registered in the RPC
+// server.
+const serviceName = "DevChain"
+
+// pollTimeout is the maximum time the server holds a long-polling call for
+// new events or blocks before replying without them.
+var pollTimeout = 30 * time.Second
+
+// maxEvents is the number of most recent events the server keeps for clients
+// which have not polled them yet.
+const maxEvents = 1000
+
+// noArgs is passed to RPC methods which take no arguments.
+const noArgs = 0
+
+// Event is a relay or stake event emitted by the development chain. Exactly
+// one of the event fields is set.
+type Event struct {
+	// Sequence is the number of the event, increasing by one with every
+	// event emitted by the chain.
+	Sequence uint64
+
+	EntrySubmitted      *event.EntrySubmitted
+	Request             *event.Request
+	GroupSelectionStart *event.GroupSelectionStart
+	GroupRegistration   *event.GroupRegistration
+	DKGResultSubmission *event.DKGResultSubmission
+
+	TokensSlashed *event.TokensSlashed
+	TokensSeized  *event.TokensSeized
+	Undelegated   *event.Undelegated
+	StakeLocked   *event.StakeLocked
+	LockReleased  *event.LockReleased
+}
+
+// operator returns the address of the operator of the stake event or nil if
+// the event is not a stake event.
+func (e *Event) operator() []byte {
+	switch {
+	case e.TokensSlashed != nil:
+		return e.TokensSlashed.Operator
+	case e.TokensSeized != nil:
+		return e.TokensSeized.Operator
+	case e.Undelegated != nil:
+		return e.Undelegated.Operator
+	case e.StakeLocked != nil:
+		return e.StakeLocked.Operator
+	case e.LockReleased != nil:
+		return e.LockReleased.Operator
+	default:
+		return nil
+	}
+}
+
+// BlockRange holds arguments of RPC calls for past events between two blocks,
+// inclusive.
+type BlockRange struct {
+	FromBlock uint64
+	ToBlock   uint64
+}
+
+// DKGResultArgs holds arguments of the DKG result submission RPC call.
+type DKGResultArgs struct {
+	MemberIndex      relaychain.GroupMemberIndex
+	Result           *relaychain.DKGResult
+	Signatures       map[relaychain.GroupMemberIndex][]byte
+	PublicationBlock uint64
+}
+
+// RewardsArgs holds arguments of RPC calls for group member rewards of an
+// operator.
+type RewardsArgs struct {
+	Operator       relaychain.StakerAddress
+	GroupPublicKey []byte
+}
+
+// UnauthorizedSigningArgs holds arguments of the unauthorized signing report
+// RPC call.
+type UnauthorizedSigningArgs struct {
+	Operator              relaychain.StakerAddress
+	GroupPublicKey        []byte
+	SignedOperatorAddress []byte
+}
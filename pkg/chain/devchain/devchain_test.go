@@ -0,0 +1,391 @@
+package devchain
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+const stakerAddress = "0x65ea55c1f10491038425725dc00dffeab2a1e28a"
+
+func TestRelayEntryRequest(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	server, socketPath := serveTestChain(ctx, t)
+	member1 := connectTestClient(ctx, t, socketPath)
+	member2 := connectTestClient(ctx, t, socketPath)
+
+	requests := make(chan *event.Request, 1)
+	_, err := member2.ThresholdRelay().OnRelayEntryRequested(
+		func(request *event.Request) {
+			requests <- request
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groupSelectionStarts := make(chan *event.GroupSelectionStart, 1)
+	_, err = member2.ThresholdRelay().OnGroupSelectionStarted(
+		func(groupSelectionStart *event.GroupSelectionStart) {
+			groupSelectionStarts <- groupSelectionStart
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := &relaychain.DKGResult{GroupPublicKey: []byte{11}}
+	signatures := map[relaychain.GroupMemberIndex][]byte{
+		1: {101},
+		2: {102},
+		3: {103},
+	}
+
+	if err := waitForDKGResult(member1, result, signatures); err != nil {
+		t.Fatal(err)
+	}
+	if err := waitForDKGResult(member2, result, signatures); err == nil {
+		t.Errorf("expected an error when the group is already registered")
+	}
+
+	if _, err := server.RequestRelayEntry(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case request := <-requests:
+		if !bytes.Equal(request.GroupPublicKey, result.GroupPublicKey) {
+			t.Errorf(
+				"unexpected group public key\nexpected: [%v]\nactual:   [%v]",
+				result.GroupPublicKey,
+				request.GroupPublicKey,
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal("expected relay entry request to be delivered")
+	}
+
+	isEntryInProgress, err := member1.ThresholdRelay().IsEntryInProgress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isEntryInProgress {
+		t.Errorf("entry should be in progress after the request")
+	}
+
+	newEntry := big.NewInt(19)
+	if err := waitForRelayEntry(member2, newEntry.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if err := waitForRelayEntry(member1, newEntry.Bytes()); err == nil {
+		t.Errorf("expected an error when the entry is not in progress")
+	}
+
+	select {
+	case groupSelectionStart := <-groupSelectionStarts:
+		if groupSelectionStart.NewEntry.Cmp(newEntry) != 0 {
+			t.Errorf(
+				"unexpected group selection seed\n"+
+					"expected: [%v]\nactual:   [%v]",
+				newEntry,
+				groupSelectionStart.NewEntry,
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal("expected group selection start to be delivered")
+	}
+}
+
+func TestStakes(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	_, socketPath := serveTestChain(ctx, t)
+	client := connectTestClient(ctx, t, socketPath)
+
+	stakeMonitor, err := client.StakeMonitor()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		address                 string
+		expectedHasMinimumStake bool
+	}{
+		"staker": {
+			address:                 stakerAddress,
+			expectedHasMinimumStake: true,
+		},
+		"staker with upper case address": {
+			address:                 "0x" + strings.ToUpper(stakerAddress[2:]),
+			expectedHasMinimumStake: true,
+		},
+		"not a staker": {
+			address:                 "0x524f2e0176350d950fa630d9a5a59a0a190daf48",
+			expectedHasMinimumStake: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			hasMinimumStake, err := stakeMonitor.HasMinimumStake(test.address)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if hasMinimumStake != test.expectedHasMinimumStake {
+				t.Errorf(
+					"\nexpected: [%v]\nactual:   [%v]",
+					test.expectedHasMinimumStake,
+					hasMinimumStake,
+				)
+			}
+		})
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	_, socketPath := serveTestChain(ctx, t)
+	client := connectTestClient(ctx, t, socketPath)
+
+	blockCounter, err := client.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	waiter, err := blockCounter.BlockHeightWaiter(currentBlock + 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-waiter:
+	case <-ctx.Done():
+		t.Fatal("expected blocks of the server to be followed")
+	}
+}
+
+func TestSigning(t *testing.T) {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signing := newSigning(operatorPrivateKey)
+
+	message := []byte("message")
+	signature, err := signing.Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := signing.VerifyWithPublicKey(message, signature, signing.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Errorf("signature should be valid")
+	}
+
+	expectedAddress := crypto.PubkeyToAddress(*operatorPublicKey).Bytes()
+
+	address := signing.PublicKeyToAddress(*operatorPublicKey)
+	if !bytes.Equal(address, expectedAddress) {
+		t.Errorf(
+			"unexpected address\nexpected: [%x]\nactual:   [%x]",
+			expectedAddress,
+			address,
+		)
+	}
+
+	address = signing.PublicKeyBytesToAddress(signing.PublicKey())
+	if !bytes.Equal(address, expectedAddress) {
+		t.Errorf(
+			"unexpected address from public key bytes\n"+
+				"expected: [%x]\nactual:   [%x]",
+			expectedAddress,
+			address,
+		)
+	}
+}
+
+func serveTestChain(ctx context.Context, t *testing.T) (*Server, string) {
+	socketDir, err := ioutil.TempDir("", "devchain-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		<-ctx.Done()
+		os.RemoveAll(socketDir)
+	}()
+
+	server, err := NewServer(
+		&local.Config{
+			GroupSize:             5,
+			HonestThreshold:       3,
+			MinimumStake:          big.NewInt(200),
+			BlockTime:             100 * time.Millisecond,
+			GroupActiveTime:       1000,
+			DKGContributionMargin: 100,
+		},
+		[]string{stakerAddress},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	socketPath := filepath.Join(socketDir, "devchain.sock")
+
+	go func() {
+		if err := server.Serve(ctx, socketPath); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	// Give the server a moment to start listening.
+	for i := 0; i < 10; i++ {
+		if _, err := os.Stat(socketPath); err == nil {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	return server, socketPath
+}
+
+func connectTestClient(
+	ctx context.Context,
+	t *testing.T,
+	socketPath string,
+) Handle {
+	operatorKey, _, err := operator.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := Connect(ctx, socketPath, operatorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func waitForDKGResult(
+	client Handle,
+	result *relaychain.DKGResult,
+	signatures map[relaychain.GroupMemberIndex][]byte,
+) error {
+	submitted := make(chan error, 1)
+	client.ThresholdRelay().SubmitDKGResult(1, result, signatures, 0).OnComplete(
+		func(submission *event.DKGResultSubmission, err error) {
+			submitted <- err
+		},
+	)
+
+	return <-submitted
+}
+
+func waitForRelayEntry(client Handle, entry []byte) error {
+	submitted := make(chan error, 1)
+	client.ThresholdRelay().SubmitRelayEntry(entry).OnComplete(
+		func(entry *event.EntrySubmitted, err error) {
+			submitted <- err
+		},
+	)
+
+	return <-submitted
+}
+
+func TestStakeEvents(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	server, socketPath := serveTestChain(ctx, t)
+	client := connectTestClient(ctx, t, socketPath)
+
+	stakeMonitor, err := client.StakeMonitor()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seizures := make(chan *event.TokensSeized, 1)
+	_, err = stakeMonitor.OnTokensSeized(
+		stakerAddress,
+		func(seized *event.TokensSeized) {
+			seizures <- seized
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherSeizures := make(chan *event.TokensSeized, 1)
+	_, err = stakeMonitor.OnTokensSeized(
+		"0x524f2e0176350d950fa630d9a5a59a0a190daf48",
+		func(seized *event.TokensSeized) {
+			otherSeizures <- seized
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	amount := big.NewInt(100)
+	if _, err := server.stakeMonitor.SeizeTokens(
+		normalizeAddress(stakerAddress),
+		amount,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case seized := <-seizures:
+		expectedOperator := common.HexToAddress(stakerAddress).Bytes()
+		if !bytes.Equal(seized.Operator, expectedOperator) {
+			t.Errorf(
+				"unexpected operator\nexpected: [%x]\nactual:   [%x]",
+				expectedOperator,
+				seized.Operator,
+			)
+		}
+		if seized.Amount.Cmp(amount) != 0 {
+			t.Errorf(
+				"unexpected amount\nexpected: [%v]\nactual:   [%v]",
+				amount,
+				seized.Amount,
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal("expected seized tokens to be delivered")
+	}
+
+	select {
+	case seized := <-otherSeizures:
+		t.Errorf("unexpected seizure of another operator [%+v]", seized)
+	default:
+	}
+}
@@ -0,0 +1,679 @@
+package devchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+	"net/rpc"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	relayconfig "github.com/keep-network/keep-core/pkg/beacon/relay/config"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/local"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+// Server serves a local chain to development chain clients.
+type Server struct {
+	chain        local.Chain
+	stakeMonitor *local.StakeMonitor
+	blockCounter chain.BlockCounter
+
+	// chainMutex serializes calls to the chain made by all clients.
+	chainMutex sync.Mutex
+
+	eventsMutex  sync.Mutex
+	events       []*Event
+	lastSequence uint64
+	newEvents    chan struct{}
+}
+
+// NewServer creates a local chain with the given parameters and stakes
+// tokens for all given staker addresses, so that they can operate on the
+// chain. The server does not accept clients until it is served.
+func NewServer(config *local.Config, stakers []string) (*Server, error) {
+	operatorKey, _, err := operator.GenerateKeyPair()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate chain key: [%v]", err)
+	}
+
+	localChain := local.ConnectWithConfig(config, operatorKey)
+
+	stakeMonitor, err := localChain.StakeMonitor()
+	if err != nil {
+		return nil, err
+	}
+
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		return nil, err
+	}
+
+	server := &Server{
+		chain:        localChain,
+		stakeMonitor: stakeMonitor.(*local.StakeMonitor),
+		blockCounter: blockCounter,
+		events:       make([]*Event, 0),
+		newEvents:    make(chan struct{}),
+	}
+
+	for _, staker := range stakers {
+		if !common.IsHexAddress(staker) {
+			return nil, fmt.Errorf("not a valid staker address: [%v]", staker)
+		}
+
+		address := normalizeAddress(staker)
+		if err := server.stakeMonitor.StakeTokens(address); err != nil {
+			return nil, fmt.Errorf(
+				"could not stake tokens for [%v]: [%v]",
+				address,
+				err,
+			)
+		}
+	}
+
+	if err := server.watchEvents(); err != nil {
+		return nil, err
+	}
+
+	for _, staker := range stakers {
+		if err := server.watchStakeEvents(normalizeAddress(staker)); err != nil {
+			return nil, err
+		}
+	}
+
+	return server, nil
+}
+
+// Serve accepts clients on the given unix socket until the context is done.
+func (s *Server) Serve(ctx context.Context, socketPath string) error {
+	rpcServer := rpc.NewServer()
+	if err := rpcServer.RegisterName(serviceName, &service{s}); err != nil {
+		return fmt.Errorf("could not register chain service: [%v]", err)
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf(
+			"could not listen on socket [%v]: [%v]",
+			socketPath,
+			err,
+		)
+	}
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	for {
+		connection, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return fmt.Errorf("could not accept client: [%v]", err)
+		}
+
+		go rpcServer.ServeConn(connection)
+	}
+}
+
+// RequestRelayEntry requests a new relay entry from one of the registered
+// groups.
+func (s *Server) RequestRelayEntry() (*event.Request, error) {
+	s.chainMutex.Lock()
+	defer s.chainMutex.Unlock()
+
+	return s.chain.RequestRelayEntry()
+}
+
+// Genesis starts the first group selection.
+func (s *Server) Genesis() error {
+	s.chainMutex.Lock()
+	defer s.chainMutex.Unlock()
+
+	return s.chain.Genesis()
+}
+
+// watchEvents records all relay events emitted by the chain so that they can
+// be polled by clients.
+func (s *Server) watchEvents() error {
+	relayChain := s.chain.ThresholdRelay()
+
+	if _, err := relayChain.OnRelayEntrySubmitted(
+		func(entry *event.EntrySubmitted) {
+			s.appendEvent(&Event{EntrySubmitted: entry})
+		},
+	); err != nil {
+		return fmt.Errorf("could not watch relay entries: [%v]", err)
+	}
+
+	if _, err := relayChain.OnRelayEntryRequested(
+		func(request *event.Request) {
+			s.appendEvent(&Event{Request: request})
+		},
+	); err != nil {
+		return fmt.Errorf("could not watch relay requests: [%v]", err)
+	}
+
+	if _, err := relayChain.OnGroupSelectionStarted(
+		func(groupSelectionStart *event.GroupSelectionStart) {
+			s.appendEvent(&Event{GroupSelectionStart: groupSelectionStart})
+		},
+	); err != nil {
+		return fmt.Errorf("could not watch group selection starts: [%v]", err)
+	}
+
+	if _, err := relayChain.OnGroupRegistered(
+		func(groupRegistration *event.GroupRegistration) {
+			s.appendEvent(&Event{GroupRegistration: groupRegistration})
+		},
+	); err != nil {
+		return fmt.Errorf("could not watch group registrations: [%v]", err)
+	}
+
+	if _, err := relayChain.OnDKGResultSubmitted(
+		func(submission *event.DKGResultSubmission) {
+			s.appendEvent(&Event{DKGResultSubmission: submission})
+		},
+	); err != nil {
+		return fmt.Errorf("could not watch DKG result submissions: [%v]", err)
+	}
+
+	return nil
+}
+
+// watchStakeEvents records all stake events of the staker with the given
+// address so that they can be polled by clients. Addresses carried by the
+// events are converted to Ethereum addresses.
+func (s *Server) watchStakeEvents(address string) error {
+	if _, err := s.stakeMonitor.OnTokensSlashed(
+		address,
+		func(slashed *event.TokensSlashed) {
+			slashed.Operator = toAddress(slashed.Operator)
+			s.appendEvent(&Event{TokensSlashed: slashed})
+		},
+	); err != nil {
+		return fmt.Errorf("could not watch slashed tokens: [%v]", err)
+	}
+
+	if _, err := s.stakeMonitor.OnTokensSeized(
+		address,
+		func(seized *event.TokensSeized) {
+			seized.Operator = toAddress(seized.Operator)
+			s.appendEvent(&Event{TokensSeized: seized})
+		},
+	); err != nil {
+		return fmt.Errorf("could not watch seized tokens: [%v]", err)
+	}
+
+	if _, err := s.stakeMonitor.OnUndelegated(
+		address,
+		func(undelegated *event.Undelegated) {
+			undelegated.Operator = toAddress(undelegated.Operator)
+			s.appendEvent(&Event{Undelegated: undelegated})
+		},
+	); err != nil {
+		return fmt.Errorf("could not watch undelegations: [%v]", err)
+	}
+
+	if _, err := s.stakeMonitor.OnStakeLocked(
+		address,
+		func(locked *event.StakeLocked) {
+			locked.Operator = toAddress(locked.Operator)
+			locked.LockCreator = toAddress(locked.LockCreator)
+			s.appendEvent(&Event{StakeLocked: locked})
+		},
+	); err != nil {
+		return fmt.Errorf("could not watch stake locks: [%v]", err)
+	}
+
+	if _, err := s.stakeMonitor.OnLockReleased(
+		address,
+		func(released *event.LockReleased) {
+			released.Operator = toAddress(released.Operator)
+			released.LockCreator = toAddress(released.LockCreator)
+			s.appendEvent(&Event{LockReleased: released})
+		},
+	); err != nil {
+		return fmt.Errorf("could not watch stake lock releases: [%v]", err)
+	}
+
+	return nil
+}
+
+func (s *Server) appendEvent(event *Event) {
+	s.eventsMutex.Lock()
+	defer s.eventsMutex.Unlock()
+
+	s.lastSequence++
+	event.Sequence = s.lastSequence
+
+	s.events = append(s.events, event)
+	if len(s.events) > maxEvents {
+		s.events = s.events[len(s.events)-maxEvents:]
+	}
+
+	close(s.newEvents)
+	s.newEvents = make(chan struct{})
+}
+
+// eventsAfter returns all kept events with a sequence number higher than the
+// given one and a channel closed once the next event is emitted.
+func (s *Server) eventsAfter(sequence uint64) ([]*Event, <-chan struct{}) {
+	s.eventsMutex.Lock()
+	defer s.eventsMutex.Unlock()
+
+	events := make([]*Event, 0)
+	for _, event := range s.events {
+		if event.Sequence > sequence {
+			events = append(events, event)
+		}
+	}
+
+	return events, s.newEvents
+}
+
+func normalizeAddress(address string) string {
+	return common.HexToAddress(address).Hex()
+}
+
+// toAddress converts the address of a local stake monitor staker, which is
+// the hexadecimal address string, to the Ethereum address bytes.
+func toAddress(address []byte) []byte {
+	return common.HexToAddress(string(address)).Bytes()
+}
+
+// service exposes the chain of the server as RPC methods. Methods taking no
+// arguments accept an ignored integer and methods returning no value reply
+// with an ignored boolean, as required by the RPC package.
+type service struct {
+	server *Server
+}
+
+func (svc *service) relayChain() relaychain.Interface {
+	return svc.server.chain.ThresholdRelay()
+}
+
+func (svc *service) lock() func() {
+	svc.server.chainMutex.Lock()
+	return svc.server.chainMutex.Unlock
+}
+
+func (svc *service) Events(after uint64, reply *[]*Event) error {
+	timeout := time.After(pollTimeout)
+	for {
+		events, newEvents := svc.server.eventsAfter(after)
+		if len(events) > 0 {
+			*reply = events
+			return nil
+		}
+
+		select {
+		case <-newEvents:
+		case <-timeout:
+			return nil
+		}
+	}
+}
+
+func (svc *service) LastEventSequence(_ int, reply *uint64) error {
+	svc.server.eventsMutex.Lock()
+	defer svc.server.eventsMutex.Unlock()
+
+	*reply = svc.server.lastSequence
+	return nil
+}
+
+func (svc *service) CurrentBlock(_ int, reply *uint64) error {
+	var err error
+	*reply, err = svc.server.blockCounter.CurrentBlock()
+	return err
+}
+
+func (svc *service) NextBlock(after uint64, reply *uint64) error {
+	waiter, err := svc.server.blockCounter.BlockHeightWaiter(after + 1)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-waiter:
+	case <-time.After(pollTimeout):
+	}
+
+	*reply, err = svc.server.blockCounter.CurrentBlock()
+	return err
+}
+
+func (svc *service) HasMinimumStake(address string, reply *bool) error {
+	var err error
+	*reply, err = svc.server.stakeMonitor.HasMinimumStake(
+		normalizeAddress(address),
+	)
+	return err
+}
+
+func (svc *service) Stake(address string, reply *big.Int) error {
+	staker, err := svc.server.stakeMonitor.StakerFor(normalizeAddress(address))
+	if err != nil {
+		return err
+	}
+
+	stake, err := staker.Stake()
+	if err != nil {
+		return err
+	}
+
+	reply.Set(stake)
+	return nil
+}
+
+func (svc *service) GetConfig(_ int, reply *relayconfig.Chain) error {
+	config, err := svc.relayChain().GetConfig()
+	if err != nil {
+		return err
+	}
+
+	*reply = *config
+	return nil
+}
+
+func (svc *service) RequestRelayEntry(_ int, reply *event.Request) error {
+	request, err := svc.server.RequestRelayEntry()
+	if err != nil {
+		return err
+	}
+
+	*reply = *request
+	return nil
+}
+
+func (svc *service) Genesis(_ int, _ *bool) error {
+	return svc.server.Genesis()
+}
+
+// SubmitRelayEntry accepts only the first entry submitted for the current
+// request.
+func (svc *service) SubmitRelayEntry(entry []byte, reply *event.EntrySubmitted) error {
+	defer svc.lock()()
+
+	isEntryInProgress, err := svc.relayChain().IsEntryInProgress()
+	if err != nil {
+		return err
+	}
+	if !isEntryInProgress {
+		return fmt.Errorf("relay entry is not in progress")
+	}
+
+	submitted := make(chan error, 1)
+	svc.relayChain().SubmitRelayEntry(entry).OnComplete(
+		func(entry *event.EntrySubmitted, err error) {
+			if err == nil {
+				*reply = *entry
+			}
+			submitted <- err
+		},
+	)
+
+	return <-submitted
+}
+
+func (svc *service) ReportRelayEntryTimeout(_ int, _ *bool) error {
+	defer svc.lock()()
+
+	return svc.relayChain().ReportRelayEntryTimeout()
+}
+
+func (svc *service) IsEntryInProgress(_ int, reply *bool) error {
+	defer svc.lock()()
+
+	var err error
+	*reply, err = svc.relayChain().IsEntryInProgress()
+	return err
+}
+
+func (svc *service) CurrentRequestStartBlock(_ int, reply *big.Int) error {
+	defer svc.lock()()
+
+	startBlock, err := svc.relayChain().CurrentRequestStartBlock()
+	if err != nil {
+		return err
+	}
+
+	reply.Set(startBlock)
+	return nil
+}
+
+func (svc *service) CurrentRequestPreviousEntry(_ int, reply *[]byte) error {
+	defer svc.lock()()
+
+	var err error
+	*reply, err = svc.relayChain().CurrentRequestPreviousEntry()
+	return err
+}
+
+func (svc *service) CurrentRequestGroupPublicKey(_ int, reply *[]byte) error {
+	defer svc.lock()()
+
+	var err error
+	*reply, err = svc.relayChain().CurrentRequestGroupPublicKey()
+	return err
+}
+
+func (svc *service) SubmitTicket(
+	ticket *relaychain.Ticket,
+	reply *event.GroupTicketSubmission,
+) error {
+	defer svc.lock()()
+
+	submitted := make(chan error, 1)
+	svc.relayChain().SubmitTicket(ticket).OnComplete(
+		func(submission *event.GroupTicketSubmission, err error) {
+			if err == nil {
+				*reply = *submission
+			}
+			submitted <- err
+		},
+	)
+
+	return <-submitted
+}
+
+func (svc *service) GetSubmittedTickets(_ int, reply *[]uint64) error {
+	defer svc.lock()()
+
+	var err error
+	*reply, err = svc.relayChain().GetSubmittedTickets()
+	return err
+}
+
+func (svc *service) GetSelectedParticipants(
+	_ int,
+	reply *[]relaychain.StakerAddress,
+) error {
+	defer svc.lock()()
+
+	var err error
+	*reply, err = svc.relayChain().GetSelectedParticipants()
+	return err
+}
+
+func (svc *service) IsGroupSelectionPossible(_ int, reply *bool) error {
+	defer svc.lock()()
+
+	var err error
+	*reply, err = svc.relayChain().IsGroupSelectionPossible()
+	return err
+}
+
+func (svc *service) PastGroupSelectionStarts(
+	blockRange BlockRange,
+	reply *[]*event.GroupSelectionStart,
+) error {
+	defer svc.lock()()
+
+	var err error
+	*reply, err = svc.relayChain().PastGroupSelectionStarts(
+		blockRange.FromBlock,
+		blockRange.ToBlock,
+	)
+	return err
+}
+
+func (svc *service) IsStaleGroup(groupPublicKey []byte, reply *bool) error {
+	defer svc.lock()()
+
+	var err error
+	*reply, err = svc.relayChain().IsStaleGroup(groupPublicKey)
+	return err
+}
+
+func (svc *service) GetGroupMembers(
+	groupPublicKey []byte,
+	reply *[]relaychain.StakerAddress,
+) error {
+	defer svc.lock()()
+
+	var err error
+	*reply, err = svc.relayChain().GetGroupMembers(groupPublicKey)
+	return err
+}
+
+func (svc *service) PastGroupRegistrations(
+	blockRange BlockRange,
+	reply *[]*event.GroupRegistration,
+) error {
+	defer svc.lock()()
+
+	var err error
+	*reply, err = svc.relayChain().PastGroupRegistrations(
+		blockRange.FromBlock,
+		blockRange.ToBlock,
+	)
+	return err
+}
+
+func (svc *service) GetGroupMemberRewards(
+	groupPublicKey []byte,
+	reply *big.Int,
+) error {
+	defer svc.lock()()
+
+	rewards, err := svc.relayChain().GetGroupMemberRewards(groupPublicKey)
+	if err != nil {
+		return err
+	}
+
+	reply.Set(rewards)
+	return nil
+}
+
+func (svc *service) HasWithdrawnRewards(args RewardsArgs, reply *bool) error {
+	defer svc.lock()()
+
+	var err error
+	*reply, err = svc.relayChain().HasWithdrawnRewards(
+		args.Operator,
+		args.GroupPublicKey,
+	)
+	return err
+}
+
+func (svc *service) WithdrawGroupMemberRewards(args RewardsArgs, _ *bool) error {
+	defer svc.lock()()
+
+	return svc.relayChain().WithdrawGroupMemberRewards(
+		args.Operator,
+		args.GroupPublicKey,
+	)
+}
+
+func (svc *service) CurrentGasPrice(_ int, reply *big.Int) error {
+	defer svc.lock()()
+
+	gasPrice, err := svc.relayChain().CurrentGasPrice()
+	if err != nil {
+		return err
+	}
+
+	reply.Set(gasPrice)
+	return nil
+}
+
+// SubmitDKGResult accepts only the first result submitted for a group.
+func (svc *service) SubmitDKGResult(
+	args DKGResultArgs,
+	reply *event.DKGResultSubmission,
+) error {
+	defer svc.lock()()
+
+	isRegistered, err := svc.relayChain().IsGroupRegistered(
+		args.Result.GroupPublicKey,
+	)
+	if err != nil {
+		return err
+	}
+	if isRegistered {
+		return fmt.Errorf(
+			"group [0x%x] is already registered",
+			args.Result.GroupPublicKey,
+		)
+	}
+
+	submitted := make(chan error, 1)
+	svc.relayChain().SubmitDKGResult(
+		args.MemberIndex,
+		args.Result,
+		args.Signatures,
+		args.PublicationBlock,
+	).OnComplete(func(submission *event.DKGResultSubmission, err error) {
+		if err == nil {
+			*reply = *submission
+		}
+		submitted <- err
+	})
+
+	return <-submitted
+}
+
+func (svc *service) IsGroupRegistered(groupPublicKey []byte, reply *bool) error {
+	defer svc.lock()()
+
+	var err error
+	*reply, err = svc.relayChain().IsGroupRegistered(groupPublicKey)
+	return err
+}
+
+func (svc *service) CalculateDKGResultHash(
+	dkgResult *relaychain.DKGResult,
+	reply *relaychain.DKGResultHash,
+) error {
+	defer svc.lock()()
+
+	var err error
+	*reply, err = svc.relayChain().CalculateDKGResultHash(dkgResult)
+	return err
+}
+
+func (svc *service) ReportUnauthorizedSigning(
+	args UnauthorizedSigningArgs,
+	_ *bool,
+) error {
+	defer svc.lock()()
+
+	// Reports are attributed to the reporting client the same way the
+	// operator contract attributes them to the transaction sender.
+	return svc.server.chain.ReportUnauthorizedSigningAs(
+		args.Operator,
+		args.GroupPublicKey,
+		args.SignedOperatorAddress,
+	)
+}
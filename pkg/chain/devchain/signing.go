@@ -0,0 +1,29 @@
+package devchain
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/local"
+)
+
+// devChainSigning signs and verifies messages the same way as the local
+// chain but converts public keys to Ethereum addresses, so that they match
+// addresses of stakers selected to groups.
+type devChainSigning struct {
+	chain.Signing
+}
+
+func newSigning(operatorKey *ecdsa.PrivateKey) chain.Signing {
+	return &devChainSigning{local.NewSigning(operatorKey)}
+}
+
+func (dcs *devChainSigning) PublicKeyToAddress(publicKey ecdsa.PublicKey) []byte {
+	return crypto.PubkeyToAddress(publicKey).Bytes()
+}
+
+func (dcs *devChainSigning) PublicKeyBytesToAddress(publicKey []byte) []byte {
+	// Does the same as crypto.PubkeyToAddress but directly on public key bytes.
+	return crypto.Keccak256(publicKey[1:])[12:]
+}
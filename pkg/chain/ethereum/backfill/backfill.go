@@ -0,0 +1,81 @@
+// Package backfill tracks logs delivered by an event subscription so that
+// logs emitted while the subscription was down can be fetched once it is
+// created again, without delivering any log twice.
+//
+// The tracker remembers the last block at which the subscription was known to
+// be alive. Logs are fetched from that block, inclusive, when the
+// subscription is created again. Logs delivered from that block on are
+// remembered so that logs delivered both by the previous subscription and by
+// the fetch, or both by the fetch and by the new subscription, are delivered
+// once.
+package backfill
+
+import (
+	"sync"
+)
+
+// Tracker tracks logs delivered by a single subscription. It is safe for
+// concurrent use.
+type Tracker struct {
+	mutex     sync.Mutex
+	lastBlock uint64
+	delivered map[string]uint64 // <log id, block number>
+}
+
+// NewTracker creates a tracker of a subscription created at the given block.
+func NewTracker(startBlock uint64) *Tracker {
+	return &Tracker{
+		lastBlock: startBlock,
+		delivered: make(map[string]uint64),
+	}
+}
+
+// BlockProcessed notifies the tracker that the subscription was alive at the
+// given block. Delivered logs from blocks before the given one are forgotten
+// since they are not fetched again.
+func (t *Tracker) BlockProcessed(blockNumber uint64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if blockNumber <= t.lastBlock {
+		return
+	}
+
+	t.lastBlock = blockNumber
+
+	for id, logBlock := range t.delivered {
+		if logBlock < blockNumber {
+			delete(t.delivered, id)
+		}
+	}
+}
+
+// FromBlock returns the block from which logs should be fetched when the
+// subscription is created again.
+func (t *Tracker) FromBlock() uint64 {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.lastBlock
+}
+
+// Deliver returns true if the log with the given identifier, emitted in the
+// given block, should be delivered, which is when it has not been delivered
+// yet. Logs from blocks before the block from which logs are fetched are
+// always delivered since they cannot be fetched again.
+func (t *Tracker) Deliver(id string, blockNumber uint64) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if blockNumber < t.lastBlock {
+		return true
+	}
+
+	if _, ok := t.delivered[id]; ok {
+		return false
+	}
+
+	t.delivered[id] = blockNumber
+
+	return true
+}
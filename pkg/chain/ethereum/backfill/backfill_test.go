@@ -0,0 +1,99 @@
+package backfill
+
+import (
+	"reflect"
+	"testing"
+)
+
+type delivery struct {
+	id          string
+	blockNumber uint64
+}
+
+type step struct {
+	delivery       *delivery
+	processedBlock uint64
+}
+
+func TestDeliver(t *testing.T) {
+	var tests = map[string]struct {
+		startBlock        uint64
+		steps             []step
+		expectedDelivered []string
+		expectedFromBlock uint64
+	}{
+		"new logs are delivered": {
+			startBlock: 10,
+			steps: []step{
+				{delivery: &delivery{"a", 11}},
+				{delivery: &delivery{"b", 12}},
+			},
+			expectedDelivered: []string{"a", "b"},
+			expectedFromBlock: 10,
+		},
+		"fetched log delivered before is not delivered again": {
+			startBlock: 10,
+			steps: []step{
+				{delivery: &delivery{"a", 11}},
+				{processedBlock: 11},
+				{delivery: &delivery{"a", 11}},
+				{delivery: &delivery{"b", 12}},
+			},
+			expectedDelivered: []string{"a", "b"},
+			expectedFromBlock: 11,
+		},
+		"log from processed block is forgotten": {
+			startBlock: 10,
+			steps: []step{
+				{delivery: &delivery{"a", 11}},
+				{processedBlock: 12},
+				{delivery: &delivery{"a", 11}},
+			},
+			expectedDelivered: []string{"a", "a"},
+			expectedFromBlock: 12,
+		},
+		"processed block does not go back": {
+			startBlock: 10,
+			steps: []step{
+				{processedBlock: 15},
+				{processedBlock: 12},
+			},
+			expectedDelivered: []string{},
+			expectedFromBlock: 15,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			tracker := NewTracker(test.startBlock)
+
+			delivered := make([]string, 0)
+			for _, step := range test.steps {
+				if step.delivery == nil {
+					tracker.BlockProcessed(step.processedBlock)
+					continue
+				}
+
+				if tracker.Deliver(step.delivery.id, step.delivery.blockNumber) {
+					delivered = append(delivered, step.delivery.id)
+				}
+			}
+
+			if !reflect.DeepEqual(test.expectedDelivered, delivered) {
+				t.Errorf(
+					"unexpected delivered logs\nexpected: [%v]\nactual:   [%v]",
+					test.expectedDelivered,
+					delivered,
+				)
+			}
+
+			if tracker.FromBlock() != test.expectedFromBlock {
+				t.Errorf(
+					"unexpected from block\nexpected: [%v]\nactual:   [%v]",
+					test.expectedFromBlock,
+					tracker.FromBlock(),
+				)
+			}
+		})
+	}
+}
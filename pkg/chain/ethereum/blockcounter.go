@@ -0,0 +1,112 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/blockcounter"
+)
+
+// latestBlockTimeout is the maximum time of a single lookup of the latest
+// block done by the block counter.
+const latestBlockTimeout = 10 * time.Second
+
+// createBlockCounter creates the block counter of the chain starting at the
+// latest block of the active endpoint of the client. The counter is not fed
+// with new blocks until forwardBlocks is called.
+func createBlockCounter(client *failoverClient) (*blockcounter.Counter, error) {
+	startBlock, err := latestBlock(client)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get initial block from the chain: [%v]",
+			err,
+		)
+	}
+
+	return blockcounter.NewCounter(startBlock), nil
+}
+
+// forwardBlocks feeds the block counter with new blocks until the context is
+// done. Blocks are delivered by a subscription on the active endpoint of the
+// client which is created again when it fails, including when the active
+// endpoint is switched.
+func forwardBlocks(
+	ctx context.Context,
+	client *failoverClient,
+	counter *blockcounter.Counter,
+) {
+	go func() {
+		for {
+			err := forwardSubscribedBlocks(ctx, client, counter)
+			if ctx.Err() != nil {
+				return
+			}
+
+			logger.Warningf(
+				"subscription to new blocks terminated with error; "+
+					"resubscribing after [%v]: [%v]",
+				resubscriptionDelay,
+				err,
+			)
+
+			select {
+			case <-time.After(resubscriptionDelay):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// forwardSubscribedBlocks subscribes to new blocks and passes them to the
+// counter until the subscription fails or the context is done.
+func forwardSubscribedBlocks(
+	ctx context.Context,
+	client *failoverClient,
+	counter *blockcounter.Counter,
+) error {
+	headers := make(chan *types.Header)
+
+	subscription, err := client.SubscribeNewHead(ctx, headers)
+	if err != nil {
+		return err
+	}
+	defer subscription.Unsubscribe()
+
+	// Blocks mined while there was no subscription are caught up with the
+	// latest block known to the endpoint.
+	currentBlock, err := latestBlock(client)
+	if err != nil {
+		logger.Warningf("could not get the latest block: [%v]", err)
+	} else {
+		counter.Update(currentBlock)
+	}
+
+	for {
+		select {
+		case header := <-headers:
+			counter.Update(header.Number.Uint64())
+		case err := <-subscription.Err():
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func latestBlock(client *failoverClient) (uint64, error) {
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		latestBlockTimeout,
+	)
+	defer cancelCtx()
+
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return header.Number.Uint64(), nil
+}
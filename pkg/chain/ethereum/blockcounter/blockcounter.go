@@ -0,0 +1,158 @@
+// Package blockcounter implements the chain block counter fed with block
+// numbers by the caller.
+//
+// The counter does not talk to the Ethereum node itself; it is updated with
+// numbers of new blocks delivered by a subscription so that the subscription
+// can be moved between Ethereum endpoints without losing waiters and watchers
+// of the counter.
+package blockcounter
+
+import (
+	"context"
+	"sync"
+)
+
+// Counter is a chain block counter. It is safe for concurrent use.
+type Counter struct {
+	mutex       sync.Mutex
+	latestBlock uint64
+	waiters     map[uint64][]chan uint64
+	watchers    []*watcher
+}
+
+// watcher queues blocks for a reader of WatchBlocks. Queued blocks are
+// guarded by the mutex of the counter.
+type watcher struct {
+	blocks chan uint64
+	queued []uint64
+	notify chan struct{}
+}
+
+// NewCounter creates a block counter starting at the given block.
+func NewCounter(startBlock uint64) *Counter {
+	return &Counter{
+		latestBlock: startBlock,
+		waiters:     make(map[uint64][]chan uint64),
+	}
+}
+
+// Update notifies the counter about a new block. Waiters and watchers are
+// notified about all blocks between the latest block seen by the counter and
+// the given block. Blocks not above the latest block seen are ignored; such
+// blocks are delivered when an endpoint lagging behind the previously active
+// one becomes active.
+func (c *Counter) Update(blockNumber uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for c.latestBlock < blockNumber {
+		c.latestBlock++
+		height := c.latestBlock
+
+		for _, waiter := range c.waiters[height] {
+			waiter <- height
+			close(waiter)
+		}
+		delete(c.waiters, height)
+
+		for _, watcher := range c.watchers {
+			watcher.queued = append(watcher.queued, height)
+			select {
+			case watcher.notify <- struct{}{}:
+			default:
+				// The watcher has been notified already.
+			}
+		}
+	}
+}
+
+// WaitForBlockHeight blocks at the caller until the given block height is
+// reached.
+func (c *Counter) WaitForBlockHeight(blockNumber uint64) error {
+	waiter, err := c.BlockHeightWaiter(blockNumber)
+	if err != nil {
+		return err
+	}
+	<-waiter
+	return nil
+}
+
+// BlockHeightWaiter returns a channel emitting the block number once the
+// given block height is reached and closed right after.
+func (c *Counter) BlockHeightWaiter(blockNumber uint64) (<-chan uint64, error) {
+	waiter := make(chan uint64, 1)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if blockNumber <= c.latestBlock {
+		waiter <- blockNumber
+		close(waiter)
+	} else {
+		c.waiters[blockNumber] = append(c.waiters[blockNumber], waiter)
+	}
+
+	return waiter, nil
+}
+
+// CurrentBlock returns the latest block seen by the counter.
+func (c *Counter) CurrentBlock() (uint64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.latestBlock, nil
+}
+
+// WatchBlocks returns a channel emitting numbers of new blocks until the
+// given context is done, in which case the channel is closed. Blocks are
+// queued if the reader of the channel is too slow; none of them is dropped.
+func (c *Counter) WatchBlocks(ctx context.Context) <-chan uint64 {
+	w := &watcher{
+		blocks: make(chan uint64),
+		notify: make(chan struct{}, 1),
+	}
+
+	c.mutex.Lock()
+	c.watchers = append(c.watchers, w)
+	c.mutex.Unlock()
+
+	go func() {
+		defer close(w.blocks)
+		defer c.removeWatcher(w)
+
+		for {
+			select {
+			case <-w.notify:
+			case <-ctx.Done():
+				return
+			}
+
+			c.mutex.Lock()
+			queued := w.queued
+			w.queued = nil
+			c.mutex.Unlock()
+
+			for _, block := range queued {
+				select {
+				case w.blocks <- block:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return w.blocks
+}
+
+func (c *Counter) removeWatcher(w *watcher) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i, watcher := range c.watchers {
+		if watcher == w {
+			c.watchers = append(c.watchers[:i], c.watchers[i+1:]...)
+			break
+		}
+	}
+}
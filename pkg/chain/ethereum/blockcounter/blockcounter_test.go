@@ -0,0 +1,111 @@
+package blockcounter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBlockHeightWaiter(t *testing.T) {
+	counter := NewCounter(10)
+
+	reached, err := counter.BlockHeightWaiter(9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending, err := counter.BlockHeightWaiter(12)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if block := <-reached; block != 9 {
+		t.Errorf(
+			"unexpected block\nexpected: [%v]\nactual:   [%v]",
+			9,
+			block,
+		)
+	}
+
+	counter.Update(11)
+	select {
+	case block := <-pending:
+		t.Fatalf("unexpected block [%v]", block)
+	default:
+	}
+
+	counter.Update(13)
+	if block := <-pending; block != 12 {
+		t.Errorf(
+			"unexpected block\nexpected: [%v]\nactual:   [%v]",
+			12,
+			block,
+		)
+	}
+	if _, ok := <-pending; ok {
+		t.Errorf("waiter channel should be closed")
+	}
+}
+
+func TestUpdateIgnoresPastBlocks(t *testing.T) {
+	counter := NewCounter(10)
+
+	counter.Update(15)
+	counter.Update(12)
+
+	currentBlock, err := counter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if currentBlock != 15 {
+		t.Errorf(
+			"unexpected current block\nexpected: [%v]\nactual:   [%v]",
+			15,
+			currentBlock,
+		)
+	}
+}
+
+func TestWatchBlocks(t *testing.T) {
+	counter := NewCounter(10)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	blocks := counter.WatchBlocks(ctx)
+
+	// The watcher does not read blocks while the counter is updated.
+	for _, update := range []uint64{11, 11, 12, 14} {
+		counter.Update(update)
+	}
+
+	received := make([]uint64, 0)
+	for len(received) < 4 {
+		select {
+		case block := <-blocks:
+			received = append(received, block)
+			continue
+		case <-time.After(time.Second):
+		}
+		break
+	}
+
+	// Blocks skipped between updates are emitted as well.
+	expected := []uint64{11, 12, 13, 14}
+	if !reflect.DeepEqual(expected, received) {
+		t.Errorf(
+			"unexpected blocks\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			received,
+		)
+	}
+
+	cancelCtx()
+
+	select {
+	case _, ok := <-blocks:
+		if ok {
+			t.Errorf("watcher channel should be closed")
+		}
+	case <-time.After(time.Second):
+		t.Errorf("watcher channel has not been closed")
+	}
+}
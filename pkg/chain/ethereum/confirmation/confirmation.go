@@ -0,0 +1,228 @@
+// Package confirmation delays chain events until the blocks they were
+// emitted in are buried under the configured number of blocks.
+//
+// An event log delivered by the Ethereum node can be reorganized out of the
+// chain shortly after it has been emitted. The confirmer holds every log until
+// the chain is the given number of blocks past the log's block and checks
+// whether the block is still part of the canonical chain before the log is
+// considered confirmed. Logs removed by a reorganization before their
+// confirmation are silently dropped. Logs removed after their confirmation,
+// which is possible if the reorganization is deeper than the confirmation
+// depth, are reported so that work started because of them can be cancelled.
+package confirmation
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ipfs/go-log"
+)
+
+var logger = log.Logger("keep-confirmation")
+
+// removalWindow is the number of blocks, counted from the confirmation
+// block, for which a confirmed log is remembered so that its removal can be
+// reported.
+const removalWindow = 100
+
+// Log identifies an event log emitted in a block.
+type Log struct {
+	BlockNumber     uint64
+	BlockHash       string
+	TransactionHash string
+	Index           uint
+	// Removed is set by the Ethereum node for logs reverted because of
+	// a chain reorganization.
+	Removed bool
+}
+
+func (l *Log) id() string {
+	return fmt.Sprintf("%v-%v-%v", l.BlockHash, l.TransactionHash, l.Index)
+}
+
+// Chain provides the confirmer with hashes of canonical chain blocks.
+type Chain interface {
+	BlockHash(blockNumber uint64) (string, error)
+}
+
+// Handlers are called by the confirmer for a single log. Confirmed is called
+// once the log is confirmed. Removed is called if the log is removed by a
+// chain reorganization after it has been confirmed.
+type Handlers struct {
+	Confirmed func()
+	Removed   func()
+}
+
+type trackedLog struct {
+	log            Log
+	handlers       Handlers
+	confirmedBlock uint64
+}
+
+// Confirmer confirms logs at the given depth. It is safe for concurrent use.
+type Confirmer struct {
+	depth uint64
+	chain Chain
+
+	mutex     sync.Mutex
+	pending   map[string]*trackedLog
+	confirmed map[string]*trackedLog
+}
+
+// NewConfirmer creates a confirmer confirming logs once the chain is the
+// given number of blocks past the block the log has been emitted in. With
+// the depth of zero, logs are confirmed as soon as they are added but their
+// removals are still reported.
+func NewConfirmer(depth uint64, chain Chain) *Confirmer {
+	return &Confirmer{
+		depth:     depth,
+		chain:     chain,
+		pending:   make(map[string]*trackedLog),
+		confirmed: make(map[string]*trackedLog),
+	}
+}
+
+// Add passes a log delivered by the Ethereum node to the confirmer. Handlers
+// are ignored for logs marked as removed; handlers of the log added before
+// are used instead.
+func (c *Confirmer) Add(log Log, handlers Handlers) {
+	c.mutex.Lock()
+
+	id := log.id()
+
+	if log.Removed {
+		if _, ok := c.pending[id]; ok {
+			delete(c.pending, id)
+			c.mutex.Unlock()
+
+			logger.Infof(
+				"log from block [%v] removed before confirmation",
+				log.BlockNumber,
+			)
+			return
+		}
+
+		tracked, ok := c.confirmed[id]
+		delete(c.confirmed, id)
+		c.mutex.Unlock()
+
+		if ok {
+			logger.Warningf(
+				"confirmed log from block [%v] has been removed by "+
+					"a chain reorganization",
+				log.BlockNumber,
+			)
+			if tracked.handlers.Removed != nil {
+				tracked.handlers.Removed()
+			}
+		}
+		return
+	}
+
+	if _, ok := c.confirmed[id]; ok {
+		c.mutex.Unlock()
+		return
+	}
+
+	tracked := &trackedLog{log: log, handlers: handlers}
+
+	if c.depth > 0 {
+		c.pending[id] = tracked
+		c.mutex.Unlock()
+		return
+	}
+
+	tracked.confirmedBlock = log.BlockNumber
+	c.confirmed[id] = tracked
+	c.mutex.Unlock()
+
+	tracked.handlers.Confirmed()
+}
+
+// NewBlock notifies the confirmer about a new block. Pending logs deep enough
+// are confirmed if their blocks are still part of the canonical chain and
+// dropped otherwise. Logs whose block hash could not be checked are checked
+// again with the next block. Block hashes are fetched without holding the
+// confirmer's lock so that logs can be added in the meantime.
+func (c *Confirmer) NewBlock(blockNumber uint64) {
+	c.mutex.Lock()
+	candidates := make(map[string]*trackedLog)
+	for id, tracked := range c.pending {
+		if tracked.log.BlockNumber+c.depth <= blockNumber {
+			candidates[id] = tracked
+		}
+	}
+	c.mutex.Unlock()
+
+	blockHashes := make(map[uint64]string)
+	for _, tracked := range candidates {
+		number := tracked.log.BlockNumber
+		if _, ok := blockHashes[number]; ok {
+			continue
+		}
+
+		blockHash, err := c.chain.BlockHash(number)
+		if err != nil {
+			logger.Warningf(
+				"could not check block [%v] of pending log; "+
+					"will retry with the next block: [%v]",
+				number,
+				err,
+			)
+			continue
+		}
+
+		blockHashes[number] = blockHash
+	}
+
+	c.mutex.Lock()
+
+	confirmed := make([]*trackedLog, 0)
+	for id, tracked := range candidates {
+		blockHash, ok := blockHashes[tracked.log.BlockNumber]
+		if !ok {
+			continue
+		}
+
+		// The log could have been removed or confirmed with another block
+		// while the block hash was being fetched.
+		if c.pending[id] != tracked {
+			continue
+		}
+
+		delete(c.pending, id)
+
+		if blockHash != tracked.log.BlockHash {
+			logger.Infof(
+				"log from block [%v] is not part of the canonical chain "+
+					"anymore; dropping it",
+				tracked.log.BlockNumber,
+			)
+			continue
+		}
+
+		tracked.confirmedBlock = blockNumber
+		c.confirmed[id] = tracked
+		confirmed = append(confirmed, tracked)
+	}
+
+	for id, tracked := range c.confirmed {
+		if tracked.confirmedBlock+removalWindow < blockNumber {
+			delete(c.confirmed, id)
+		}
+	}
+
+	c.mutex.Unlock()
+
+	sort.Slice(confirmed, func(i, j int) bool {
+		if confirmed[i].log.BlockNumber != confirmed[j].log.BlockNumber {
+			return confirmed[i].log.BlockNumber < confirmed[j].log.BlockNumber
+		}
+		return confirmed[i].log.Index < confirmed[j].log.Index
+	})
+
+	for _, tracked := range confirmed {
+		tracked.handlers.Confirmed()
+	}
+}
@@ -0,0 +1,233 @@
+package confirmation
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type mockChain struct {
+	blockHashes map[uint64]string
+}
+
+func (mc *mockChain) BlockHash(blockNumber uint64) (string, error) {
+	blockHash, ok := mc.blockHashes[blockNumber]
+	if !ok {
+		return "", fmt.Errorf("unknown block [%v]", blockNumber)
+	}
+	return blockHash, nil
+}
+
+func testLog(blockNumber uint64, blockHash string) Log {
+	return Log{
+		BlockNumber:     blockNumber,
+		BlockHash:       blockHash,
+		TransactionHash: fmt.Sprintf("tx-%v", blockNumber),
+		Index:           1,
+	}
+}
+
+func removed(log Log) Log {
+	log.Removed = true
+	return log
+}
+
+type step struct {
+	log      *Log
+	newBlock uint64
+}
+
+func TestConfirmer(t *testing.T) {
+	var tests = map[string]struct {
+		depth             uint64
+		blockHashes       map[uint64]string
+		steps             []step
+		expectedConfirmed []string
+		expectedRemoved   []string
+	}{
+		"log is confirmed at depth": {
+			depth:       3,
+			blockHashes: map[uint64]string{10: "a"},
+			steps: []step{
+				{log: &Log{BlockNumber: 10, BlockHash: "a"}},
+				{newBlock: 11},
+				{newBlock: 12},
+				{newBlock: 13},
+				{newBlock: 14},
+			},
+			expectedConfirmed: []string{"a@13"},
+		},
+		"log is confirmed immediately with no depth": {
+			depth: 0,
+			steps: []step{
+				{log: &Log{BlockNumber: 10, BlockHash: "a"}},
+			},
+			expectedConfirmed: []string{"a@0"},
+		},
+		"log from reorganized block is dropped": {
+			depth:       2,
+			blockHashes: map[uint64]string{10: "b"},
+			steps: []step{
+				{log: &Log{BlockNumber: 10, BlockHash: "a"}},
+				{newBlock: 12},
+			},
+			expectedConfirmed: []string{},
+		},
+		"log removed before confirmation is dropped": {
+			depth:       2,
+			blockHashes: map[uint64]string{10: "a"},
+			steps: []step{
+				{log: &Log{BlockNumber: 10, BlockHash: "a"}},
+				{log: &Log{BlockNumber: 10, BlockHash: "a", Removed: true}},
+				{newBlock: 12},
+			},
+			expectedConfirmed: []string{},
+		},
+		"removal of confirmed log is reported": {
+			depth:       2,
+			blockHashes: map[uint64]string{10: "a"},
+			steps: []step{
+				{log: &Log{BlockNumber: 10, BlockHash: "a"}},
+				{newBlock: 12},
+				{log: &Log{BlockNumber: 10, BlockHash: "a", Removed: true}},
+			},
+			expectedConfirmed: []string{"a@12"},
+			expectedRemoved:   []string{"a"},
+		},
+		"removal of confirmed log is reported with no depth": {
+			depth: 0,
+			steps: []step{
+				{log: &Log{BlockNumber: 10, BlockHash: "a"}},
+				{log: &Log{BlockNumber: 10, BlockHash: "a", Removed: true}},
+			},
+			expectedConfirmed: []string{"a@0"},
+			expectedRemoved:   []string{"a"},
+		},
+		"log is kept pending while its block is unknown": {
+			depth:       1,
+			blockHashes: map[uint64]string{},
+			steps: []step{
+				{log: &Log{BlockNumber: 10, BlockHash: "a"}},
+				{newBlock: 11},
+			},
+			expectedConfirmed: []string{},
+		},
+		"redelivered log is confirmed once": {
+			depth: 0,
+			steps: []step{
+				{log: &Log{BlockNumber: 10, BlockHash: "a"}},
+				{log: &Log{BlockNumber: 10, BlockHash: "a"}},
+			},
+			expectedConfirmed: []string{"a@0"},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			confirmer := NewConfirmer(
+				test.depth,
+				&mockChain{test.blockHashes},
+			)
+
+			confirmed := make([]string, 0)
+			var removedLogs []string
+			currentBlock := uint64(0)
+
+			for _, step := range test.steps {
+				if step.log == nil {
+					currentBlock = step.newBlock
+					confirmer.NewBlock(step.newBlock)
+					continue
+				}
+
+				blockHash := step.log.BlockHash
+				confirmer.Add(*step.log, Handlers{
+					Confirmed: func() {
+						confirmed = append(
+							confirmed,
+							fmt.Sprintf("%v@%v", blockHash, currentBlock),
+						)
+					},
+					Removed: func() {
+						removedLogs = append(removedLogs, blockHash)
+					},
+				})
+			}
+
+			if !reflect.DeepEqual(test.expectedConfirmed, confirmed) {
+				t.Errorf(
+					"unexpected confirmed logs\nexpected: [%v]\nactual:   [%v]",
+					test.expectedConfirmed,
+					confirmed,
+				)
+			}
+			if !reflect.DeepEqual(test.expectedRemoved, removedLogs) {
+				t.Errorf(
+					"unexpected removed logs\nexpected: [%v]\nactual:   [%v]",
+					test.expectedRemoved,
+					removedLogs,
+				)
+			}
+		})
+	}
+}
+
+func TestConfirmedLogsAreForgotten(t *testing.T) {
+	confirmer := NewConfirmer(0, &mockChain{})
+
+	removedLogs := 0
+	log := testLog(10, "a")
+	confirmer.Add(log, Handlers{
+		Confirmed: func() {},
+		Removed:   func() { removedLogs++ },
+	})
+
+	confirmer.NewBlock(10 + removalWindow + 1)
+	confirmer.Add(removed(log), Handlers{})
+
+	if removedLogs != 0 {
+		t.Errorf(
+			"unexpected number of removed logs\nexpected: [%v]\nactual:   [%v]",
+			0,
+			removedLogs,
+		)
+	}
+}
+
+type addingChain struct {
+	mockChain
+	onBlockHash func()
+}
+
+func (ac *addingChain) BlockHash(blockNumber uint64) (string, error) {
+	ac.onBlockHash()
+	return ac.mockChain.BlockHash(blockNumber)
+}
+
+func TestLogRemovedWhileCheckingBlockHash(t *testing.T) {
+	chain := &addingChain{
+		mockChain: mockChain{blockHashes: map[uint64]string{10: "a"}},
+	}
+	confirmer := NewConfirmer(5, chain)
+
+	confirmedLogs := 0
+	log := testLog(10, "a")
+	confirmer.Add(log, Handlers{
+		Confirmed: func() { confirmedLogs++ },
+	})
+
+	// The confirmer must not hold its lock while the block hash is fetched.
+	chain.onBlockHash = func() {
+		confirmer.Add(removed(log), Handlers{})
+	}
+
+	confirmer.NewBlock(15)
+
+	if confirmedLogs != 0 {
+		t.Errorf(
+			"unexpected number of confirmed logs\nexpected: [%v]\nactual:   [%v]",
+			0,
+			confirmedLogs,
+		)
+	}
+}
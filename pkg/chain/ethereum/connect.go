@@ -1,43 +1,75 @@
 package ethereum
 
 import (
+	"context"
 	"fmt"
+	"math/big"
+	"path/filepath"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum"
-	"github.com/keep-network/keep-common/pkg/chain/ethereum/blockcounter"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/blockcounter"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/failover"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/gasprice"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/nonce"
 	"github.com/keep-network/keep-core/pkg/chain/gen/contract"
 )
 
 type ethereumChain struct {
 	config                           ethereum.Config
 	client                           bind.ContractBackend
-	clientRPC                        *rpc.Client
-	clientWS                         *rpc.Client
 	keepRandomBeaconOperatorContract *contract.KeepRandomBeaconOperator
 	stakingContract                  *contract.TokenStaking
 	accountKey                       *keystore.Key
-	blockCounter                     *blockcounter.EthereumBlockCounter
+	blockCounter                     *blockcounter.Counter
 
-	// transactionMutex allows interested parties to forcibly serialize
-	// transaction submission.
+	// failoverClient talks to the active one of the configured Ethereum
+	// endpoints. The client of the chain delegates to it.
+	failoverClient *failoverClient
+
+	// transactionMutex is held by contract bindings while a transaction is
+	// being built, signed and sent.
 	//
 	// When transactions are submitted, they require a valid nonce. The nonce is
 	// equal to the count of transactions the account has submitted so far, and
 	// for a transaction to be accepted it should be monotonically greater than
-	// any previous submitted transaction. To do this, transaction submission
-	// asks the Ethereum client it is connected to for the next pending nonce,
-	// and uses that value for the transaction. Unfortunately, if multiple
-	// transactions are submitted in short order, they may all get the same
-	// nonce. Serializing submission ensures that each nonce is requested after
-	// a previous transaction has been submitted.
+	// any previous submitted transaction. Nonces are assigned by the nonce
+	// manager of the account, which tracks transactions submitted by the
+	// client locally instead of asking the Ethereum node for the pending nonce.
+	// The mutex only ensures that a nonce assigned by the manager is used by
+	// the transaction sent right after. Gas prices and gas limits are
+	// determined before a binding is called, see transactionOptions, so the
+	// mutex covers just the nonce assignment, signing and sending; estimating
+	// gas of one transaction does not delay others and transactions are not
+	// delayed until the node sees the previous ones.
 	transactionMutex *sync.Mutex
+
+	// gasPriceStrategy prices submitted transactions and transactions
+	// replacing stuck ones.
+	gasPriceStrategy *gasprice.Strategy
+
+	// confirmationDepth is the number of blocks a relay entry request or
+	// a group selection start has to be buried under before it is passed to
+	// event handlers, see watchConfirmedLogs.
+	confirmationDepth uint64
+	// removalHandlers are notified about confirmed events of the chain
+	// removed by a chain reorganization.
+	removalHandlers *removalHandlers
+
+	// lastTicketSubmissionDeadline caches the end of ticket submission of
+	// the last group selection, see ticketSubmissionDeadline.
+	lastTicketSubmissionDeadline  uint64
+	ticketSubmissionDeadlineMutex *sync.Mutex
+
+	// groupIndexes caches indexes of groups in the operator contract, keyed
+	// by the group public key, see groupIndex.
+	groupIndexes      map[string]*big.Int
+	groupIndexesMutex *sync.Mutex
 }
 
 type ethereumUtilityChain struct {
@@ -47,7 +79,38 @@ type ethereumUtilityChain struct {
 }
 
 func connect(config ethereum.Config) (*ethereumChain, error) {
-	client, clientWS, clientRPC, err := ethutil.ConnectClients(config.URL, config.URLRPC)
+	connection, err := connectClients(
+		config,
+		&failover.Config{},
+		&gasprice.Config{},
+		0,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return connection.withAccount(config.Account, "")
+}
+
+// connectClients connects to the Ethereum node and creates the block counter
+// and the gas price strategy. The Ethereum node of the config is the primary
+// endpoint; endpoints of the failover config are used when it is not healthy.
+// The returned chain has no account and is not attached to contracts; it is
+// meant to be shared by chains of all operators with withAccount. Relay entry
+// requests and group selection starts are passed to event handlers once they
+// are buried under the given number of blocks.
+func connectClients(
+	config ethereum.Config,
+	failoverConfig *failover.Config,
+	gasPriceConfig *gasprice.Config,
+	confirmationDepth uint64,
+) (*ethereumChain, error) {
+	endpoints := append(
+		[]failover.EndpointConfig{{URL: config.URL, URLRPC: config.URLRPC}},
+		failoverConfig.Endpoints...,
+	)
+
+	client, err := newFailoverClient(endpoints, failoverConfig)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"error connecting to Ethereum server: %s [%v]",
@@ -56,7 +119,7 @@ func connect(config ethereum.Config) (*ethereumChain, error) {
 		)
 	}
 
-	blockCounter, err := blockcounter.CreateBlockCounter(client)
+	blockCounter, err := createBlockCounter(client)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to create Ethereum blockcounter: [%v]",
@@ -64,28 +127,95 @@ func connect(config ethereum.Config) (*ethereumChain, error) {
 		)
 	}
 
-	pv := &ethereumChain{
+	gasPriceStrategy, err := gasprice.NewStrategy(gasPriceConfig, client)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to create gas price strategy: [%v]",
+			err,
+		)
+	}
+
+	return &ethereumChain{
 		config:           config,
 		client:           ethutil.WrapCallLogging(logger, client),
-		clientRPC:        clientRPC,
-		clientWS:         clientWS,
-		transactionMutex: &sync.Mutex{},
+		failoverClient:   client,
 		blockCounter:     blockCounter,
+		gasPriceStrategy: gasPriceStrategy,
+
+		confirmationDepth: confirmationDepth,
+	}, nil
+}
+
+// run keeps the connection up to date until the context is done: endpoints
+// are checked by the failover monitor and the block counter is fed with new
+// blocks.
+func (ec *ethereumChain) run(ctx context.Context) {
+	ec.failoverClient.run(ctx)
+	forwardBlocks(ctx, ec.failoverClient, ec.blockCounter)
+}
+
+// withAccount returns a chain sharing the connection and the block counter of
+// this chain which submits transactions from the given account. Transactions
+// of the account which have not been mined yet are persisted in the given
+// storage directory; they are not persisted if the directory is empty.
+func (ec *ethereumChain) withAccount(
+	account ethereum.Account,
+	dataDir string,
+) (*ethereumChain, error) {
+	config := ec.config
+	config.Account = account
+
+	pv := &ethereumChain{
+		config:           config,
+		failoverClient:   ec.failoverClient,
+		transactionMutex: &sync.Mutex{},
+		blockCounter:     ec.blockCounter,
+		gasPriceStrategy: ec.gasPriceStrategy,
+
+		confirmationDepth: ec.confirmationDepth,
+		removalHandlers:   newRemovalHandlers(),
+
+		ticketSubmissionDeadlineMutex: &sync.Mutex{},
+
+		groupIndexes:      make(map[string]*big.Int),
+		groupIndexesMutex: &sync.Mutex{},
 	}
 
-	if pv.accountKey == nil {
-		key, err := ethutil.DecryptKeyFile(
-			config.Account.KeyFile,
-			config.Account.KeyFilePassword,
+	key, err := ethutil.DecryptKeyFile(
+		account.KeyFile,
+		account.KeyFilePassword,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to read KeyFile: %s: [%v]",
+			account.KeyFile,
+			err,
 		)
-		if err != nil {
-			return nil, fmt.Errorf(
-				"failed to read KeyFile: %s: [%v]",
-				config.Account.KeyFile,
-				err,
-			)
-		}
-		pv.accountKey = key
+	}
+	pv.accountKey = key
+
+	var pendingTransactions nonce.Store
+	if dataDir != "" {
+		pendingTransactions = nonce.NewFileStore(
+			filepath.Join(dataDir, pendingTransactionsFile),
+		)
+	}
+
+	nonceManager, err := nonce.NewManager(
+		&nonceNode{
+			client:  ec.failoverClient,
+			address: key.Address,
+		},
+		pendingTransactions,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create nonce manager: [%v]", err)
+	}
+
+	pv.client = &nonceTrackingBackend{
+		ContractBackend: ec.client,
+		address:         key.Address,
+		nonceManager:    nonceManager,
 	}
 
 	address, err := addressForContract(config, "KeepRandomBeaconOperator")
@@ -130,6 +260,10 @@ func connect(config ethereum.Config) (*ethereumChain, error) {
 // non- standard client interactions. Note: for other things to work correctly
 // the configuration will need to reference a websocket, "ws://", or local IPC
 // connection.
+//
+// The utility handle is meant for short-lived commands: the Ethereum endpoint
+// is not failed over once connected and the block counter stays at the block
+// current at the time of connecting.
 func ConnectUtility(config ethereum.Config) (chain.Utility, error) {
 	base, err := connect(config)
 	if err != nil {
@@ -161,9 +295,75 @@ func ConnectUtility(config ethereum.Config) (chain.Utility, error) {
 // Connect makes the network connection to the Ethereum network and returns a
 // standard handle to the chain interface. Note: for other things to work
 // correctly the configuration will need to reference a websocket, "ws://", or
-// local IPC connection.
-func Connect(config ethereum.Config) (chain.Handle, error) {
-	return connect(config)
+// local IPC connection. The connection is kept up to date until the context is
+// done.
+func Connect(ctx context.Context, config ethereum.Config) (chain.Handle, error) {
+	base, err := connect(config)
+	if err != nil {
+		return nil, err
+	}
+
+	base.run(ctx)
+
+	return base, nil
+}
+
+// OperatorConfig holds configuration of an operator connected to the chain
+// with ConnectOperators.
+type OperatorConfig struct {
+	Account ethereum.Account
+
+	// DataDir is the storage directory of the operator where transactions
+	// of the operator which have not been mined yet are persisted.
+	DataDir string
+}
+
+// ConnectOperators makes a single network connection to the Ethereum network
+// and returns a standard handle to the chain interface for each of the given
+// operators, in the same order. All handles share the connection, the block
+// counter and the gas price strategy with the given configuration; each of
+// them submits transactions from its own account. The connection fails over
+// to endpoints of the failover configuration when the Ethereum node of the
+// config is not healthy. Relay entry requests and group selection starts are
+// passed to event handlers once they are buried under the given number of
+// blocks. The connection is kept up to date until the context is done.
+func ConnectOperators(
+	ctx context.Context,
+	config ethereum.Config,
+	operators []OperatorConfig,
+	failoverConfig *failover.Config,
+	gasPriceConfig *gasprice.Config,
+	confirmationDepth uint64,
+) ([]chain.Handle, error) {
+	connection, err := connectClients(
+		config,
+		failoverConfig,
+		gasPriceConfig,
+		confirmationDepth,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	connection.run(ctx)
+
+	handles := make([]chain.Handle, 0, len(operators))
+	for _, operatorConfig := range operators {
+		handle, err := connection.withAccount(
+			operatorConfig.Account,
+			operatorConfig.DataDir,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error connecting account [%v]: [%v]",
+				operatorConfig.Account.Address,
+				err,
+			)
+		}
+		handles = append(handles, handle)
+	}
+
+	return handles, nil
 }
 
 func addressForContract(config ethereum.Config, contractName string) (*common.Address, error) {
@@ -1,26 +1,34 @@
 package ethereum
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"math/big"
 	"time"
 
 	"github.com/ipfs/go-log"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	relayconfig "github.com/keep-network/keep-core/pkg/beacon/relay/config"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
 	"github.com/keep-network/keep-core/pkg/gen/async"
 	"github.com/keep-network/keep-core/pkg/operator"
-	"github.com/keep-network/keep-core/pkg/subscription"
 )
 
 var logger = log.Logger("keep-chain-ethereum")
 
+// withdrawalMiningTimeout is the maximum time the client waits for a rewards
+// withdrawal transaction to be mined.
+const withdrawalMiningTimeout = 30 * time.Minute
+
 // ThresholdRelay converts from ethereumChain to beacon.ChainInterface.
 func (ec *ethereumChain) ThresholdRelay() relaychain.Interface {
 	return ec
@@ -101,16 +109,28 @@ func (ec *ethereumChain) SubmitTicket(ticket *chain.Ticket) *async.EventGroupTic
 
 	ticketBytes := ec.packTicket(ticket)
 
-	_, err := ec.keepRandomBeaconOperatorContract.SubmitTicket(
+	gasPriceCeiling := ec.gasPriceCeiling()
+	transactionOptions, err := ec.transactionOptions(250000, gasPriceCeiling)
+	if err != nil {
+		failPromise(err)
+		return submittedTicketPromise
+	}
+
+	transaction, err := ec.keepRandomBeaconOperatorContract.SubmitTicket(
 		ticketBytes,
-		ethutil.TransactionOptions{
-			GasLimit: 250000,
-		},
+		transactionOptions,
 	)
 	if err != nil {
 		failPromise(err)
+		return submittedTicketPromise
 	}
 
+	ec.replaceStuckTransaction(
+		transaction,
+		gasPriceCeiling,
+		ec.ticketSubmissionDeadline,
+	)
+
 	// TODO: fulfill when submitted
 
 	return submittedTicketPromise
@@ -177,6 +197,51 @@ func (ec *ethereumChain) GetSelectedParticipants() ([]chain.StakerAddress, error
 	}
 }
 
+func (ec *ethereumChain) IsGroupSelectionPossible() (bool, error) {
+	return ec.keepRandomBeaconOperatorContract.IsGroupSelectionPossible()
+}
+
+func (ec *ethereumChain) PastGroupSelectionStarts(
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	filterer, err := ec.operatorFilterer()
+	if err != nil {
+		return nil, err
+	}
+
+	iterator, err := filterer.FilterGroupSelectionStarted(
+		&bind.FilterOpts{Start: fromBlock, End: &toBlock},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not filter group selection starts: [%v]",
+			err,
+		)
+	}
+	defer iterator.Close()
+
+	groupSelectionStarts := make([]*event.GroupSelectionStart, 0)
+	for iterator.Next() {
+		groupSelectionStarts = append(
+			groupSelectionStarts,
+			&event.GroupSelectionStart{
+				NewEntry:        iterator.Event.NewEntry,
+				BlockNumber:     iterator.Event.Raw.BlockNumber,
+				TransactionHash: iterator.Event.Raw.TxHash.Hex(),
+			},
+		)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, fmt.Errorf(
+			"could not iterate over group selection starts: [%v]",
+			err,
+		)
+	}
+
+	return groupSelectionStarts, nil
+}
+
 func (ec *ethereumChain) SubmitRelayEntry(
 	entry []byte,
 ) *async.EventEntrySubmittedPromise {
@@ -234,108 +299,43 @@ func (ec *ethereumChain) SubmitRelayEntry(
 
 	gasEstimate, err := ec.keepRandomBeaconOperatorContract.RelayEntryGasEstimate(entry)
 	if err != nil {
-		logger.Errorf("failed to estimate gas [%v]", err)
+		subscription.Unsubscribe()
+		close(generatedEntry)
+		failPromise(fmt.Errorf("failed to estimate gas [%v]", err))
+		return relayEntryPromise
 	}
 
 	gasEstimateWithMargin := float64(gasEstimate) * float64(1.2) // 20% more than original
-	_, err = ec.keepRandomBeaconOperatorContract.RelayEntry(
-		entry,
-		ethutil.TransactionOptions{
-			GasLimit: uint64(gasEstimateWithMargin),
-		},
+	gasPriceCeiling := ec.gasPriceCeiling()
+	transactionOptions, err := ec.transactionOptions(
+		uint64(gasEstimateWithMargin),
+		gasPriceCeiling,
 	)
 	if err != nil {
 		subscription.Unsubscribe()
 		close(generatedEntry)
 		failPromise(err)
+		return relayEntryPromise
 	}
 
-	return relayEntryPromise
-}
-
-func (ec *ethereumChain) OnRelayEntrySubmitted(
-	handle func(entry *event.EntrySubmitted),
-) (subscription.EventSubscription, error) {
-	return ec.keepRandomBeaconOperatorContract.WatchRelayEntrySubmitted(
-		func(blockNumber uint64) {
-			handle(&event.EntrySubmitted{
-				BlockNumber: blockNumber,
-			})
-		},
-		func(err error) error {
-			return fmt.Errorf(
-				"watch relay entry generated failed with [%v]",
-				err,
-			)
-		},
-	)
-}
-
-func (ec *ethereumChain) OnRelayEntryRequested(
-	handle func(request *event.Request),
-) (subscription.EventSubscription, error) {
-	return ec.keepRandomBeaconOperatorContract.WatchRelayEntryRequested(
-		func(
-			previousEntry []byte,
-			groupPublicKey []byte,
-			blockNumber uint64,
-		) {
-			handle(&event.Request{
-				PreviousEntry:  previousEntry,
-				GroupPublicKey: groupPublicKey,
-				BlockNumber:    blockNumber,
-			})
-		},
-		func(err error) error {
-			return fmt.Errorf(
-				"watch relay entry requested failed with [%v]",
-				err,
-			)
-		},
+	transaction, err := ec.keepRandomBeaconOperatorContract.RelayEntry(
+		entry,
+		transactionOptions,
 	)
-}
+	if err != nil {
+		subscription.Unsubscribe()
+		close(generatedEntry)
+		failPromise(err)
+		return relayEntryPromise
+	}
 
-func (ec *ethereumChain) OnGroupSelectionStarted(
-	handle func(groupSelectionStart *event.GroupSelectionStart),
-) (subscription.EventSubscription, error) {
-	return ec.keepRandomBeaconOperatorContract.WatchGroupSelectionStarted(
-		func(
-			newEntry *big.Int,
-			blockNumber uint64,
-		) {
-			handle(&event.GroupSelectionStart{
-				NewEntry:    newEntry,
-				BlockNumber: blockNumber,
-			})
-		},
-		func(err error) error {
-			return fmt.Errorf(
-				"watch group selection started failed with [%v]",
-				err,
-			)
-		},
+	ec.replaceStuckTransaction(
+		transaction,
+		gasPriceCeiling,
+		ec.relayEntryDeadline,
 	)
-}
 
-func (ec *ethereumChain) OnGroupRegistered(
-	handle func(groupRegistration *event.GroupRegistration),
-) (subscription.EventSubscription, error) {
-	return ec.keepRandomBeaconOperatorContract.WatchDkgResultSubmittedEvent(
-		func(
-			memberIndex *big.Int,
-			groupPublicKey []byte,
-			misbehaved []byte,
-			blockNumber uint64,
-		) {
-			handle(&event.GroupRegistration{
-				GroupPublicKey: groupPublicKey,
-				BlockNumber:    blockNumber,
-			})
-		},
-		func(err error) error {
-			return fmt.Errorf("entry of group key failed with: [%v]", err)
-		},
-	)
+	return relayEntryPromise
 }
 
 func (ec *ethereumChain) IsGroupRegistered(groupPublicKey []byte) (bool, error) {
@@ -365,41 +365,252 @@ func (ec *ethereumChain) GetGroupMembers(groupPublicKey []byte) (
 	return stakerAddresses, nil
 }
 
-func (ec *ethereumChain) OnDKGResultSubmitted(
-	handler func(dkgResultPublication *event.DKGResultSubmission),
-) (subscription.EventSubscription, error) {
-	return ec.keepRandomBeaconOperatorContract.WatchDkgResultSubmittedEvent(
-		func(
-			memberIndex *big.Int,
-			groupPublicKey []byte,
-			misbehaved []byte,
-			blockNumber uint64,
-		) {
-			handler(&event.DKGResultSubmission{
-				MemberIndex:    uint32(memberIndex.Uint64()),
-				GroupPublicKey: groupPublicKey,
-				Misbehaved:     misbehaved,
-				BlockNumber:    blockNumber,
-			})
-		},
-		func(err error) error {
-			return fmt.Errorf(
-				"watch DKG result published failed with: [%v]",
+func (ec *ethereumChain) PastGroupRegistrations(
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*event.GroupRegistration, error) {
+	filterer, err := ec.operatorFilterer()
+	if err != nil {
+		return nil, err
+	}
+
+	iterator, err := filterer.FilterDkgResultSubmittedEvent(
+		&bind.FilterOpts{Start: fromBlock, End: &toBlock},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not filter group registrations: [%v]",
+			err,
+		)
+	}
+	defer iterator.Close()
+
+	groupRegistrations := make([]*event.GroupRegistration, 0)
+	for iterator.Next() {
+		groupRegistrations = append(
+			groupRegistrations,
+			&event.GroupRegistration{
+				GroupPublicKey:  iterator.Event.GroupPubKey,
+				BlockNumber:     iterator.Event.Raw.BlockNumber,
+				TransactionHash: iterator.Event.Raw.TxHash.Hex(),
+			},
+		)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, fmt.Errorf(
+			"could not iterate over group registrations: [%v]",
+			err,
+		)
+	}
+
+	return groupRegistrations, nil
+}
+
+func (ec *ethereumChain) ReportRelayEntryTimeout() error {
+	gasEstimate, err := ec.keepRandomBeaconOperatorContract.ReportRelayEntryTimeoutGasEstimate()
+	if err != nil {
+		return fmt.Errorf("failed to estimate gas [%v]", err)
+	}
+
+	gasPriceCeiling := ec.gasPriceCeiling()
+	transactionOptions, err := ec.transactionOptions(gasEstimate, gasPriceCeiling)
+	if err != nil {
+		return err
+	}
+
+	transaction, err := ec.keepRandomBeaconOperatorContract.ReportRelayEntryTimeout(
+		transactionOptions,
+	)
+	if err != nil {
+		return err
+	}
+
+	// The report is useful only until the next relay entry times out.
+	ec.replaceStuckTransaction(
+		transaction,
+		gasPriceCeiling,
+		ec.blocksFromNow(ec.keepRandomBeaconOperatorContract.RelayEntryTimeout),
+	)
+
+	return nil
+}
+
+func (ec *ethereumChain) ReportUnauthorizedSigning(
+	groupPublicKey []byte,
+	signedOperatorAddress []byte,
+) error {
+	groupIndex, err := ec.groupIndex(groupPublicKey)
+	if err != nil {
+		return err
+	}
+
+	gasEstimate, err := ec.keepRandomBeaconOperatorContract.ReportUnauthorizedSigningGasEstimate(
+		groupIndex,
+		signedOperatorAddress,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to estimate gas [%v]", err)
+	}
+
+	_, err = ec.keepRandomBeaconOperatorContract.ReportUnauthorizedSigning(
+		groupIndex,
+		signedOperatorAddress,
+		ethutil.TransactionOptions{GasLimit: gasEstimate},
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// groupIndex looks up the index of the group with the given public key.
+// The operator contract accepts group indexes, not public keys, for some of
+// its operations. Group indexes never change so they are cached once found.
+func (ec *ethereumChain) groupIndex(groupPublicKey []byte) (*big.Int, error) {
+	ec.groupIndexesMutex.Lock()
+	defer ec.groupIndexesMutex.Unlock()
+
+	if index, ok := ec.groupIndexes[string(groupPublicKey)]; ok {
+		return index, nil
+	}
+
+	index, err := ec.findGroupIndex(groupPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ec.groupIndexes[string(groupPublicKey)] = index
+
+	return index, nil
+}
+
+// findGroupIndex searches the operator contract for the index of the group
+// with the given public key. Active groups are searched first; expired groups
+// are searched starting from the most recently expired one.
+func (ec *ethereumChain) findGroupIndex(groupPublicKey []byte) (*big.Int, error) {
+	firstActiveIndex, err := ec.keepRandomBeaconOperatorContract.GetFirstActiveGroupIndex()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not get first active group index: [%v]",
+			err,
+		)
+	}
+
+	index := firstActiveIndex
+	for {
+		publicKey, err := ec.keepRandomBeaconOperatorContract.GetGroupPublicKey(
+			index,
+		)
+		if err != nil {
+			// The call reverts once the index is past the last group.
+			break
+		}
+
+		if bytes.Equal(publicKey, groupPublicKey) {
+			return index, nil
+		}
+
+		index = new(big.Int).Add(index, big.NewInt(1))
+	}
+
+	index = new(big.Int).Sub(firstActiveIndex, big.NewInt(1))
+	for index.Sign() >= 0 {
+		publicKey, err := ec.keepRandomBeaconOperatorContract.GetGroupPublicKey(
+			index,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not get public key of group [%v]: [%v]",
+				index,
 				err,
 			)
-		},
+		}
+
+		if bytes.Equal(publicKey, groupPublicKey) {
+			return index, nil
+		}
+
+		index = new(big.Int).Sub(index, big.NewInt(1))
+	}
+
+	return nil, fmt.Errorf("could not find group [0x%x]", groupPublicKey)
+}
+
+func (ec *ethereumChain) GetGroupMemberRewards(
+	groupPublicKey []byte,
+) (*big.Int, error) {
+	return ec.keepRandomBeaconOperatorContract.GetGroupMemberRewards(
+		groupPublicKey,
 	)
 }
 
-func (ec *ethereumChain) ReportRelayEntryTimeout() error {
-	_, err := ec.keepRandomBeaconOperatorContract.ReportRelayEntryTimeout()
+func (ec *ethereumChain) HasWithdrawnRewards(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+) (bool, error) {
+	groupIndex, err := ec.groupIndex(groupPublicKey)
+	if err != nil {
+		return false, err
+	}
+
+	return ec.keepRandomBeaconOperatorContract.HasWithdrawnRewards(
+		common.BytesToAddress(operator),
+		groupIndex,
+	)
+}
+
+func (ec *ethereumChain) WithdrawGroupMemberRewards(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+) error {
+	groupIndex, err := ec.groupIndex(groupPublicKey)
+	if err != nil {
+		return err
+	}
+
+	gasEstimate, err := ec.keepRandomBeaconOperatorContract.WithdrawGroupMemberRewardsGasEstimate(
+		common.BytesToAddress(operator),
+		groupIndex,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to estimate gas [%v]", err)
+	}
+
+	transaction, err := ec.keepRandomBeaconOperatorContract.WithdrawGroupMemberRewards(
+		common.BytesToAddress(operator),
+		groupIndex,
+		ethutil.TransactionOptions{GasLimit: gasEstimate},
+	)
 	if err != nil {
 		return err
 	}
 
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		withdrawalMiningTimeout,
+	)
+	defer cancelCtx()
+
+	receipt, err := bind.WaitMined(ctx, ec.failoverClient.rpcClient(), transaction)
+	if err != nil {
+		return fmt.Errorf(
+			"could not wait for transaction [%v] to be mined: [%v]",
+			transaction.Hash().Hex(),
+			err,
+		)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("transaction [%v] reverted", transaction.Hash().Hex())
+	}
+
 	return nil
 }
 
+func (ec *ethereumChain) CurrentGasPrice() (*big.Int, error) {
+	return ec.client.SuggestGasPrice(context.Background())
+}
+
 func (ec *ethereumChain) IsEntryInProgress() (bool, error) {
 	return ec.keepRandomBeaconOperatorContract.IsEntryInProgress()
 }
@@ -425,6 +636,7 @@ func (ec *ethereumChain) SubmitDKGResult(
 	participantIndex chain.GroupMemberIndex,
 	result *relaychain.DKGResult,
 	signatures map[chain.GroupMemberIndex][]byte,
+	publicationBlock uint64,
 ) *async.EventDKGResultSubmissionPromise {
 	resultPublicationPromise := &async.EventDKGResultSubmissionPromise{}
 
@@ -486,18 +698,57 @@ func (ec *ethereumChain) SubmitDKGResult(
 		return resultPublicationPromise
 	}
 
-	if _, err = ec.keepRandomBeaconOperatorContract.SubmitDkgResult(
-		big.NewInt(int64(participantIndex)),
+	submitterMemberIndex := big.NewInt(int64(participantIndex))
+	gasEstimate, err := ec.keepRandomBeaconOperatorContract.SubmitDkgResultGasEstimate(
+		submitterMemberIndex,
 		result.GroupPublicKey,
 		result.Misbehaved,
 		signaturesOnChainFormat,
 		membersIndicesOnChainFormat,
-	); err != nil {
+	)
+	if err != nil {
+		subscription.Unsubscribe()
+		close(publishedResult)
+		failPromise(fmt.Errorf("failed to estimate gas [%v]", err))
+		return resultPublicationPromise
+	}
+
+	gasPriceCeiling := ec.gasPriceCeiling()
+	transactionOptions, err := ec.transactionOptions(gasEstimate, gasPriceCeiling)
+	if err != nil {
 		subscription.Unsubscribe()
 		close(publishedResult)
 		failPromise(err)
+		return resultPublicationPromise
 	}
 
+	transaction, err := ec.keepRandomBeaconOperatorContract.SubmitDkgResult(
+		submitterMemberIndex,
+		result.GroupPublicKey,
+		result.Misbehaved,
+		signaturesOnChainFormat,
+		membersIndicesOnChainFormat,
+		transactionOptions,
+	)
+	if err != nil {
+		subscription.Unsubscribe()
+		close(publishedResult)
+		failPromise(err)
+		return resultPublicationPromise
+	}
+
+	// The next member becomes eligible to submit the result once the slot
+	// of this member ends, no matter how late in the slot the transaction
+	// was sent.
+	ec.replaceStuckTransaction(
+		transaction,
+		gasPriceCeiling,
+		ec.blocksFrom(
+			publicationBlock,
+			ec.keepRandomBeaconOperatorContract.ResultPublicationBlockStep,
+		),
+	)
+
 	return resultPublicationPromise
 }
 
@@ -542,3 +793,26 @@ func (ec *ethereumChain) CalculateDKGResultHash(
 
 	return relaychain.DKGResultHashFromBytes(hash)
 }
+
+func (ec *ethereumChain) operatorFilterer() (
+	*abi.KeepRandomBeaconOperatorFilterer,
+	error,
+) {
+	address, err := addressForContract(ec.config, "KeepRandomBeaconOperator")
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error resolving KeepRandomBeaconOperator contract: [%v]",
+			err,
+		)
+	}
+
+	filterer, err := abi.NewKeepRandomBeaconOperatorFilterer(*address, ec.client)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error attaching to KeepRandomBeaconOperator contract: [%v]",
+			err,
+		)
+	}
+
+	return filterer, nil
+}
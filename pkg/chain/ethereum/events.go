@@ -0,0 +1,776 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/backfill"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/confirmation"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+// resubscriptionDelay is the delay before a failed subscription to confirmed
+// events is created again. There is no sense to resubscribe immediately after
+// the failure because the Ethereum node must have some time to recover.
+const resubscriptionDelay = 5 * time.Second
+
+// blockHashTimeout is the maximum time of a single block header lookup done
+// to confirm an event.
+const blockHashTimeout = 10 * time.Second
+
+// backfillTimeout is the maximum time of fetching events emitted in a single
+// range of blocks while there was no subscription to them.
+const backfillTimeout = 30 * time.Second
+
+// maxBackfillBlocks is the maximum number of blocks events are fetched from
+// with a single query. Ethereum providers limit the range of blocks or the
+// number of logs returned by a single query.
+const maxBackfillBlocks = 1000
+
+// removalHandlers holds handlers registered with OnRelayEntryRequestRemoved
+// and OnGroupSelectionStartRemoved. They are notified about confirmed events
+// of this chain handle which have been removed by a chain reorganization.
+type removalHandlers struct {
+	mutex                sync.Mutex
+	relayEntryRequests   map[int]func(request *event.Request)
+	groupSelectionStarts map[int]func(groupSelectionStart *event.GroupSelectionStart)
+}
+
+func newRemovalHandlers() *removalHandlers {
+	return &removalHandlers{
+		relayEntryRequests: make(map[int]func(request *event.Request)),
+		groupSelectionStarts: make(
+			map[int]func(groupSelectionStart *event.GroupSelectionStart),
+		),
+	}
+}
+
+func (rh *removalHandlers) relayEntryRequestRemoved(request *event.Request) {
+	rh.mutex.Lock()
+	defer rh.mutex.Unlock()
+
+	for _, handler := range rh.relayEntryRequests {
+		go handler(request)
+	}
+}
+
+func (rh *removalHandlers) groupSelectionStartRemoved(
+	groupSelectionStart *event.GroupSelectionStart,
+) {
+	rh.mutex.Lock()
+	defer rh.mutex.Unlock()
+
+	for _, handler := range rh.groupSelectionStarts {
+		go handler(groupSelectionStart)
+	}
+}
+
+func (ec *ethereumChain) OnRelayEntryRequested(
+	handle func(request *event.Request),
+) (subscription.EventSubscription, error) {
+	filterer, err := ec.operatorFilterer()
+	if err != nil {
+		return nil, err
+	}
+
+	return ec.watchConfirmedLogs(
+		"RelayEntryRequested",
+		ec.confirmationDepth,
+		func(log types.Log) (confirmation.Handlers, error) {
+			parsed, err := filterer.ParseRelayEntryRequested(log)
+			if err != nil {
+				return confirmation.Handlers{}, err
+			}
+
+			request := &event.Request{
+				PreviousEntry:   parsed.PreviousEntry,
+				GroupPublicKey:  parsed.GroupPublicKey,
+				BlockNumber:     log.BlockNumber,
+				TransactionHash: log.TxHash.Hex(),
+			}
+
+			return confirmation.Handlers{
+				Confirmed: func() {
+					request.RequestID = ec.relayRequestID(
+						log.TxHash,
+						"RelayEntryRequested",
+					)
+					handle(request)
+				},
+				Removed: func() {
+					ec.removalHandlers.relayEntryRequestRemoved(request)
+				},
+			}, nil
+		},
+	)
+}
+
+// OnRelayEntryRequestRemoved registers a handler of relay requests removed
+// by a chain reorganization after they have been passed to handlers
+// registered with OnRelayEntryRequested of this chain handle.
+func (ec *ethereumChain) OnRelayEntryRequestRemoved(
+	handle func(request *event.Request),
+) (subscription.EventSubscription, error) {
+	ec.removalHandlers.mutex.Lock()
+	defer ec.removalHandlers.mutex.Unlock()
+
+	handlerID := rand.Int()
+	ec.removalHandlers.relayEntryRequests[handlerID] = handle
+
+	return subscription.NewEventSubscription(func() {
+		ec.removalHandlers.mutex.Lock()
+		defer ec.removalHandlers.mutex.Unlock()
+
+		delete(ec.removalHandlers.relayEntryRequests, handlerID)
+	}), nil
+}
+
+func (ec *ethereumChain) OnGroupSelectionStarted(
+	handle func(groupSelectionStart *event.GroupSelectionStart),
+) (subscription.EventSubscription, error) {
+	filterer, err := ec.operatorFilterer()
+	if err != nil {
+		return nil, err
+	}
+
+	return ec.watchConfirmedLogs(
+		"GroupSelectionStarted",
+		ec.confirmationDepth,
+		func(log types.Log) (confirmation.Handlers, error) {
+			parsed, err := filterer.ParseGroupSelectionStarted(log)
+			if err != nil {
+				return confirmation.Handlers{}, err
+			}
+
+			groupSelectionStart := &event.GroupSelectionStart{
+				NewEntry:        parsed.NewEntry,
+				BlockNumber:     log.BlockNumber,
+				TransactionHash: log.TxHash.Hex(),
+			}
+
+			return confirmation.Handlers{
+				Confirmed: func() { handle(groupSelectionStart) },
+				Removed: func() {
+					ec.removalHandlers.groupSelectionStartRemoved(
+						groupSelectionStart,
+					)
+				},
+			}, nil
+		},
+	)
+}
+
+// OnGroupSelectionStartRemoved registers a handler of group selection starts
+// removed by a chain reorganization after they have been passed to handlers
+// registered with OnGroupSelectionStarted of this chain handle.
+func (ec *ethereumChain) OnGroupSelectionStartRemoved(
+	handle func(groupSelectionStart *event.GroupSelectionStart),
+) (subscription.EventSubscription, error) {
+	ec.removalHandlers.mutex.Lock()
+	defer ec.removalHandlers.mutex.Unlock()
+
+	handlerID := rand.Int()
+	ec.removalHandlers.groupSelectionStarts[handlerID] = handle
+
+	return subscription.NewEventSubscription(func() {
+		ec.removalHandlers.mutex.Lock()
+		defer ec.removalHandlers.mutex.Unlock()
+
+		delete(ec.removalHandlers.groupSelectionStarts, handlerID)
+	}), nil
+}
+
+// OnRelayEntrySubmitted registers a handler of relay entry submissions.
+// Submissions are not confirmed; they are passed to the handler as soon as
+// they are seen.
+func (ec *ethereumChain) OnRelayEntrySubmitted(
+	handle func(entry *event.EntrySubmitted),
+) (subscription.EventSubscription, error) {
+	filterer, err := ec.operatorFilterer()
+	if err != nil {
+		return nil, err
+	}
+
+	return ec.watchConfirmedLogs(
+		"RelayEntrySubmitted",
+		0,
+		func(log types.Log) (confirmation.Handlers, error) {
+			if _, err := filterer.ParseRelayEntrySubmitted(log); err != nil {
+				return confirmation.Handlers{}, err
+			}
+
+			entry := &event.EntrySubmitted{
+				BlockNumber:     log.BlockNumber,
+				TransactionHash: log.TxHash.Hex(),
+			}
+
+			return confirmation.Handlers{
+				Confirmed: func() {
+					entry.RequestID = ec.relayRequestID(
+						log.TxHash,
+						"RelayEntryGenerated",
+					)
+					handle(entry)
+				},
+			}, nil
+		},
+	)
+}
+
+// relayRequestID returns the id of the relay request carried by the given
+// KeepRandomBeaconService event emitted in the given transaction. Events of
+// the operator contract do not carry request ids but the service contract
+// emits RelayEntryRequested in the same transaction as the request and
+// RelayEntryGenerated in the same transaction as the entry submission.
+// It returns nil if the id could not be determined, for example for
+// a request repeated after a relay entry timeout which is emitted by the
+// operator contract alone.
+func (ec *ethereumChain) relayRequestID(
+	transactionHash common.Hash,
+	serviceEventName string,
+) *big.Int {
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		transactionLookupTimeout,
+	)
+	defer cancelCtx()
+
+	receipt, err := ec.failoverClient.rpcClient().TransactionReceipt(
+		ctx,
+		transactionHash,
+	)
+	if err != nil {
+		logger.Warningf(
+			"could not get receipt of transaction [%v]: [%v]",
+			transactionHash.Hex(),
+			err,
+		)
+		return nil
+	}
+
+	requestID, err := requestIDFromLogs(receipt.Logs, serviceEventName)
+	if err != nil {
+		logger.Warningf(
+			"could not read relay request id of transaction [%v]: [%v]",
+			transactionHash.Hex(),
+			err,
+		)
+		return nil
+	}
+
+	return requestID
+}
+
+// requestIDFromLogs decodes the relay request id from the first of the logs
+// being the given KeepRandomBeaconService event. It returns nil if there is no
+// such log.
+func requestIDFromLogs(
+	logs []*types.Log,
+	serviceEventName string,
+) (*big.Int, error) {
+	serviceABI, err := ethabi.JSON(
+		strings.NewReader(abi.KeepRandomBeaconServiceImplV1ABI),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse service contract ABI: [%v]", err)
+	}
+
+	serviceEvent, ok := serviceABI.Events[serviceEventName]
+	if !ok {
+		return nil, fmt.Errorf("unknown service event [%v]", serviceEventName)
+	}
+
+	for _, log := range logs {
+		if len(log.Topics) == 0 || log.Topics[0] != serviceEvent.ID() {
+			continue
+		}
+
+		values, err := serviceEvent.Inputs.UnpackValues(log.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		// The request id is the first argument of all service events
+		// carrying it.
+		requestID, ok := values[0].(*big.Int)
+		if !ok {
+			return nil, fmt.Errorf("unexpected request id type")
+		}
+
+		return requestID, nil
+	}
+
+	return nil, nil
+}
+
+// OnGroupRegistered registers a handler of group registrations. A group is
+// registered once its DKG result is submitted. Registrations are not
+// confirmed; they are passed to the handler as soon as they are seen.
+func (ec *ethereumChain) OnGroupRegistered(
+	handle func(groupRegistration *event.GroupRegistration),
+) (subscription.EventSubscription, error) {
+	filterer, err := ec.operatorFilterer()
+	if err != nil {
+		return nil, err
+	}
+
+	return ec.watchConfirmedLogs(
+		"DkgResultSubmittedEvent",
+		0,
+		func(log types.Log) (confirmation.Handlers, error) {
+			parsed, err := filterer.ParseDkgResultSubmittedEvent(log)
+			if err != nil {
+				return confirmation.Handlers{}, err
+			}
+
+			groupRegistration := &event.GroupRegistration{
+				GroupPublicKey:  parsed.GroupPubKey,
+				BlockNumber:     log.BlockNumber,
+				TransactionHash: log.TxHash.Hex(),
+			}
+
+			return confirmation.Handlers{
+				Confirmed: func() { handle(groupRegistration) },
+			}, nil
+		},
+	)
+}
+
+// OnDKGResultSubmitted registers a handler of DKG result submissions.
+// Submissions are not confirmed; they are passed to the handler as soon as
+// they are seen.
+func (ec *ethereumChain) OnDKGResultSubmitted(
+	handle func(dkgResultPublication *event.DKGResultSubmission),
+) (subscription.EventSubscription, error) {
+	filterer, err := ec.operatorFilterer()
+	if err != nil {
+		return nil, err
+	}
+
+	return ec.watchConfirmedLogs(
+		"DkgResultSubmittedEvent",
+		0,
+		func(log types.Log) (confirmation.Handlers, error) {
+			parsed, err := filterer.ParseDkgResultSubmittedEvent(log)
+			if err != nil {
+				return confirmation.Handlers{}, err
+			}
+
+			dkgResultSubmission := &event.DKGResultSubmission{
+				MemberIndex:     uint32(parsed.MemberIndex.Uint64()),
+				GroupPublicKey:  parsed.GroupPubKey,
+				Misbehaved:      parsed.Misbehaved,
+				BlockNumber:     log.BlockNumber,
+				TransactionHash: log.TxHash.Hex(),
+			}
+
+			return confirmation.Handlers{
+				Confirmed: func() { handle(dkgResultSubmission) },
+			}, nil
+		},
+	)
+}
+
+// watchConfirmedLogs watches logs of the given KeepRandomBeaconOperator event
+// and passes them through a confirmer with the given confirmation depth.
+// Confirmer handlers of every log are created with handlersFor.
+func (ec *ethereumChain) watchConfirmedLogs(
+	eventName string,
+	depth uint64,
+	handlersFor func(log types.Log) (confirmation.Handlers, error),
+) (subscription.EventSubscription, error) {
+	contract, err := ec.operatorLogContract()
+	if err != nil {
+		return nil, err
+	}
+
+	return ec.watchConfirmedContractLogs(
+		contract,
+		eventName,
+		nil,
+		depth,
+		handlersFor,
+	)
+}
+
+// watchConfirmedContractLogs watches logs of the given contract event
+// matching the query on indexed event parameters and passes them through
+// a confirmer with the given confirmation depth. Confirmer handlers of every
+// log are created with handlersFor.
+// The subscription to logs is created again if it fails, until the returned
+// subscription is unsubscribed. Logs emitted while there was no subscription
+// are fetched from the chain once the subscription is created again.
+func (ec *ethereumChain) watchConfirmedContractLogs(
+	contract *bind.BoundContract,
+	eventName string,
+	query [][]interface{},
+	depth uint64,
+	handlersFor func(log types.Log) (confirmation.Handlers, error),
+) (subscription.EventSubscription, error) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	confirmer := confirmation.NewConfirmer(
+		depth,
+		&blockHashSource{ec.failoverClient},
+	)
+
+	// Blocks are watched even without the confirmation depth so that
+	// confirmed logs are eventually forgotten by the confirmer.
+	blocks := ec.blockCounter.WatchBlocks(ctx)
+	go func() {
+		for block := range blocks {
+			confirmer.NewBlock(block)
+		}
+	}()
+
+	deliver := func(log types.Log) {
+		handlers, err := handlersFor(log)
+		if err != nil {
+			logger.Errorf(
+				"could not parse [%v] event from block [%v]: [%v]",
+				eventName,
+				log.BlockNumber,
+				err,
+			)
+			return
+		}
+
+		confirmer.Add(
+			confirmation.Log{
+				BlockNumber:     log.BlockNumber,
+				BlockHash:       log.BlockHash.Hex(),
+				TransactionHash: log.TxHash.Hex(),
+				Index:           log.Index,
+				Removed:         log.Removed,
+			},
+			handlers,
+		)
+	}
+
+	go func() {
+		startBlock, _ := ec.blockCounter.CurrentBlock()
+		tracker := backfill.NewTracker(startBlock)
+
+		for attempt := 0; ; attempt++ {
+			err := ec.watchLogs(
+				ctx,
+				contract,
+				eventName,
+				query,
+				tracker,
+				attempt > 0,
+				deliver,
+			)
+			if err == nil {
+				return
+			}
+
+			logger.Warningf(
+				"subscription to [%v] events failed; "+
+					"resubscribing after [%v]: [%v]",
+				eventName,
+				resubscriptionDelay,
+				err,
+			)
+
+			select {
+			case <-time.After(resubscriptionDelay):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return subscription.NewEventSubscription(cancelCtx), nil
+}
+
+// watchLogs subscribes to logs of the event matching the query and delivers
+// logs which have not been delivered yet until the context is done, in which
+// case nil is returned, or until the subscription fails. With catchUp set,
+// logs emitted since the block from which the tracker needs logs to be fetched
+// are delivered first.
+//
+// The tracker is advanced only to blocks the logs are known to be delivered
+// up to: blocks of logs delivered by the subscription and blocks covered by
+// completed fetches. Blocks seen by the block counter are not used since the
+// subscription could still be behind them.
+func (ec *ethereumChain) watchLogs(
+	ctx context.Context,
+	contract *bind.BoundContract,
+	eventName string,
+	query [][]interface{},
+	tracker *backfill.Tracker,
+	catchUp bool,
+	deliver func(log types.Log),
+) error {
+	logs, logSubscription, err := contract.WatchLogs(nil, eventName, query...)
+	if err != nil {
+		return fmt.Errorf("could not subscribe: [%v]", err)
+	}
+	defer logSubscription.Unsubscribe()
+
+	if catchUp {
+		latestBlock, err := ec.blockCounter.CurrentBlock()
+		if err != nil {
+			return fmt.Errorf("could not get current block: [%v]", err)
+		}
+
+		if err := backfillLogs(
+			ctx,
+			contract,
+			eventName,
+			query,
+			tracker,
+			latestBlock,
+			deliver,
+		); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case log := <-logs:
+			deliverOnce(log, tracker, deliver)
+			if !log.Removed {
+				tracker.BlockProcessed(log.BlockNumber)
+			}
+		case err := <-logSubscription.Err():
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// backfillLogs fetches logs of the event matching the query emitted since
+// the block from which the tracker needs logs to be fetched and delivers those
+// which have not been delivered yet. Logs are fetched in ranges of at most
+// maxBackfillBlocks blocks; the last range reaches the latest block of the
+// Ethereum node, which may be past the given latest block. The tracker is
+// advanced to the end of every fetched range.
+func backfillLogs(
+	ctx context.Context,
+	contract *bind.BoundContract,
+	eventName string,
+	query [][]interface{},
+	tracker *backfill.Tracker,
+	latestBlock uint64,
+	deliver func(log types.Log),
+) error {
+	fromBlock := tracker.FromBlock()
+
+	logger.Infof(
+		"catching up on [%v] events emitted since block [%v]",
+		eventName,
+		fromBlock,
+	)
+
+	for start := fromBlock; ; {
+		if start+maxBackfillBlocks > latestBlock {
+			if err := filterLogs(
+				ctx,
+				contract,
+				eventName,
+				query,
+				start,
+				nil,
+				tracker,
+				deliver,
+			); err != nil {
+				return err
+			}
+
+			tracker.BlockProcessed(latestBlock)
+			return nil
+		}
+
+		end := start + maxBackfillBlocks - 1
+		if err := filterLogs(
+			ctx,
+			contract,
+			eventName,
+			query,
+			start,
+			&end,
+			tracker,
+			deliver,
+		); err != nil {
+			return err
+		}
+
+		tracker.BlockProcessed(end)
+		start = end + 1
+	}
+}
+
+// filterLogs fetches logs of the event matching the query emitted between
+// the given blocks, inclusive, and delivers those which have not been
+// delivered yet. Logs up to the latest block are fetched if the end block is
+// nil.
+func filterLogs(
+	ctx context.Context,
+	contract *bind.BoundContract,
+	eventName string,
+	query [][]interface{},
+	start uint64,
+	end *uint64,
+	tracker *backfill.Tracker,
+	deliver func(log types.Log),
+) error {
+	filterCtx, cancelFilterCtx := context.WithTimeout(ctx, backfillTimeout)
+	defer cancelFilterCtx()
+
+	logs, logSubscription, err := contract.FilterLogs(
+		&bind.FilterOpts{Start: start, End: end, Context: filterCtx},
+		eventName,
+		query...,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"could not fetch [%v] events since block [%v]: [%v]",
+			eventName,
+			start,
+			err,
+		)
+	}
+	defer logSubscription.Unsubscribe()
+
+	for {
+		select {
+		case log := <-logs:
+			deliverOnce(log, tracker, deliver)
+		case err := <-logSubscription.Err():
+			if err != nil {
+				return fmt.Errorf(
+					"could not fetch [%v] events since block [%v]: [%v]",
+					eventName,
+					start,
+					err,
+				)
+			}
+
+			// All fetched logs have been passed to the channel once the
+			// subscription is over.
+			for {
+				select {
+				case log := <-logs:
+					deliverOnce(log, tracker, deliver)
+				default:
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// deliverOnce delivers the log unless it has been already delivered. Logs
+// removed by a chain reorganization are always delivered so that the
+// confirmer can handle their removal.
+func deliverOnce(
+	log types.Log,
+	tracker *backfill.Tracker,
+	deliver func(log types.Log),
+) {
+	id := fmt.Sprintf("%v-%v-%v", log.BlockHash.Hex(), log.TxHash.Hex(), log.Index)
+	if log.Removed || tracker.Deliver(id, log.BlockNumber) {
+		deliver(log)
+	}
+}
+
+// operatorLogContract returns the KeepRandomBeaconOperator contract binding
+// delivering raw logs, including logs removed by chain reorganizations.
+func (ec *ethereumChain) operatorLogContract() (*bind.BoundContract, error) {
+	address, err := addressForContract(ec.config, "KeepRandomBeaconOperator")
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error resolving KeepRandomBeaconOperator contract: [%v]",
+			err,
+		)
+	}
+
+	parsedABI, err := ethabi.JSON(
+		strings.NewReader(abi.KeepRandomBeaconOperatorABI),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not parse KeepRandomBeaconOperator ABI: [%v]",
+			err,
+		)
+	}
+
+	return bind.NewBoundContract(*address, parsedABI, nil, nil, ec.client), nil
+}
+
+// stakingLogContract returns the TokenStaking contract binding delivering raw
+// logs, including logs removed by chain reorganizations, and the filterer
+// parsing them.
+func (ec *ethereumChain) stakingLogContract() (
+	*bind.BoundContract,
+	*abi.TokenStakingFilterer,
+	error,
+) {
+	address, err := addressForContract(ec.config, "TokenStaking")
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"error resolving TokenStaking contract: [%v]",
+			err,
+		)
+	}
+
+	parsedABI, err := ethabi.JSON(strings.NewReader(abi.TokenStakingABI))
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"could not parse TokenStaking ABI: [%v]",
+			err,
+		)
+	}
+
+	filterer, err := abi.NewTokenStakingFilterer(*address, ec.client)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"error attaching to TokenStaking contract: [%v]",
+			err,
+		)
+	}
+
+	contract := bind.NewBoundContract(*address, parsedABI, nil, nil, ec.client)
+
+	return contract, filterer, nil
+}
+
+// blockHashSource provides the confirmer with hashes of canonical chain
+// blocks known to the active Ethereum endpoint.
+type blockHashSource struct {
+	client *failoverClient
+}
+
+func (bhs *blockHashSource) BlockHash(blockNumber uint64) (string, error) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), blockHashTimeout)
+	defer cancelCtx()
+
+	header, err := bhs.client.rpcClient().HeaderByNumber(
+		ctx,
+		new(big.Int).SetUint64(blockNumber),
+	)
+	if err != nil {
+		return "", fmt.Errorf(
+			"could not get header of block [%v]: [%v]",
+			blockNumber,
+			err,
+		)
+	}
+
+	return header.Hash().Hex(), nil
+}
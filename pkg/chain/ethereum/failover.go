@@ -0,0 +1,358 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/failover"
+)
+
+var errEndpointSwitched = fmt.Errorf("active Ethereum endpoint has been switched")
+
+// endpoint is a single Ethereum endpoint. The endpoint is dialed by its
+// health checks so that an endpoint which is not available when the client
+// starts can become active later.
+type endpoint struct {
+	url    string
+	urlRPC string
+
+	mutex     sync.Mutex
+	client    *ethclient.Client
+	clientRPC *ethclient.Client
+}
+
+func (e *endpoint) dial(ctx context.Context) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.client != nil {
+		return nil
+	}
+
+	client, err := rpc.DialContext(ctx, e.url)
+	if err != nil {
+		return fmt.Errorf(
+			"error connecting to Ethereum server: %s [%v]",
+			e.url,
+			err,
+		)
+	}
+
+	clientRPC, err := rpc.DialContext(ctx, e.urlRPC)
+	if err != nil {
+		client.Close()
+		return fmt.Errorf(
+			"error connecting to Ethereum server: %s [%v]",
+			e.urlRPC,
+			err,
+		)
+	}
+
+	e.client = ethclient.NewClient(client)
+	e.clientRPC = ethclient.NewClient(clientRPC)
+
+	return nil
+}
+
+// clients returns the client of the endpoint's URL and the client of the
+// endpoint's RPC URL. Both are nil until the endpoint is dialed.
+func (e *endpoint) clients() (*ethclient.Client, *ethclient.Client) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.client, e.clientRPC
+}
+
+// LatestBlock returns the latest block known to both the endpoint's URL and
+// the endpoint's RPC URL, so that the endpoint is healthy only if both of
+// them are.
+func (e *endpoint) LatestBlock(ctx context.Context) (uint64, error) {
+	if err := e.dial(ctx); err != nil {
+		return 0, err
+	}
+
+	client, clientRPC := e.clients()
+
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"could not get the latest block from [%v]: [%v]",
+			e.url,
+			err,
+		)
+	}
+
+	headerRPC, err := clientRPC.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"could not get the latest block from [%v]: [%v]",
+			e.urlRPC,
+			err,
+		)
+	}
+
+	if headerRPC.Number.Cmp(header.Number) < 0 {
+		return headerRPC.Number.Uint64(), nil
+	}
+
+	return header.Number.Uint64(), nil
+}
+
+// failoverClient is an Ethereum client delegating calls to the endpoint made
+// active by the failover monitor. Subscriptions created with the client fail
+// with errEndpointSwitched once the active endpoint is switched so that their
+// owners subscribe again on the new active endpoint.
+type failoverClient struct {
+	endpoints []*endpoint
+	monitor   *failover.Monitor
+
+	subscriptionsMutex sync.Mutex
+	subscriptions      map[*endpointSubscription]bool
+}
+
+// newFailoverClient creates a client of the given endpoints, listed in the
+// order of preference, and checks them once. It fails if none of the
+// endpoints is healthy. Endpoints are not checked again until run is called.
+func newFailoverClient(
+	endpointConfigs []failover.EndpointConfig,
+	config *failover.Config,
+) (*failoverClient, error) {
+	fc := &failoverClient{
+		subscriptions: make(map[*endpointSubscription]bool),
+	}
+
+	monitoredEndpoints := make([]failover.Endpoint, 0, len(endpointConfigs))
+	for _, endpointConfig := range endpointConfigs {
+		ethEndpoint := &endpoint{
+			url:    endpointConfig.URL,
+			urlRPC: endpointConfig.URLRPC,
+		}
+		fc.endpoints = append(fc.endpoints, ethEndpoint)
+		monitoredEndpoints = append(monitoredEndpoints, ethEndpoint)
+	}
+
+	fc.monitor = failover.NewMonitor(monitoredEndpoints, config)
+	fc.monitor.OnSwitch(fc.endpointSwitched)
+
+	if err := fc.monitor.Check(); err != nil {
+		return nil, err
+	}
+
+	return fc, nil
+}
+
+// run checks endpoints periodically and switches the active endpoint until
+// the context is done.
+func (fc *failoverClient) run(ctx context.Context) {
+	go fc.monitor.Run(ctx)
+}
+
+// active returns the position of the active endpoint and its client. The
+// active endpoint has always been dialed since only healthy endpoints become
+// active.
+func (fc *failoverClient) active() (int, *ethclient.Client) {
+	index := fc.monitor.Active()
+	client, _ := fc.endpoints[index].clients()
+	return index, client
+}
+
+func (fc *failoverClient) client() *ethclient.Client {
+	_, client := fc.active()
+	return client
+}
+
+// rpcClient returns the client of the RPC URL of the active endpoint.
+func (fc *failoverClient) rpcClient() *ethclient.Client {
+	_, clientRPC := fc.endpoints[fc.monitor.Active()].clients()
+	return clientRPC
+}
+
+func (fc *failoverClient) CodeAt(
+	ctx context.Context,
+	contract common.Address,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	return fc.client().CodeAt(ctx, contract, blockNumber)
+}
+
+func (fc *failoverClient) CallContract(
+	ctx context.Context,
+	call goethereum.CallMsg,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	return fc.client().CallContract(ctx, call, blockNumber)
+}
+
+func (fc *failoverClient) HeaderByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Header, error) {
+	return fc.client().HeaderByNumber(ctx, number)
+}
+
+func (fc *failoverClient) PendingCodeAt(
+	ctx context.Context,
+	account common.Address,
+) ([]byte, error) {
+	return fc.client().PendingCodeAt(ctx, account)
+}
+
+func (fc *failoverClient) PendingNonceAt(
+	ctx context.Context,
+	account common.Address,
+) (uint64, error) {
+	return fc.client().PendingNonceAt(ctx, account)
+}
+
+func (fc *failoverClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return fc.client().SuggestGasPrice(ctx)
+}
+
+func (fc *failoverClient) EstimateGas(
+	ctx context.Context,
+	call goethereum.CallMsg,
+) (uint64, error) {
+	return fc.client().EstimateGas(ctx, call)
+}
+
+func (fc *failoverClient) SendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	return fc.client().SendTransaction(ctx, transaction)
+}
+
+func (fc *failoverClient) FilterLogs(
+	ctx context.Context,
+	query goethereum.FilterQuery,
+) ([]types.Log, error) {
+	return fc.client().FilterLogs(ctx, query)
+}
+
+func (fc *failoverClient) SubscribeFilterLogs(
+	ctx context.Context,
+	query goethereum.FilterQuery,
+	logs chan<- types.Log,
+) (goethereum.Subscription, error) {
+	index, client := fc.active()
+
+	subscription, err := client.SubscribeFilterLogs(ctx, query, logs)
+	if err != nil {
+		return nil, err
+	}
+
+	return fc.track(index, subscription), nil
+}
+
+func (fc *failoverClient) SubscribeNewHead(
+	ctx context.Context,
+	headers chan<- *types.Header,
+) (goethereum.Subscription, error) {
+	index, client := fc.active()
+
+	subscription, err := client.SubscribeNewHead(ctx, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return fc.track(index, subscription), nil
+}
+
+// track wraps the subscription created on the endpoint at the given position
+// so that it fails once the endpoint is not active anymore.
+func (fc *failoverClient) track(
+	index int,
+	subscription goethereum.Subscription,
+) goethereum.Subscription {
+	tracked := &endpointSubscription{
+		subscription: subscription,
+		switched:     make(chan struct{}),
+		unsubscribed: make(chan struct{}),
+		err:          make(chan error, 1),
+	}
+
+	fc.subscriptionsMutex.Lock()
+	fc.subscriptions[tracked] = true
+	if fc.monitor.Active() != index {
+		// The endpoint has been switched while the subscription was being
+		// created.
+		tracked.fail()
+	}
+	fc.subscriptionsMutex.Unlock()
+
+	go func() {
+		tracked.forward()
+
+		fc.subscriptionsMutex.Lock()
+		delete(fc.subscriptions, tracked)
+		fc.subscriptionsMutex.Unlock()
+	}()
+
+	return tracked
+}
+
+func (fc *failoverClient) endpointSwitched(active int) {
+	fc.subscriptionsMutex.Lock()
+	defer fc.subscriptionsMutex.Unlock()
+
+	logger.Infof(
+		"terminating [%v] subscriptions of the previously active "+
+			"Ethereum endpoint",
+		len(fc.subscriptions),
+	)
+
+	for subscription := range fc.subscriptions {
+		subscription.fail()
+	}
+}
+
+// endpointSubscription is a subscription created on a single endpoint. It
+// fails with errEndpointSwitched once the endpoint is not active anymore.
+type endpointSubscription struct {
+	subscription goethereum.Subscription
+
+	switchOnce      sync.Once
+	switched        chan struct{}
+	unsubscribeOnce sync.Once
+	unsubscribed    chan struct{}
+	err             chan error
+}
+
+// forward passes the error of the endpoint subscription to the subscriber
+// or fails the subscription once the endpoint is switched. It returns when
+// the subscription is over.
+func (es *endpointSubscription) forward() {
+	defer close(es.err)
+
+	select {
+	case err, ok := <-es.subscription.Err():
+		es.subscription.Unsubscribe()
+		if ok {
+			es.err <- err
+		}
+	case <-es.switched:
+		es.subscription.Unsubscribe()
+		es.err <- errEndpointSwitched
+	case <-es.unsubscribed:
+		es.subscription.Unsubscribe()
+	}
+}
+
+func (es *endpointSubscription) fail() {
+	es.switchOnce.Do(func() { close(es.switched) })
+}
+
+func (es *endpointSubscription) Unsubscribe() {
+	es.unsubscribeOnce.Do(func() { close(es.unsubscribed) })
+}
+
+func (es *endpointSubscription) Err() <-chan error {
+	return es.err
+}
@@ -0,0 +1,252 @@
+// Package failover selects the Ethereum endpoint the client talks to out of
+// an ordered list of endpoints.
+//
+// All endpoints are checked periodically. An endpoint is healthy if it
+// returns its latest block and the block is not behind the best block
+// returned by any endpoint by more than the allowed lag. The first healthy
+// endpoint on the list becomes the active one, so the client falls back to
+// the next endpoint when the active one fails and returns to the preferred
+// endpoint once it recovers. The active endpoint is kept if no endpoint is
+// healthy.
+package failover
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"github.com/keep-network/keep-core/pkg/metrics"
+)
+
+var logger = log.Logger("keep-failover")
+
+const (
+	// DefaultCheckInterval is the time between health checks of endpoints
+	// used if the interval is not configured.
+	DefaultCheckInterval = 15 * time.Second
+
+	// DefaultMaxBlockLag is the number of blocks an endpoint can be behind
+	// the best endpoint and still be considered healthy, used if the lag is
+	// not configured.
+	DefaultMaxBlockLag = 5
+
+	// checkTimeout is the maximum time of a single endpoint health check.
+	checkTimeout = 10 * time.Second
+)
+
+var (
+	activeEndpoint = metrics.NewGauge(
+		"keep_ethereum_active_endpoint",
+		"Position of the active Ethereum endpoint on the list of "+
+			"configured endpoints, 0 being the primary endpoint.",
+	)
+	endpointSwitches = metrics.NewCounter(
+		"keep_ethereum_endpoint_switches_total",
+		"Number of times the active Ethereum endpoint has been switched.",
+	)
+	failedChecks = metrics.NewCounterVec(
+		"keep_ethereum_endpoint_failed_checks_total",
+		"Number of failed health checks of Ethereum endpoints.",
+		"endpoint",
+	)
+)
+
+// Config is the configuration of the Ethereum endpoints failover.
+type Config struct {
+	// Endpoints are the Ethereum endpoints used when the primary endpoint is
+	// not healthy, in the order of preference.
+	Endpoints []EndpointConfig
+
+	// CheckInterval is the time between health checks of endpoints.
+	// DefaultCheckInterval is used if not set.
+	CheckInterval time.Duration
+
+	// MaxBlockLag is the number of blocks an endpoint can be behind the best
+	// endpoint and still be considered healthy. DefaultMaxBlockLag is used if
+	// not set.
+	MaxBlockLag uint64
+}
+
+// EndpointConfig holds URLs of a single Ethereum endpoint.
+type EndpointConfig struct {
+	URL    string
+	URLRPC string
+}
+
+// Endpoint is a single Ethereum endpoint checked by the monitor.
+type Endpoint interface {
+	// LatestBlock returns the number of the latest block known to the
+	// endpoint.
+	LatestBlock(ctx context.Context) (uint64, error)
+}
+
+// Monitor checks endpoints and selects the active one. It is safe for
+// concurrent use.
+type Monitor struct {
+	endpoints     []Endpoint
+	checkInterval time.Duration
+	maxBlockLag   uint64
+
+	// checkMutex serializes health checks.
+	checkMutex sync.Mutex
+
+	mutex          sync.Mutex
+	active         int
+	switchHandlers []func(active int)
+}
+
+// NewMonitor creates a monitor of the given endpoints, listed in the order of
+// preference. The first endpoint is active until endpoints are checked.
+func NewMonitor(endpoints []Endpoint, config *Config) *Monitor {
+	checkInterval := config.CheckInterval
+	if checkInterval == 0 {
+		checkInterval = DefaultCheckInterval
+	}
+
+	maxBlockLag := config.MaxBlockLag
+	if maxBlockLag == 0 {
+		maxBlockLag = DefaultMaxBlockLag
+	}
+
+	activeEndpoint.Set(0)
+
+	return &Monitor{
+		endpoints:     endpoints,
+		checkInterval: checkInterval,
+		maxBlockLag:   maxBlockLag,
+	}
+}
+
+// Active returns the position of the active endpoint on the list.
+func (m *Monitor) Active() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.active
+}
+
+// OnSwitch registers a handler called with the position of the new active
+// endpoint every time the active endpoint is switched.
+func (m *Monitor) OnSwitch(handler func(active int)) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.switchHandlers = append(m.switchHandlers, handler)
+}
+
+// Run checks endpoints periodically until the context is done.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.Check(); err != nil {
+				logger.Errorf("%v; keeping the active endpoint", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Check checks all endpoints and switches to the first healthy one. It
+// returns an error if no endpoint is healthy; the active endpoint is not
+// switched in such case.
+func (m *Monitor) Check() error {
+	m.checkMutex.Lock()
+	defer m.checkMutex.Unlock()
+
+	type checkResult struct {
+		latestBlock uint64
+		err         error
+	}
+
+	results := make([]checkResult, len(m.endpoints))
+
+	wg := &sync.WaitGroup{}
+	wg.Add(len(m.endpoints))
+	for i, endpoint := range m.endpoints {
+		go func(i int, endpoint Endpoint) {
+			defer wg.Done()
+
+			ctx, cancelCtx := context.WithTimeout(
+				context.Background(),
+				checkTimeout,
+			)
+			defer cancelCtx()
+
+			latestBlock, err := endpoint.LatestBlock(ctx)
+			results[i] = checkResult{latestBlock, err}
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	bestBlock := uint64(0)
+	for _, result := range results {
+		if result.err == nil && result.latestBlock > bestBlock {
+			bestBlock = result.latestBlock
+		}
+	}
+
+	healthy := -1
+	for i, result := range results {
+		if result.err != nil {
+			failedChecks.WithLabelValues(strconv.Itoa(i)).Inc()
+			logger.Warningf(
+				"health check of Ethereum endpoint [%v] failed: [%v]",
+				i,
+				result.err,
+			)
+			continue
+		}
+
+		if bestBlock-result.latestBlock > m.maxBlockLag {
+			failedChecks.WithLabelValues(strconv.Itoa(i)).Inc()
+			logger.Warningf(
+				"Ethereum endpoint [%v] is at block [%v], "+
+					"[%v] blocks behind the best endpoint",
+				i,
+				result.latestBlock,
+				bestBlock-result.latestBlock,
+			)
+			continue
+		}
+
+		if healthy < 0 {
+			healthy = i
+		}
+	}
+
+	if healthy < 0 {
+		return fmt.Errorf("no healthy Ethereum endpoint")
+	}
+
+	m.mutex.Lock()
+	previous := m.active
+	if healthy == previous {
+		m.mutex.Unlock()
+		return nil
+	}
+	m.active = healthy
+	switchHandlers := append([]func(int){}, m.switchHandlers...)
+	m.mutex.Unlock()
+
+	logger.Warningf(
+		"switching from Ethereum endpoint [%v] to endpoint [%v]",
+		previous,
+		healthy,
+	)
+	activeEndpoint.Set(float64(healthy))
+	endpointSwitches.Inc()
+
+	for _, handler := range switchHandlers {
+		handler(healthy)
+	}
+
+	return nil
+}
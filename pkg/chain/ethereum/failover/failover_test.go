@@ -0,0 +1,142 @@
+package failover
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type mockEndpoint struct {
+	latestBlock uint64
+	err         error
+}
+
+func (me *mockEndpoint) LatestBlock(ctx context.Context) (uint64, error) {
+	return me.latestBlock, me.err
+}
+
+func healthy(latestBlock uint64) *mockEndpoint {
+	return &mockEndpoint{latestBlock: latestBlock}
+}
+
+func failing() *mockEndpoint {
+	return &mockEndpoint{err: fmt.Errorf("connection refused")}
+}
+
+func TestCheck(t *testing.T) {
+	var tests = map[string]struct {
+		endpoints        []*mockEndpoint
+		initiallyActive  int
+		expectedActive   int
+		expectedSwitches []int
+		expectedError    error
+	}{
+		"primary endpoint is healthy": {
+			endpoints:        []*mockEndpoint{healthy(100), healthy(100)},
+			expectedActive:   0,
+			expectedSwitches: []int{},
+		},
+		"primary endpoint fails": {
+			endpoints:        []*mockEndpoint{failing(), healthy(100)},
+			expectedActive:   1,
+			expectedSwitches: []int{1},
+		},
+		"primary endpoint lags behind": {
+			endpoints:        []*mockEndpoint{healthy(90), healthy(100)},
+			expectedActive:   1,
+			expectedSwitches: []int{1},
+		},
+		"primary endpoint lags within the allowed lag": {
+			endpoints:        []*mockEndpoint{healthy(98), healthy(100)},
+			expectedActive:   0,
+			expectedSwitches: []int{},
+		},
+		"first healthy endpoint is preferred": {
+			endpoints: []*mockEndpoint{
+				failing(),
+				healthy(100),
+				healthy(100),
+			},
+			initiallyActive:  2,
+			expectedActive:   1,
+			expectedSwitches: []int{1},
+		},
+		"primary endpoint recovers": {
+			endpoints:        []*mockEndpoint{healthy(100), healthy(100)},
+			initiallyActive:  1,
+			expectedActive:   0,
+			expectedSwitches: []int{0},
+		},
+		"no endpoint is healthy": {
+			endpoints:        []*mockEndpoint{failing(), failing()},
+			initiallyActive:  1,
+			expectedActive:   1,
+			expectedSwitches: []int{},
+			expectedError:    fmt.Errorf("no healthy Ethereum endpoint"),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			endpoints := make([]Endpoint, len(test.endpoints))
+			for i, endpoint := range test.endpoints {
+				endpoints[i] = endpoint
+			}
+
+			monitor := NewMonitor(endpoints, &Config{MaxBlockLag: 2})
+			monitor.active = test.initiallyActive
+
+			switches := make([]int, 0)
+			monitor.OnSwitch(func(active int) {
+				switches = append(switches, active)
+			})
+
+			err := monitor.Check()
+			if !reflect.DeepEqual(test.expectedError, err) {
+				t.Errorf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+
+			if monitor.Active() != test.expectedActive {
+				t.Errorf(
+					"unexpected active endpoint\nexpected: [%v]\nactual:   [%v]",
+					test.expectedActive,
+					monitor.Active(),
+				)
+			}
+
+			if !reflect.DeepEqual(test.expectedSwitches, switches) {
+				t.Errorf(
+					"unexpected switches\nexpected: [%v]\nactual:   [%v]",
+					test.expectedSwitches,
+					switches,
+				)
+			}
+		})
+	}
+}
+
+func TestCheckUpdatesActiveEndpointMetric(t *testing.T) {
+	monitor := NewMonitor(
+		[]Endpoint{failing(), healthy(100)},
+		&Config{},
+	)
+
+	if err := monitor.Check(); err != nil {
+		t.Fatal(err)
+	}
+
+	if value := testutil.ToFloat64(activeEndpoint); value != 1 {
+		t.Errorf(
+			"unexpected active endpoint metric\nexpected: [%v]\nactual:   [%v]",
+			1,
+			value,
+		)
+	}
+}
@@ -0,0 +1,207 @@
+// Package gasprice determines gas prices of transactions submitted by the
+// client and gas prices of transactions replacing stuck ones.
+//
+// The gas price is either fixed, suggested by the Ethereum node or read from
+// an external gas price oracle. It is capped at the configured maximum price
+// and at the gas price ceiling of the operator contract, above which the
+// contract does not reimburse transaction costs. A transaction not mined in
+// the configured number of blocks is replaced with a transaction with the
+// same nonce and the gas price bumped by the configured percentage.
+package gasprice
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ipfs/go-log"
+)
+
+var logger = log.Logger("keep-gasprice")
+
+// Supported gas price strategies.
+const (
+	// NodeStrategy uses the gas price suggested by the Ethereum node.
+	NodeStrategy = "node"
+	// FixedStrategy uses the configured gas price.
+	FixedStrategy = "fixed"
+	// OracleStrategy uses the gas price read from an external oracle.
+	OracleStrategy = "oracle"
+)
+
+const (
+	// DefaultBumpPercent is the percentage by which the gas price of a stuck
+	// transaction is bumped if not configured.
+	DefaultBumpPercent = 20
+	// MinimumBumpPercent is the lowest gas price bump accepted by Ethereum
+	// nodes for a transaction replacing a pending one.
+	MinimumBumpPercent = 10
+	// DefaultResubmitBlocks is the number of blocks after which a transaction
+	// which has not been mined is replaced if not configured.
+	DefaultResubmitBlocks = 5
+)
+
+// ValidateStrategy checks whether the strategy with the given name is
+// supported. An empty name stands for the node strategy.
+func ValidateStrategy(name string) error {
+	switch name {
+	case "", NodeStrategy, FixedStrategy, OracleStrategy:
+		return nil
+	default:
+		return fmt.Errorf(
+			"unknown gas price strategy [%v]; expected one of [%v, %v, %v]",
+			name,
+			NodeStrategy,
+			FixedStrategy,
+			OracleStrategy,
+		)
+	}
+}
+
+// Config holds the gas price strategy configuration. Prices are in wei.
+type Config struct {
+	// Strategy is the name of the gas price strategy; the node strategy is
+	// used if not set.
+	Strategy string
+	// Price is the gas price of the fixed strategy.
+	Price *big.Int
+	// OracleURL is the URL of the oracle of the oracle strategy.
+	OracleURL string
+	// OracleField is the field of the oracle response holding the gas price.
+	OracleField string
+	// MaxPrice caps the gas price. There is no cap other than the gas price
+	// ceiling of the operator contract if nil or zero.
+	MaxPrice *big.Int
+	// BumpPercent is the percentage by which the gas price of a stuck
+	// transaction is bumped. DefaultBumpPercent is used if zero.
+	BumpPercent int
+	// ResubmitBlocks is the number of blocks after which a transaction which
+	// has not been mined is replaced. DefaultResubmitBlocks is used if zero.
+	ResubmitBlocks uint64
+}
+
+// Strategy prices transactions and transactions replacing stuck ones.
+type Strategy struct {
+	oracle Oracle
+	// nodeOracle is used when the oracle of the strategy fails; it is nil
+	// for the node strategy.
+	nodeOracle     Oracle
+	maxPrice       *big.Int
+	bumpPercent    int
+	resubmitBlocks uint64
+}
+
+// NewStrategy creates the gas price strategy with the given configuration.
+// The node strategy uses gas prices suggested by the given node.
+func NewStrategy(config *Config, node Suggester) (*Strategy, error) {
+	var oracle, nodeOracle Oracle
+	switch config.Strategy {
+	case "", NodeStrategy:
+		oracle = NewNodeOracle(node)
+	case FixedStrategy:
+		if config.Price == nil || config.Price.Sign() <= 0 {
+			return nil, fmt.Errorf("fixed gas price must be positive")
+		}
+		oracle = NewFixedOracle(config.Price)
+	case OracleStrategy:
+		if config.OracleURL == "" || config.OracleField == "" {
+			return nil, fmt.Errorf("oracle URL and field are required")
+		}
+		oracle = NewHTTPOracle(config.OracleURL, config.OracleField)
+		nodeOracle = NewNodeOracle(node)
+	default:
+		return nil, ValidateStrategy(config.Strategy)
+	}
+
+	bumpPercent := config.BumpPercent
+	if bumpPercent == 0 {
+		bumpPercent = DefaultBumpPercent
+	}
+	if bumpPercent < MinimumBumpPercent {
+		return nil, fmt.Errorf(
+			"gas price bump [%v%%] is lower than the minimum [%v%%]",
+			bumpPercent,
+			MinimumBumpPercent,
+		)
+	}
+
+	resubmitBlocks := config.ResubmitBlocks
+	if resubmitBlocks == 0 {
+		resubmitBlocks = DefaultResubmitBlocks
+	}
+
+	return &Strategy{
+		oracle:         oracle,
+		nodeOracle:     nodeOracle,
+		maxPrice:       config.MaxPrice,
+		bumpPercent:    bumpPercent,
+		resubmitBlocks: resubmitBlocks,
+	}, nil
+}
+
+// Price returns the gas price of a new transaction capped at the maximum
+// price and the given ceiling. A nil or zero ceiling is ignored. If the
+// oracle of the strategy fails, the gas price suggested by the Ethereum node
+// is used, capped the same way. An error is returned if the gas price could
+// not be determined at all.
+func (s *Strategy) Price(ctx context.Context, ceiling *big.Int) (*big.Int, error) {
+	price, err := s.oracle.GasPrice(ctx)
+	if err != nil {
+		if s.nodeOracle == nil {
+			return nil, err
+		}
+
+		logger.Warningf(
+			"could not get gas price from the oracle; "+
+				"using the price suggested by the Ethereum node: [%v]",
+			err,
+		)
+
+		price, err = s.nodeOracle.GasPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.capPrice(price, ceiling), nil
+}
+
+// Bump returns the gas price of a transaction replacing a stuck transaction
+// with the given gas price. The bumped price is capped at the maximum price
+// and the given ceiling. False is returned if the capped price is too low
+// to replace the stuck transaction.
+func (s *Strategy) Bump(price *big.Int, ceiling *big.Int) (*big.Int, bool) {
+	bumped := percentOf(price, 100+s.bumpPercent)
+	if bumped.Cmp(price) <= 0 {
+		bumped = new(big.Int).Add(price, big.NewInt(1))
+	}
+
+	bumped = s.capPrice(bumped, ceiling)
+	if bumped.Cmp(percentOf(price, 100+MinimumBumpPercent)) < 0 {
+		return nil, false
+	}
+
+	return bumped, true
+}
+
+// ResubmitBlocks returns the number of blocks after which a transaction
+// which has not been mined is replaced.
+func (s *Strategy) ResubmitBlocks() uint64 {
+	return s.resubmitBlocks
+}
+
+func (s *Strategy) capPrice(price *big.Int, ceiling *big.Int) *big.Int {
+	capped := price
+	for _, limit := range []*big.Int{s.maxPrice, ceiling} {
+		if limit != nil && limit.Sign() > 0 && capped.Cmp(limit) > 0 {
+			capped = limit
+		}
+	}
+
+	return new(big.Int).Set(capped)
+}
+
+func percentOf(value *big.Int, percent int) *big.Int {
+	result := new(big.Int).Mul(value, big.NewInt(int64(percent)))
+	return result.Div(result, big.NewInt(100))
+}
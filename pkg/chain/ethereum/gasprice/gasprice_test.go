@@ -0,0 +1,242 @@
+package gasprice
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type suggester struct {
+	price *big.Int
+}
+
+func (s *suggester) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return s.price, nil
+}
+
+type failingSuggester struct{}
+
+func (fs *failingSuggester) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return nil, fmt.Errorf("node unavailable")
+}
+
+func TestPrice(t *testing.T) {
+	var tests = map[string]struct {
+		config        *Config
+		ceiling       *big.Int
+		expectedPrice *big.Int
+	}{
+		"node price": {
+			config:        &Config{},
+			expectedPrice: big.NewInt(100),
+		},
+		"fixed price": {
+			config: &Config{
+				Strategy: FixedStrategy,
+				Price:    big.NewInt(70),
+			},
+			expectedPrice: big.NewInt(70),
+		},
+		"price capped at max price": {
+			config: &Config{
+				Strategy: NodeStrategy,
+				MaxPrice: big.NewInt(80),
+			},
+			ceiling:       big.NewInt(90),
+			expectedPrice: big.NewInt(80),
+		},
+		"price capped at ceiling": {
+			config: &Config{
+				Strategy: NodeStrategy,
+				MaxPrice: big.NewInt(80),
+			},
+			ceiling:       big.NewInt(60),
+			expectedPrice: big.NewInt(60),
+		},
+		"zero ceiling ignored": {
+			config:        &Config{},
+			ceiling:       big.NewInt(0),
+			expectedPrice: big.NewInt(100),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			strategy, err := NewStrategy(
+				test.config,
+				&suggester{big.NewInt(100)},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			price, err := strategy.Price(context.Background(), test.ceiling)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if price.Cmp(test.expectedPrice) != 0 {
+				t.Errorf(
+					"unexpected gas price\nexpected: [%v]\nactual:   [%v]",
+					test.expectedPrice,
+					price,
+				)
+			}
+		})
+	}
+}
+
+func TestPriceWhenOracleFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(writer http.ResponseWriter, request *http.Request) {
+			writer.WriteHeader(http.StatusInternalServerError)
+		},
+	))
+	defer server.Close()
+
+	config := &Config{
+		Strategy:    OracleStrategy,
+		OracleURL:   server.URL,
+		OracleField: "fast",
+		MaxPrice:    big.NewInt(80),
+	}
+
+	var tests = map[string]struct {
+		node          Suggester
+		ceiling       *big.Int
+		expectedPrice *big.Int
+		expectedError bool
+	}{
+		"node price capped at max price": {
+			node:          &suggester{big.NewInt(100)},
+			ceiling:       big.NewInt(90),
+			expectedPrice: big.NewInt(80),
+		},
+		"node price capped at ceiling": {
+			node:          &suggester{big.NewInt(100)},
+			ceiling:       big.NewInt(60),
+			expectedPrice: big.NewInt(60),
+		},
+		"node fails too": {
+			node:          &failingSuggester{},
+			ceiling:       big.NewInt(90),
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			strategy, err := NewStrategy(config, test.node)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			price, err := strategy.Price(context.Background(), test.ceiling)
+			if test.expectedError {
+				if err == nil {
+					t.Fatalf("expected an error; got price [%v]", price)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if price.Cmp(test.expectedPrice) != 0 {
+				t.Errorf(
+					"unexpected gas price\nexpected: [%v]\nactual:   [%v]",
+					test.expectedPrice,
+					price,
+				)
+			}
+		})
+	}
+}
+
+func TestBump(t *testing.T) {
+	var tests = map[string]struct {
+		config         *Config
+		price          *big.Int
+		ceiling        *big.Int
+		expectedPrice  *big.Int
+		expectedBumped bool
+	}{
+		"default bump": {
+			config:         &Config{},
+			price:          big.NewInt(1000),
+			expectedPrice:  big.NewInt(1200),
+			expectedBumped: true,
+		},
+		"configured bump": {
+			config:         &Config{BumpPercent: 50},
+			price:          big.NewInt(1000),
+			expectedPrice:  big.NewInt(1500),
+			expectedBumped: true,
+		},
+		"bump capped at ceiling": {
+			config:         &Config{},
+			price:          big.NewInt(1000),
+			ceiling:        big.NewInt(1150),
+			expectedPrice:  big.NewInt(1150),
+			expectedBumped: true,
+		},
+		"bump capped below minimum replacement price": {
+			config:         &Config{MaxPrice: big.NewInt(1050)},
+			price:          big.NewInt(1000),
+			expectedBumped: false,
+		},
+		"bump of minimal price": {
+			config:         &Config{},
+			price:          big.NewInt(1),
+			expectedPrice:  big.NewInt(2),
+			expectedBumped: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			strategy, err := NewStrategy(test.config, &suggester{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			price, bumped := strategy.Bump(test.price, test.ceiling)
+			if bumped != test.expectedBumped {
+				t.Fatalf(
+					"unexpected bump result\nexpected: [%v]\nactual:   [%v]",
+					test.expectedBumped,
+					bumped,
+				)
+			}
+
+			if bumped && price.Cmp(test.expectedPrice) != 0 {
+				t.Errorf(
+					"unexpected gas price\nexpected: [%v]\nactual:   [%v]",
+					test.expectedPrice,
+					price,
+				)
+			}
+		})
+	}
+}
+
+func TestNewStrategyWithInvalidConfig(t *testing.T) {
+	var tests = map[string]*Config{
+		"unknown strategy":     {Strategy: "cheapest"},
+		"missing fixed price":  {Strategy: FixedStrategy},
+		"missing oracle URL":   {Strategy: OracleStrategy, OracleField: "fast"},
+		"missing oracle field": {Strategy: OracleStrategy, OracleURL: "http://oracle"},
+		"bump below minimum":   {BumpPercent: 5},
+	}
+
+	for testName, config := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if _, err := NewStrategy(config, &suggester{}); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
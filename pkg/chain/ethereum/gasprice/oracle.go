@@ -0,0 +1,157 @@
+package gasprice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// oracleTimeout is the maximum time of a single request to the gas price
+// oracle.
+const oracleTimeout = 10 * time.Second
+
+// weiPerGwei is the number of wei in a gwei, the unit of oracle gas prices.
+var weiPerGwei = big.NewInt(1000000000)
+
+// Oracle provides the current gas price in wei.
+type Oracle interface {
+	GasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// Suggester is an Ethereum node suggesting the gas price of new transactions.
+type Suggester interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+type fixedOracle struct {
+	price *big.Int
+}
+
+// NewFixedOracle creates an oracle always returning the given gas price.
+func NewFixedOracle(price *big.Int) Oracle {
+	return &fixedOracle{price}
+}
+
+func (fo *fixedOracle) GasPrice(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(fo.price), nil
+}
+
+type nodeOracle struct {
+	node Suggester
+}
+
+// NewNodeOracle creates an oracle returning the gas price suggested by the
+// given Ethereum node.
+func NewNodeOracle(node Suggester) Oracle {
+	return &nodeOracle{node}
+}
+
+func (no *nodeOracle) GasPrice(ctx context.Context) (*big.Int, error) {
+	price, err := no.node.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get suggested gas price: [%v]", err)
+	}
+
+	return price, nil
+}
+
+type httpOracle struct {
+	url    string
+	field  string
+	client *http.Client
+}
+
+// NewHTTPOracle creates an oracle reading the gas price from the JSON object
+// served at the given URL. The field holds the gas price in gwei, as a number
+// or a numeric string; fields of nested objects are separated with dots, for
+// example `result.ProposeGasPrice`.
+func NewHTTPOracle(url string, field string) Oracle {
+	return &httpOracle{
+		url:    url,
+		field:  field,
+		client: &http.Client{Timeout: oracleTimeout},
+	}
+}
+
+func (ho *httpOracle) GasPrice(ctx context.Context) (*big.Int, error) {
+	request, err := http.NewRequest(http.MethodGet, ho.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create oracle request: [%v]", err)
+	}
+
+	response, err := ho.client.Do(request.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("could not query gas price oracle: [%v]", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(
+			"gas price oracle responded with status [%v]",
+			response.Status,
+		)
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("could not decode oracle response: [%v]", err)
+	}
+
+	value, err := fieldValue(document, ho.field)
+	if err != nil {
+		return nil, err
+	}
+
+	return gweiToWei(value)
+}
+
+// fieldValue returns the string representation of the value of the dotted
+// field path in the decoded JSON document.
+func fieldValue(document interface{}, field string) (string, error) {
+	value := document
+	for _, name := range strings.Split(field, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf(
+				"oracle response has no object containing field [%v]",
+				name,
+			)
+		}
+
+		value, ok = object[name]
+		if !ok {
+			return "", fmt.Errorf("oracle response has no field [%v]", field)
+		}
+	}
+
+	switch typed := value.(type) {
+	case json.Number:
+		return typed.String(), nil
+	case string:
+		return typed, nil
+	default:
+		return "", fmt.Errorf(
+			"oracle response field [%v] is not a number: [%v]",
+			field,
+			value,
+		)
+	}
+}
+
+// gweiToWei converts the decimal amount of gwei to wei, rounding down.
+func gweiToWei(gwei string) (*big.Int, error) {
+	amount, ok := new(big.Rat).SetString(strings.TrimSpace(gwei))
+	if !ok || amount.Sign() <= 0 {
+		return nil, fmt.Errorf("[%v] is not a valid gas price", gwei)
+	}
+
+	wei := new(big.Int).Mul(amount.Num(), weiPerGwei)
+	return wei.Quo(wei, amount.Denom()), nil
+}
@@ -0,0 +1,102 @@
+package gasprice
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPOracle(t *testing.T) {
+	var tests = map[string]struct {
+		response      string
+		field         string
+		expectedPrice *big.Int
+		expectedError bool
+	}{
+		"number field": {
+			response:      `{"fast": 25, "average": 20}`,
+			field:         "fast",
+			expectedPrice: big.NewInt(25000000000),
+		},
+		"fractional number field": {
+			response:      `{"fast": 25.5}`,
+			field:         "fast",
+			expectedPrice: big.NewInt(25500000000),
+		},
+		"nested string field": {
+			response:      `{"status": "1", "result": {"ProposeGasPrice": "31"}}`,
+			field:         "result.ProposeGasPrice",
+			expectedPrice: big.NewInt(31000000000),
+		},
+		"missing field": {
+			response:      `{"fast": 25}`,
+			field:         "fastest",
+			expectedError: true,
+		},
+		"non-numeric field": {
+			response:      `{"fast": "soon"}`,
+			field:         "fast",
+			expectedError: true,
+		},
+		"negative price": {
+			response:      `{"fast": -1}`,
+			field:         "fast",
+			expectedError: true,
+		},
+		"invalid response": {
+			response:      `fast: 25`,
+			field:         "fast",
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(
+				func(writer http.ResponseWriter, request *http.Request) {
+					fmt.Fprint(writer, test.response)
+				},
+			))
+			defer server.Close()
+
+			price, err := NewHTTPOracle(server.URL, test.field).GasPrice(
+				context.Background(),
+			)
+			if test.expectedError {
+				if err == nil {
+					t.Fatalf("expected an error; got price [%v]", price)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if price.Cmp(test.expectedPrice) != 0 {
+				t.Errorf(
+					"unexpected gas price\nexpected: [%v]\nactual:   [%v]",
+					test.expectedPrice,
+					price,
+				)
+			}
+		})
+	}
+}
+
+func TestHTTPOracleErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(writer http.ResponseWriter, request *http.Request) {
+			http.Error(writer, "unavailable", http.StatusServiceUnavailable)
+		},
+	))
+	defer server.Close()
+
+	if _, err := NewHTTPOracle(server.URL, "fast").GasPrice(
+		context.Background(),
+	); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,93 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/nonce"
+)
+
+// pendingTransactionsFile is the file in the operator's storage directory
+// holding transactions submitted by the operator which have not been mined
+// yet.
+const pendingTransactionsFile = "pending-transactions.json"
+
+// nonceTrackingBackend is a contract backend assigning nonces to transactions
+// of the account with the nonce manager instead of asking the Ethereum node
+// for the pending nonce. All transactions sent through the backend must be
+// signed by the account.
+type nonceTrackingBackend struct {
+	bind.ContractBackend
+
+	address      common.Address
+	nonceManager *nonce.Manager
+}
+
+func (ntb *nonceTrackingBackend) PendingNonceAt(
+	ctx context.Context,
+	account common.Address,
+) (uint64, error) {
+	if account != ntb.address {
+		return ntb.ContractBackend.PendingNonceAt(ctx, account)
+	}
+
+	return ntb.nonceManager.Next(ctx)
+}
+
+func (ntb *nonceTrackingBackend) SendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	if err := ntb.ContractBackend.SendTransaction(ctx, transaction); err != nil {
+		ntb.nonceManager.Failed(err)
+		return err
+	}
+
+	raw, err := rlp.EncodeToBytes(transaction)
+	if err != nil {
+		// The transaction has been sent; it is just not resubmitted if the
+		// node drops it.
+		logger.Errorf(
+			"could not encode transaction [%v]: [%v]",
+			transaction.Hash().Hex(),
+			err,
+		)
+		raw = nil
+	}
+
+	ntb.nonceManager.Sent(&nonce.Transaction{
+		Nonce: transaction.Nonce(),
+		Hash:  transaction.Hash().Hex(),
+		Raw:   raw,
+	})
+
+	return nil
+}
+
+// nonceNode provides the nonce manager with the state of the account in the
+// active Ethereum endpoint.
+type nonceNode struct {
+	client  *failoverClient
+	address common.Address
+}
+
+func (nn *nonceNode) PendingNonce(ctx context.Context) (uint64, error) {
+	return nn.client.rpcClient().PendingNonceAt(ctx, nn.address)
+}
+
+func (nn *nonceNode) MinedNonce(ctx context.Context) (uint64, error) {
+	return nn.client.rpcClient().NonceAt(ctx, nn.address, nil)
+}
+
+func (nn *nonceNode) Rebroadcast(ctx context.Context, raw []byte) error {
+	transaction := new(types.Transaction)
+	if err := rlp.DecodeBytes(raw, transaction); err != nil {
+		return fmt.Errorf("could not decode transaction: [%v]", err)
+	}
+
+	return nn.client.rpcClient().SendTransaction(ctx, transaction)
+}
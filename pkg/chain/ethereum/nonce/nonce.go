@@ -0,0 +1,286 @@
+// Package nonce assigns nonces to transactions submitted from a single
+// account without asking the Ethereum node for the pending nonce of every
+// transaction.
+//
+// The manager tracks transactions submitted by the client which have not been
+// mined yet and assigns the lowest nonce neither known to the node nor used by
+// a pending transaction, so that transactions can be submitted one after
+// another without waiting for the node to see the previous ones. The pending
+// nonce of the node is checked before every assignment, so transactions
+// submitted from the same account by other clients are taken into account.
+// The manager fully resynchronizes with the node on start, after failed
+// submissions and periodically. On resynchronization, mined transactions are
+// forgotten and pending transactions the node does not know about, for example
+// because they were dropped or reorganized out of the chain, are submitted
+// again. A nonce of a pending transaction is reused only if the node rejects
+// the transaction and reports the nonce as unused. Pending transactions are
+// persisted so that their nonces are not reused after a restart.
+package nonce
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+)
+
+var logger = log.Logger("keep-nonce")
+
+// resyncInterval is the maximum time between resynchronizations with the
+// Ethereum node.
+const resyncInterval = time.Minute
+
+// Node is the Ethereum node transactions of the account are submitted to.
+type Node interface {
+	// PendingNonce returns the nonce following the highest nonce of
+	// transactions of the account known to the node, including pending ones.
+	PendingNonce(ctx context.Context) (uint64, error)
+	// MinedNonce returns the number of transactions of the account mined in
+	// the latest block.
+	MinedNonce(ctx context.Context) (uint64, error)
+	// Rebroadcast submits the raw signed transaction again.
+	Rebroadcast(ctx context.Context, raw []byte) error
+}
+
+// Transaction is a transaction submitted from the account.
+type Transaction struct {
+	Nonce uint64 `json:"nonce"`
+	Hash  string `json:"hash"`
+	// Raw is the signed transaction in the form accepted by Rebroadcast.
+	Raw []byte `json:"raw"`
+}
+
+// Manager assigns nonces to transactions of a single account. It is safe
+// for concurrent use.
+type Manager struct {
+	node  Node
+	store Store
+
+	mutex   sync.Mutex
+	pending map[uint64]*Transaction
+	// floor is the lowest nonce not known to the node.
+	floor      uint64
+	minedNonce uint64
+	synced     bool
+	lastSync   time.Time
+}
+
+// NewManager creates a nonce manager for the account of the given node,
+// restoring pending transactions from the store. Pending transactions are
+// not persisted if the store is nil.
+func NewManager(node Node, store Store) (*Manager, error) {
+	pending := make(map[uint64]*Transaction)
+
+	if store != nil {
+		transactions, err := store.Load()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not load pending transactions: [%v]",
+				err,
+			)
+		}
+
+		for _, transaction := range transactions {
+			pending[transaction.Nonce] = transaction
+		}
+	}
+
+	return &Manager{
+		node:    node,
+		store:   store,
+		pending: pending,
+	}, nil
+}
+
+// Next returns the nonce of the next transaction. The manager is
+// resynchronized with the node first if needed; otherwise only the pending
+// nonce of the node is checked.
+func (m *Manager) Next(ctx context.Context) (uint64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if !m.synced || time.Since(m.lastSync) > resyncInterval {
+		if err := m.resync(ctx); err != nil {
+			return 0, err
+		}
+	} else {
+		pendingNonce, err := m.node.PendingNonce(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("could not get pending nonce: [%v]", err)
+		}
+		if pendingNonce > m.floor {
+			m.floor = pendingNonce
+		}
+	}
+
+	next := m.floor
+	for {
+		if _, ok := m.pending[next]; !ok {
+			return next, nil
+		}
+		next++
+	}
+}
+
+// Sent records the transaction as successfully submitted. A transaction with
+// the nonce of a pending transaction replaces it.
+func (m *Manager) Sent(transaction *Transaction) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.pending[transaction.Nonce] = transaction
+
+	m.persist()
+}
+
+// Failed records a failed submission. The manager resynchronizes with the
+// node before assigning the next nonce.
+func (m *Manager) Failed(err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	logger.Warningf(
+		"transaction submission failed; "+
+			"nonces will be resynchronized with the Ethereum node: [%v]",
+		err,
+	)
+	m.synced = false
+}
+
+// resync forgets mined transactions and submits again pending transactions
+// the node does not know about. If the node rejects a pending transaction,
+// the transaction is forgotten and its nonce is reused only if the node
+// reports the nonce as unused. Transactions following the reused nonce stay
+// pending; the node executes them once the nonce is used again.
+func (m *Manager) resync(ctx context.Context) error {
+	minedNonce, err := m.node.MinedNonce(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get mined nonce: [%v]", err)
+	}
+
+	pendingNonce, err := m.node.PendingNonce(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get pending nonce: [%v]", err)
+	}
+
+	if m.synced && minedNonce < m.minedNonce {
+		logger.Warningf(
+			"mined nonce decreased from [%v] to [%v]; chain reorganization "+
+				"detected",
+			m.minedNonce,
+			minedNonce,
+		)
+	}
+
+	for nonce := range m.pending {
+		if nonce < minedNonce {
+			delete(m.pending, nonce)
+		}
+	}
+
+	floor := minedNonce
+	if pendingNonce > floor {
+		floor = pendingNonce
+	}
+
+	for _, transaction := range m.sortedPending() {
+		if transaction.Nonce < floor {
+			continue
+		}
+
+		err := m.node.Rebroadcast(ctx, transaction.Raw)
+		if err == nil || isKnownTransactionError(err) {
+			logger.Infof(
+				"resubmitted pending transaction [%v] with nonce [%v]",
+				transaction.Hash,
+				transaction.Nonce,
+			)
+			continue
+		}
+
+		isUsed, checkErr := m.isNonceUsed(ctx, transaction.Nonce)
+		if checkErr != nil {
+			return fmt.Errorf(
+				"could not check nonce [%v] of rejected transaction [%v]: [%v]",
+				transaction.Nonce,
+				transaction.Hash,
+				checkErr,
+			)
+		}
+		if isUsed {
+			logger.Warningf(
+				"could not resubmit pending transaction [%v] with nonce [%v] "+
+					"but the nonce is already used: [%v]",
+				transaction.Hash,
+				transaction.Nonce,
+				err,
+			)
+			continue
+		}
+
+		logger.Warningf(
+			"could not resubmit pending transaction [%v] with nonce [%v]; "+
+				"nonce will be reused: [%v]",
+			transaction.Hash,
+			transaction.Nonce,
+			err,
+		)
+		delete(m.pending, transaction.Nonce)
+	}
+
+	m.floor = floor
+	m.minedNonce = minedNonce
+	m.synced = true
+	m.lastSync = time.Now()
+
+	m.persist()
+
+	return nil
+}
+
+// isNonceUsed checks whether the node knows a transaction of the account
+// with the given nonce, mined or pending.
+func (m *Manager) isNonceUsed(ctx context.Context, nonce uint64) (bool, error) {
+	pendingNonce, err := m.node.PendingNonce(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return pendingNonce > nonce, nil
+}
+
+func (m *Manager) sortedPending() []*Transaction {
+	transactions := make([]*Transaction, 0, len(m.pending))
+	for _, transaction := range m.pending {
+		transactions = append(transactions, transaction)
+	}
+
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].Nonce < transactions[j].Nonce
+	})
+
+	return transactions
+}
+
+func (m *Manager) persist() {
+	if m.store == nil {
+		return
+	}
+
+	if err := m.store.Save(m.sortedPending()); err != nil {
+		logger.Errorf("could not persist pending transactions: [%v]", err)
+	}
+}
+
+// isKnownTransactionError checks whether the node rejected the transaction
+// because it already knows it or because it has already been mined.
+func isKnownTransactionError(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "already known") ||
+		strings.Contains(message, "known transaction") ||
+		strings.Contains(message, "nonce too low")
+}
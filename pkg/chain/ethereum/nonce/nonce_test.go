@@ -0,0 +1,283 @@
+package nonce
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type mockNode struct {
+	minedNonce   uint64
+	pendingNonce uint64
+	// nextPendingNonces are returned by subsequent pending nonce calls
+	// before pendingNonce is.
+	nextPendingNonces []uint64
+	rejected          map[string]error
+	rebroadcast       []string
+}
+
+func (mn *mockNode) PendingNonce(ctx context.Context) (uint64, error) {
+	if len(mn.nextPendingNonces) > 0 {
+		pendingNonce := mn.nextPendingNonces[0]
+		mn.nextPendingNonces = mn.nextPendingNonces[1:]
+		return pendingNonce, nil
+	}
+
+	return mn.pendingNonce, nil
+}
+
+func (mn *mockNode) MinedNonce(ctx context.Context) (uint64, error) {
+	return mn.minedNonce, nil
+}
+
+func (mn *mockNode) Rebroadcast(ctx context.Context, raw []byte) error {
+	mn.rebroadcast = append(mn.rebroadcast, string(raw))
+	return mn.rejected[string(raw)]
+}
+
+type memoryStore struct {
+	transactions []*Transaction
+}
+
+func (ms *memoryStore) Save(transactions []*Transaction) error {
+	ms.transactions = transactions
+	return nil
+}
+
+func (ms *memoryStore) Load() ([]*Transaction, error) {
+	return ms.transactions, nil
+}
+
+func transaction(nonce uint64) *Transaction {
+	return &Transaction{
+		Nonce: nonce,
+		Hash:  fmt.Sprintf("0x%v", nonce),
+		Raw:   []byte(fmt.Sprintf("tx-%v", nonce)),
+	}
+}
+
+func nonces(transactions []*Transaction) []uint64 {
+	result := make([]uint64, 0)
+	for _, transaction := range transactions {
+		result = append(result, transaction.Nonce)
+	}
+	return result
+}
+
+func TestNextAssignsSubsequentNonces(t *testing.T) {
+	node := &mockNode{minedNonce: 3, pendingNonce: 3}
+	store := &memoryStore{}
+
+	manager, err := NewManager(node, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expectedNonce := range []uint64{3, 4, 5} {
+		nonce, err := manager.Next(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if nonce != expectedNonce {
+			t.Fatalf(
+				"unexpected nonce\nexpected: [%v]\nactual:   [%v]",
+				expectedNonce,
+				nonce,
+			)
+		}
+
+		// the node does not see the transaction yet
+		manager.Sent(transaction(nonce))
+	}
+
+	expectedPending := []uint64{3, 4, 5}
+	if !reflect.DeepEqual(expectedPending, nonces(store.transactions)) {
+		t.Errorf(
+			"unexpected persisted transactions\nexpected: [%v]\nactual:   [%v]",
+			expectedPending,
+			nonces(store.transactions),
+		)
+	}
+}
+
+func TestResync(t *testing.T) {
+	var tests = map[string]struct {
+		node                *mockNode
+		stored              []*Transaction
+		expectedNonce       uint64
+		expectedRebroadcast []string
+		expectedPending     []uint64
+	}{
+		"no pending transactions": {
+			node:                &mockNode{minedNonce: 7, pendingNonce: 9},
+			expectedNonce:       9,
+			expectedRebroadcast: nil,
+			expectedPending:     []uint64{},
+		},
+		"mined transactions are forgotten": {
+			node:                &mockNode{minedNonce: 5, pendingNonce: 6},
+			stored:              []*Transaction{transaction(3), transaction(4), transaction(5)},
+			expectedNonce:       6,
+			expectedRebroadcast: nil,
+			expectedPending:     []uint64{5},
+		},
+		"transactions unknown to the node are resubmitted": {
+			node:                &mockNode{minedNonce: 5, pendingNonce: 5},
+			stored:              []*Transaction{transaction(5), transaction(6)},
+			expectedNonce:       7,
+			expectedRebroadcast: []string{"tx-5", "tx-6"},
+			expectedPending:     []uint64{5, 6},
+		},
+		"known transactions are not reused": {
+			node: &mockNode{
+				minedNonce:   5,
+				pendingNonce: 5,
+				rejected: map[string]error{
+					"tx-5": fmt.Errorf("already known"),
+				},
+			},
+			stored:              []*Transaction{transaction(5)},
+			expectedNonce:       6,
+			expectedRebroadcast: []string{"tx-5"},
+			expectedPending:     []uint64{5},
+		},
+		"nonces of rejected transactions are reused": {
+			node: &mockNode{
+				minedNonce:   5,
+				pendingNonce: 5,
+				rejected: map[string]error{
+					"tx-6": fmt.Errorf("insufficient funds for gas * price + value"),
+				},
+			},
+			stored:              []*Transaction{transaction(5), transaction(6), transaction(7)},
+			expectedNonce:       6,
+			expectedRebroadcast: []string{"tx-5", "tx-6", "tx-7"},
+			expectedPending:     []uint64{5, 7},
+		},
+		"nonces of rejected transactions known to the node are not reused": {
+			node: &mockNode{
+				minedNonce:        5,
+				pendingNonce:      5,
+				nextPendingNonces: []uint64{5, 6},
+				rejected: map[string]error{
+					"tx-5": fmt.Errorf("i/o timeout"),
+				},
+			},
+			stored:              []*Transaction{transaction(5)},
+			expectedNonce:       6,
+			expectedRebroadcast: []string{"tx-5"},
+			expectedPending:     []uint64{5},
+		},
+		"transactions following a gap are kept": {
+			node:                &mockNode{minedNonce: 5, pendingNonce: 5},
+			stored:              []*Transaction{transaction(6), transaction(7)},
+			expectedNonce:       5,
+			expectedRebroadcast: []string{"tx-6", "tx-7"},
+			expectedPending:     []uint64{6, 7},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			store := &memoryStore{transactions: test.stored}
+
+			manager, err := NewManager(test.node, store)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			nonce, err := manager.Next(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if nonce != test.expectedNonce {
+				t.Errorf(
+					"unexpected nonce\nexpected: [%v]\nactual:   [%v]",
+					test.expectedNonce,
+					nonce,
+				)
+			}
+			if !reflect.DeepEqual(test.expectedRebroadcast, test.node.rebroadcast) {
+				t.Errorf(
+					"unexpected resubmitted transactions\nexpected: [%v]\nactual:   [%v]",
+					test.expectedRebroadcast,
+					test.node.rebroadcast,
+				)
+			}
+			if !reflect.DeepEqual(test.expectedPending, nonces(store.transactions)) {
+				t.Errorf(
+					"unexpected persisted transactions\nexpected: [%v]\nactual:   [%v]",
+					test.expectedPending,
+					nonces(store.transactions),
+				)
+			}
+		})
+	}
+}
+
+func TestResyncAfterFailure(t *testing.T) {
+	node := &mockNode{minedNonce: 3, pendingNonce: 3}
+
+	manager, err := NewManager(node, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonce, err := manager.Next(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager.Sent(transaction(nonce))
+
+	// transactions submitted by another client from the same account
+	node.minedNonce = 10
+	node.pendingNonce = 10
+	manager.Failed(fmt.Errorf("nonce too low"))
+
+	nonce, err = manager.Next(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if nonce != 10 {
+		t.Errorf(
+			"unexpected nonce\nexpected: [%v]\nactual:   [%v]",
+			10,
+			nonce,
+		)
+	}
+}
+
+func TestNextFollowsNodePendingNonce(t *testing.T) {
+	node := &mockNode{minedNonce: 3, pendingNonce: 3}
+
+	manager, err := NewManager(node, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonce, err := manager.Next(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager.Sent(transaction(nonce))
+
+	// transactions submitted by a command-line utility from the same account
+	node.pendingNonce = 6
+
+	nonce, err = manager.Next(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if nonce != 6 {
+		t.Errorf(
+			"unexpected nonce\nexpected: [%v]\nactual:   [%v]",
+			6,
+			nonce,
+		)
+	}
+}
@@ -0,0 +1,69 @@
+package nonce
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Store persists pending transactions of the account.
+type Store interface {
+	Save(transactions []*Transaction) error
+	Load() ([]*Transaction, error)
+}
+
+type fileStore struct {
+	path string
+}
+
+// NewFileStore creates a store keeping pending transactions in a JSON file
+// at the given path. The file is replaced atomically on every save so that
+// an interrupted save does not corrupt it.
+func NewFileStore(path string) Store {
+	return &fileStore{path}
+}
+
+func (fs *fileStore) Save(transactions []*Transaction) error {
+	content, err := json.Marshal(transactions)
+	if err != nil {
+		return fmt.Errorf("could not encode transactions: [%v]", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fs.path), 0700); err != nil {
+		return fmt.Errorf("could not create directory: [%v]", err)
+	}
+
+	temporaryPath := fs.path + ".tmp"
+	if err := ioutil.WriteFile(temporaryPath, content, 0600); err != nil {
+		return fmt.Errorf("could not write file: [%v]", err)
+	}
+
+	if err := os.Rename(temporaryPath, fs.path); err != nil {
+		return fmt.Errorf("could not replace file: [%v]", err)
+	}
+
+	return nil
+}
+
+func (fs *fileStore) Load() ([]*Transaction, error) {
+	content, err := ioutil.ReadFile(fs.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read file: [%v]", err)
+	}
+
+	var transactions []*Transaction
+	if err := json.Unmarshal(content, &transactions); err != nil {
+		return nil, fmt.Errorf(
+			"could not decode file [%v]: [%v]",
+			fs.path,
+			err,
+		)
+	}
+
+	return transactions, nil
+}
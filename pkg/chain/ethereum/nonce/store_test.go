@@ -0,0 +1,45 @@
+package nonce
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFileStore(t *testing.T) {
+	directory, err := ioutil.TempDir("", "nonce-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	store := NewFileStore(filepath.Join(directory, "nonce", "pending.json"))
+
+	transactions, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transactions) != 0 {
+		t.Fatalf("expected no transactions; got [%v]", len(transactions))
+	}
+
+	expectedTransactions := []*Transaction{transaction(1), transaction(2)}
+	if err := store.Save(expectedTransactions); err != nil {
+		t.Fatal(err)
+	}
+
+	transactions, err = store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(expectedTransactions, transactions) {
+		t.Errorf(
+			"unexpected transactions\nexpected: [%v]\nactual:   [%v]",
+			expectedTransactions,
+			transactions,
+		)
+	}
+}
@@ -5,8 +5,13 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/confirmation"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
+	"github.com/keep-network/keep-core/pkg/subscription"
 )
 
 type ethereumStakeMonitor struct {
@@ -32,6 +37,173 @@ func (esm *ethereumStakeMonitor) StakerFor(address string) (chain.Staker, error)
 	}, nil
 }
 
+func (esm *ethereumStakeMonitor) OnTokensSlashed(
+	address string,
+	handle func(slashed *event.TokensSlashed),
+) (subscription.EventSubscription, error) {
+	return esm.watchOperatorLogs(
+		"TokensSlashed",
+		address,
+		func(filterer *abi.TokenStakingFilterer, log types.Log) (func(), error) {
+			parsed, err := filterer.ParseTokensSlashed(log)
+			if err != nil {
+				return nil, err
+			}
+
+			slashed := &event.TokensSlashed{
+				Operator:        parsed.Operator.Bytes(),
+				Amount:          parsed.Amount,
+				BlockNumber:     log.BlockNumber,
+				TransactionHash: log.TxHash.Hex(),
+			}
+
+			return func() { handle(slashed) }, nil
+		},
+	)
+}
+
+func (esm *ethereumStakeMonitor) OnTokensSeized(
+	address string,
+	handle func(seized *event.TokensSeized),
+) (subscription.EventSubscription, error) {
+	return esm.watchOperatorLogs(
+		"TokensSeized",
+		address,
+		func(filterer *abi.TokenStakingFilterer, log types.Log) (func(), error) {
+			parsed, err := filterer.ParseTokensSeized(log)
+			if err != nil {
+				return nil, err
+			}
+
+			seized := &event.TokensSeized{
+				Operator:        parsed.Operator.Bytes(),
+				Amount:          parsed.Amount,
+				BlockNumber:     log.BlockNumber,
+				TransactionHash: log.TxHash.Hex(),
+			}
+
+			return func() { handle(seized) }, nil
+		},
+	)
+}
+
+func (esm *ethereumStakeMonitor) OnUndelegated(
+	address string,
+	handle func(undelegated *event.Undelegated),
+) (subscription.EventSubscription, error) {
+	return esm.watchOperatorLogs(
+		"Undelegated",
+		address,
+		func(filterer *abi.TokenStakingFilterer, log types.Log) (func(), error) {
+			parsed, err := filterer.ParseUndelegated(log)
+			if err != nil {
+				return nil, err
+			}
+
+			undelegated := &event.Undelegated{
+				Operator:        parsed.Operator.Bytes(),
+				UndelegatedAt:   parsed.UndelegatedAt,
+				BlockNumber:     log.BlockNumber,
+				TransactionHash: log.TxHash.Hex(),
+			}
+
+			return func() { handle(undelegated) }, nil
+		},
+	)
+}
+
+func (esm *ethereumStakeMonitor) OnStakeLocked(
+	address string,
+	handle func(locked *event.StakeLocked),
+) (subscription.EventSubscription, error) {
+	return esm.watchOperatorLogs(
+		"StakeLocked",
+		address,
+		func(filterer *abi.TokenStakingFilterer, log types.Log) (func(), error) {
+			parsed, err := filterer.ParseStakeLocked(log)
+			if err != nil {
+				return nil, err
+			}
+
+			locked := &event.StakeLocked{
+				Operator:        parsed.Operator.Bytes(),
+				LockCreator:     parsed.LockCreator.Bytes(),
+				Until:           parsed.Until,
+				BlockNumber:     log.BlockNumber,
+				TransactionHash: log.TxHash.Hex(),
+			}
+
+			return func() { handle(locked) }, nil
+		},
+	)
+}
+
+func (esm *ethereumStakeMonitor) OnLockReleased(
+	address string,
+	handle func(released *event.LockReleased),
+) (subscription.EventSubscription, error) {
+	return esm.watchOperatorLogs(
+		"LockReleased",
+		address,
+		func(filterer *abi.TokenStakingFilterer, log types.Log) (func(), error) {
+			parsed, err := filterer.ParseLockReleased(log)
+			if err != nil {
+				return nil, err
+			}
+
+			released := &event.LockReleased{
+				Operator:        parsed.Operator.Bytes(),
+				LockCreator:     parsed.LockCreator.Bytes(),
+				BlockNumber:     log.BlockNumber,
+				TransactionHash: log.TxHash.Hex(),
+			}
+
+			return func() { handle(released) }, nil
+		},
+	)
+}
+
+// watchOperatorLogs watches logs of the given TokenStaking event emitted for
+// the operator with the given address. Logs are passed to handlers returned
+// by handlerFor once they are confirmed, with the same confirmation depth as
+// relay entry requests and group selection starts, so that the operator is
+// not alarmed by events removed by a chain reorganization.
+func (esm *ethereumStakeMonitor) watchOperatorLogs(
+	eventName string,
+	address string,
+	handlerFor func(
+		filterer *abi.TokenStakingFilterer,
+		log types.Log,
+	) (func(), error),
+) (subscription.EventSubscription, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("not a valid ethereum address: %v", address)
+	}
+
+	contract, filterer, err := esm.ethereum.stakingLogContract()
+	if err != nil {
+		return nil, err
+	}
+
+	// The operator is the first indexed parameter of all watched events.
+	query := [][]interface{}{{common.HexToAddress(address)}}
+
+	return esm.ethereum.watchConfirmedContractLogs(
+		contract,
+		eventName,
+		query,
+		esm.ethereum.confirmationDepth,
+		func(log types.Log) (confirmation.Handlers, error) {
+			handler, err := handlerFor(filterer, log)
+			if err != nil {
+				return confirmation.Handlers{}, err
+			}
+
+			return confirmation.Handlers{Confirmed: handler}, nil
+		},
+	)
+}
+
 func (ec *ethereumChain) StakeMonitor() (chain.StakeMonitor, error) {
 	stakeMonitor := &ethereumStakeMonitor{
 		ethereum: ec,
@@ -0,0 +1,322 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+)
+
+// gasPriceLookupTimeout is the maximum time spent on determining the gas
+// price of a single transaction.
+const gasPriceLookupTimeout = 15 * time.Second
+
+// transactionDeadline returns the block before which a transaction has to be
+// mined to be of any use.
+type transactionDeadline func() (uint64, error)
+
+// transactionOptions returns options of a transaction with the given gas
+// limit, priced with the gas price strategy and capped at the given ceiling.
+// The transaction must not be submitted if the gas price could not be
+// determined: an uncapped price suggested by the Ethereum node could exceed
+// both the configured maximum price and the ceiling.
+//
+// The gas limit has to be estimated before; contract bindings would estimate
+// it while holding the transaction mutex otherwise.
+func (ec *ethereumChain) transactionOptions(
+	gasLimit uint64,
+	ceiling *big.Int,
+) (ethutil.TransactionOptions, error) {
+	if gasLimit == 0 {
+		return ethutil.TransactionOptions{}, fmt.Errorf("gas limit not set")
+	}
+
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		gasPriceLookupTimeout,
+	)
+	defer cancelCtx()
+
+	gasPrice, err := ec.gasPriceStrategy.Price(ctx, ceiling)
+	if err != nil {
+		return ethutil.TransactionOptions{}, fmt.Errorf(
+			"could not determine gas price: [%v]",
+			err,
+		)
+	}
+
+	return ethutil.TransactionOptions{
+		GasLimit: gasLimit,
+		GasPrice: gasPrice,
+	}, nil
+}
+
+// gasPriceCeiling returns the gas price ceiling of the operator contract,
+// above which the contract does not reimburse transaction costs, or nil if
+// it could not be read.
+func (ec *ethereumChain) gasPriceCeiling() *big.Int {
+	ceiling, err := ec.keepRandomBeaconOperatorContract.GasPriceCeiling()
+	if err != nil {
+		logger.Warningf(
+			"could not get gas price ceiling of the operator contract: [%v]",
+			err,
+		)
+		return nil
+	}
+
+	return ceiling
+}
+
+// replaceStuckTransaction waits for the transaction to be mined in the
+// background. Every time the transaction has not been mined within the number
+// of blocks configured in the gas price strategy, it is replaced with a
+// transaction with the same nonce and a bumped gas price. Replacement stops
+// once any of the submitted transactions has been mined, the gas price can
+// not be bumped anymore or there is no time left before the deadline.
+func (ec *ethereumChain) replaceStuckTransaction(
+	transaction *types.Transaction,
+	ceiling *big.Int,
+	deadline transactionDeadline,
+) {
+	go func() {
+		deadlineBlock, err := deadline()
+		if err != nil {
+			logger.Warningf(
+				"could not determine deadline of transaction [%v]; "+
+					"transaction will not be replaced if stuck: [%v]",
+				transaction.Hash().Hex(),
+				err,
+			)
+			return
+		}
+
+		hashes := []common.Hash{transaction.Hash()}
+
+		for {
+			currentBlock, err := ec.blockCounter.CurrentBlock()
+			if err != nil {
+				logger.Errorf("could not get current block: [%v]", err)
+				return
+			}
+
+			checkBlock := currentBlock + ec.gasPriceStrategy.ResubmitBlocks()
+			if checkBlock >= deadlineBlock {
+				return
+			}
+
+			if err := ec.blockCounter.WaitForBlockHeight(checkBlock); err != nil {
+				logger.Errorf("could not wait for block [%v]: [%v]", checkBlock, err)
+				return
+			}
+
+			if isAnyTransactionMined(ec.failoverClient.rpcClient(), hashes) {
+				return
+			}
+
+			gasPrice, ok := ec.gasPriceStrategy.Bump(transaction.GasPrice(), ceiling)
+			if !ok {
+				logger.Warningf(
+					"transaction [%v] has not been mined yet but its "+
+						"gas price [%v] cannot be bumped anymore",
+					transaction.Hash().Hex(),
+					transaction.GasPrice(),
+				)
+				return
+			}
+
+			replacement, err := ec.resubmitTransaction(transaction, gasPrice)
+			if err != nil {
+				if strings.Contains(err.Error(), "nonce too low") {
+					// One of the submitted transactions has been mined in
+					// the meantime.
+					return
+				}
+
+				logger.Warningf(
+					"could not replace transaction [%v]: [%v]",
+					transaction.Hash().Hex(),
+					err,
+				)
+				continue
+			}
+
+			logger.Infof(
+				"replaced transaction [%v] not mined in [%v] blocks "+
+					"with transaction [%v] with gas price [%v]",
+				transaction.Hash().Hex(),
+				ec.gasPriceStrategy.ResubmitBlocks(),
+				replacement.Hash().Hex(),
+				gasPrice,
+			)
+
+			hashes = append(hashes, replacement.Hash())
+			transaction = replacement
+		}
+	}()
+}
+
+// resubmitTransaction signs and submits a copy of the transaction with the
+// given gas price.
+func (ec *ethereumChain) resubmitTransaction(
+	transaction *types.Transaction,
+	gasPrice *big.Int,
+) (*types.Transaction, error) {
+	// Contract bindings sign transactions with the Homestead signer; the
+	// replacement is signed the same way.
+	replacement, err := types.SignTx(
+		types.NewTransaction(
+			transaction.Nonce(),
+			*transaction.To(),
+			transaction.Value(),
+			transaction.Gas(),
+			gasPrice,
+			transaction.Data(),
+		),
+		types.HomesteadSigner{},
+		ec.accountKey.PrivateKey,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not sign transaction: [%v]", err)
+	}
+
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		transactionLookupTimeout,
+	)
+	defer cancelCtx()
+
+	if err := ec.client.SendTransaction(ctx, replacement); err != nil {
+		return nil, err
+	}
+
+	return replacement, nil
+}
+
+// isAnyTransactionMined checks whether a transaction with any of the given
+// hashes has been mined.
+func isAnyTransactionMined(client *ethclient.Client, hashes []common.Hash) bool {
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		transactionLookupTimeout,
+	)
+	defer cancelCtx()
+
+	for _, hash := range hashes {
+		receipt, err := client.TransactionReceipt(ctx, hash)
+		if err == goethereum.NotFound {
+			continue
+		}
+		if err != nil {
+			logger.Warningf(
+				"could not get receipt of transaction [%v]: [%v]",
+				hash.Hex(),
+				err,
+			)
+			continue
+		}
+		if receipt != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// relayEntryDeadline returns the block at which the current relay entry
+// times out.
+func (ec *ethereumChain) relayEntryDeadline() (uint64, error) {
+	startBlock, err := ec.keepRandomBeaconOperatorContract.CurrentRequestStartBlock()
+	if err != nil {
+		return 0, fmt.Errorf("could not get current request start block: [%v]", err)
+	}
+
+	timeout, err := ec.keepRandomBeaconOperatorContract.RelayEntryTimeout()
+	if err != nil {
+		return 0, fmt.Errorf("could not get relay entry timeout: [%v]", err)
+	}
+
+	return startBlock.Uint64() + timeout.Uint64(), nil
+}
+
+// ticketSubmissionDeadline returns the block at which ticket submission of
+// the current group selection ends. The deadline is cached until it passes
+// as all tickets of a group selection are submitted one after another.
+func (ec *ethereumChain) ticketSubmissionDeadline() (uint64, error) {
+	ec.ticketSubmissionDeadlineMutex.Lock()
+	defer ec.ticketSubmissionDeadlineMutex.Unlock()
+
+	currentBlock, err := ec.blockCounter.CurrentBlock()
+	if err != nil {
+		return 0, fmt.Errorf("could not get current block: [%v]", err)
+	}
+
+	if currentBlock < ec.lastTicketSubmissionDeadline {
+		return ec.lastTicketSubmissionDeadline, nil
+	}
+
+	timeout, err := ec.keepRandomBeaconOperatorContract.TicketSubmissionTimeout()
+	if err != nil {
+		return 0, fmt.Errorf("could not get ticket submission timeout: [%v]", err)
+	}
+
+	fromBlock := uint64(0)
+	if currentBlock > timeout.Uint64() {
+		fromBlock = currentBlock - timeout.Uint64()
+	}
+
+	groupSelectionStarts, err := ec.PastGroupSelectionStarts(fromBlock, currentBlock)
+	if err != nil {
+		return 0, err
+	}
+	if len(groupSelectionStarts) == 0 {
+		return 0, fmt.Errorf("no group selection in progress")
+	}
+
+	lastStart := groupSelectionStarts[len(groupSelectionStarts)-1]
+	ec.lastTicketSubmissionDeadline = lastStart.BlockNumber + timeout.Uint64()
+
+	return ec.lastTicketSubmissionDeadline, nil
+}
+
+// blocksFromNow returns a deadline which is the given number of blocks after
+// the current block.
+func (ec *ethereumChain) blocksFromNow(
+	blocks func() (*big.Int, error),
+) transactionDeadline {
+	return func() (uint64, error) {
+		currentBlock, err := ec.blockCounter.CurrentBlock()
+		if err != nil {
+			return 0, fmt.Errorf("could not get current block: [%v]", err)
+		}
+
+		duration, err := blocks()
+		if err != nil {
+			return 0, err
+		}
+
+		return currentBlock + duration.Uint64(), nil
+	}
+}
+
+// blocksFrom returns a deadline which is the given number of blocks after
+// the given block.
+func (ec *ethereumChain) blocksFrom(
+	block uint64,
+	blocks func() (*big.Int, error),
+) transactionDeadline {
+	return func() (uint64, error) {
+		duration, err := blocks()
+		if err != nil {
+			return 0, err
+		}
+
+		return block + duration.Uint64(), nil
+	}
+}
@@ -1,12 +1,29 @@
 package ethereum
 
 import (
+	"bytes"
+	"context"
+	"fmt"
 	"math/big"
+	"strings"
+	"time"
 
+	ethereumabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/gen/abi"
 	"github.com/keep-network/keep-core/pkg/gen/async"
+	"github.com/keep-network/keep-core/pkg/subscription"
 )
 
+// transactionLookupTimeout is the maximum time spent on fetching a single
+// transaction from the Ethereum client.
+const transactionLookupTimeout = 30 * time.Second
+
 func (euc *ethereumUtilityChain) Genesis() error {
 	// expressed in gas units
 	dkgGasEstimate, err := euc.keepRandomBeaconOperatorContract.DkgGasEstimate()
@@ -26,50 +43,452 @@ func (euc *ethereumUtilityChain) Genesis() error {
 	return err
 }
 
-func (euc *ethereumUtilityChain) RequestRelayEntry() *async.EventEntryGeneratedPromise {
-	promise := &async.EventEntryGeneratedPromise{}
+func (euc *ethereumUtilityChain) RequestRelayEntry(
+	callbackContract string,
+	callbackGas *big.Int,
+) *async.EventEntryRequestedPromise {
+	promise := &async.EventEntryRequestedPromise{}
+
+	if callbackContract != "" && !common.IsHexAddress(callbackContract) {
+		promise.Fail(fmt.Errorf(
+			"callback contract address [%v] is not a valid hex address",
+			callbackContract,
+		))
+		return promise
+	}
 
-	callbackGas := big.NewInt(0) // no callback
 	payment, err := euc.keepRandomBeaconServiceContract.EntryFeeEstimate(callbackGas)
 	if err != nil {
 		promise.Fail(err)
 		return promise
 	}
 
-	onWatchError := func(err error) error {
+	var transaction *types.Transaction
+	if callbackContract == "" {
+		transaction, err = euc.keepRandomBeaconServiceContract.RequestRelayEntry(payment)
+	} else {
+		transaction, err = euc.keepRandomBeaconServiceContract.RequestRelayEntry0(
+			common.HexToAddress(callbackContract),
+			callbackGas,
+			payment,
+		)
+	}
+	if err != nil {
 		promise.Fail(err)
-		return err
+		return promise
 	}
 
-	// In the rare case relay entry submission happens before relay request in
-	// the same block, we need to make sure we install relay entry generated
-	// callback after relay entry request tx has been confirmed to do not
-	// react on the previous relay entry.
-	_, err = euc.keepRandomBeaconServiceContract.WatchRelayEntryRequested(
-		func(requestId *big.Int, blockNumber uint64) {
-			logger.Infof(
-				"Relay request with id [%v] created at block [%v]",
-				requestId,
-				blockNumber,
-			)
-			euc.keepRandomBeaconServiceContract.WatchRelayEntryGenerated(
-				func(_, entry *big.Int, blockNumber uint64) {
-					promise.Fulfill(&event.EntryGenerated{
-						Value:       entry,
-						BlockNumber: blockNumber,
-					})
-				},
-				onWatchError,
+	// Other requesters may submit relay requests at the same time so the
+	// request id is read from the receipt of this very transaction instead of
+	// being taken from the first request event seen on-chain.
+	go func() {
+		request, err := euc.entryRequestedFromReceipt(transaction)
+		if err != nil {
+			promise.Fail(fmt.Errorf(
+				"could not read relay request of transaction [%v]: [%v]",
+				transaction.Hash().Hex(),
+				err,
+			))
+			return
+		}
+
+		logger.Infof(
+			"Relay request with id [%v] created at block [%v]",
+			request.RequestID,
+			request.BlockNumber,
+		)
+		promise.Fulfill(request)
+	}()
+
+	return promise
+}
+
+// entryRequestedFromReceipt waits until the given relay request transaction
+// is mined and decodes the RelayEntryRequested event the service contract
+// emitted in that transaction.
+func (euc *ethereumUtilityChain) entryRequestedFromReceipt(
+	transaction *types.Transaction,
+) (*event.EntryRequested, error) {
+	serviceAddress, err := addressForContract(
+		euc.config,
+		"KeepRandomBeaconService",
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error resolving KeepRandomBeaconService contract: [%v]",
+			err,
+		)
+	}
+
+	receipt, err := bind.WaitMined(
+		context.Background(),
+		euc.failoverClient.rpcClient(),
+		transaction,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction reverted")
+	}
+
+	// The operator contract emits an event of the same name in the same
+	// transaction; it does not carry the request id.
+	serviceLogs := make([]*types.Log, 0)
+	for _, log := range receipt.Logs {
+		if log.Address == *serviceAddress {
+			serviceLogs = append(serviceLogs, log)
+		}
+	}
+
+	requestID, err := requestIDFromLogs(serviceLogs, "RelayEntryRequested")
+	if err != nil {
+		return nil, err
+	}
+	if requestID == nil {
+		return nil, fmt.Errorf("no relay request event in transaction receipt")
+	}
+
+	return &event.EntryRequested{
+		RequestID:   requestID,
+		BlockNumber: receipt.BlockNumber.Uint64(),
+	}, nil
+}
+
+func (euc *ethereumUtilityChain) OnRelayEntryGenerated(
+	handle func(entry *event.EntryGenerated),
+) (subscription.EventSubscription, error) {
+	return euc.keepRandomBeaconServiceContract.WatchRelayEntryGenerated(
+		func(requestID *big.Int, entry *big.Int, blockNumber uint64) {
+			handle(&event.EntryGenerated{
+				RequestID:   requestID,
+				Value:       entry,
+				BlockNumber: blockNumber,
+			})
+		},
+		func(err error) error {
+			return fmt.Errorf(
+				"watch relay entry generated failed with [%v]",
+				err,
 			)
 		},
-		onWatchError,
 	)
+}
+
+func (euc *ethereumUtilityChain) EntryFeeEstimate(
+	callbackGas *big.Int,
+) (*big.Int, error) {
+	return euc.keepRandomBeaconServiceContract.EntryFeeEstimate(callbackGas)
+}
+
+func (euc *ethereumUtilityChain) EntryFeeBreakdown() (
+	*chain.EntryFeeBreakdown,
+	error,
+) {
+	breakdown, err := euc.keepRandomBeaconServiceContract.EntryFeeBreakdown()
+	if err != nil {
+		return nil, err
+	}
+
+	return &chain.EntryFeeBreakdown{
+		EntryVerificationFee: breakdown.EntryVerificationFee,
+		DkgContributionFee:   breakdown.DkgContributionFee,
+		GroupProfitFee:       breakdown.GroupProfitFee,
+		GasPriceCeiling:      breakdown.GasPriceCeiling,
+	}, nil
+}
+
+func (euc *ethereumUtilityChain) PastRelayEntryRequests(
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*event.Request, error) {
+	filterer, err := euc.operatorFilterer()
+	if err != nil {
+		return nil, err
+	}
+
+	iterator, err := filterer.FilterRelayEntryRequested(
+		&bind.FilterOpts{Start: fromBlock, End: &toBlock},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not filter relay entry requests: [%v]",
+			err,
+		)
+	}
+	defer iterator.Close()
+
+	requests := make([]*event.Request, 0)
+	for iterator.Next() {
+		requests = append(requests, &event.Request{
+			PreviousEntry:   iterator.Event.PreviousEntry,
+			GroupPublicKey:  iterator.Event.GroupPublicKey,
+			BlockNumber:     iterator.Event.Raw.BlockNumber,
+			TransactionHash: iterator.Event.Raw.TxHash.Hex(),
+		})
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, fmt.Errorf(
+			"could not iterate over relay entry requests: [%v]",
+			err,
+		)
+	}
 
-	_, err = euc.keepRandomBeaconServiceContract.RequestRelayEntry(payment)
+	return requests, nil
+}
+
+// RelayRequestID looks up the id of the given relay request in the receipt
+// of the transaction which made the request.
+func (euc *ethereumUtilityChain) RelayRequestID(
+	request *event.Request,
+) (*big.Int, error) {
+	if request.RequestID != nil {
+		return request.RequestID, nil
+	}
+
+	if request.TransactionHash == "" {
+		return nil, fmt.Errorf("request transaction is not known")
+	}
+
+	requestID := euc.relayRequestID(
+		common.HexToHash(request.TransactionHash),
+		"RelayEntryRequested",
+	)
+	if requestID == nil {
+		return nil, fmt.Errorf(
+			"no relay request id in transaction [%v]",
+			request.TransactionHash,
+		)
+	}
+
+	return requestID, nil
+}
+
+// PastRelayEntries looks up relay entries submitted in the given block range.
+// RelayEntrySubmitted event does not carry the entry itself so the entry is
+// decoded from the input of the relayEntry transaction that emitted the event.
+func (euc *ethereumUtilityChain) PastRelayEntries(
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*chain.RelayEntry, error) {
+	filterer, err := euc.operatorFilterer()
 	if err != nil {
-		promise.Fail(err)
-		return promise
+		return nil, err
 	}
 
-	return promise
+	operatorABI, err := ethereumabi.JSON(
+		strings.NewReader(abi.KeepRandomBeaconOperatorABI),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse operator contract ABI: [%v]", err)
+	}
+
+	iterator, err := filterer.FilterRelayEntrySubmitted(
+		&bind.FilterOpts{Start: fromBlock, End: &toBlock},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not filter submitted relay entries: [%v]",
+			err,
+		)
+	}
+	defer iterator.Close()
+
+	client := euc.failoverClient.rpcClient()
+
+	entries := make([]*chain.RelayEntry, 0)
+	for iterator.Next() {
+		transactionHash := iterator.Event.Raw.TxHash
+
+		entry, err := euc.relayEntryFromTransaction(
+			client,
+			&operatorABI,
+			transactionHash,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not read relay entry from transaction [%v]: [%v]",
+				transactionHash.Hex(),
+				err,
+			)
+		}
+
+		entries = append(entries, &chain.RelayEntry{
+			RequestID: euc.relayRequestID(
+				transactionHash,
+				"RelayEntryGenerated",
+			),
+			Entry:       entry,
+			BlockNumber: iterator.Event.Raw.BlockNumber,
+		})
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, fmt.Errorf(
+			"could not iterate over submitted relay entries: [%v]",
+			err,
+		)
+	}
+
+	return entries, nil
+}
+
+func (euc *ethereumUtilityChain) RequestGroupPublicKey(
+	blockNumber uint64,
+) ([]byte, error) {
+	groupIndex, err := euc.keepRandomBeaconOperatorContract.CurrentRequestGroupIndexAtBlock(
+		new(big.Int).SetUint64(blockNumber),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not get request group index at block [%v]: [%v]",
+			blockNumber,
+			err,
+		)
+	}
+
+	return euc.keepRandomBeaconOperatorContract.GetGroupPublicKey(groupIndex)
+}
+
+// PastGroupMemberRewardsWithdrawals looks up group member rewards withdrawals
+// in the given block range. GroupMemberRewardsWithdrawn event identifies the
+// group by its index so the index is resolved to the group public key.
+func (euc *ethereumUtilityChain) PastGroupMemberRewardsWithdrawals(
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*event.GroupMemberRewardsWithdrawal, error) {
+	filterer, err := euc.operatorFilterer()
+	if err != nil {
+		return nil, err
+	}
+
+	iterator, err := filterer.FilterGroupMemberRewardsWithdrawn(
+		&bind.FilterOpts{Start: fromBlock, End: &toBlock},
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not filter group member rewards withdrawals: [%v]",
+			err,
+		)
+	}
+	defer iterator.Close()
+
+	// key is group index as a decimal string
+	groupPublicKeys := make(map[string][]byte)
+
+	withdrawals := make([]*event.GroupMemberRewardsWithdrawal, 0)
+	for iterator.Next() {
+		groupIndex := iterator.Event.GroupIndex
+
+		groupPublicKey, ok := groupPublicKeys[groupIndex.String()]
+		if !ok {
+			groupPublicKey, err = euc.keepRandomBeaconOperatorContract.GetGroupPublicKey(
+				groupIndex,
+			)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"could not get public key of group [%v]: [%v]",
+					groupIndex,
+					err,
+				)
+			}
+			groupPublicKeys[groupIndex.String()] = groupPublicKey
+		}
+
+		withdrawals = append(withdrawals, &event.GroupMemberRewardsWithdrawal{
+			Beneficiary:    iterator.Event.Beneficiary.Bytes(),
+			Operator:       iterator.Event.Operator.Bytes(),
+			Amount:         iterator.Event.Amount,
+			GroupPublicKey: groupPublicKey,
+			BlockNumber:    iterator.Event.Raw.BlockNumber,
+		})
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, fmt.Errorf(
+			"could not iterate over group member rewards withdrawals: [%v]",
+			err,
+		)
+	}
+
+	return withdrawals, nil
+}
+
+func (euc *ethereumUtilityChain) BlockTime(blockNumber uint64) (time.Time, error) {
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		transactionLookupTimeout,
+	)
+	defer cancelCtx()
+
+	header, err := euc.failoverClient.rpcClient().HeaderByNumber(
+		ctx,
+		new(big.Int).SetUint64(blockNumber),
+	)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"could not get header of block [%v]: [%v]",
+			blockNumber,
+			err,
+		)
+	}
+
+	return time.Unix(int64(header.Time), 0), nil
+}
+
+// SubmittedRelayEntry decodes the relay entry from the input of the
+// relayEntry transaction which emitted the given submission event.
+func (euc *ethereumUtilityChain) SubmittedRelayEntry(
+	submission *event.EntrySubmitted,
+) ([]byte, error) {
+	if submission.TransactionHash == "" {
+		return nil, fmt.Errorf("submission transaction is not known")
+	}
+
+	operatorABI, err := ethereumabi.JSON(
+		strings.NewReader(abi.KeepRandomBeaconOperatorABI),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse operator contract ABI: [%v]", err)
+	}
+
+	return euc.relayEntryFromTransaction(
+		euc.failoverClient.rpcClient(),
+		&operatorABI,
+		common.HexToHash(submission.TransactionHash),
+	)
+}
+
+func (euc *ethereumUtilityChain) relayEntryFromTransaction(
+	client *ethclient.Client,
+	operatorABI *ethereumabi.ABI,
+	transactionHash common.Hash,
+) ([]byte, error) {
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		transactionLookupTimeout,
+	)
+	defer cancelCtx()
+
+	transaction, _, err := client.TransactionByHash(ctx, transactionHash)
+	if err != nil {
+		return nil, err
+	}
+
+	data := transaction.Data()
+	relayEntryMethod := operatorABI.Methods["relayEntry"]
+	if len(data) < 4 || !bytes.Equal(data[:4], relayEntryMethod.ID()) {
+		return nil, fmt.Errorf("transaction is not a relayEntry call")
+	}
+
+	values, err := relayEntryMethod.Inputs.UnpackValues(data[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	entry, ok := values[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected relayEntry input type")
+	}
+
+	return entry, nil
 }
@@ -88,38 +88,66 @@ func (lbc *localBlockCounter) WatchBlocks(ctx context.Context) <-chan uint64 {
 
 // count is an internal function that counts up time to simulate the generation
 // of blocks.
-func (lbc *localBlockCounter) count() {
+func (lbc *localBlockCounter) count(blockTime time.Duration) {
 	ticker := time.NewTicker(blockTime)
 
 	for range ticker.C {
 		lbc.structMutex.Lock()
-		lbc.blockHeight++
-		height := lbc.blockHeight
-		waiters, exists := lbc.waiters[height]
-		delete(lbc.waiters, height)
+		height := lbc.blockHeight + 1
 		lbc.structMutex.Unlock()
 
-		if exists {
-			for _, waiter := range waiters {
-				go func(w chan uint64) { w <- height }(waiter)
-			}
-		}
+		lbc.advance(height)
+	}
+}
 
-		lbc.structMutex.Lock()
-		watchers := make([]*watcher, len(lbc.watchers))
-		copy(watchers, lbc.watchers)
+// follow is an internal function that advances the block height to heights
+// received from the given channel until the channel is closed.
+func (lbc *localBlockCounter) follow(heights <-chan uint64) {
+	for height := range heights {
+		lbc.advance(height)
+	}
+}
+
+// advance sets the block height to the given one if it is higher than the
+// current one and notifies waiters of all blocks up to the new height as
+// well as all watchers.
+func (lbc *localBlockCounter) advance(height uint64) {
+	lbc.structMutex.Lock()
+	if height <= lbc.blockHeight {
 		lbc.structMutex.Unlock()
+		return
+	}
 
-		for _, watcher := range watchers {
-			if watcher.ctx.Err() != nil {
-				close(watcher.channel)
-				continue
-			}
+	notifiedWaiters := make(map[uint64][]chan uint64)
+	for waitedHeight, waiters := range lbc.waiters {
+		if waitedHeight <= height {
+			notifiedWaiters[waitedHeight] = waiters
+			delete(lbc.waiters, waitedHeight)
+		}
+	}
+	lbc.blockHeight = height
+	lbc.structMutex.Unlock()
 
-			select {
-			case watcher.channel <- height: // perfect
-			default: // we don't care, let's drop it
-			}
+	for waitedHeight, waiters := range notifiedWaiters {
+		for _, waiter := range waiters {
+			go func(w chan uint64, h uint64) { w <- h }(waiter, waitedHeight)
+		}
+	}
+
+	lbc.structMutex.Lock()
+	watchers := make([]*watcher, len(lbc.watchers))
+	copy(watchers, lbc.watchers)
+	lbc.structMutex.Unlock()
+
+	for _, watcher := range watchers {
+		if watcher.ctx.Err() != nil {
+			close(watcher.channel)
+			continue
+		}
+
+		select {
+		case watcher.channel <- height: // perfect
+		default: // we don't care, let's drop it
 		}
 	}
 }
@@ -128,9 +156,31 @@ func (lbc *localBlockCounter) count() {
 // designed to simply increase block height at a set time interval in the
 // background.
 func BlockCounter() (chain.BlockCounter, error) {
-	counter := localBlockCounter{blockHeight: 0, waiters: make(map[uint64][]chan uint64)}
+	return newBlockCounter(blockTime), nil
+}
+
+func newBlockCounter(blockTime time.Duration) *localBlockCounter {
+	counter := &localBlockCounter{
+		blockHeight: 0,
+		waiters:     make(map[uint64][]chan uint64),
+	}
+
+	go counter.count(blockTime)
+
+	return counter
+}
+
+// FollowingBlockCounter creates a BlockCounter which does not count blocks on
+// its own but follows block heights received from the given channel, e.g.
+// heights of a local chain running in another process. Heights lower than
+// the current one are ignored.
+func FollowingBlockCounter(heights <-chan uint64) chain.BlockCounter {
+	counter := &localBlockCounter{
+		blockHeight: 0,
+		waiters:     make(map[uint64][]chan uint64),
+	}
 
-	go counter.count()
+	go counter.follow(heights)
 
-	return &counter, nil
+	return counter
 }
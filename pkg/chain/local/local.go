@@ -10,7 +10,10 @@ import (
 	"math/rand"
 	"sort"
 	"sync"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 	"github.com/ipfs/go-log"
 
 	crand "crypto/rand"
@@ -18,6 +21,7 @@ import (
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
 	relayconfig "github.com/keep-network/keep-core/pkg/beacon/relay/config"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/bls"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/gen/async"
 	"github.com/keep-network/keep-core/pkg/operator"
@@ -30,7 +34,8 @@ var logger = log.Logger("keep-chain-local")
 var seedGroupPublicKey = []byte("seed to group public key")
 var seedRelayEntry = big.NewInt(123456789)
 var groupActiveTime = uint64(10)
-var relayRequestTimeout = uint64(8)
+var groupSelectionTimeout = uint64(150)
+var gasPrice = big.NewInt(20000000000) // 20 Gwei
 
 // Chain is an extention of chain.Handle interface which exposes
 // additional functions useful for testing.
@@ -47,17 +52,90 @@ type Chain interface {
 	// GetRelayEntryTimeoutReports returns an array of blocks which denote at what
 	// block a relay entry timeout occured.
 	GetRelayEntryTimeoutReports() []uint64
+
+	// GetUnauthorizedSigningReports returns public keys of all groups
+	// reported for unauthorized signing.
+	GetUnauthorizedSigningReports() [][]byte
+
+	// ReportUnauthorizedSigningAs reports unauthorized signing the same way
+	// ReportUnauthorizedSigning does, verifying the signature over the
+	// address of the given operator instead of the local chain operator.
+	// It lets a chain shared by many operators attribute reports the way the
+	// operator contract attributes them to the transaction sender.
+	ReportUnauthorizedSigningAs(
+		operator relaychain.StakerAddress,
+		groupPublicKey []byte,
+		signedOperatorAddress []byte,
+	) error
+
+	// RequestRelayEntry requests a new relay entry from one of the registered
+	// groups, selected based on the last relay entry. Once the entry for the
+	// request is submitted, a new group selection is started if possible.
+	RequestRelayEntry() (*event.Request, error)
+
+	// Genesis starts the first group selection, using the seed relay entry
+	// as the group selection seed.
+	Genesis() error
+
+	// EntryFeeEstimate returns the fee a relay consumer has to pay for a new
+	// relay entry with a callback using at most callbackGas gas.
+	EntryFeeEstimate(callbackGas *big.Int) (*big.Int, error)
+
+	// EntryFeeBreakdown returns the components of the relay entry fee.
+	EntryFeeBreakdown() (*chain.EntryFeeBreakdown, error)
+
+	// GetDKGFeePool returns the part of relay entry fees collected for the
+	// creation of new groups and not spent yet.
+	GetDKGFeePool() *big.Int
+}
+
+// Config contains parameters of a local chain created with
+// ConnectWithConfig.
+type Config struct {
+	GroupSize       int
+	HonestThreshold int
+	MinimumStake    *big.Int
+
+	// BlockTime is the time between two consecutive blocks.
+	BlockTime time.Duration
+
+	// SeedGroup determines whether the chain starts with a seed group
+	// registered. Nobody is a member of the seed group, so relay entries
+	// requested from it are never submitted.
+	SeedGroup bool
+
+	// GroupActiveTime is the time in blocks after which a group expires and
+	// is no longer selected for new relay entries. Ten blocks if not set.
+	GroupActiveTime uint64
+
+	// DKGContributionMargin is the fraction, in percent, of the group
+	// creation fee included in the relay entry fee. A new group selection
+	// starts after a requested relay entry only if the collected
+	// contributions cover the group creation fee. One percent if not set.
+	DKGContributionMargin uint64
 }
 
 type localGroup struct {
 	groupPublicKey          []byte
 	registrationBlockHeight uint64
+	members                 []relaychain.StakerAddress
+
+	// terminated is set if the group did not submit a relay entry on time.
+	// Terminated groups are not selected for new relay entries.
+	terminated bool
+	// memberReward is the reward accumulated by each member of the group
+	// for relay entries submitted on time.
+	memberReward *big.Int
 }
 
 type localChain struct {
 	relayConfig *relayconfig.Chain
 
-	groups []localGroup
+	groupActiveTime       uint64
+	dkgContributionMargin uint64
+
+	groupsMutex sync.Mutex
+	groups      []localGroup
 
 	lastSubmittedDKGResult           *relaychain.DKGResult
 	lastSubmittedDKGResultSignatures map[relaychain.GroupMemberIndex][]byte
@@ -70,9 +148,17 @@ type localChain struct {
 	groupRegisteredHandlers       map[int]func(groupRegistration *event.GroupRegistration)
 	resultSubmissionHandlers      map[int]func(submission *event.DKGResultSubmission)
 
-	simulatedHeight uint64
-	stakeMonitor    chain.StakeMonitor
-	blockCounter    chain.BlockCounter
+	requestMutex   sync.Mutex
+	requestCounter int64
+	currentRequest *event.Request
+
+	groupSelectionMutex      sync.Mutex
+	groupSelectionStarts     []*event.GroupSelectionStart
+	groupSelectionInProgress bool
+	dkgFeePool               *big.Int
+
+	stakeMonitor *StakeMonitor
+	blockCounter chain.BlockCounter
 
 	tickets      []*relaychain.Ticket
 	ticketsMutex sync.Mutex
@@ -80,6 +166,12 @@ type localChain struct {
 	relayEntryTimeoutReportsMutex sync.Mutex
 	relayEntryTimeoutReports      []uint64
 
+	unauthorizedSigningReportsMutex sync.Mutex
+	unauthorizedSigningReports      [][]byte
+
+	withdrawnRewardsMutex sync.Mutex
+	withdrawnRewards      map[string]bool
+
 	operatorKey *ecdsa.PrivateKey
 }
 
@@ -92,7 +184,7 @@ func (c *localChain) StakeMonitor() (chain.StakeMonitor, error) {
 }
 
 func (c *localChain) Signing() chain.Signing {
-	return &localSigning{c.operatorKey}
+	return NewSigning(c.operatorKey)
 }
 
 func (c *localChain) GetKeys() (*operator.PrivateKey, *operator.PublicKey) {
@@ -118,9 +210,15 @@ func (c *localChain) SubmitTicket(ticket *relaychain.Ticket) *async.EventGroupTi
 		return iValue.Cmp(jValue) == -1
 	})
 
+	currentBlock, err := c.blockCounter.CurrentBlock()
+	if err != nil {
+		promise.Fail(fmt.Errorf("cannot read current block"))
+		return promise
+	}
+
 	_ = promise.Fulfill(&event.GroupTicketSubmission{
 		TicketValue: new(big.Int).SetBytes(ticket.Value[:]),
-		BlockNumber: c.simulatedHeight,
+		BlockNumber: currentBlock,
 	})
 
 	return promise
@@ -140,6 +238,13 @@ func (c *localChain) GetSelectedParticipants() ([]relaychain.StakerAddress, erro
 	c.ticketsMutex.Lock()
 	defer c.ticketsMutex.Unlock()
 
+	return c.selectedParticipants(), nil
+}
+
+// selectedParticipants returns stakers of the submitted tickets with the
+// lowest values. It must be called with the tickets mutex held.
+func (c *localChain) selectedParticipants() []relaychain.StakerAddress {
+
 	selectTickets := func() []*relaychain.Ticket {
 		if len(c.tickets) <= c.relayConfig.GroupSize {
 			return c.tickets
@@ -157,14 +262,227 @@ func (c *localChain) GetSelectedParticipants() ([]relaychain.StakerAddress, erro
 		selectedParticipants[i] = ticket.Proof.StakerValue.Bytes()
 	}
 
-	return selectedParticipants, nil
+	return selectedParticipants
 }
 
-func (c *localChain) SubmitRelayEntry(newEntry []byte) *async.EventEntrySubmittedPromise {
+// IsGroupSelectionPossible returns false only if a group selection has been
+// started with Genesis or after a requested relay entry, no DKG result has
+// been submitted since, and the group selection has not timed out yet.
+func (c *localChain) IsGroupSelectionPossible() (bool, error) {
+	c.groupSelectionMutex.Lock()
+	defer c.groupSelectionMutex.Unlock()
+
+	return c.isGroupSelectionPossible()
+}
+
+// isGroupSelectionPossible must be called with the group selection mutex
+// held.
+func (c *localChain) isGroupSelectionPossible() (bool, error) {
+	if !c.groupSelectionInProgress {
+		return true, nil
+	}
+
+	currentBlock, err := c.blockCounter.CurrentBlock()
+	if err != nil {
+		return false, fmt.Errorf("could not determine current block: [%v]", err)
+	}
+
+	lastStart := c.groupSelectionStarts[len(c.groupSelectionStarts)-1]
+	timeoutBlock := lastStart.BlockNumber +
+		c.relayConfig.TicketSubmissionTimeout +
+		groupSelectionTimeout
+
+	return currentBlock > timeoutBlock, nil
+}
+
+// PastGroupSelectionStarts returns group selections started with Genesis or
+// after requested relay entries between fromBlock and toBlock, inclusive.
+func (c *localChain) PastGroupSelectionStarts(
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*event.GroupSelectionStart, error) {
+	c.groupSelectionMutex.Lock()
+	defer c.groupSelectionMutex.Unlock()
+
+	groupSelectionStarts := make([]*event.GroupSelectionStart, 0)
+	for _, groupSelectionStart := range c.groupSelectionStarts {
+		if groupSelectionStart.BlockNumber < fromBlock ||
+			groupSelectionStart.BlockNumber > toBlock {
+			continue
+		}
+
+		groupSelectionStarts = append(groupSelectionStarts, groupSelectionStart)
+	}
+
+	return groupSelectionStarts, nil
+}
+
+func (c *localChain) Genesis() error {
+	c.groupSelectionMutex.Lock()
+	defer c.groupSelectionMutex.Unlock()
+
+	return c.startGroupSelection(seedRelayEntry)
+}
+
+// startGroupSelection starts a new group selection with the given seed if
+// there is no group selection in progress. It must be called with the group
+// selection mutex held.
+func (c *localChain) startGroupSelection(seed *big.Int) error {
+	isGroupSelectionPossible, err := c.isGroupSelectionPossible()
+	if err != nil {
+		return err
+	}
+	if !isGroupSelectionPossible {
+		return fmt.Errorf("group selection is already in progress")
+	}
+
+	currentBlock, err := c.blockCounter.CurrentBlock()
+	if err != nil {
+		return fmt.Errorf("could not determine current block: [%v]", err)
+	}
+
 	c.ticketsMutex.Lock()
 	c.tickets = make([]*relaychain.Ticket, 0)
 	c.ticketsMutex.Unlock()
 
+	groupSelectionStart := &event.GroupSelectionStart{
+		NewEntry:    seed,
+		BlockNumber: currentBlock,
+	}
+
+	c.groupSelectionStarts = append(c.groupSelectionStarts, groupSelectionStart)
+	c.groupSelectionInProgress = true
+
+	c.handlerMutex.Lock()
+	for _, handler := range c.groupSelectionStartedHandlers {
+		go func(
+			handler func(*event.GroupSelectionStart),
+			groupSelectionStart *event.GroupSelectionStart,
+		) {
+			handler(groupSelectionStart)
+		}(handler, groupSelectionStart)
+	}
+	c.handlerMutex.Unlock()
+
+	return nil
+}
+
+// startFundedGroupSelection starts a new group selection with the given seed
+// if the DKG fee pool covers the group creation fee. The fee is taken from
+// the pool once the group selection is started.
+func (c *localChain) startFundedGroupSelection(seed *big.Int) error {
+	c.groupSelectionMutex.Lock()
+	defer c.groupSelectionMutex.Unlock()
+
+	fee := groupCreationFee()
+	if c.dkgFeePool.Cmp(fee) < 0 {
+		return fmt.Errorf(
+			"DKG fee pool [%v] does not cover group creation fee [%v]",
+			c.dkgFeePool,
+			fee,
+		)
+	}
+
+	if err := c.startGroupSelection(seed); err != nil {
+		return err
+	}
+
+	c.dkgFeePool = new(big.Int).Sub(c.dkgFeePool, fee)
+
+	return nil
+}
+
+func (c *localChain) GetDKGFeePool() *big.Int {
+	c.groupSelectionMutex.Lock()
+	defer c.groupSelectionMutex.Unlock()
+
+	return new(big.Int).Set(c.dkgFeePool)
+}
+
+// RequestRelayEntry requests a new relay entry from one of the active groups
+// and adds the DKG contribution of the entry fee to the DKG fee pool.
+func (c *localChain) RequestRelayEntry() (*event.Request, error) {
+	c.requestMutex.Lock()
+	defer c.requestMutex.Unlock()
+
+	if c.currentRequest != nil {
+		return nil, fmt.Errorf("relay entry is already in progress")
+	}
+
+	previousEntry := c.lastSubmittedRelayEntry
+	if previousEntry == nil {
+		previousEntry = seedRelayEntry.Bytes()
+	}
+
+	c.requestCounter++
+	request, err := c.signRelayEntry(
+		big.NewInt(c.requestCounter),
+		previousEntry,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.groupSelectionMutex.Lock()
+	c.dkgFeePool = new(big.Int).Add(c.dkgFeePool, c.dkgContributionFee())
+	c.groupSelectionMutex.Unlock()
+
+	return request, nil
+}
+
+// signRelayEntry selects one of the active groups based on the previous
+// entry and requests the new entry from it. It must be called with the
+// request mutex held.
+func (c *localChain) signRelayEntry(
+	requestID *big.Int,
+	previousEntry []byte,
+) (*event.Request, error) {
+	currentBlock, err := c.blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("could not determine current block: [%v]", err)
+	}
+
+	groupPublicKey, err := c.selectActiveGroup(previousEntry, currentBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &event.Request{
+		RequestID:      requestID,
+		PreviousEntry:  previousEntry,
+		GroupPublicKey: groupPublicKey,
+		BlockNumber:    currentBlock,
+	}
+	c.currentRequest = request
+
+	c.handlerMutex.Lock()
+	for _, handler := range c.relayRequestHandlers {
+		go func(handler func(*event.Request), request *event.Request) {
+			handler(request)
+		}(handler, request)
+	}
+	c.handlerMutex.Unlock()
+
+	return request, nil
+}
+
+// hasEntryTimedOut returns true if the requested entry can no longer be
+// submitted at the given block.
+func (c *localChain) hasEntryTimedOut(
+	request *event.Request,
+	currentBlock uint64,
+) bool {
+	return currentBlock > request.BlockNumber+c.relayConfig.RelayEntryTimeout
+}
+
+// SubmitRelayEntry accepts any entry, even if no entry has been requested.
+// If the entry has been requested, it is rejected once the request timed out.
+// Otherwise the request is completed, members of the group which submitted
+// the entry are rewarded and a new group selection is started with the
+// submitted entry as the seed if it is possible and the DKG fee pool covers
+// the group creation fee. Submitted tickets are cleared unless a group
+// selection is in progress.
+func (c *localChain) SubmitRelayEntry(newEntry []byte) *async.EventEntrySubmittedPromise {
 	relayEntryPromise := &async.EventEntrySubmittedPromise{}
 
 	currentBlock, err := c.blockCounter.CurrentBlock()
@@ -173,9 +491,38 @@ func (c *localChain) SubmitRelayEntry(newEntry []byte) *async.EventEntrySubmitte
 		return relayEntryPromise
 	}
 
+	c.requestMutex.Lock()
+	request := c.currentRequest
+	if request != nil && c.hasEntryTimedOut(request, currentBlock) {
+		c.requestMutex.Unlock()
+		relayEntryPromise.Fail(fmt.Errorf("relay entry timed out"))
+		return relayEntryPromise
+	}
+	c.lastSubmittedRelayEntry = newEntry
+	c.currentRequest = nil
+	c.requestMutex.Unlock()
+
+	if request != nil {
+		c.addGroupMemberReward(
+			request.GroupPublicKey,
+			c.groupMemberReward(request.BlockNumber, currentBlock),
+		)
+	}
+
+	c.groupSelectionMutex.Lock()
+	if !c.groupSelectionInProgress {
+		c.ticketsMutex.Lock()
+		c.tickets = make([]*relaychain.Ticket, 0)
+		c.ticketsMutex.Unlock()
+	}
+	c.groupSelectionMutex.Unlock()
+
 	entry := &event.EntrySubmitted{
 		BlockNumber: currentBlock,
 	}
+	if request != nil {
+		entry.RequestID = request.RequestID
+	}
 
 	c.handlerMutex.Lock()
 	for _, handler := range c.relayEntryHandlers {
@@ -187,7 +534,12 @@ func (c *localChain) SubmitRelayEntry(newEntry []byte) *async.EventEntrySubmitte
 
 	relayEntryPromise.Fulfill(entry)
 
-	c.lastSubmittedRelayEntry = newEntry
+	if request != nil {
+		err := c.startFundedGroupSelection(new(big.Int).SetBytes(newEntry))
+		if err != nil {
+			logger.Infof("not starting group selection: [%v]", err)
+		}
+	}
 
 	return relayEntryPromise
 }
@@ -247,6 +599,22 @@ func (c *localChain) OnGroupSelectionStarted(
 	}), nil
 }
 
+// OnRelayEntryRequestRemoved never calls the handler as the local chain is
+// never reorganized.
+func (c *localChain) OnRelayEntryRequestRemoved(
+	handler func(request *event.Request),
+) (subscription.EventSubscription, error) {
+	return subscription.NewEventSubscription(func() {}), nil
+}
+
+// OnGroupSelectionStartRemoved never calls the handler as the local chain is
+// never reorganized.
+func (c *localChain) OnGroupSelectionStartRemoved(
+	handler func(entry *event.GroupSelectionStart),
+) (subscription.EventSubscription, error) {
+	return subscription.NewEventSubscription(func() {}), nil
+}
+
 func (c *localChain) OnGroupRegistered(
 	handler func(groupRegistration *event.GroupRegistration),
 ) (subscription.EventSubscription, error) {
@@ -292,34 +660,72 @@ func ConnectWithKey(
 	minimumStake *big.Int,
 	operatorKey *ecdsa.PrivateKey,
 ) Chain {
-	bc, _ := BlockCounter()
+	return ConnectWithConfig(
+		&Config{
+			GroupSize:       groupSize,
+			HonestThreshold: honestThreshold,
+			MinimumStake:    minimumStake,
+			BlockTime:       blockTime,
+			SeedGroup:       true,
+		},
+		operatorKey,
+	)
+}
 
-	currentBlock, _ := bc.CurrentBlock()
-	group := localGroup{
-		groupPublicKey:          seedGroupPublicKey,
-		registrationBlockHeight: currentBlock,
+// ConnectWithConfig initializes a local implementation of the chain
+// interfaces with the given parameters.
+func ConnectWithConfig(config *Config, operatorKey *ecdsa.PrivateKey) Chain {
+	bc := newBlockCounter(config.BlockTime)
+
+	groups := make([]localGroup, 0)
+	if config.SeedGroup {
+		currentBlock, _ := bc.CurrentBlock()
+		groups = append(groups, localGroup{
+			groupPublicKey:          seedGroupPublicKey,
+			registrationBlockHeight: currentBlock,
+			memberReward:            big.NewInt(0),
+		})
+	}
+
+	activeTime := config.GroupActiveTime
+	if activeTime == 0 {
+		activeTime = groupActiveTime
+	}
+
+	contributionMargin := config.DKGContributionMargin
+	if contributionMargin == 0 {
+		contributionMargin = dkgContributionMargin
 	}
 
 	resultPublicationBlockStep := uint64(3)
 
+	stakeMonitor := NewStakeMonitor(config.MinimumStake)
+	stakeMonitor.blockCounter = bc
+
 	return &localChain{
 		relayConfig: &relayconfig.Chain{
-			GroupSize:                  groupSize,
-			HonestThreshold:            honestThreshold,
+			GroupSize:                  config.GroupSize,
+			HonestThreshold:            config.HonestThreshold,
 			TicketSubmissionTimeout:    6,
 			ResultPublicationBlockStep: resultPublicationBlockStep,
-			MinimumStake:               minimumStake,
-			RelayEntryTimeout:          resultPublicationBlockStep * uint64(groupSize),
+			MinimumStake:               config.MinimumStake,
+			RelayEntryTimeout:          resultPublicationBlockStep * uint64(config.GroupSize),
 		},
-		relayEntryHandlers:       make(map[int]func(request *event.EntrySubmitted)),
-		relayRequestHandlers:     make(map[int]func(request *event.Request)),
-		groupRegisteredHandlers:  make(map[int]func(groupRegistration *event.GroupRegistration)),
-		resultSubmissionHandlers: make(map[int]func(submission *event.DKGResultSubmission)),
-		blockCounter:             bc,
-		stakeMonitor:             NewStakeMonitor(minimumStake),
-		tickets:                  make([]*relaychain.Ticket, 0),
-		groups:                   []localGroup{group},
-		operatorKey:              operatorKey,
+		groupActiveTime:               activeTime,
+		dkgContributionMargin:         contributionMargin,
+		relayEntryHandlers:            make(map[int]func(request *event.EntrySubmitted)),
+		relayRequestHandlers:          make(map[int]func(request *event.Request)),
+		groupSelectionStartedHandlers: make(map[int]func(groupSelectionStart *event.GroupSelectionStart)),
+		groupRegisteredHandlers:       make(map[int]func(groupRegistration *event.GroupRegistration)),
+		resultSubmissionHandlers:      make(map[int]func(submission *event.DKGResultSubmission)),
+		blockCounter:                  bc,
+		stakeMonitor:                  stakeMonitor,
+		tickets:                       make([]*relaychain.Ticket, 0),
+		groups:                        groups,
+		groupSelectionStarts:          make([]*event.GroupSelectionStart, 0),
+		dkgFeePool:                    big.NewInt(0),
+		withdrawnRewards:              make(map[string]bool),
+		operatorKey:                   operatorKey,
 	}
 }
 
@@ -331,35 +737,217 @@ func selectGroup(entry *big.Int, numberOfGroups int) int {
 	return int(new(big.Int).Mod(entry, big.NewInt(int64(numberOfGroups))).Int64())
 }
 
-func (c *localChain) IsStaleGroup(groupPublicKey []byte) (bool, error) {
-	c.handlerMutex.Lock()
-	defer c.handlerMutex.Unlock()
+// selectActiveGroup returns the public key of the group selected based on
+// the previous entry from groups which are neither expired nor terminated at
+// the given block.
+func (c *localChain) selectActiveGroup(
+	previousEntry []byte,
+	currentBlock uint64,
+) ([]byte, error) {
+	c.groupsMutex.Lock()
+	defer c.groupsMutex.Unlock()
+
+	activeGroups := make([]localGroup, 0)
+	for _, group := range c.groups {
+		if !group.terminated && !c.isExpired(group, currentBlock) {
+			activeGroups = append(activeGroups, group)
+		}
+	}
 
-	bc, _ := BlockCounter()
-	bc.WaitForBlockHeight(c.simulatedHeight)
-	currentBlock, err := bc.CurrentBlock()
+	if len(activeGroups) == 0 {
+		return nil, fmt.Errorf("no active groups")
+	}
+
+	selectedGroup := activeGroups[selectGroup(
+		new(big.Int).SetBytes(previousEntry),
+		len(activeGroups),
+	)]
+
+	return selectedGroup.groupPublicKey, nil
+}
 
+// isExpired returns true if the group active time passed at the given block.
+func (c *localChain) isExpired(group localGroup, currentBlock uint64) bool {
+	return group.registrationBlockHeight+c.groupActiveTime < currentBlock
+}
+
+// IsStaleGroup returns true if the group expired and the relay entry timeout
+// following its expiration passed, so that the group can no longer perform
+// any operation it was selected for while still active. Groups which are not
+// registered are reported as stale.
+func (c *localChain) IsStaleGroup(groupPublicKey []byte) (bool, error) {
+	currentBlock, err := c.blockCounter.CurrentBlock()
 	if err != nil {
 		return false, fmt.Errorf("could not determine current block: [%v]", err)
 	}
 
+	c.groupsMutex.Lock()
+	defer c.groupsMutex.Unlock()
+
 	for _, group := range c.groups {
 		if bytes.Compare(group.groupPublicKey, groupPublicKey) == 0 {
-			return group.registrationBlockHeight+groupActiveTime+relayRequestTimeout < currentBlock, nil
+			staleTime := group.registrationBlockHeight +
+				c.groupActiveTime +
+				c.relayConfig.RelayEntryTimeout
+			return staleTime < currentBlock, nil
 		}
 	}
 
 	return true, nil
 }
 
+// GetGroupMembers returns stakers selected to the group in the group
+// selection preceding the submission of the group's DKG result. The members
+// of the seed group and of groups with DKG results submitted without a group
+// selection, as well as of groups which are not registered, are not known.
 func (c *localChain) GetGroupMembers(groupPublicKey []byte) (
 	[]relaychain.StakerAddress,
 	error,
 ) {
-	return nil, nil // no-op
+	c.groupsMutex.Lock()
+	defer c.groupsMutex.Unlock()
+
+	for _, group := range c.groups {
+		if bytes.Compare(group.groupPublicKey, groupPublicKey) == 0 {
+			return group.members, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *localChain) PastGroupRegistrations(
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*event.GroupRegistration, error) {
+	c.groupsMutex.Lock()
+	defer c.groupsMutex.Unlock()
+
+	groupRegistrations := make([]*event.GroupRegistration, 0)
+	for _, group := range c.groups {
+		if group.registrationBlockHeight < fromBlock ||
+			group.registrationBlockHeight > toBlock {
+			continue
+		}
+
+		groupRegistrations = append(
+			groupRegistrations,
+			&event.GroupRegistration{
+				GroupPublicKey: group.groupPublicKey,
+				BlockNumber:    group.registrationBlockHeight,
+			},
+		)
+	}
+
+	return groupRegistrations, nil
+}
+
+// GetGroupMemberRewards returns the reward accumulated by each member of the
+// group for relay entries submitted on time.
+func (c *localChain) GetGroupMemberRewards(
+	groupPublicKey []byte,
+) (*big.Int, error) {
+	c.groupsMutex.Lock()
+	defer c.groupsMutex.Unlock()
+
+	for _, group := range c.groups {
+		if bytes.Compare(group.groupPublicKey, groupPublicKey) == 0 {
+			if group.memberReward == nil {
+				return big.NewInt(0), nil
+			}
+			return new(big.Int).Set(group.memberReward), nil
+		}
+	}
+
+	return nil, fmt.Errorf("group [0x%x] is not registered", groupPublicKey)
+}
+
+// addGroupMemberReward adds the given reward to the reward of each member of
+// the group.
+func (c *localChain) addGroupMemberReward(
+	groupPublicKey []byte,
+	reward *big.Int,
+) {
+	c.groupsMutex.Lock()
+	defer c.groupsMutex.Unlock()
+
+	for i, group := range c.groups {
+		if bytes.Compare(group.groupPublicKey, groupPublicKey) == 0 {
+			memberReward := new(big.Int).Set(reward)
+			if group.memberReward != nil {
+				memberReward.Add(memberReward, group.memberReward)
+			}
+			c.groups[i].memberReward = memberReward
+			return
+		}
+	}
+}
+
+func (c *localChain) HasWithdrawnRewards(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+) (bool, error) {
+	c.withdrawnRewardsMutex.Lock()
+	defer c.withdrawnRewardsMutex.Unlock()
+
+	return c.withdrawnRewards[withdrawalKey(operator, groupPublicKey)], nil
+}
+
+// WithdrawGroupMemberRewards records the withdrawal if the group with the
+// given public key is stale and the operator has not withdrawn its rewards
+// for that group yet.
+func (c *localChain) WithdrawGroupMemberRewards(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+) error {
+	isRegistered, err := c.IsGroupRegistered(groupPublicKey)
+	if err != nil {
+		return err
+	}
+	if !isRegistered {
+		return fmt.Errorf("group [0x%x] is not registered", groupPublicKey)
+	}
+
+	isStale, err := c.IsStaleGroup(groupPublicKey)
+	if err != nil {
+		return err
+	}
+	if !isStale {
+		return fmt.Errorf("group [0x%x] is not stale", groupPublicKey)
+	}
+
+	c.withdrawnRewardsMutex.Lock()
+	defer c.withdrawnRewardsMutex.Unlock()
+
+	key := withdrawalKey(operator, groupPublicKey)
+	if c.withdrawnRewards[key] {
+		return fmt.Errorf(
+			"rewards of group [0x%x] already withdrawn by operator [0x%x]",
+			groupPublicKey,
+			operator,
+		)
+	}
+
+	c.withdrawnRewards[key] = true
+
+	return nil
+}
+
+func withdrawalKey(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+) string {
+	return fmt.Sprintf("%x-%x", operator, groupPublicKey)
+}
+
+func (c *localChain) CurrentGasPrice() (*big.Int, error) {
+	return new(big.Int).Set(gasPrice), nil
 }
 
 func (c *localChain) IsGroupRegistered(groupPublicKey []byte) (bool, error) {
+	c.groupsMutex.Lock()
+	defer c.groupsMutex.Unlock()
+
 	for _, group := range c.groups {
 		if bytes.Compare(group.groupPublicKey, groupPublicKey) == 0 {
 			return true, nil
@@ -373,6 +961,7 @@ func (c *localChain) SubmitDKGResult(
 	participantIndex relaychain.GroupMemberIndex,
 	resultToPublish *relaychain.DKGResult,
 	signatures map[relaychain.GroupMemberIndex][]byte,
+	publicationBlock uint64,
 ) *async.EventDKGResultSubmissionPromise {
 	dkgResultPublicationPromise := &async.EventDKGResultSubmissionPromise{}
 
@@ -398,11 +987,19 @@ func (c *localChain) SubmitDKGResult(
 		BlockNumber:    currentBlock,
 	}
 
+	c.ticketsMutex.Lock()
+	members := c.selectedParticipants()
+	c.ticketsMutex.Unlock()
+
 	myGroup := localGroup{
 		groupPublicKey:          resultToPublish.GroupPublicKey,
 		registrationBlockHeight: currentBlock,
+		members:                 members,
+		memberReward:            big.NewInt(0),
 	}
+	c.groupsMutex.Lock()
 	c.groups = append(c.groups, myGroup)
+	c.groupsMutex.Unlock()
 	c.lastSubmittedDKGResult = resultToPublish
 	c.lastSubmittedDKGResultSignatures = signatures
 
@@ -411,6 +1008,10 @@ func (c *localChain) SubmitDKGResult(
 		BlockNumber:    currentBlock,
 	}
 
+	c.groupSelectionMutex.Lock()
+	c.groupSelectionInProgress = false
+	c.groupSelectionMutex.Unlock()
+
 	c.handlerMutex.Lock()
 	for _, handler := range c.resultSubmissionHandlers {
 		go func(handler func(*event.DKGResultSubmission), dkgResultPublication *event.DKGResultSubmission) {
@@ -457,33 +1058,222 @@ func (c *localChain) GetLastDKGResult() (
 	return c.lastSubmittedDKGResult, c.lastSubmittedDKGResultSignatures
 }
 
+// ReportRelayEntryTimeout records the report of a relay entry timeout. If the
+// entry has been requested with RequestRelayEntry, the report is accepted
+// only once the entry timed out. The group selected for the entry is then
+// terminated, a part of the minimum stake is seized from each of its members
+// and the entry is requested from another active group if there is any.
+// Reports are recorded even if no entry has been requested.
 func (c *localChain) ReportRelayEntryTimeout() error {
-	c.relayEntryTimeoutReportsMutex.Lock()
-	defer c.relayEntryTimeoutReportsMutex.Unlock()
-
 	currentBlock, err := c.blockCounter.CurrentBlock()
 	if err != nil {
 		return err
 	}
 
+	c.requestMutex.Lock()
+	defer c.requestMutex.Unlock()
+
+	if request := c.currentRequest; request != nil {
+		// As on-chain, the report is mined in the next block at the
+		// earliest.
+		if !c.hasEntryTimedOut(request, currentBlock+1) {
+			return fmt.Errorf("relay entry did not time out")
+		}
+
+		c.punishGroup(request.GroupPublicKey)
+
+		c.currentRequest = nil
+		if _, err := c.signRelayEntry(
+			request.RequestID,
+			request.PreviousEntry,
+		); err != nil {
+			logger.Warningf("relay entry not requested again: [%v]", err)
+		}
+	}
+
+	c.relayEntryTimeoutReportsMutex.Lock()
 	c.relayEntryTimeoutReports = append(c.relayEntryTimeoutReports, currentBlock)
+	c.relayEntryTimeoutReportsMutex.Unlock()
+
 	return nil
 }
 
+// punishGroup terminates the group which did not submit the relay entry on
+// time and seizes the relay entry timeout punishment from each of its
+// members.
+func (c *localChain) punishGroup(groupPublicKey []byte) {
+	c.groupsMutex.Lock()
+	members := make([]relaychain.StakerAddress, 0)
+	for i, group := range c.groups {
+		if bytes.Compare(group.groupPublicKey, groupPublicKey) == 0 {
+			c.groups[i].terminated = true
+			members = group.members
+			break
+		}
+	}
+	c.groupsMutex.Unlock()
+
+	punishment := c.relayEntryTimeoutPunishmentAmount()
+	for _, member := range members {
+		address := memberStakerAddress(member)
+		seized, err := c.stakeMonitor.SeizeTokens(address, punishment)
+		if err != nil {
+			logger.Errorf(
+				"could not seize tokens of member [%v]: [%v]",
+				address,
+				err,
+			)
+			continue
+		}
+
+		logger.Infof(
+			"seized [%v] tokens of member [%v] of group [0x%x]",
+			seized,
+			address,
+			groupPublicKey,
+		)
+	}
+}
+
+// memberStakerAddress returns the address the stake of the group member is
+// kept under. Group members are either addresses of local stakers or 20-byte
+// Ethereum addresses, e.g. of stakers of the development chain.
+func memberStakerAddress(member relaychain.StakerAddress) string {
+	if common.IsHexAddress(string(member)) {
+		return string(member)
+	}
+
+	return common.BytesToAddress(member).Hex()
+}
+
+// ReportUnauthorizedSigning records the report if the group with the given
+// public key is registered and the signature is a valid signature over the
+// address of the local chain operator made with the group private key, the
+// same way the operator contract verifies it for the transaction sender.
+func (c *localChain) ReportUnauthorizedSigning(
+	groupPublicKey []byte,
+	signedOperatorAddress []byte,
+) error {
+	return c.ReportUnauthorizedSigningAs(
+		c.operatorAddress(),
+		groupPublicKey,
+		signedOperatorAddress,
+	)
+}
+
+// ReportUnauthorizedSigningAs records the report if the group with the given
+// public key is registered and the signature is a valid signature over the
+// given operator address made with the group private key.
+func (c *localChain) ReportUnauthorizedSigningAs(
+	operator relaychain.StakerAddress,
+	groupPublicKey []byte,
+	signedOperatorAddress []byte,
+) error {
+	isRegistered, err := c.IsGroupRegistered(groupPublicKey)
+	if err != nil {
+		return err
+	}
+	if !isRegistered {
+		return fmt.Errorf("group [0x%x] is not registered", groupPublicKey)
+	}
+
+	publicKey := new(bn256.G2)
+	if _, err := publicKey.Unmarshal(groupPublicKey); err != nil {
+		return fmt.Errorf(
+			"could not unmarshal group public key: [%v]",
+			err,
+		)
+	}
+
+	signature := new(bn256.G1)
+	if _, err := signature.Unmarshal(signedOperatorAddress); err != nil {
+		return fmt.Errorf("could not unmarshal signature: [%v]", err)
+	}
+
+	if !bls.Verify(publicKey, operator, signature) {
+		return fmt.Errorf(
+			"invalid signature of operator [%v] address",
+			memberStakerAddress(operator),
+		)
+	}
+
+	c.unauthorizedSigningReportsMutex.Lock()
+	defer c.unauthorizedSigningReportsMutex.Unlock()
+
+	c.unauthorizedSigningReports = append(
+		c.unauthorizedSigningReports,
+		groupPublicKey,
+	)
+
+	return nil
+}
+
+// operatorAddress returns the address of the local chain operator in the
+// form of the address of its local staker.
+func (c *localChain) operatorAddress() relaychain.StakerAddress {
+	signing := c.Signing()
+	return []byte(common.BytesToAddress(
+		signing.PublicKeyBytesToAddress(signing.PublicKey()),
+	).Hex())
+}
+
+func (c *localChain) GetUnauthorizedSigningReports() [][]byte {
+	c.unauthorizedSigningReportsMutex.Lock()
+	defer c.unauthorizedSigningReportsMutex.Unlock()
+
+	reports := make([][]byte, len(c.unauthorizedSigningReports))
+	copy(reports, c.unauthorizedSigningReports)
+
+	return reports
+}
+
+// IsEntryInProgress returns true if an entry has been requested with
+// RequestRelayEntry and it has been neither submitted nor reported as timed
+// out yet.
 func (c *localChain) IsEntryInProgress() (bool, error) {
-	panic("not implemented")
+	c.requestMutex.Lock()
+	defer c.requestMutex.Unlock()
+
+	return c.currentRequest != nil, nil
 }
 
+// CurrentRequestStartBlock returns the block at which the entry in progress
+// has been requested or zero if there is no entry in progress.
 func (c *localChain) CurrentRequestStartBlock() (*big.Int, error) {
-	panic("not implemented")
+	c.requestMutex.Lock()
+	defer c.requestMutex.Unlock()
+
+	if c.currentRequest == nil {
+		return big.NewInt(0), nil
+	}
+
+	return new(big.Int).SetUint64(c.currentRequest.BlockNumber), nil
 }
 
+// CurrentRequestPreviousEntry returns the previous entry of the entry in
+// progress or nil if there is no entry in progress.
 func (c *localChain) CurrentRequestPreviousEntry() ([]byte, error) {
-	panic("not implemented")
+	c.requestMutex.Lock()
+	defer c.requestMutex.Unlock()
+
+	if c.currentRequest == nil {
+		return nil, nil
+	}
+
+	return c.currentRequest.PreviousEntry, nil
 }
 
+// CurrentRequestGroupPublicKey returns the public key of the group selected
+// to generate the entry in progress or nil if there is no entry in progress.
 func (c *localChain) CurrentRequestGroupPublicKey() ([]byte, error) {
-	panic("not implemented")
+	c.requestMutex.Lock()
+	defer c.requestMutex.Unlock()
+
+	if c.currentRequest == nil {
+		return nil, nil
+	}
+
+	return c.currentRequest.GroupPublicKey, nil
 }
 
 func (c *localChain) GetRelayEntryTimeoutReports() []uint64 {
@@ -1,7 +1,11 @@
 package local
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	crand "crypto/rand"
 	"fmt"
 	"math/big"
 	"reflect"
@@ -9,9 +13,12 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
+	"github.com/keep-network/keep-core/pkg/bls"
 
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	relayconfig "github.com/keep-network/keep-core/pkg/beacon/relay/config"
 )
 
 func TestSubmitTicketAndGetSelectedParticipants(t *testing.T) {
@@ -221,7 +228,7 @@ func TestLocalOnGroupRegistered(t *testing.T) {
 		4: []byte{104},
 	}
 
-	chainHandle.SubmitDKGResult(memberIndex, dkgResult, signatures)
+	chainHandle.SubmitDKGResult(memberIndex, dkgResult, signatures, 0)
 
 	expectedGroupRegistrationEvent := &event.GroupRegistration{
 		GroupPublicKey: groupPublicKey,
@@ -270,7 +277,7 @@ func TestLocalOnGroupRegisteredUnsubscribed(t *testing.T) {
 		4: []byte{104},
 	}
 
-	chainHandle.SubmitDKGResult(memberIndex, dkgResult, signatures)
+	chainHandle.SubmitDKGResult(memberIndex, dkgResult, signatures, 0)
 
 	select {
 	case event := <-eventFired:
@@ -309,7 +316,7 @@ func TestLocalOnDKGResultSubmitted(t *testing.T) {
 		4: []byte{104},
 	}
 
-	chainHandle.SubmitDKGResult(memberIndex, dkgResult, signatures)
+	chainHandle.SubmitDKGResult(memberIndex, dkgResult, signatures, 0)
 
 	expectedResultSubmissionEvent := &event.DKGResultSubmission{
 		MemberIndex:    uint32(memberIndex),
@@ -359,7 +366,7 @@ func TestLocalOnDKGResultSubmittedUnsubscribed(t *testing.T) {
 		4: []byte{104},
 	}
 
-	chainHandle.SubmitDKGResult(memberIndex, dkgResult, signatures)
+	chainHandle.SubmitDKGResult(memberIndex, dkgResult, signatures, 0)
 
 	select {
 	case event := <-eventFired:
@@ -523,6 +530,7 @@ func TestLocalIsGroupStale(t *testing.T) {
 	}
 
 	availableGroups := []localGroup{group1, group2, group3}
+	relayConfig := &relayconfig.Chain{RelayEntryTimeout: 8}
 
 	var tests = map[string]struct {
 		group           localGroup
@@ -556,7 +564,7 @@ func TestLocalIsGroupStale(t *testing.T) {
 			},
 			simulatedHeight: group3.registrationBlockHeight +
 				groupActiveTime +
-				relayRequestTimeout +
+				relayConfig.RelayEntryTimeout +
 				1,
 			expectedResult: true,
 		},
@@ -566,7 +574,7 @@ func TestLocalIsGroupStale(t *testing.T) {
 			},
 			simulatedHeight: group2.registrationBlockHeight +
 				groupActiveTime +
-				relayRequestTimeout,
+				relayConfig.RelayEntryTimeout,
 			expectedResult: false,
 		},
 	}
@@ -574,8 +582,10 @@ func TestLocalIsGroupStale(t *testing.T) {
 	for testName, test := range tests {
 		t.Run(testName, func(t *testing.T) {
 			localChain := &localChain{
+				relayConfig:     relayConfig,
+				groupActiveTime: groupActiveTime,
 				groups:          availableGroups,
-				simulatedHeight: test.simulatedHeight,
+				blockCounter:    newTestBlockCounter(test.simulatedHeight),
 			}
 			chainHandle := localChain.ThresholdRelay()
 			actualResult, err := chainHandle.IsStaleGroup(test.group.groupPublicKey)
@@ -607,7 +617,7 @@ func TestLocalSubmitDKGResult(t *testing.T) {
 		4: []byte{104},
 	}
 
-	chainHandle.SubmitDKGResult(memberIndex, result, signatures)
+	chainHandle.SubmitDKGResult(memberIndex, result, signatures, 0)
 	groupRegistered, err := chainHandle.IsGroupRegistered(result.GroupPublicKey)
 	if err != nil {
 		t.Fatal(err)
@@ -691,6 +701,7 @@ func TestLocalSubmitDKGResultWithSignatures(t *testing.T) {
 				memberIndex,
 				result,
 				test.signatures,
+				0,
 			)
 			promise.OnComplete(func(event *event.DKGResultSubmission, err error) {
 				errorChan <- err
@@ -790,3 +801,776 @@ func TestNextGroupIndex(t *testing.T) {
 		})
 	}
 }
+
+func TestLocalReportUnauthorizedSigning(t *testing.T) {
+	chainHandle := Connect(10, 4, big.NewInt(200)).(*localChain)
+
+	groupPrivateKey := big.NewInt(1234567890)
+	groupPublicKey := new(bn256.G2).ScalarBaseMult(groupPrivateKey).Marshal()
+
+	dkgResult := &relaychain.DKGResult{GroupPublicKey: groupPublicKey}
+	signatures := map[relaychain.GroupMemberIndex][]byte{
+		1: []byte{101},
+		2: []byte{102},
+		3: []byte{103},
+		4: []byte{104},
+	}
+	chainHandle.SubmitDKGResult(1, dkgResult, signatures, 0)
+
+	operatorAddress := chainHandle.operatorAddress()
+
+	var tests = map[string]struct {
+		groupPublicKey        []byte
+		signedOperatorAddress []byte
+		expectError           bool
+	}{
+		"signature over the operator's address": {
+			groupPublicKey: groupPublicKey,
+			signedOperatorAddress: bls.Sign(
+				groupPrivateKey,
+				operatorAddress,
+			).Marshal(),
+		},
+		"signature over another address": {
+			groupPublicKey: groupPublicKey,
+			signedOperatorAddress: bls.Sign(
+				groupPrivateKey,
+				[]byte("0x65ea55c1f10ed31b06162c8b3f4b575833c92cb5"),
+			).Marshal(),
+			expectError: true,
+		},
+		"signature made with another key": {
+			groupPublicKey: groupPublicKey,
+			signedOperatorAddress: bls.Sign(
+				big.NewInt(987654321),
+				operatorAddress,
+			).Marshal(),
+			expectError: true,
+		},
+		"invalid signature": {
+			groupPublicKey:        groupPublicKey,
+			signedOperatorAddress: []byte("signature"),
+			expectError:           true,
+		},
+		"not registered group": {
+			groupPublicKey: []byte("unknown group"),
+			signedOperatorAddress: bls.Sign(
+				groupPrivateKey,
+				operatorAddress,
+			).Marshal(),
+			expectError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := chainHandle.ReportUnauthorizedSigning(
+				test.groupPublicKey,
+				test.signedOperatorAddress,
+			)
+			if test.expectError != (err != nil) {
+				t.Errorf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectError,
+					err,
+				)
+			}
+		})
+	}
+
+	expectedReports := [][]byte{groupPublicKey}
+	reports := chainHandle.GetUnauthorizedSigningReports()
+	if !reflect.DeepEqual(expectedReports, reports) {
+		t.Errorf(
+			"unexpected reports\nexpected: [%v]\nactual:   [%v]",
+			expectedReports,
+			reports,
+		)
+	}
+
+	// Modifying reports returned to the caller does not modify reports
+	// recorded by the chain.
+	reports[0] = nil
+	if !reflect.DeepEqual(
+		expectedReports,
+		chainHandle.GetUnauthorizedSigningReports(),
+	) {
+		t.Errorf("reports recorded by the chain modified by the caller")
+	}
+
+	// Reports are verified against the address of the given operator.
+	anotherOperator := []byte("0x65ea55c1f10ed31b06162c8b3f4b575833c92cb5")
+	err := chainHandle.ReportUnauthorizedSigningAs(
+		anotherOperator,
+		groupPublicKey,
+		bls.Sign(groupPrivateKey, anotherOperator).Marshal(),
+	)
+	if err != nil {
+		t.Errorf("unexpected error [%v]", err)
+	}
+}
+
+func TestLocalWithdrawGroupMemberRewards(t *testing.T) {
+	operator := relaychain.StakerAddress("operator")
+	group := localGroup{
+		groupPublicKey:          []byte{'g'},
+		registrationBlockHeight: 0,
+	}
+
+	relayConfig := &relayconfig.Chain{RelayEntryTimeout: 8}
+	blockCounter := newTestBlockCounter(1)
+
+	chainHandle := &localChain{
+		relayConfig:      relayConfig,
+		groupActiveTime:  groupActiveTime,
+		groups:           []localGroup{group},
+		withdrawnRewards: make(map[string]bool),
+		blockCounter:     blockCounter,
+	}
+
+	err := chainHandle.WithdrawGroupMemberRewards(operator, []byte{'z'})
+	if err == nil {
+		t.Errorf("expected an error for not registered group")
+	}
+
+	err = chainHandle.WithdrawGroupMemberRewards(operator, group.groupPublicKey)
+	if err == nil {
+		t.Errorf("expected an error for not stale group")
+	}
+
+	blockCounter.advance(
+		group.registrationBlockHeight +
+			groupActiveTime +
+			relayConfig.RelayEntryTimeout +
+			1,
+	)
+
+	err = chainHandle.WithdrawGroupMemberRewards(operator, group.groupPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hasWithdrawn, err := chainHandle.HasWithdrawnRewards(
+		operator,
+		group.groupPublicKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasWithdrawn {
+		t.Errorf("rewards withdrawal not recorded")
+	}
+
+	hasWithdrawn, err = chainHandle.HasWithdrawnRewards(
+		relaychain.StakerAddress("other operator"),
+		group.groupPublicKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasWithdrawn {
+		t.Errorf("rewards withdrawal recorded for another operator")
+	}
+}
+
+func TestLocalRequestRelayEntry(t *testing.T) {
+	localChain := ConnectWithConfig(
+		&Config{
+			GroupSize:             5,
+			HonestThreshold:       3,
+			MinimumStake:          big.NewInt(200),
+			BlockTime:             blockTime,
+			DKGContributionMargin: 100,
+		},
+		newTestOperatorKey(t),
+	)
+	chainHandle := localChain.ThresholdRelay()
+
+	if _, err := localChain.RequestRelayEntry(); err == nil {
+		t.Fatal("expected an error when no groups are registered")
+	}
+
+	groupPublicKey := []byte{11}
+	chainHandle.SubmitDKGResult(
+		1,
+		&relaychain.DKGResult{GroupPublicKey: groupPublicKey},
+		map[relaychain.GroupMemberIndex][]byte{1: {101}, 2: {102}, 3: {103}},
+		0,
+	)
+
+	groupSelectionStarts := make(chan *event.GroupSelectionStart, 1)
+	_, err := chainHandle.OnGroupSelectionStarted(
+		func(groupSelectionStart *event.GroupSelectionStart) {
+			groupSelectionStarts <- groupSelectionStart
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request, err := localChain.RequestRelayEntry()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(request.GroupPublicKey, groupPublicKey) {
+		t.Errorf(
+			"unexpected group public key\nexpected: [%v]\nactual:   [%v]",
+			groupPublicKey,
+			request.GroupPublicKey,
+		)
+	}
+	if !reflect.DeepEqual(request.PreviousEntry, seedRelayEntry.Bytes()) {
+		t.Errorf(
+			"unexpected previous entry\nexpected: [%v]\nactual:   [%v]",
+			seedRelayEntry.Bytes(),
+			request.PreviousEntry,
+		)
+	}
+
+	isEntryInProgress, err := chainHandle.IsEntryInProgress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isEntryInProgress {
+		t.Errorf("entry should be in progress after the request")
+	}
+
+	if _, err := localChain.RequestRelayEntry(); err == nil {
+		t.Errorf("expected an error when an entry is in progress")
+	}
+
+	newEntry := big.NewInt(19)
+	chainHandle.SubmitRelayEntry(newEntry.Bytes())
+
+	isEntryInProgress, err = chainHandle.IsEntryInProgress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isEntryInProgress {
+		t.Errorf("entry should not be in progress after the submission")
+	}
+
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	select {
+	case groupSelectionStart := <-groupSelectionStarts:
+		if groupSelectionStart.NewEntry.Cmp(newEntry) != 0 {
+			t.Errorf(
+				"unexpected group selection seed\n"+
+					"expected: [%v]\nactual:   [%v]",
+				newEntry,
+				groupSelectionStart.NewEntry,
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal("expected group selection to start after the entry")
+	}
+}
+
+func TestLocalGenesis(t *testing.T) {
+	localChain := Connect(5, 3, big.NewInt(200))
+	chainHandle := localChain.ThresholdRelay()
+
+	if err := localChain.Genesis(); err != nil {
+		t.Fatal(err)
+	}
+
+	isGroupSelectionPossible, err := chainHandle.IsGroupSelectionPossible()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isGroupSelectionPossible {
+		t.Errorf("group selection should not be possible after genesis")
+	}
+
+	if err := localChain.Genesis(); err == nil {
+		t.Errorf("expected an error when group selection is in progress")
+	}
+
+	groupSelectionStarts, err := chainHandle.PastGroupSelectionStarts(0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groupSelectionStarts) != 1 {
+		t.Fatalf(
+			"unexpected number of group selection starts\n"+
+				"expected: [%v]\nactual:   [%v]",
+			1,
+			len(groupSelectionStarts),
+		)
+	}
+	if groupSelectionStarts[0].NewEntry.Cmp(seedRelayEntry) != 0 {
+		t.Errorf(
+			"unexpected group selection seed\nexpected: [%v]\nactual:   [%v]",
+			seedRelayEntry,
+			groupSelectionStarts[0].NewEntry,
+		)
+	}
+
+	chainHandle.SubmitDKGResult(
+		1,
+		&relaychain.DKGResult{GroupPublicKey: []byte{11}},
+		map[relaychain.GroupMemberIndex][]byte{1: {101}, 2: {102}, 3: {103}},
+		0,
+	)
+
+	isGroupSelectionPossible, err = chainHandle.IsGroupSelectionPossible()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isGroupSelectionPossible {
+		t.Errorf("group selection should be possible after DKG result")
+	}
+}
+
+func TestLocalGroupSelectionAfterRelayEntry(t *testing.T) {
+	var tests = map[string]struct {
+		dkgContributionMargin         uint64
+		expectedGroupSelectionStarted bool
+		expectedDKGFeePool            *big.Int
+	}{
+		"contributions do not cover the group creation fee": {
+			dkgContributionMargin:         1,
+			expectedGroupSelectionStarted: false,
+			expectedDKGFeePool:            big.NewInt(582000000000000),
+		},
+		"contributions cover the group creation fee": {
+			dkgContributionMargin:         100,
+			expectedGroupSelectionStarted: true,
+			expectedDKGFeePool:            big.NewInt(0),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			localChain := ConnectWithConfig(
+				&Config{
+					GroupSize:             5,
+					HonestThreshold:       3,
+					MinimumStake:          big.NewInt(200),
+					BlockTime:             blockTime,
+					SeedGroup:             true,
+					DKGContributionMargin: test.dkgContributionMargin,
+				},
+				newTestOperatorKey(t),
+			)
+			chainHandle := localChain.ThresholdRelay()
+
+			if _, err := localChain.RequestRelayEntry(); err != nil {
+				t.Fatal(err)
+			}
+
+			chainHandle.SubmitRelayEntry(big.NewInt(19).Bytes())
+
+			isGroupSelectionPossible, err := chainHandle.IsGroupSelectionPossible()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if isGroupSelectionPossible != !test.expectedGroupSelectionStarted {
+				t.Errorf(
+					"unexpected group selection start\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedGroupSelectionStarted,
+					!isGroupSelectionPossible,
+				)
+			}
+
+			dkgFeePool := localChain.GetDKGFeePool()
+			if dkgFeePool.Cmp(test.expectedDKGFeePool) != 0 {
+				t.Errorf(
+					"unexpected DKG fee pool\nexpected: [%v]\nactual:   [%v]",
+					test.expectedDKGFeePool,
+					dkgFeePool,
+				)
+			}
+		})
+	}
+}
+
+func TestLocalRequestRelayEntryFromActiveGroups(t *testing.T) {
+	expiredGroup := localGroup{
+		groupPublicKey:          []byte{'e'},
+		registrationBlockHeight: 1,
+	}
+	terminatedGroup := localGroup{
+		groupPublicKey:          []byte{'t'},
+		registrationBlockHeight: 10,
+		terminated:              true,
+	}
+	activeGroup := localGroup{
+		groupPublicKey:          []byte{'a'},
+		registrationBlockHeight: 10,
+	}
+
+	var tests = map[string]struct {
+		groups                 []localGroup
+		expectedGroupPublicKey []byte
+	}{
+		"one active group": {
+			groups: []localGroup{
+				expiredGroup,
+				terminatedGroup,
+				activeGroup,
+			},
+			expectedGroupPublicKey: activeGroup.groupPublicKey,
+		},
+		"no active groups": {
+			groups: []localGroup{
+				expiredGroup,
+				terminatedGroup,
+			},
+			expectedGroupPublicKey: nil,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			localChain := Connect(5, 3, big.NewInt(200)).(*localChain)
+			localChain.blockCounter = newTestBlockCounter(
+				expiredGroup.registrationBlockHeight + groupActiveTime + 1,
+			)
+			localChain.groups = test.groups
+
+			request, err := localChain.RequestRelayEntry()
+			if test.expectedGroupPublicKey == nil {
+				if err == nil {
+					t.Fatal("expected an error when there are no active groups")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(
+				request.GroupPublicKey,
+				test.expectedGroupPublicKey,
+			) {
+				t.Errorf(
+					"unexpected group public key\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedGroupPublicKey,
+					request.GroupPublicKey,
+				)
+			}
+		})
+	}
+}
+
+func TestLocalRelayEntryRewards(t *testing.T) {
+	localChain := Connect(5, 3, big.NewInt(200)).(*localChain)
+	blockCounter := newTestBlockCounter(1)
+	localChain.blockCounter = blockCounter
+	localChain.groups = []localGroup{{
+		groupPublicKey:          []byte{'g'},
+		registrationBlockHeight: 1,
+		memberReward:            big.NewInt(0),
+	}}
+
+	request, err := localChain.RequestRelayEntry()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blockCounter.advance(request.BlockNumber + 8)
+
+	submitted := make(chan error, 1)
+	localChain.SubmitRelayEntry(big.NewInt(19).Bytes()).OnComplete(
+		func(entry *event.EntrySubmitted, err error) {
+			submitted <- err
+		},
+	)
+	if err := <-submitted; err != nil {
+		t.Fatal(err)
+	}
+
+	expectedReward := big.NewInt(284444444444444)
+	reward, err := localChain.GetGroupMemberRewards(request.GroupPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reward.Cmp(expectedReward) != 0 {
+		t.Errorf(
+			"unexpected member reward\nexpected: [%v]\nactual:   [%v]",
+			expectedReward,
+			reward,
+		)
+	}
+
+	request, err = localChain.RequestRelayEntry()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blockCounter.advance(
+		request.BlockNumber + localChain.relayConfig.RelayEntryTimeout + 1,
+	)
+
+	localChain.SubmitRelayEntry(big.NewInt(20).Bytes()).OnComplete(
+		func(entry *event.EntrySubmitted, err error) {
+			submitted <- err
+		},
+	)
+	if err := <-submitted; err == nil {
+		t.Errorf("expected an error when the entry timed out")
+	}
+
+	reward, err = localChain.GetGroupMemberRewards(request.GroupPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reward.Cmp(expectedReward) != 0 {
+		t.Errorf(
+			"unexpected member reward after the timeout\n"+
+				"expected: [%v]\nactual:   [%v]",
+			expectedReward,
+			reward,
+		)
+	}
+}
+
+func TestLocalRelayRequestIDs(t *testing.T) {
+	localChain := Connect(5, 3, big.NewInt(200)).(*localChain)
+	localChain.groups = []localGroup{{
+		groupPublicKey:          []byte{'g'},
+		registrationBlockHeight: 0,
+		memberReward:            big.NewInt(0),
+	}}
+
+	for _, expectedRequestID := range []int64{1, 2} {
+		request, err := localChain.RequestRelayEntry()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if request.RequestID.Cmp(big.NewInt(expectedRequestID)) != 0 {
+			t.Errorf(
+				"unexpected request id\nexpected: [%v]\nactual:   [%v]",
+				expectedRequestID,
+				request.RequestID,
+			)
+		}
+
+		submitted := make(chan *event.EntrySubmitted, 1)
+		localChain.SubmitRelayEntry(big.NewInt(19).Bytes()).OnSuccess(
+			func(entry *event.EntrySubmitted) {
+				submitted <- entry
+			},
+		)
+		if entry := <-submitted; entry.RequestID.Cmp(request.RequestID) != 0 {
+			t.Errorf(
+				"unexpected request id of the entry\nexpected: [%v]\nactual:   [%v]",
+				request.RequestID,
+				entry.RequestID,
+			)
+		}
+	}
+}
+
+func TestLocalReportRelayEntryTimeout(t *testing.T) {
+	minimumStake := big.NewInt(200)
+	member1 := "0x65ea55c1f10491038425725dc00dffeab2a1e28a"
+	member2 := "0x524f2e0176350d950fa630d9a5a59a0a190daf48"
+
+	localChain := Connect(5, 3, minimumStake).(*localChain)
+	blockCounter := newTestBlockCounter(1)
+	localChain.blockCounter = blockCounter
+	localChain.groupActiveTime = 100
+	localChain.groups = []localGroup{
+		{
+			groupPublicKey:          []byte{'a'},
+			registrationBlockHeight: 1,
+			members: []relaychain.StakerAddress{
+				relaychain.StakerAddress(member1),
+			},
+		},
+		{
+			groupPublicKey:          []byte{'b'},
+			registrationBlockHeight: 1,
+			members: []relaychain.StakerAddress{
+				relaychain.StakerAddress(member2),
+			},
+		},
+	}
+
+	for _, member := range []string{member1, member2} {
+		if err := localChain.stakeMonitor.StakeTokens(member); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	request, err := localChain.RequestRelayEntry()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := localChain.ReportRelayEntryTimeout(); err == nil {
+		t.Errorf("expected an error when the entry did not time out")
+	}
+
+	blockCounter.advance(
+		request.BlockNumber + localChain.relayConfig.RelayEntryTimeout + 1,
+	)
+
+	if err := localChain.ReportRelayEntryTimeout(); err != nil {
+		t.Fatal(err)
+	}
+
+	punishedMember, otherMember := member1, member2
+	if bytes.Equal(request.GroupPublicKey, []byte{'b'}) {
+		punishedMember, otherMember = member2, member1
+	}
+
+	var stakeTests = map[string]struct {
+		member        string
+		expectedStake *big.Int
+	}{
+		"member of the group which timed out": {
+			member:        punishedMember,
+			expectedStake: big.NewInt(998),
+		},
+		"member of the other group": {
+			member:        otherMember,
+			expectedStake: big.NewInt(1000),
+		},
+	}
+
+	for testName, test := range stakeTests {
+		t.Run(testName, func(t *testing.T) {
+			staker, err := localChain.stakeMonitor.StakerFor(test.member)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stake, err := staker.Stake()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if stake.Cmp(test.expectedStake) != 0 {
+				t.Errorf(
+					"unexpected stake\nexpected: [%v]\nactual:   [%v]",
+					test.expectedStake,
+					stake,
+				)
+			}
+		})
+	}
+
+	groupPublicKey, err := localChain.CurrentRequestGroupPublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(groupPublicKey, request.GroupPublicKey) {
+		t.Errorf("entry should be requested again from the other group")
+	}
+
+	previousEntry, err := localChain.CurrentRequestPreviousEntry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(previousEntry, request.PreviousEntry) {
+		t.Errorf(
+			"unexpected previous entry\nexpected: [%v]\nactual:   [%v]",
+			request.PreviousEntry,
+			previousEntry,
+		)
+	}
+
+	repeatedRequestID := localChain.currentRequest.RequestID
+	if repeatedRequestID.Cmp(request.RequestID) != 0 {
+		t.Errorf(
+			"unexpected id of the repeated request\nexpected: [%v]\nactual:   [%v]",
+			request.RequestID,
+			repeatedRequestID,
+		)
+	}
+
+	blockCounter.advance(
+		request.BlockNumber + 2*(localChain.relayConfig.RelayEntryTimeout+1),
+	)
+
+	if err := localChain.ReportRelayEntryTimeout(); err != nil {
+		t.Fatal(err)
+	}
+
+	isEntryInProgress, err := localChain.IsEntryInProgress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isEntryInProgress {
+		t.Errorf("entry should not be in progress without active groups")
+	}
+
+	if len(localChain.GetRelayEntryTimeoutReports()) != 2 {
+		t.Errorf(
+			"unexpected number of timeout reports\n"+
+				"expected: [%v]\nactual:   [%v]",
+			2,
+			len(localChain.GetRelayEntryTimeoutReports()),
+		)
+	}
+}
+
+func TestFollowingBlockCounter(t *testing.T) {
+	heights := make(chan uint64)
+	defer close(heights)
+
+	blockCounter := FollowingBlockCounter(heights)
+
+	waiter, err := blockCounter.BlockHeightWaiter(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	heights <- 5
+
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	select {
+	case height := <-waiter:
+		if height != 3 {
+			t.Errorf(
+				"unexpected waited height\nexpected: [%v]\nactual:   [%v]",
+				3,
+				height,
+			)
+		}
+	case <-ctx.Done():
+		t.Fatal("expected waiter to be notified after skipped blocks")
+	}
+
+	heights <- 4
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if currentBlock != 5 {
+		t.Errorf(
+			"unexpected current block\nexpected: [%v]\nactual:   [%v]",
+			5,
+			currentBlock,
+		)
+	}
+}
+
+// newTestBlockCounter returns a block counter which stays at the given
+// height until advanced.
+func newTestBlockCounter(height uint64) *localBlockCounter {
+	return &localBlockCounter{
+		blockHeight: height,
+		waiters:     make(map[uint64][]chan uint64),
+	}
+}
+
+func newTestOperatorKey(t *testing.T) *ecdsa.PrivateKey {
+	operatorKey, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return operatorKey
+}
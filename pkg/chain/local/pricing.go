@@ -0,0 +1,151 @@
+package local
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+// Pricing parameters of the local chain are the same as the default ones of
+// the operator and service contracts.
+var (
+	entryVerificationGasEstimate = big.NewInt(280000)
+	dkgGasEstimate               = big.NewInt(1740000)
+	groupSelectionGasEstimate    = big.NewInt(200000)
+	groupMemberBaseReward        = big.NewInt(1000000000000000) // 1M Gwei
+	gasPriceCeiling              = big.NewInt(30000000000)      // 30 Gwei
+	baseCallbackGas              = big.NewInt(10226)
+	maxCallbackGas               = big.NewInt(2000000)
+)
+
+// dkgContributionMargin is the default fraction, in percent, of the group
+// creation fee included in the relay entry fee.
+var dkgContributionMargin = uint64(1)
+
+// relayEntryTimeoutPunishment is the fraction, in percent, of the minimum
+// stake seized from each member of a group which did not submit the relay
+// entry on time. It is the punishment of the first three months of the
+// contract's minimum stake schedule.
+var relayEntryTimeoutPunishment = int64(1)
+
+// delayFactorDecimals is the precision of the delay factor.
+var delayFactorDecimals = big.NewInt(1e16)
+
+// EntryFeeEstimate returns the fee a relay consumer has to pay for a new relay
+// entry with a callback using at most callbackGas gas. The fee is the sum of
+// the entry verification fee, the DKG contribution, the group profit fee and
+// the callback fee.
+func (c *localChain) EntryFeeEstimate(callbackGas *big.Int) (*big.Int, error) {
+	if callbackGas.Cmp(maxCallbackGas) > 0 {
+		return nil, fmt.Errorf(
+			"callback gas exceeds [%v] gas limit",
+			maxCallbackGas,
+		)
+	}
+
+	breakdown, err := c.EntryFeeBreakdown()
+	if err != nil {
+		return nil, err
+	}
+
+	callbackFee := big.NewInt(0)
+	if callbackGas.Sign() > 0 {
+		callbackFee = new(big.Int).Mul(
+			new(big.Int).Add(callbackGas, baseCallbackGas),
+			breakdown.GasPriceCeiling,
+		)
+	}
+
+	fee := new(big.Int).Add(
+		breakdown.EntryVerificationFee,
+		breakdown.DkgContributionFee,
+	)
+	fee.Add(fee, breakdown.GroupProfitFee)
+	fee.Add(fee, callbackFee)
+
+	return fee, nil
+}
+
+// EntryFeeBreakdown returns the components of the relay entry fee.
+func (c *localChain) EntryFeeBreakdown() (*chain.EntryFeeBreakdown, error) {
+	return &chain.EntryFeeBreakdown{
+		EntryVerificationFee: new(big.Int).Mul(
+			entryVerificationGasEstimate,
+			gasPriceCeiling,
+		),
+		DkgContributionFee: c.dkgContributionFee(),
+		GroupProfitFee:     c.groupProfitFee(),
+		GasPriceCeiling:    new(big.Int).Set(gasPriceCeiling),
+	}, nil
+}
+
+// groupCreationFee returns the fee covering the cost of the group selection
+// and of the DKG result submission.
+func groupCreationFee() *big.Int {
+	return new(big.Int).Mul(
+		new(big.Int).Add(dkgGasEstimate, groupSelectionGasEstimate),
+		gasPriceCeiling,
+	)
+}
+
+// dkgContributionFee returns the part of the relay entry fee added to the DKG
+// fee pool.
+func (c *localChain) dkgContributionFee() *big.Int {
+	contribution := new(big.Int).Mul(
+		groupCreationFee(),
+		new(big.Int).SetUint64(c.dkgContributionMargin),
+	)
+	return contribution.Div(contribution, big.NewInt(100))
+}
+
+// groupProfitFee returns the part of the relay entry fee paid to members of
+// the group which submitted the entry on time.
+func (c *localChain) groupProfitFee() *big.Int {
+	return new(big.Int).Mul(
+		groupMemberBaseReward,
+		big.NewInt(int64(c.relayConfig.GroupSize)),
+	)
+}
+
+// groupMemberReward returns the reward of a single group member for the relay
+// entry requested at requestBlock and submitted at submissionBlock. The base
+// reward is reduced by the delay factor, which is the square of the fraction
+// of the submission window remaining when the entry has been submitted.
+func (c *localChain) groupMemberReward(
+	requestBlock uint64,
+	submissionBlock uint64,
+) *big.Int {
+	deadlineBlock := requestBlock + c.relayConfig.RelayEntryTimeout + 1
+	submissionStartBlock := requestBlock + 1
+
+	if submissionBlock < submissionStartBlock {
+		submissionBlock = submissionStartBlock
+	}
+	if submissionBlock > deadlineBlock {
+		submissionBlock = deadlineBlock
+	}
+
+	remainingBlocks := new(big.Int).SetUint64(deadlineBlock - submissionBlock)
+	submissionWindow := new(big.Int).SetUint64(
+		deadlineBlock - submissionStartBlock,
+	)
+
+	delayFactor := new(big.Int).Mul(remainingBlocks, delayFactorDecimals)
+	delayFactor.Div(delayFactor, submissionWindow)
+	delayFactor.Mul(delayFactor, delayFactor)
+	delayFactor.Div(delayFactor, delayFactorDecimals)
+
+	reward := new(big.Int).Mul(groupMemberBaseReward, delayFactor)
+	return reward.Div(reward, delayFactorDecimals)
+}
+
+// relayEntryTimeoutPunishmentAmount returns the amount of tokens seized from
+// each member of a group which did not submit the relay entry on time.
+func (c *localChain) relayEntryTimeoutPunishmentAmount() *big.Int {
+	punishment := new(big.Int).Mul(
+		c.relayConfig.MinimumStake,
+		big.NewInt(relayEntryTimeoutPunishment),
+	)
+	return punishment.Div(punishment, big.NewInt(100))
+}
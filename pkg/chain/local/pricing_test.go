@@ -0,0 +1,145 @@
+package local
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestEntryFeeEstimate(t *testing.T) {
+	var tests = map[string]struct {
+		callbackGas   *big.Int
+		expectedFee   *big.Int
+		expectedError bool
+	}{
+		"no callback": {
+			callbackGas: big.NewInt(0),
+			expectedFee: big.NewInt(13982000000000000),
+		},
+		"callback": {
+			callbackGas: big.NewInt(100000),
+			expectedFee: big.NewInt(17288780000000000),
+		},
+		"callback gas exceeding the limit": {
+			callbackGas:   big.NewInt(2000001),
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			chain := Connect(5, 3, big.NewInt(200))
+
+			fee, err := chain.EntryFeeEstimate(test.callbackGas)
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if fee.Cmp(test.expectedFee) != 0 {
+				t.Errorf(
+					"unexpected fee\nexpected: [%v]\nactual:   [%v]",
+					test.expectedFee,
+					fee,
+				)
+			}
+		})
+	}
+}
+
+func TestEntryFeeBreakdown(t *testing.T) {
+	chain := ConnectWithConfig(
+		&Config{
+			GroupSize:             5,
+			HonestThreshold:       3,
+			MinimumStake:          big.NewInt(200),
+			BlockTime:             blockTime,
+			DKGContributionMargin: 10,
+		},
+		newTestOperatorKey(t),
+	)
+
+	breakdown, err := chain.EntryFeeBreakdown()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		expected *big.Int
+		actual   *big.Int
+	}{
+		"entry verification fee": {
+			expected: big.NewInt(8400000000000000),
+			actual:   breakdown.EntryVerificationFee,
+		},
+		"DKG contribution fee": {
+			expected: big.NewInt(5820000000000000),
+			actual:   breakdown.DkgContributionFee,
+		},
+		"group profit fee": {
+			expected: big.NewInt(5000000000000000),
+			actual:   breakdown.GroupProfitFee,
+		},
+		"gas price ceiling": {
+			expected: big.NewInt(30000000000),
+			actual:   breakdown.GasPriceCeiling,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if test.expected.Cmp(test.actual) != 0 {
+				t.Errorf(
+					"\nexpected: [%v]\nactual:   [%v]",
+					test.expected,
+					test.actual,
+				)
+			}
+		})
+	}
+}
+
+func TestGroupMemberReward(t *testing.T) {
+	requestBlock := uint64(10)
+
+	var tests = map[string]struct {
+		submissionBlock uint64
+		expectedReward  *big.Int
+	}{
+		"submitted in the request block": {
+			submissionBlock: requestBlock,
+			expectedReward:  big.NewInt(1000000000000000),
+		},
+		"submitted in the first block after the request": {
+			submissionBlock: requestBlock + 1,
+			expectedReward:  big.NewInt(1000000000000000),
+		},
+		"submitted in the middle of the submission window": {
+			submissionBlock: requestBlock + 8,
+			expectedReward:  big.NewInt(284444444444444),
+		},
+		"submitted in the last block before the timeout": {
+			submissionBlock: requestBlock + 15,
+			expectedReward:  big.NewInt(4444444444444),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			chain := Connect(5, 3, big.NewInt(200)).(*localChain)
+
+			reward := chain.groupMemberReward(requestBlock, test.submissionBlock)
+			if reward.Cmp(test.expectedReward) != 0 {
+				t.Errorf(
+					"unexpected reward\nexpected: [%v]\nactual:   [%v]",
+					test.expectedReward,
+					reward,
+				)
+			}
+		})
+	}
+}
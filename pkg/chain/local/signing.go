@@ -8,12 +8,20 @@ import (
 	"encoding/asn1"
 	"fmt"
 	"math/big"
+
+	"github.com/keep-network/keep-core/pkg/chain"
 )
 
 type localSigning struct {
 	operatorKey *ecdsa.PrivateKey
 }
 
+// NewSigning creates a local implementation of chain.Signing for the given
+// operator key. Addresses of the local chain are serialized public keys.
+func NewSigning(operatorKey *ecdsa.PrivateKey) chain.Signing {
+	return &localSigning{operatorKey}
+}
+
 type ecdsaSignature struct {
 	R, S *big.Int
 }
@@ -3,17 +3,41 @@ package local
 import (
 	"fmt"
 	"math/big"
+	"math/rand"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	relaychain "github.com/keep-network/keep-core/pkg/beacon/relay/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/subscription"
 )
 
 // StakeMonitor implements `chain.StakeMonitor` interface and works
 // as a local stub for testing.
 type StakeMonitor struct {
 	minimumStake *big.Int
+
+	// blockCounter provides block numbers of stake events. If it is not set,
+	// events are emitted with a zero block number.
+	blockCounter chain.BlockCounter
+
+	stakersMutex sync.Mutex
 	stakers      []*localStaker
+	// key is the operator address, value is the expiration of the lock of
+	// each lock creator
+	locks map[string]map[string]*big.Int
+
+	handlerMutex sync.Mutex
+	handlers     map[int]*operatorHandler
+}
+
+// operatorHandler is a handler of stake events emitted for the operator with
+// the given address.
+type operatorHandler struct {
+	address string
+	handle  func(stakeEvent interface{})
 }
 
 // NewStakeMonitor creates a new instance of `StakeMonitor` test stub.
@@ -21,6 +45,8 @@ func NewStakeMonitor(minimumStake *big.Int) *StakeMonitor {
 	return &StakeMonitor{
 		minimumStake: minimumStake,
 		stakers:      make([]*localStaker, 0),
+		locks:        make(map[string]map[string]*big.Int),
+		handlers:     make(map[int]*operatorHandler),
 	}
 }
 
@@ -31,6 +57,9 @@ func (lsm *StakeMonitor) StakerFor(address string) (chain.Staker, error) {
 		return nil, fmt.Errorf("not a valid ethereum address: %v", address)
 	}
 
+	lsm.stakersMutex.Lock()
+	defer lsm.stakersMutex.Unlock()
+
 	if staker := lsm.findStakerByAddress(address); staker != nil {
 		return staker, nil
 	}
@@ -82,13 +111,14 @@ func (lsm *StakeMonitor) StakeTokens(address string) error {
 		return fmt.Errorf("invalid type of staker")
 	}
 
-	stakerLocal.stake = new(big.Int).Mul(big.NewInt(5), lsm.minimumStake)
+	stakerLocal.setStake(new(big.Int).Mul(big.NewInt(5), lsm.minimumStake))
 
 	return nil
 }
 
 // UnstakeTokens unstakes all tokens from the provided address so it can no
-// longer be a network operator.
+// longer be a network operator. Undelegated event is emitted with the current
+// time as the undelegation timestamp.
 func (lsm *StakeMonitor) UnstakeTokens(address string) error {
 	staker, err := lsm.StakerFor(address)
 	if err != nil {
@@ -100,14 +130,257 @@ func (lsm *StakeMonitor) UnstakeTokens(address string) error {
 		return fmt.Errorf("invalid type of staker")
 	}
 
-	stakerLocal.stake = big.NewInt(0)
+	stakerLocal.setStake(big.NewInt(0))
+
+	lsm.emit(address, &event.Undelegated{
+		Operator:      staker.Address(),
+		UndelegatedAt: big.NewInt(time.Now().Unix()),
+		BlockNumber:   lsm.currentBlock(),
+	})
+
+	return nil
+}
+
+// SlashTokens takes away the given amount of tokens from the stake of the
+// provided address or the entire stake if it is lower than the amount.
+// It returns the amount of tokens actually taken away.
+func (lsm *StakeMonitor) SlashTokens(
+	address string,
+	amount *big.Int,
+) (*big.Int, error) {
+	staker, err := lsm.localStakerFor(address)
+	if err != nil {
+		return nil, err
+	}
+
+	slashed := staker.slash(amount)
+
+	lsm.emit(address, &event.TokensSlashed{
+		Operator:    staker.Address(),
+		Amount:      slashed,
+		BlockNumber: lsm.currentBlock(),
+	})
+
+	return slashed, nil
+}
+
+// SeizeTokens takes away the given amount of tokens from the stake of the
+// provided address or the entire stake if it is lower than the amount, the
+// same way SlashTokens does. Unlike slashed tokens, seized tokens could be
+// partially awarded to the party reporting the misbehavior; the local chain
+// does not model such rewards. It returns the amount of tokens actually
+// taken away.
+func (lsm *StakeMonitor) SeizeTokens(
+	address string,
+	amount *big.Int,
+) (*big.Int, error) {
+	staker, err := lsm.localStakerFor(address)
+	if err != nil {
+		return nil, err
+	}
+
+	seized := staker.slash(amount)
+
+	lsm.emit(address, &event.TokensSeized{
+		Operator:    staker.Address(),
+		Amount:      seized,
+		BlockNumber: lsm.currentBlock(),
+	})
+
+	return seized, nil
+}
+
+// LockStake locks the stake of the provided address on behalf of the given
+// lock creator until the given timestamp. The lock is not enforced; the
+// stake can still be unstaked.
+func (lsm *StakeMonitor) LockStake(
+	address string,
+	lockCreator string,
+	until *big.Int,
+) error {
+	staker, err := lsm.localStakerFor(address)
+	if err != nil {
+		return err
+	}
+
+	lsm.stakersMutex.Lock()
+	if _, ok := lsm.locks[address]; !ok {
+		lsm.locks[address] = make(map[string]*big.Int)
+	}
+	lsm.locks[address][lockCreator] = until
+	lsm.stakersMutex.Unlock()
+
+	lsm.emit(address, &event.StakeLocked{
+		Operator:    staker.Address(),
+		LockCreator: []byte(lockCreator),
+		Until:       until,
+		BlockNumber: lsm.currentBlock(),
+	})
 
 	return nil
 }
 
+// ReleaseLock releases the lock put on the stake of the provided address by
+// the given lock creator. It returns an error if there is no such lock.
+func (lsm *StakeMonitor) ReleaseLock(address string, lockCreator string) error {
+	staker, err := lsm.localStakerFor(address)
+	if err != nil {
+		return err
+	}
+
+	lsm.stakersMutex.Lock()
+	if _, ok := lsm.locks[address][lockCreator]; !ok {
+		lsm.stakersMutex.Unlock()
+		return fmt.Errorf(
+			"no lock of [%v] on the stake of [%v]",
+			lockCreator,
+			address,
+		)
+	}
+	delete(lsm.locks[address], lockCreator)
+	lsm.stakersMutex.Unlock()
+
+	lsm.emit(address, &event.LockReleased{
+		Operator:    staker.Address(),
+		LockCreator: []byte(lockCreator),
+		BlockNumber: lsm.currentBlock(),
+	})
+
+	return nil
+}
+
+func (lsm *StakeMonitor) localStakerFor(address string) (*localStaker, error) {
+	staker, err := lsm.StakerFor(address)
+	if err != nil {
+		return nil, err
+	}
+
+	stakerLocal, ok := staker.(*localStaker)
+	if !ok {
+		return nil, fmt.Errorf("invalid type of staker")
+	}
+
+	return stakerLocal, nil
+}
+
+// OnTokensSlashed registers a handler of tokens slashed with SlashTokens
+// from the stake of the provided address.
+func (lsm *StakeMonitor) OnTokensSlashed(
+	address string,
+	handler func(slashed *event.TokensSlashed),
+) (subscription.EventSubscription, error) {
+	return lsm.subscribe(address, func(stakeEvent interface{}) {
+		if slashed, ok := stakeEvent.(*event.TokensSlashed); ok {
+			handler(slashed)
+		}
+	})
+}
+
+// OnTokensSeized registers a handler of tokens seized with SeizeTokens
+// from the stake of the provided address.
+func (lsm *StakeMonitor) OnTokensSeized(
+	address string,
+	handler func(seized *event.TokensSeized),
+) (subscription.EventSubscription, error) {
+	return lsm.subscribe(address, func(stakeEvent interface{}) {
+		if seized, ok := stakeEvent.(*event.TokensSeized); ok {
+			handler(seized)
+		}
+	})
+}
+
+// OnUndelegated registers a handler of unstaking tokens of the provided
+// address with UnstakeTokens.
+func (lsm *StakeMonitor) OnUndelegated(
+	address string,
+	handler func(undelegated *event.Undelegated),
+) (subscription.EventSubscription, error) {
+	return lsm.subscribe(address, func(stakeEvent interface{}) {
+		if undelegated, ok := stakeEvent.(*event.Undelegated); ok {
+			handler(undelegated)
+		}
+	})
+}
+
+// OnStakeLocked registers a handler of locks put with LockStake on the stake
+// of the provided address.
+func (lsm *StakeMonitor) OnStakeLocked(
+	address string,
+	handler func(locked *event.StakeLocked),
+) (subscription.EventSubscription, error) {
+	return lsm.subscribe(address, func(stakeEvent interface{}) {
+		if locked, ok := stakeEvent.(*event.StakeLocked); ok {
+			handler(locked)
+		}
+	})
+}
+
+// OnLockReleased registers a handler of locks released with ReleaseLock from
+// the stake of the provided address.
+func (lsm *StakeMonitor) OnLockReleased(
+	address string,
+	handler func(released *event.LockReleased),
+) (subscription.EventSubscription, error) {
+	return lsm.subscribe(address, func(stakeEvent interface{}) {
+		if released, ok := stakeEvent.(*event.LockReleased); ok {
+			handler(released)
+		}
+	})
+}
+
+func (lsm *StakeMonitor) subscribe(
+	address string,
+	handle func(stakeEvent interface{}),
+) (subscription.EventSubscription, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("not a valid ethereum address: %v", address)
+	}
+
+	lsm.handlerMutex.Lock()
+	defer lsm.handlerMutex.Unlock()
+
+	handlerID := rand.Int()
+	lsm.handlers[handlerID] = &operatorHandler{address, handle}
+
+	return subscription.NewEventSubscription(func() {
+		lsm.handlerMutex.Lock()
+		defer lsm.handlerMutex.Unlock()
+
+		delete(lsm.handlers, handlerID)
+	}), nil
+}
+
+// emit passes the stake event to handlers registered for the provided
+// address.
+func (lsm *StakeMonitor) emit(address string, stakeEvent interface{}) {
+	lsm.handlerMutex.Lock()
+	defer lsm.handlerMutex.Unlock()
+
+	for _, handler := range lsm.handlers {
+		if handler.address == address {
+			go handler.handle(stakeEvent)
+		}
+	}
+}
+
+func (lsm *StakeMonitor) currentBlock() uint64 {
+	if lsm.blockCounter == nil {
+		return 0
+	}
+
+	currentBlock, err := lsm.blockCounter.CurrentBlock()
+	if err != nil {
+		return 0
+	}
+
+	return currentBlock
+}
+
 type localStaker struct {
 	address string
-	stake   *big.Int
+
+	stakeMutex sync.Mutex
+	stake      *big.Int
 }
 
 func (ls *localStaker) Address() relaychain.StakerAddress {
@@ -115,5 +388,29 @@ func (ls *localStaker) Address() relaychain.StakerAddress {
 }
 
 func (ls *localStaker) Stake() (*big.Int, error) {
+	ls.stakeMutex.Lock()
+	defer ls.stakeMutex.Unlock()
+
 	return ls.stake, nil
 }
+
+func (ls *localStaker) setStake(stake *big.Int) {
+	ls.stakeMutex.Lock()
+	defer ls.stakeMutex.Unlock()
+
+	ls.stake = stake
+}
+
+func (ls *localStaker) slash(amount *big.Int) *big.Int {
+	ls.stakeMutex.Lock()
+	defer ls.stakeMutex.Unlock()
+
+	slashed := amount
+	if ls.stake.Cmp(amount) < 0 {
+		slashed = ls.stake
+	}
+
+	ls.stake = new(big.Int).Sub(ls.stake, slashed)
+
+	return new(big.Int).Set(slashed)
+}
@@ -5,6 +5,9 @@ import (
 	"math/big"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/beacon/relay/event"
 )
 
 func TestDetectInvalidAddress(t *testing.T) {
@@ -158,3 +161,251 @@ func TestStake(t *testing.T) {
 		)
 	}
 }
+
+func TestSlashTokens(t *testing.T) {
+	minimumStake := big.NewInt(200)
+
+	var tests = map[string]struct {
+		amount          *big.Int
+		expectedSlashed *big.Int
+		expectedStake   *big.Int
+	}{
+		"amount lower than the stake": {
+			amount:          big.NewInt(300),
+			expectedSlashed: big.NewInt(300),
+			expectedStake:   big.NewInt(700),
+		},
+		"amount equal to the stake": {
+			amount:          big.NewInt(1000),
+			expectedSlashed: big.NewInt(1000),
+			expectedStake:   big.NewInt(0),
+		},
+		"amount higher than the stake": {
+			amount:          big.NewInt(1500),
+			expectedSlashed: big.NewInt(1000),
+			expectedStake:   big.NewInt(0),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			monitor := NewStakeMonitor(minimumStake)
+			address := "0x524f2e0176350d950fa630d9a5a59a0a190daf48"
+
+			err := monitor.StakeTokens(address)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			slashed, err := monitor.SlashTokens(address, test.amount)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if slashed.Cmp(test.expectedSlashed) != 0 {
+				t.Errorf(
+					"unexpected slashed amount\nexpected: [%v]\nactual:   [%v]",
+					test.expectedSlashed,
+					slashed,
+				)
+			}
+
+			staker, err := monitor.StakerFor(address)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stake, err := staker.Stake()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if stake.Cmp(test.expectedStake) != 0 {
+				t.Errorf(
+					"unexpected stake\nexpected: [%v]\nactual:   [%v]",
+					test.expectedStake,
+					stake,
+				)
+			}
+		})
+	}
+}
+
+func TestStakeEvents(t *testing.T) {
+	address := "0x524f2e0176350d950fa630d9a5a59a0a190daf48"
+	lockCreator := "0x8a2ffd6ab17de3fad1b2d0d21a6d2c1d90d1ae5a"
+
+	var tests = map[string]struct {
+		changeStake   func(monitor *StakeMonitor) error
+		expectedEvent interface{}
+	}{
+		"tokens slashed": {
+			changeStake: func(monitor *StakeMonitor) error {
+				_, err := monitor.SlashTokens(address, big.NewInt(300))
+				return err
+			},
+			expectedEvent: &event.TokensSlashed{
+				Operator: []byte(address),
+				Amount:   big.NewInt(300),
+			},
+		},
+		"tokens seized": {
+			changeStake: func(monitor *StakeMonitor) error {
+				_, err := monitor.SeizeTokens(address, big.NewInt(2000))
+				return err
+			},
+			expectedEvent: &event.TokensSeized{
+				Operator: []byte(address),
+				Amount:   big.NewInt(1000),
+			},
+		},
+		"stake locked": {
+			changeStake: func(monitor *StakeMonitor) error {
+				return monitor.LockStake(address, lockCreator, big.NewInt(100))
+			},
+			expectedEvent: &event.StakeLocked{
+				Operator:    []byte(address),
+				LockCreator: []byte(lockCreator),
+				Until:       big.NewInt(100),
+			},
+		},
+		"lock released": {
+			changeStake: func(monitor *StakeMonitor) error {
+				err := monitor.LockStake(address, lockCreator, big.NewInt(100))
+				if err != nil {
+					return err
+				}
+				return monitor.ReleaseLock(address, lockCreator)
+			},
+			expectedEvent: &event.LockReleased{
+				Operator:    []byte(address),
+				LockCreator: []byte(lockCreator),
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			monitor := NewStakeMonitor(big.NewInt(200))
+
+			if err := monitor.StakeTokens(address); err != nil {
+				t.Fatal(err)
+			}
+
+			events := make(chan interface{}, 10)
+			subscribeStakeEvents(t, monitor, address, events)
+
+			if err := test.changeStake(monitor); err != nil {
+				t.Fatal(err)
+			}
+
+			var lastEvent interface{}
+			for lastEvent == nil ||
+				reflect.TypeOf(lastEvent) != reflect.TypeOf(test.expectedEvent) {
+				select {
+				case lastEvent = <-events:
+				case <-time.After(time.Second):
+					t.Fatalf("expected [%T] event", test.expectedEvent)
+				}
+			}
+
+			if !reflect.DeepEqual(test.expectedEvent, lastEvent) {
+				t.Errorf(
+					"unexpected event\nexpected: [%+v]\nactual:   [%+v]",
+					test.expectedEvent,
+					lastEvent,
+				)
+			}
+		})
+	}
+}
+
+func TestUndelegatedEvent(t *testing.T) {
+	address := "0x524f2e0176350d950fa630d9a5a59a0a190daf48"
+	otherAddress := "0x65ea55c1f10491038425725dc00dffeab2a1e28a"
+
+	monitor := NewStakeMonitor(big.NewInt(200))
+
+	events := make(chan interface{}, 10)
+	subscribeStakeEvents(t, monitor, address, events)
+
+	otherEvents := make(chan interface{}, 10)
+	subscribeStakeEvents(t, monitor, otherAddress, otherEvents)
+
+	if err := monitor.UnstakeTokens(address); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case undelegatedEvent := <-events:
+		undelegated, ok := undelegatedEvent.(*event.Undelegated)
+		if !ok {
+			t.Fatalf("unexpected event [%+v]", undelegatedEvent)
+		}
+		if string(undelegated.Operator) != address {
+			t.Errorf(
+				"unexpected operator\nexpected: [%v]\nactual:   [%s]",
+				address,
+				undelegated.Operator,
+			)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected undelegated event")
+	}
+
+	select {
+	case otherEvent := <-otherEvents:
+		t.Errorf("unexpected event of another operator [%+v]", otherEvent)
+	default:
+	}
+}
+
+func TestReleaseMissingLock(t *testing.T) {
+	monitor := NewStakeMonitor(big.NewInt(200))
+
+	err := monitor.ReleaseLock(
+		"0x524f2e0176350d950fa630d9a5a59a0a190daf48",
+		"0x8a2ffd6ab17de3fad1b2d0d21a6d2c1d90d1ae5a",
+	)
+	if err == nil {
+		t.Fatal("expected an error when there is no lock")
+	}
+}
+
+func subscribeStakeEvents(
+	t *testing.T,
+	monitor *StakeMonitor,
+	address string,
+	events chan<- interface{},
+) {
+	if _, err := monitor.OnTokensSlashed(
+		address,
+		func(slashed *event.TokensSlashed) { events <- slashed },
+	); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := monitor.OnTokensSeized(
+		address,
+		func(seized *event.TokensSeized) { events <- seized },
+	); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := monitor.OnUndelegated(
+		address,
+		func(undelegated *event.Undelegated) { events <- undelegated },
+	); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := monitor.OnStakeLocked(
+		address,
+		func(locked *event.StakeLocked) { events <- locked },
+	); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := monitor.OnLockReleased(
+		address,
+		func(released *event.LockReleased) { events <- released },
+	); err != nil {
+		t.Fatal(err)
+	}
+}
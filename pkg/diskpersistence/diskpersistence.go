@@ -0,0 +1,124 @@
+// Package diskpersistence provides an on-disk persistence handle which saves
+// data atomically, so that a client killed in the middle of saving does not
+// leave truncated or empty files behind.
+package diskpersistence
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+const (
+	// currentDir is the directory of the non-archived data, the same as used
+	// by the keep-common disk persistence.
+	currentDir = "current"
+
+	maxFileNameLength = 128
+
+	temporaryFilePattern = "save-*.tmp"
+)
+
+type diskHandle struct {
+	// Data are read and archived by the keep-common disk persistence which
+	// uses the same directory layout.
+	persistence.Handle
+
+	dataDir string
+}
+
+// NewHandle creates an on-disk data persistence handle storing data in the
+// given directory the same way as the keep-common disk persistence does,
+// except that data are saved atomically.
+func NewHandle(path string) (persistence.Handle, error) {
+	handle, err := persistence.NewDiskHandle(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &diskHandle{
+		Handle:  handle,
+		dataDir: path,
+	}, nil
+}
+
+// Save writes the data to a temporary file, syncs it to the disk and renames
+// it to the target file only then. If the client is killed while saving, the
+// target file keeps its previous content. Temporary files are created in the
+// data directory itself which is not read by ReadAll, so a temporary file
+// left by an interrupted save is never read as data.
+func (dh *diskHandle) Save(data []byte, directory string, name string) error {
+	if len(directory) > maxFileNameLength {
+		return fmt.Errorf(
+			"the maximum directory name length of [%v] exceeded for [%v]",
+			maxFileNameLength,
+			directory,
+		)
+	}
+
+	if len(name) > maxFileNameLength {
+		return fmt.Errorf(
+			"the maximum file name length of [%v] exceeded for [%v]",
+			maxFileNameLength,
+			name,
+		)
+	}
+
+	directoryPath := filepath.Join(dh.dataDir, currentDir, directory)
+	if err := os.MkdirAll(directoryPath, os.ModePerm); err != nil {
+		return fmt.Errorf("could not create directory: [%v]", err)
+	}
+
+	temporaryFile, err := ioutil.TempFile(dh.dataDir, temporaryFilePattern)
+	if err != nil {
+		return fmt.Errorf("could not create temporary file: [%v]", err)
+	}
+	temporaryPath := temporaryFile.Name()
+
+	if err := writeAndSync(temporaryFile, data); err != nil {
+		os.Remove(temporaryPath)
+		return fmt.Errorf("could not write temporary file: [%v]", err)
+	}
+
+	if err := os.Rename(
+		temporaryPath,
+		filepath.Join(directoryPath, name),
+	); err != nil {
+		os.Remove(temporaryPath)
+		return fmt.Errorf("could not replace file: [%v]", err)
+	}
+
+	// The rename is durable only once the directory is synced.
+	if err := syncDirectory(directoryPath); err != nil {
+		return fmt.Errorf("could not sync directory: [%v]", err)
+	}
+
+	return nil
+}
+
+func writeAndSync(file *os.File, data []byte) error {
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func syncDirectory(path string) error {
+	directory, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer directory.Close()
+
+	return directory.Sync()
+}
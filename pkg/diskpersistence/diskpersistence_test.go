@@ -0,0 +1,109 @@
+package diskpersistence
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveAndReadAll(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "diskpersistence")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	handle, err := NewHandle(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handle.Save([]byte("first"), "group", "/membership_1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := handle.Save([]byte("second"), "group", "/membership_1"); err != nil {
+		t.Fatal(err)
+	}
+
+	dataChan, errorChan := handle.ReadAll()
+	go func() {
+		for err := range errorChan {
+			t.Error(err)
+		}
+	}()
+
+	var read [][]byte
+	for descriptor := range dataChan {
+		content, err := descriptor.Content()
+		if err != nil {
+			t.Fatal(err)
+		}
+		read = append(read, content)
+	}
+
+	if len(read) != 1 {
+		t.Fatalf(
+			"unexpected number of files\nexpected: [%v]\nactual:   [%v]",
+			1,
+			len(read),
+		)
+	}
+	if !bytes.Equal(read[0], []byte("second")) {
+		t.Errorf(
+			"unexpected content\nexpected: [%s]\nactual:   [%s]",
+			"second",
+			read[0],
+		)
+	}
+
+	temporaryFiles, err := filepath.Glob(
+		filepath.Join(dataDir, temporaryFilePattern),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(temporaryFiles) != 0 {
+		t.Errorf("unexpected temporary files left: [%v]", temporaryFiles)
+	}
+}
+
+func TestSaveTooLongNames(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "diskpersistence")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	handle, err := NewHandle(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tooLongName := strings.Repeat("a", maxFileNameLength+1)
+
+	var tests = map[string]struct {
+		directory string
+		name      string
+	}{
+		"too long directory name": {
+			directory: tooLongName,
+			name:      "/membership_1",
+		},
+		"too long file name": {
+			directory: "group",
+			name:      tooLongName,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := handle.Save([]byte("data"), test.directory, test.name)
+			if err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
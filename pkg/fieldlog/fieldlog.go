@@ -0,0 +1,155 @@
+// Package fieldlog attaches structured fields, like the index of the group
+// member or the broadcast channel name, to the log messages of keep
+// subsystems and provides a JSON output format for all logs.
+//
+// Field loggers log through the same subsystems as the package-level loggers
+// created with go-log, so their messages are subject to the same log levels.
+// In the text format, fields are rendered as a prefix of the message, e.g.
+// `[member_index:3,channel:4a5f] message`. In the JSON format, every field is
+// a separate key of the logged JSON object, which lets log pipelines follow
+// one protocol execution across all group members.
+package fieldlog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	logging "github.com/whyrusleeping/go-logging"
+)
+
+// Names of fields used to correlate log messages of protocol executions.
+const (
+	MemberIndex    = "member_index"
+	Channel        = "channel"
+	GroupPublicKey = "group_pubkey"
+	Seed           = "seed"
+	RequestBlock   = "request_block"
+	Phase          = "phase"
+)
+
+// knownFields defines the order in which fields are rendered. Fields not
+// listed here are rendered after the known ones, in alphabetical order.
+var knownFields = []string{
+	MemberIndex,
+	Channel,
+	GroupPublicKey,
+	Seed,
+	RequestBlock,
+	Phase,
+}
+
+// Fields maps field names to their values.
+type Fields map[string]interface{}
+
+// Logger logs messages of a subsystem together with a set of fields.
+// Loggers are immutable and safe for concurrent use.
+type Logger struct {
+	logger *logging.Logger
+	fields Fields
+}
+
+// New creates a logger for the given subsystem, logging all messages with
+// the given fields. The subsystem should be registered with go-log, usually
+// by the package-level logger of the package creating the field logger.
+func New(subsystem string, fields Fields) *Logger {
+	logger := logging.MustGetLogger(subsystem)
+	// Skip the field logger's own frame when resolving the caller.
+	logger.ExtraCalldepth = 1
+
+	return &Logger{
+		logger: logger,
+		fields: copyFields(fields, nil),
+	}
+}
+
+// With returns a logger of the same subsystem logging with the fields of this
+// logger and the given fields. Given fields replace the existing fields with
+// the same name.
+func (l *Logger) With(fields Fields) *Logger {
+	return &Logger{
+		logger: l.logger,
+		fields: copyFields(l.fields, fields),
+	}
+}
+
+// Debugf logs a message with the debug level.
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.logger.Debugf("%s", l.message(format, args))
+}
+
+// Infof logs a message with the info level.
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.logger.Infof("%s", l.message(format, args))
+}
+
+// Warningf logs a message with the warning level.
+func (l *Logger) Warningf(format string, args ...interface{}) {
+	l.logger.Warningf("%s", l.message(format, args))
+}
+
+// Errorf logs a message with the error level.
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	l.logger.Errorf("%s", l.message(format, args))
+}
+
+func (l *Logger) message(format string, args []interface{}) string {
+	message := fmt.Sprintf(format, args...)
+
+	if len(l.fields) == 0 {
+		return message
+	}
+
+	if isJSONFormat() {
+		return encodeFieldsMessage(l.fields, message)
+	}
+
+	renderedFields := make([]string, 0, len(l.fields))
+	for _, name := range orderedNames(l.fields) {
+		renderedFields = append(
+			renderedFields,
+			fmt.Sprintf("%v:%v", name, l.fields[name]),
+		)
+	}
+
+	return fmt.Sprintf("[%v] %v", strings.Join(renderedFields, ","), message)
+}
+
+func copyFields(fields Fields, additionalFields Fields) Fields {
+	copied := make(Fields, len(fields)+len(additionalFields))
+	for name, value := range fields {
+		copied[name] = value
+	}
+	for name, value := range additionalFields {
+		copied[name] = value
+	}
+	return copied
+}
+
+func orderedNames(fields Fields) []string {
+	names := make([]string, 0, len(fields))
+	for _, name := range knownFields {
+		if _, ok := fields[name]; ok {
+			names = append(names, name)
+		}
+	}
+
+	otherNames := make([]string, 0)
+	for name := range fields {
+		if !isKnownField(name) {
+			otherNames = append(otherNames, name)
+		}
+	}
+	sort.Strings(otherNames)
+
+	return append(names, otherNames...)
+}
+
+func isKnownField(name string) bool {
+	for _, knownName := range knownFields {
+		if name == knownName {
+			return true
+		}
+	}
+	return false
+}
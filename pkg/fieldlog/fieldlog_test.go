@@ -0,0 +1,178 @@
+package fieldlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	logging "github.com/whyrusleeping/go-logging"
+)
+
+func TestTextMessage(t *testing.T) {
+	var tests = map[string]struct {
+		fields           Fields
+		additionalFields Fields
+		expectedMessage  string
+	}{
+		"no fields": {
+			expectedMessage: "submitting relay entry",
+		},
+		"known fields": {
+			fields: Fields{
+				Phase:       "ephemeralKeyPairGeneration",
+				Channel:     "4a5f",
+				MemberIndex: 3,
+			},
+			expectedMessage: "[member_index:3,channel:4a5f," +
+				"phase:ephemeralKeyPairGeneration] submitting relay entry",
+		},
+		"known and other fields": {
+			fields: Fields{
+				"operator":   "0x65ea",
+				RequestBlock: 120,
+				"attempt":    2,
+			},
+			expectedMessage: "[request_block:120,attempt:2,operator:0x65ea] " +
+				"submitting relay entry",
+		},
+		"replaced fields": {
+			fields: Fields{
+				MemberIndex: 3,
+				Phase:       "ephemeralKeyPairGeneration",
+			},
+			additionalFields: Fields{
+				Phase: "finalization",
+			},
+			expectedMessage: "[member_index:3,phase:finalization] " +
+				"submitting relay entry",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			logger := New("keep-fieldlog-test", test.fields).With(
+				test.additionalFields,
+			)
+
+			message := logger.message(
+				"submitting %v entry",
+				[]interface{}{"relay"},
+			)
+
+			if test.expectedMessage != message {
+				t.Errorf(
+					"unexpected message\nexpected: [%v]\nactual:   [%v]",
+					test.expectedMessage,
+					message,
+				)
+			}
+		})
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	atomic.StoreInt32(&jsonFormat, 1)
+	defer atomic.StoreInt32(&jsonFormat, 0)
+
+	var buffer bytes.Buffer
+	backend := logging.AddModuleLevel(
+		logging.NewBackendFormatter(
+			logging.NewLogBackend(&buffer, "", 0),
+			&jsonFormatter{},
+		),
+	)
+	backend.SetLevel(logging.DEBUG, "")
+
+	logger := New("keep-fieldlog-test", Fields{
+		MemberIndex: 3,
+		Seed:        "0x1f",
+	})
+	logger.logger.SetBackend(backend)
+
+	logger.With(Fields{Phase: "finalization"}).Infof(
+		"reached \"final\" state at block [%v]",
+		120,
+	)
+
+	line := strings.TrimSuffix(buffer.String(), "\n")
+	if strings.Contains(line, "\n") {
+		t.Fatalf("expected a single line; has: [%v]", line)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+		t.Fatalf("could not decode [%v]: [%v]", line, err)
+	}
+
+	if _, ok := decoded["time"]; !ok {
+		t.Errorf("time is missing in [%v]", line)
+	}
+	delete(decoded, "time")
+
+	if caller, ok := decoded["caller"].(string); !ok ||
+		!strings.HasPrefix(caller, "fieldlog_test.go:") {
+		t.Errorf("unexpected caller in [%v]", line)
+	}
+	delete(decoded, "caller")
+
+	expected := map[string]interface{}{
+		"level":        "INFO",
+		"logger":       "keep-fieldlog-test",
+		"message":      "reached \"final\" state at block [120]",
+		"member_index": float64(3),
+		"seed":         "0x1f",
+		"phase":        "finalization",
+	}
+	if !reflect.DeepEqual(expected, decoded) {
+		t.Errorf(
+			"unexpected log entry\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			decoded,
+		)
+	}
+}
+
+func TestDecodeFieldsMessage(t *testing.T) {
+	var tests = map[string]struct {
+		message         string
+		expectedFields  string
+		expectedMessage string
+	}{
+		"message without fields": {
+			message:         "connected to [5] peers",
+			expectedMessage: "connected to [5] peers",
+		},
+		"message with fields": {
+			message: encodeFieldsMessage(
+				Fields{Channel: "4a5f", MemberIndex: 3},
+				"connected to [5] peers",
+			),
+			expectedFields:  `"member_index":3,"channel":"4a5f"`,
+			expectedMessage: "connected to [5] peers",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			fields, message := decodeFieldsMessage(test.message)
+
+			if test.expectedFields != fields {
+				t.Errorf(
+					"unexpected fields\nexpected: [%v]\nactual:   [%v]",
+					test.expectedFields,
+					fields,
+				)
+			}
+			if test.expectedMessage != message {
+				t.Errorf(
+					"unexpected message\nexpected: [%v]\nactual:   [%v]",
+					test.expectedMessage,
+					message,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package fieldlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ipfs/go-log"
+	logging "github.com/whyrusleeping/go-logging"
+)
+
+// Supported log output formats.
+const (
+	// TextFormat is the default go-log format, configured with the
+	// IPFS_LOGGING_FMT environment variable.
+	TextFormat = "text"
+	// JSONFormat writes every log message as a JSON object in a separate
+	// line.
+	JSONFormat = "json"
+)
+
+// fieldsSeparator delimits fields encoded into the message by field loggers
+// when the JSON format is used, so that the formatter can tell them apart
+// from the message itself.
+const fieldsSeparator = "\x1e"
+
+var jsonFormat int32
+
+func isJSONFormat() bool {
+	return atomic.LoadInt32(&jsonFormat) == 1
+}
+
+// ValidateFormat returns an error if the given log output format is not
+// supported. An empty format stands for the text format.
+func ValidateFormat(format string) error {
+	switch format {
+	case "", TextFormat, JSONFormat:
+		return nil
+	default:
+		return fmt.Errorf(
+			"unsupported log format [%v]; use [%v] or [%v]",
+			format,
+			TextFormat,
+			JSONFormat,
+		)
+	}
+}
+
+// SetFormat sets the output format of all logs. An empty format stands for
+// the text format, which is used by default. The format should be set at
+// startup; switching from the JSON format back to the text format is not
+// supported. Log levels set so far are retained.
+//
+// In the JSON format, logs are written to the standard error output.
+func SetFormat(format string) error {
+	if err := ValidateFormat(format); err != nil {
+		return err
+	}
+
+	if format != JSONFormat {
+		if isJSONFormat() {
+			return fmt.Errorf("log format cannot be changed back to text")
+		}
+		return nil
+	}
+
+	if !atomic.CompareAndSwapInt32(&jsonFormat, 0, 1) {
+		return nil
+	}
+
+	// Backends cache the formatter on the first logged message, so a new
+	// backend is needed for the formatter to take effect. The new backend
+	// comes with no log levels set and the current ones are copied to it.
+	subsystems := log.GetSubsystems()
+	levels := make(map[string]logging.Level, len(subsystems))
+	for _, subsystem := range subsystems {
+		levels[subsystem] = logging.GetLevel(subsystem)
+	}
+	defaultLevel := logging.GetLevel("")
+
+	logging.SetFormatter(&jsonFormatter{})
+	logging.SetBackend(logging.NewLogBackend(os.Stderr, "", 0))
+
+	logging.SetLevel(defaultLevel, "")
+	for subsystem, level := range levels {
+		logging.SetLevel(level, subsystem)
+	}
+
+	return nil
+}
+
+// encodeFieldsMessage encodes fields into the message so that they can be
+// extracted by the JSON formatter.
+func encodeFieldsMessage(fields Fields, message string) string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString(fieldsSeparator)
+	for i, name := range orderedNames(fields) {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+		buffer.Write(encodeJSON(name))
+		buffer.WriteString(":")
+		buffer.Write(encodeJSON(fields[name]))
+	}
+	buffer.WriteString(fieldsSeparator)
+	buffer.WriteString(message)
+
+	return buffer.String()
+}
+
+// decodeFieldsMessage splits the message encoded with encodeFieldsMessage
+// into the encoded fields and the message itself. Messages logged without
+// fields are returned unchanged along with empty fields.
+func decodeFieldsMessage(encoded string) (string, string) {
+	if !strings.HasPrefix(encoded, fieldsSeparator) {
+		return "", encoded
+	}
+
+	fieldsAndMessage := strings.SplitN(
+		strings.TrimPrefix(encoded, fieldsSeparator),
+		fieldsSeparator,
+		2,
+	)
+	if len(fieldsAndMessage) != 2 {
+		return "", encoded
+	}
+
+	return fieldsAndMessage[0], fieldsAndMessage[1]
+}
+
+func encodeJSON(value interface{}) []byte {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		encoded, _ = json.Marshal(fmt.Sprint(value))
+	}
+	return encoded
+}
+
+// jsonFormatter formats log records as JSON objects with the time, level,
+// subsystem, caller and message of the record, followed by the record's
+// fields, if any.
+type jsonFormatter struct{}
+
+func (jf *jsonFormatter) Format(
+	calldepth int,
+	record *logging.Record,
+	output io.Writer,
+) error {
+	fields, message := decodeFieldsMessage(record.Message())
+
+	caller := "???"
+	if _, file, line, ok := runtime.Caller(calldepth + 1); ok {
+		caller = fmt.Sprintf("%s:%d", filepath.Base(file), line)
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(`{"time":`)
+	buffer.Write(encodeJSON(record.Time.UTC().Format(time.RFC3339Nano)))
+	buffer.WriteString(`,"level":`)
+	buffer.Write(encodeJSON(record.Level.String()))
+	buffer.WriteString(`,"logger":`)
+	buffer.Write(encodeJSON(record.Module))
+	buffer.WriteString(`,"caller":`)
+	buffer.Write(encodeJSON(caller))
+	buffer.WriteString(`,"message":`)
+	buffer.Write(encodeJSON(message))
+	if fields != "" {
+		buffer.WriteString(",")
+		buffer.WriteString(fields)
+	}
+	buffer.WriteString("}")
+
+	_, err := output.Write(buffer.Bytes())
+	return err
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/keep-network/keep-common/pkg/cache"
 
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/metrics"
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/key"
 )
@@ -29,6 +30,12 @@ const MinimumStakeCachePeriod = 12 * time.Hour
 
 var errNoMinimumStake = fmt.Errorf("remote peer has no minimum stake")
 
+var rejections = metrics.NewCounterVec(
+	"keep_firewall_rejections_total",
+	"Number of remote peers rejected by the firewall, partitioned by reason.",
+	"reason",
+)
+
 // MinimumStakePolicy is a net.Firewall rule making sure the remote peer
 // has a minimum stake of KEEP.
 func MinimumStakePolicy(stakeMonitor chain.StakeMonitor) net.Firewall {
@@ -67,6 +74,7 @@ func (msp *minimumStakePolicy) Validate(
 
 	hasMinimumStake, err := msp.stakeMonitor.HasMinimumStake(address)
 	if err != nil {
+		rejections.WithLabelValues("stakeCheckFailed").Inc()
 		return fmt.Errorf(
 			"could not validate remote peer's minimum stake: [%v]",
 			err,
@@ -74,6 +82,7 @@ func (msp *minimumStakePolicy) Validate(
 	}
 
 	if !hasMinimumStake {
+		rejections.WithLabelValues("noMinimumStake").Inc()
 		return errNoMinimumStake
 	}
 
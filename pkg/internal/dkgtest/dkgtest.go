@@ -142,25 +142,22 @@ func executeDKG(
 	gjkr.RegisterUnmarshallers(broadcastChannel)
 	dkgResult.RegisterUnmarshallers(broadcastChannel)
 
-	membershipValidator := group.NewStakersMembershipValidator(
-		selectedStakers,
-		chain.Signing(),
-	)
-
 	for i := 0; i < relayConfig.GroupSize; i++ {
 		i := i // capture for goroutine
 		go func() {
 			signer, err := dkg.ExecuteDKG(
+				context.Background(),
 				seed,
 				uint8(i),
 				relayConfig.GroupSize,
 				relayConfig.DishonestThreshold(),
-				membershipValidator,
+				selectedStakers,
 				startBlockHeight,
 				blockCounter,
 				chain.ThresholdRelay(),
 				chain.Signing(),
 				broadcastChannel,
+				nil,
 			)
 			if signer != nil {
 				signersMutex.Lock()
@@ -130,15 +130,21 @@ func executeSigning(
 
 	entry.RegisterUnmarshallers(broadcastChannel)
 
+	// All signers are members of the same operator.
+	signing := chain.Signing()
+	operatorAddress := signing.PublicKeyBytesToAddress(signing.PublicKey())
+
 	for _, signer := range signers {
 		go func(signer *dkg.ThresholdSigner) {
 			err := entry.SignAndSubmit(
+				context.Background(),
 				blockCounter,
 				broadcastChannel,
 				chain.ThresholdRelay(),
 				previousEntry,
 				threshold,
 				signer,
+				operatorAddress,
 				startBlockHeight,
 			)
 			if err != nil {
@@ -0,0 +1,194 @@
+// Package metrics registers client metrics in a Prometheus registry and
+// serves them over HTTP.
+//
+// Metrics are expected to be declared as package-level variables in the
+// packages being instrumented, the same way loggers are. All metrics created
+// with the functions from this package are registered in Registry which is
+// served by EnableServer.
+//
+// Metrics of a single operator, such as relay, rewards and stake metrics, are
+// partitioned by OperatorLabel so that operators hosted by the same client
+// can be told apart. Metrics of the whole process, such as network and
+// Ethereum connection metrics, are not.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var logger = log.Logger("keep-metrics")
+
+// Path is the HTTP path under which metrics are served.
+const Path = "/metrics"
+
+const shutdownTimeout = 5 * time.Second
+
+// OperatorLabel is the name of the label holding the address of the operator
+// a metric is reported for.
+const OperatorLabel = "operator"
+
+// OperatorLabelValue returns the value of OperatorLabel for the operator
+// with the given chain-specific address.
+func OperatorLabelValue(address []byte) string {
+	return fmt.Sprintf("0x%x", address)
+}
+
+// Registry is the registry in which all metrics created with this package's
+// constructors are registered.
+var Registry = prometheus.NewRegistry()
+
+// NewCounter creates a counter and registers it in Registry. It panics if
+// a metric with the same name has been already registered.
+func NewCounter(name, help string) prometheus.Counter {
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: name,
+		Help: help,
+	})
+
+	Registry.MustRegister(counter)
+
+	return counter
+}
+
+// NewCounterVec creates a set of counters partitioned by the given labels
+// and registers it in Registry. It panics if a metric with the same name has
+// been already registered.
+func NewCounterVec(name, help string, labels ...string) *prometheus.CounterVec {
+	counterVec := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: name,
+			Help: help,
+		},
+		labels,
+	)
+
+	Registry.MustRegister(counterVec)
+
+	return counterVec
+}
+
+// NewGauge creates a gauge and registers it in Registry. It panics if
+// a metric with the same name has been already registered.
+func NewGauge(name, help string) prometheus.Gauge {
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: name,
+		Help: help,
+	})
+
+	Registry.MustRegister(gauge)
+
+	return gauge
+}
+
+// NewGaugeVec creates a set of gauges partitioned by the given labels and
+// registers it in Registry. It panics if a metric with the same name has
+// been already registered.
+func NewGaugeVec(name, help string, labels ...string) *prometheus.GaugeVec {
+	gaugeVec := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: name,
+			Help: help,
+		},
+		labels,
+	)
+
+	Registry.MustRegister(gaugeVec)
+
+	return gaugeVec
+}
+
+// NewHistogram creates a histogram with the given bucket upper bounds and
+// registers it in Registry. It panics if a metric with the same name has
+// been already registered.
+func NewHistogram(name, help string, buckets []float64) prometheus.Histogram {
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    name,
+		Help:    help,
+		Buckets: buckets,
+	})
+
+	Registry.MustRegister(histogram)
+
+	return histogram
+}
+
+// NewHistogramVec creates a set of histograms with the given bucket upper
+// bounds partitioned by the given labels and registers it in Registry. It
+// panics if a metric with the same name has been already registered.
+func NewHistogramVec(
+	name, help string,
+	buckets []float64,
+	labels ...string,
+) *prometheus.HistogramVec {
+	histogramVec := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    name,
+			Help:    help,
+			Buckets: buckets,
+		},
+		labels,
+	)
+
+	Registry.MustRegister(histogramVec)
+
+	return histogramVec
+}
+
+// Handler returns an http.Handler serving all metrics from Registry.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{
+		ErrorLog:      errorLogger{},
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+// EnableServer starts an HTTP server exposing all metrics from Registry on
+// the given host and port. The server is shut down when the provided context
+// is done. The function returns immediately; the server runs in the
+// background.
+func EnableServer(ctx context.Context, address string) {
+	mux := http.NewServeMux()
+	mux.Handle(Path, Handler())
+
+	server := &http.Server{
+		Addr:    address,
+		Handler: mux,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancelShutdownCtx := context.WithTimeout(
+			context.Background(),
+			shutdownTimeout,
+		)
+		defer cancelShutdownCtx()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Errorf("could not shut down metrics server: [%v]", err)
+		}
+	}()
+
+	go func() {
+		logger.Infof("serving metrics on [%v]", address)
+
+		err := server.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			logger.Errorf("metrics server failed: [%v]", err)
+		}
+	}()
+}
+
+// errorLogger passes errors of gathering metrics to the package logger.
+type errorLogger struct{}
+
+func (errorLogger) Println(values ...interface{}) {
+	logger.Error(values...)
+}
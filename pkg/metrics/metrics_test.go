@@ -0,0 +1,207 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+func TestCounter(t *testing.T) {
+	counter := NewCounter("test_counter_total", "Test counter.")
+	counter.Inc()
+	counter.Add(2)
+
+	family := scrape(t)["test_counter_total"]
+	assertFamily(t, family, dto.MetricType_COUNTER, "Test counter.")
+
+	if value := family.GetMetric()[0].GetCounter().GetValue(); value != 3 {
+		t.Errorf("unexpected value\nexpected: [%v]\nactual:   [%v]", 3, value)
+	}
+}
+
+func TestCounterVec(t *testing.T) {
+	counterVec := NewCounterVec(
+		"test_counter_vec_total",
+		"Test counter vec.",
+		"phase",
+	)
+	counterVec.WithLabelValues("sharing").Inc()
+	counterVec.WithLabelValues("commitment").Add(2)
+	counterVec.WithLabelValues("sharing").Inc()
+	counterVec.WithLabelValues("with \"quotes\"\nand new lines").Inc()
+
+	family := scrape(t)["test_counter_vec_total"]
+	assertFamily(t, family, dto.MetricType_COUNTER, "Test counter vec.")
+
+	values := make(map[string]float64)
+	for _, metric := range family.GetMetric() {
+		values[metric.GetLabel()[0].GetValue()] = metric.GetCounter().GetValue()
+	}
+
+	expectedValues := map[string]float64{
+		"sharing":                        2,
+		"commitment":                     2,
+		"with \"quotes\"\nand new lines": 1,
+	}
+	for label, expectedValue := range expectedValues {
+		if values[label] != expectedValue {
+			t.Errorf(
+				"unexpected value of [%q]\nexpected: [%v]\nactual:   [%v]",
+				label,
+				expectedValue,
+				values[label],
+			)
+		}
+	}
+}
+
+func TestGauge(t *testing.T) {
+	gauge := NewGauge("test_gauge", "Test gauge.")
+	gauge.Set(10)
+	gauge.Inc()
+	gauge.Dec()
+	gauge.Dec()
+
+	family := scrape(t)["test_gauge"]
+	assertFamily(t, family, dto.MetricType_GAUGE, "Test gauge.")
+
+	if value := family.GetMetric()[0].GetGauge().GetValue(); value != 9 {
+		t.Errorf("unexpected value\nexpected: [%v]\nactual:   [%v]", 9, value)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	histogram := NewHistogram(
+		"test_histogram",
+		"Test histogram.",
+		[]float64{1, 5},
+	)
+	histogram.Observe(0.5)
+	histogram.Observe(3)
+	histogram.Observe(10)
+
+	family := scrape(t)["test_histogram"]
+	assertFamily(t, family, dto.MetricType_HISTOGRAM, "Test histogram.")
+
+	scraped := family.GetMetric()[0].GetHistogram()
+	if scraped.GetSampleCount() != 3 {
+		t.Errorf(
+			"unexpected count\nexpected: [%v]\nactual:   [%v]",
+			3,
+			scraped.GetSampleCount(),
+		)
+	}
+	if scraped.GetSampleSum() != 13.5 {
+		t.Errorf(
+			"unexpected sum\nexpected: [%v]\nactual:   [%v]",
+			13.5,
+			scraped.GetSampleSum(),
+		)
+	}
+
+	expectedCounts := []uint64{1, 2}
+	for i, bucket := range scraped.GetBucket() {
+		if bucket.GetCumulativeCount() != expectedCounts[i] {
+			t.Errorf(
+				"unexpected count of bucket [%v]\nexpected: [%v]\nactual:   [%v]",
+				bucket.GetUpperBound(),
+				expectedCounts[i],
+				bucket.GetCumulativeCount(),
+			)
+		}
+	}
+}
+
+func TestHistogramVec(t *testing.T) {
+	histogramVec := NewHistogramVec(
+		"test_histogram_vec",
+		"Test histogram vec.",
+		[]float64{1, 5},
+		OperatorLabel,
+	)
+	histogramVec.WithLabelValues(OperatorLabelValue([]byte{0xab})).Observe(3)
+	histogramVec.WithLabelValues(OperatorLabelValue([]byte{0xcd})).Observe(10)
+	histogramVec.WithLabelValues(OperatorLabelValue([]byte{0xcd})).Observe(4)
+
+	family := scrape(t)["test_histogram_vec"]
+	assertFamily(t, family, dto.MetricType_HISTOGRAM, "Test histogram vec.")
+
+	counts := make(map[string]uint64)
+	for _, metric := range family.GetMetric() {
+		label := metric.GetLabel()[0]
+		if label.GetName() != OperatorLabel {
+			t.Errorf(
+				"unexpected label\nexpected: [%v]\nactual:   [%v]",
+				OperatorLabel,
+				label.GetName(),
+			)
+		}
+		counts[label.GetValue()] = metric.GetHistogram().GetSampleCount()
+	}
+
+	expectedCounts := map[string]uint64{"0xab": 1, "0xcd": 2}
+	for operator, expectedCount := range expectedCounts {
+		if counts[operator] != expectedCount {
+			t.Errorf(
+				"unexpected count of [%v]\nexpected: [%v]\nactual:   [%v]",
+				operator,
+				expectedCount,
+				counts[operator],
+			)
+		}
+	}
+}
+
+func TestDuplicateRegistration(t *testing.T) {
+	NewGauge("test_duplicate", "Test duplicate.")
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic on duplicate registration")
+		}
+	}()
+
+	NewGauge("test_duplicate", "Test duplicate.")
+}
+
+// scrape serves the registry the same way the metrics server does and
+// parses the response with the Prometheus text format parser.
+func scrape(t *testing.T) map[string]*dto.MetricFamily {
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest("GET", Path, nil))
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(recorder.Body)
+	if err != nil {
+		t.Fatalf("could not parse metrics: [%v]", err)
+	}
+
+	return families
+}
+
+func assertFamily(
+	t *testing.T,
+	family *dto.MetricFamily,
+	expectedType dto.MetricType,
+	expectedHelp string,
+) {
+	if family == nil {
+		t.Fatal("metric not found")
+	}
+	if family.GetType() != expectedType {
+		t.Errorf(
+			"unexpected type\nexpected: [%v]\nactual:   [%v]",
+			expectedType,
+			family.GetType(),
+		)
+	}
+	if family.GetHelp() != expectedHelp {
+		t.Errorf(
+			"unexpected help\nexpected: [%v]\nactual:   [%v]",
+			expectedHelp,
+			family.GetHelp(),
+		)
+	}
+}
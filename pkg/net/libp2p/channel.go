@@ -9,6 +9,7 @@ import (
 	"sync/atomic"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/keep-network/keep-core/pkg/metrics"
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/gen/pb"
 	"github.com/keep-network/keep-core/pkg/net/internal"
@@ -25,6 +26,28 @@ var (
 	messageWorkers      = runtime.NumCPU()
 )
 
+var (
+	messagesSent = metrics.NewCounter(
+		"keep_net_broadcast_messages_sent_total",
+		"Number of messages published to broadcast channels.",
+	)
+	messagesRetransmitted = metrics.NewCounter(
+		"keep_net_broadcast_messages_retransmitted_total",
+		"Number of message retransmissions published to broadcast channels.",
+	)
+	messagesReceived = metrics.NewCounter(
+		"keep_net_broadcast_messages_received_total",
+		"Number of messages received from broadcast channels and delivered "+
+			"to message handlers.",
+	)
+	messagesDropped = metrics.NewCounterVec(
+		"keep_net_broadcast_messages_dropped_total",
+		"Number of incoming broadcast channel messages dropped, partitioned "+
+			"by reason.",
+		"reason",
+	)
+)
+
 const (
 	incomingMessageThrottle = 4096
 	messageHandlerThrottle  = 256
@@ -82,8 +105,14 @@ func (c *channel) Send(ctx context.Context, message net.TaggedMarshaler) error {
 		return c.publishToPubSub(messageProto)
 	}
 
-	retransmission.ScheduleRetransmissions(ctx, c.retransmissionTicker, doSend)
+	doRetransmit := func() error {
+		messagesRetransmitted.Inc()
+		return doSend()
+	}
+
+	retransmission.ScheduleRetransmissions(ctx, c.retransmissionTicker, doRetransmit)
 
+	messagesSent.Inc()
 	return doSend()
 }
 
@@ -210,6 +239,7 @@ func (c *channel) subscriptionWorker(ctx context.Context) {
 			select {
 			case c.incomingMessageQueue <- message:
 			default:
+				messagesDropped.WithLabelValues("slowWorkers").Inc()
 				logger.Warningf("message workers are too slow; dropping message")
 			}
 		}
@@ -287,6 +317,7 @@ func (c *channel) processContainerMessage(
 		message.SequenceNumber,
 	)
 
+	messagesReceived.Inc()
 	c.deliver(netMessage)
 
 	return nil
@@ -317,6 +348,7 @@ func (c *channel) deliver(message net.Message) {
 		select {
 		case handler.channel <- message:
 		default:
+			messagesDropped.WithLabelValues("slowHandler").Inc()
 			logger.Warningf("message handler is too slow; dropping message")
 		}
 	}
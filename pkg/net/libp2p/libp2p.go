@@ -84,6 +84,9 @@ type provider struct {
 	disseminationTime int
 
 	connectionManager *connectionManager
+
+	bootstrapPeersMutex sync.Mutex
+	bootstrapPeers      []peerstore.PeerInfo
 }
 
 func (p *provider) UnicastChannelWith(
@@ -259,7 +262,8 @@ func WithRoutingTableRefreshPeriod(period time.Duration) ConnectOption {
 
 // Connect connects to a libp2p network based on the provided config. The
 // connection is managed in part by the passed context, and provides access to
-// the functionality specified in the net.Provider interface.
+// the functionality specified in the net.Provider interface. The connection is
+// closed when the passed context is done.
 //
 // An error is returned if any part of the connection or bootstrap process
 // fails.
@@ -346,6 +350,15 @@ func Connect(
 		provider.connectionManager,
 	)
 
+	go func() {
+		<-ctx.Done()
+
+		logger.Infof("closing the network connection")
+		if err := provider.host.Close(); err != nil {
+			logger.Errorf("could not close the host: [%v]", err)
+		}
+	}()
+
 	return provider, nil
 }
 
@@ -441,12 +454,14 @@ func (p *provider) bootstrap(
 	ctx context.Context,
 	bootstrapPeers []string,
 ) error {
-	peerInfos, err := extractMultiAddrFromPeers(bootstrapPeers)
-	if err != nil {
+	if err := p.SetBootstrapPeers(bootstrapPeers); err != nil {
 		return err
 	}
 
-	bootstrapConfig := bootstrap.BootstrapConfigWithPeers(peerInfos)
+	// Bootstrap peers are read at the beginning of every bootstrap round so
+	// that they can be replaced while the provider is running.
+	bootstrapConfig := bootstrap.DefaultBootstrapConfig
+	bootstrapConfig.BootstrapPeers = p.getBootstrapPeers
 
 	// TODO: use the io.Closer to shutdown the bootstrapper when we build out
 	// a shutdown process.
@@ -459,15 +474,33 @@ func (p *provider) bootstrap(
 	return err
 }
 
+// SetBootstrapPeers replaces the peers the provider bootstraps its
+// connections with. New peers are used starting from the next bootstrap round.
+func (p *provider) SetBootstrapPeers(addresses []string) error {
+	peerInfos, err := extractMultiAddrFromPeers(addresses)
+	if err != nil {
+		return err
+	}
+
+	p.bootstrapPeersMutex.Lock()
+	defer p.bootstrapPeersMutex.Unlock()
+
+	p.bootstrapPeers = peerInfos
+
+	return nil
+}
+
+func (p *provider) getBootstrapPeers() []peerstore.PeerInfo {
+	p.bootstrapPeersMutex.Lock()
+	defer p.bootstrapPeersMutex.Unlock()
+
+	return p.bootstrapPeers
+}
+
 func extractMultiAddrFromPeers(peers []string) ([]peerstore.PeerInfo, error) {
 	var peerInfos []peerstore.PeerInfo
 	for _, peer := range peers {
-		ipfsaddr, err := ma.NewMultiaddr(peer)
-		if err != nil {
-			return nil, err
-		}
-
-		peerInfo, err := peerstore.InfoFromP2pAddr(ipfsaddr)
+		peerInfo, err := peerInfoFromAddress(peer)
 		if err != nil {
 			return nil, err
 		}
@@ -477,6 +510,29 @@ func extractMultiAddrFromPeers(peers []string) ([]peerstore.PeerInfo, error) {
 	return peerInfos, nil
 }
 
+func peerInfoFromAddress(address string) (*peerstore.PeerInfo, error) {
+	ipfsaddr, err := ma.NewMultiaddr(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return peerstore.InfoFromP2pAddr(ipfsaddr)
+}
+
+// ValidatePeerAddress checks whether the given bootstrap peer address is
+// a multiaddress containing the peer identity, as expected in Config.Peers.
+func ValidatePeerAddress(address string) error {
+	_, err := peerInfoFromAddress(address)
+	return err
+}
+
+// ValidateAnnouncedAddress checks whether the given address is a valid
+// multiaddress, as expected in Config.AnnouncedAddresses.
+func ValidateAnnouncedAddress(address string) error {
+	_, err := ma.NewMultiaddr(address)
+	return err
+}
+
 func buildNotifiee() libp2pnet.Notifiee {
 	notifyBundle := &libp2pnet.NotifyBundle{}
 
@@ -73,6 +73,10 @@ func (lp *localProvider) BroadcastChannelForwarderFor(name string) {
 	//no-op
 }
 
+func (lp *localProvider) SetBootstrapPeers(addresses []string) error {
+	return nil // no-op
+}
+
 // Connect returns a local instance of a net provider that does not go over the
 // network.
 func Connect() Provider {
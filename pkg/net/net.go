@@ -65,6 +65,11 @@ type Provider interface {
 
 	// BroadcastChannelForwarderFor creates a message relay for given channel name.
 	BroadcastChannelForwarderFor(name string)
+
+	// SetBootstrapPeers replaces the addresses of peers the provider
+	// bootstraps its connections with. An error is returned and the current
+	// bootstrap peers are kept if any of the addresses is invalid.
+	SetBootstrapPeers(addresses []string) error
 }
 
 // ConnectionManager is an interface which exposes peers a client is connected
@@ -0,0 +1,202 @@
+// Package status exposes a local HTTP/JSON endpoint reporting the current
+// state of a running node. Other parts of the client register status sources
+// which are evaluated every time the endpoint is queried, so the reported
+// state is always up to date.
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+)
+
+var logger = log.Logger("keep-status")
+
+// Path is the HTTP path under which the status is served. The full status
+// document is available directly under this path and every single source is
+// available under `Path/<source name>`.
+const Path = "/status"
+
+const shutdownTimeout = 5 * time.Second
+
+// Source is a function returning the current state of a part of the node.
+// The returned value must be serializable to JSON.
+type Source func() (interface{}, error)
+
+// Registry is a collection of named status sources.
+type Registry struct {
+	sourcesMutex sync.RWMutex
+	sources      map[string]Source
+	// included are registries whose sources are reported by this registry
+	// as its own, see Include.
+	included []*Registry
+}
+
+// NewRegistry creates an empty status registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		sources: make(map[string]Source),
+	}
+}
+
+// RegisterSource registers the status source under the given name. If there
+// was already a source registered under the same name, it is replaced.
+func (r *Registry) RegisterSource(name string, source Source) {
+	r.sourcesMutex.Lock()
+	defer r.sourcesMutex.Unlock()
+
+	r.sources[name] = source
+}
+
+// Include makes the registry report all sources of the other registry,
+// including sources registered there later, as its own. Sources registered
+// directly in the registry take precedence over the included ones with the
+// same name. The other registry must not include this registry.
+func (r *Registry) Include(other *Registry) {
+	r.sourcesMutex.Lock()
+	defer r.sourcesMutex.Unlock()
+
+	r.included = append(r.included, other)
+}
+
+// Snapshot evaluates all registered status sources and returns their values
+// keyed by source name. If a source failed, the error message is reported
+// as the value of that source.
+func (r *Registry) Snapshot() map[string]interface{} {
+	sources := r.allSources()
+
+	snapshot := make(map[string]interface{}, len(sources))
+	for name, source := range sources {
+		snapshot[name] = evaluate(source)
+	}
+
+	return snapshot
+}
+
+// SourceNames returns names of all registered status sources in
+// alphabetical order.
+func (r *Registry) SourceNames() []string {
+	sources := r.allSources()
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (r *Registry) source(name string) (Source, bool) {
+	source, ok := r.allSources()[name]
+	return source, ok
+}
+
+// allSources returns sources registered directly in the registry and sources
+// of the included registries. Sources are evaluated by the caller, without
+// holding the lock, so that a source may query another registry.
+func (r *Registry) allSources() map[string]Source {
+	r.sourcesMutex.RLock()
+	defer r.sourcesMutex.RUnlock()
+
+	sources := make(map[string]Source, len(r.sources))
+	for _, included := range r.included {
+		for name, source := range included.allSources() {
+			sources[name] = source
+		}
+	}
+	for name, source := range r.sources {
+		sources[name] = source
+	}
+
+	return sources
+}
+
+func evaluate(source Source) interface{} {
+	value, err := source()
+	if err != nil {
+		return map[string]string{"error": err.Error()}
+	}
+
+	return value
+}
+
+// ServeHTTP implements http.Handler. A request to Path returns the full
+// status document; a request to `Path/<source name>` returns the value of
+// the single source.
+func (r *Registry) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		http.Error(writer, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.Trim(strings.TrimPrefix(request.URL.Path, Path), "/")
+
+	var document interface{}
+	if name == "" {
+		document = r.Snapshot()
+	} else {
+		source, ok := r.source(name)
+		if !ok {
+			http.Error(
+				writer,
+				fmt.Sprintf("unknown status source [%v]", name),
+				http.StatusNotFound,
+			)
+			return
+		}
+		document = evaluate(source)
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		logger.Errorf("could not encode status document: [%v]", err)
+	}
+}
+
+// EnableServer starts an HTTP server serving the registry on the given
+// host and port. The server is shut down when the provided context is done.
+// The function returns immediately; the server runs in the background.
+func (r *Registry) EnableServer(ctx context.Context, address string) {
+	mux := http.NewServeMux()
+	mux.Handle(Path, r)
+	mux.Handle(Path+"/", r)
+
+	server := &http.Server{
+		Addr:    address,
+		Handler: mux,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancelShutdownCtx := context.WithTimeout(
+			context.Background(),
+			shutdownTimeout,
+		)
+		defer cancelShutdownCtx()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Errorf("could not shut down status server: [%v]", err)
+		}
+	}()
+
+	go func() {
+		logger.Infof("serving node status on [%v]", address)
+
+		err := server.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			logger.Errorf("status server failed: [%v]", err)
+		}
+	}()
+}
@@ -0,0 +1,159 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	registry := NewRegistry()
+	registry.RegisterSource("block", func() (interface{}, error) {
+		return 100, nil
+	})
+	registry.RegisterSource("peers", func() (interface{}, error) {
+		return nil, fmt.Errorf("not connected")
+	})
+
+	snapshot := registry.Snapshot()
+
+	expected := map[string]interface{}{
+		"block": 100,
+		"peers": map[string]string{"error": "not connected"},
+	}
+	if !reflect.DeepEqual(expected, snapshot) {
+		t.Errorf(
+			"unexpected snapshot\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			snapshot,
+		)
+	}
+}
+
+func TestSourceNames(t *testing.T) {
+	registry := NewRegistry()
+	registry.RegisterSource("peers", func() (interface{}, error) { return nil, nil })
+	registry.RegisterSource("block", func() (interface{}, error) { return nil, nil })
+	registry.RegisterSource("peers", func() (interface{}, error) { return nil, nil })
+
+	expected := []string{"block", "peers"}
+	actual := registry.SourceNames()
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf(
+			"unexpected source names\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			actual,
+		)
+	}
+}
+
+func TestInclude(t *testing.T) {
+	operatorRegistry := NewRegistry()
+	operatorRegistry.RegisterSource("block", func() (interface{}, error) {
+		return 100, nil
+	})
+
+	registry := NewRegistry()
+	registry.Include(operatorRegistry)
+	registry.RegisterSource("operators", func() (interface{}, error) {
+		return map[string]interface{}{
+			"0x65ea55c1f10491038425725dc00dffeab2a1e28a": operatorRegistry.Snapshot(),
+		}, nil
+	})
+
+	// sources registered after the registry was included are reported too
+	operatorRegistry.RegisterSource("peers", func() (interface{}, error) {
+		return 3, nil
+	})
+
+	expected := map[string]interface{}{
+		"block": 100,
+		"peers": 3,
+		"operators": map[string]interface{}{
+			"0x65ea55c1f10491038425725dc00dffeab2a1e28a": map[string]interface{}{
+				"block": 100,
+				"peers": 3,
+			},
+		},
+	}
+	actual := registry.Snapshot()
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf(
+			"unexpected snapshot\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			actual,
+		)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	registry := NewRegistry()
+	registry.RegisterSource("staker", func() (interface{}, error) {
+		return "0x65ea55c1f10491038425725dc00dffeab2a1e28a", nil
+	})
+	registry.RegisterSource("block", func() (interface{}, error) {
+		return 100, nil
+	})
+
+	var tests = map[string]struct {
+		path           string
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		"full document": {
+			path:           Path,
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"staker": "0x65ea55c1f10491038425725dc00dffeab2a1e28a",
+				"block":  float64(100),
+			},
+		},
+		"single source": {
+			path:           Path + "/block",
+			expectedStatus: http.StatusOK,
+			expectedBody:   float64(100),
+		},
+		"unknown source": {
+			path:           Path + "/groups",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			registry.ServeHTTP(
+				recorder,
+				httptest.NewRequest(http.MethodGet, test.path, nil),
+			)
+
+			if recorder.Code != test.expectedStatus {
+				t.Fatalf(
+					"unexpected status code\nexpected: [%v]\nactual:   [%v]",
+					test.expectedStatus,
+					recorder.Code,
+				)
+			}
+
+			if test.expectedBody == nil {
+				return
+			}
+
+			var body interface{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expectedBody, body) {
+				t.Errorf(
+					"unexpected body\nexpected: [%v]\nactual:   [%v]",
+					test.expectedBody,
+					body,
+				)
+			}
+		})
+	}
+}